@@ -20,6 +20,23 @@ type Config struct {
 	Order       dist.Order          `toml:"order"`
 	Stack       StackConfig         `toml:"stack"`
 	Lifecycle   LifecycleConfig     `toml:"lifecycle"`
+	Platforms   []PlatformConfig    `toml:"platforms"`
+}
+
+// PlatformConfig details a platform of a multi-architecture builder, which is created from its own build image
+type PlatformConfig struct {
+	OS           string `toml:"os"`
+	Architecture string `toml:"arch"`
+	Variant      string `toml:"variant,omitempty"`
+	BuildImage   string `toml:"build-image"`
+}
+
+// String returns the platform in the os/arch[/variant] form
+func (p PlatformConfig) String() string {
+	if p.Variant != "" {
+		return fmt.Sprintf("%s/%s/%s", p.OS, p.Architecture, p.Variant)
+	}
+	return fmt.Sprintf("%s/%s", p.OS, p.Architecture)
 }
 
 // BuildpackCollection is a list of BuildpackConfigs
@@ -51,6 +68,8 @@ type StackConfig struct {
 type LifecycleConfig struct {
 	URI     string `toml:"uri"`
 	Version string `toml:"version"`
+	// Expected sha256 checksum of the lifecycle archive, verified after downloading it
+	SHA256 string `toml:"sha256"`
 }
 
 // ReadConfig reads a builder configuration from the file path provided and returns the
@@ -80,7 +99,7 @@ func ValidateConfig(c Config) error {
 		return errors.New("stack.id is required")
 	}
 
-	if c.Stack.BuildImage == "" {
+	if c.Stack.BuildImage == "" && len(c.Platforms) == 0 {
 		return errors.New("stack.build-image is required")
 	}
 
@@ -88,6 +107,20 @@ func ValidateConfig(c Config) error {
 		return errors.New("stack.run-image is required")
 	}
 
+	platforms := map[string]bool{}
+	for _, platform := range c.Platforms {
+		if platform.OS == "" || platform.Architecture == "" {
+			return errors.New("platforms require an os and an arch")
+		}
+		if platform.BuildImage == "" {
+			return errors.Errorf("platform %s requires a build-image", style.Symbol(platform.String()))
+		}
+		if platforms[platform.String()] {
+			return errors.Errorf("platform %s is defined more than once", style.Symbol(platform.String()))
+		}
+		platforms[platform.String()] = true
+	}
+
 	return nil
 }
 
@@ -193,5 +193,37 @@ uri = "noop-buildpack.tgz"
 				}}
 			h.AssertError(t, builder.ValidateConfig(config), "stack.run-image is required")
 		})
+
+		when("platforms are configured", func() {
+			it("does not require a stack build image", func() {
+				config := builder.Config{
+					Stack: builder.StackConfig{ID: testID, RunImage: testRunImage},
+					Platforms: []builder.PlatformConfig{
+						{OS: "linux", Architecture: "amd64", BuildImage: "test-build-image:amd64"},
+						{OS: "linux", Architecture: "arm64", BuildImage: "test-build-image:arm64"},
+					},
+				}
+				h.AssertNil(t, builder.ValidateConfig(config))
+			})
+
+			it("returns error if a platform has no build image", func() {
+				config := builder.Config{
+					Stack:     builder.StackConfig{ID: testID, RunImage: testRunImage},
+					Platforms: []builder.PlatformConfig{{OS: "linux", Architecture: "arm64"}},
+				}
+				h.AssertError(t, builder.ValidateConfig(config), "platform 'linux/arm64' requires a build-image")
+			})
+
+			it("returns error if a platform is repeated", func() {
+				config := builder.Config{
+					Stack: builder.StackConfig{ID: testID, RunImage: testRunImage},
+					Platforms: []builder.PlatformConfig{
+						{OS: "linux", Architecture: "arm64", Variant: "v8", BuildImage: "test-build-image:arm64"},
+						{OS: "linux", Architecture: "arm64", Variant: "v8", BuildImage: "other-build-image:arm64"},
+					},
+				}
+				h.AssertError(t, builder.ValidateConfig(config), "platform 'linux/arm64/v8' is defined more than once")
+			})
+		})
 	})
 }
@@ -46,6 +46,7 @@ func testBuildpackageConfigReader(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, err)
 
 			h.AssertEq(t, config.Platform.OS, "windows")
+			h.AssertEq(t, config.Platform.Arch, "amd64")
 			h.AssertEq(t, config.Buildpack.URI, "https://example.com/bp/a.tgz")
 			h.AssertEq(t, len(config.Dependencies), 1)
 			h.AssertEq(t, config.Dependencies[0].URI, "https://example.com/bp/b.tgz")
@@ -205,6 +206,7 @@ uri = "https://example.com/bp/b.tgz"
 
 [platform]
 os = "windows"
+arch = "amd64"
 `
 
 const validPackageWithoutPlatformToml = `
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -12,6 +13,7 @@ import (
 	imagewriter "github.com/buildpacks/pack/internal/inspectimage/writer"
 	"github.com/buildpacks/pack/internal/term"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -32,7 +34,8 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 		return nil, err
 	}
 
-	packClient, err := initClient(logger, cfg)
+	registryTokens := client.NewTokenKeychain()
+	packClient, err := initClient(logger, cfg, registryTokens)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +43,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd := &cobra.Command{
 		Use:   "pack",
 		Short: "CLI for building apps using Cloud Native Buildpacks",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if fs := cmd.Flags(); fs != nil {
 				if flag, err := fs.GetBool("no-color"); err == nil && flag {
 					color.Disable(flag)
@@ -60,7 +63,18 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 				if flag, err := fs.GetBool("timestamps"); err == nil {
 					logger.WantTime(flag)
 				}
+				if tokens, err := fs.GetStringArray("registry-token"); err == nil {
+					for _, token := range tokens {
+						if err := registryTokens.Add(token); err != nil {
+							cmd.SilenceErrors = true
+							cmd.SilenceUsage = true
+							logger.Error(err.Error())
+							return err
+						}
+					}
+				}
 			}
+			return nil
 		},
 	}
 
@@ -68,18 +82,27 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.PersistentFlags().Bool("timestamps", false, "Enable timestamps in output")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Show less output")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show more output")
+	rootCmd.PersistentFlags().StringArray("registry-token", nil, "Bearer token to access a registry with, in the form '<registry>=<token>', such as a short-lived token exchanged for the identity of a cloud workload, instead of the credentials of the docker config.\nRepeat for each registry.")
 	rootCmd.Flags().Bool("version", false, "Show current 'pack' version")
 
 	commands.AddHelpFlag(rootCmd, "pack")
 
 	rootCmd.AddCommand(commands.Build(logger, cfg, packClient))
-	rootCmd.AddCommand(commands.NewBuilderCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.Run(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.New(logger, packClient))
+	rootCmd.AddCommand(commands.NewBuilderCommand(logger, cfg, cfgPath, packClient))
 	rootCmd.AddCommand(commands.NewBuildpackCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
 	rootCmd.AddCommand(commands.NewConfigCommand(logger, cfg, cfgPath, packClient))
 	rootCmd.AddCommand(commands.InspectImage(logger, imagewriter.NewFactory(), cfg, packClient))
 	rootCmd.AddCommand(commands.NewStackCommand(logger))
 	rootCmd.AddCommand(commands.Rebase(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.VerifyImage(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.WatchRunImage(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.NewSBOMCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewTagCommand(logger, packClient))
+	rootCmd.AddCommand(commands.NewImagesCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewCacheCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewStateCommand(logger, cfg, packClient))
 
 	rootCmd.AddCommand(commands.InspectBuildpack(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.InspectBuilder(logger, cfg, packClient, builderwriter.NewFactory()))
@@ -133,10 +156,39 @@ func initConfig() (config.Config, string, error) {
 	return cfg, path, nil
 }
 
-func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error) {
+func initClient(logger logging.Logger, cfg config.Config, registryTokens authn.Keychain) (*client.Client, error) {
 	dc, err := tryInitSSHDockerClient()
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc))
+	keychain, err := client.NewKeychain(cfg.RegistryKeychains...)
+	if err != nil {
+		return nil, errors.Wrap(err, "configuring registry keychains")
+	}
+	// tokens given on the command line take precedence over the credentials of the keychains
+	keychain = authn.NewMultiKeychain(registryTokens, keychain)
+	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithInsecureRegistries(cfg.InsecureRegistries), client.WithRegistryCACertificates(cfg.CACertificates...), client.WithKeychain(keychain), client.WithRetryPolicy(retryPolicy(cfg)), client.WithDockerClient(dc))
+}
+
+// retryPolicy is the default retry policy of registry operations, overridden by the registry-retry of the config.
+func retryPolicy(cfg config.Config) image.RetryPolicy {
+	policy := image.DefaultRetryPolicy
+	if cfg.RegistryRetry == nil {
+		return policy
+	}
+
+	if cfg.RegistryRetry.Attempts > 0 {
+		policy.Attempts = cfg.RegistryRetry.Attempts
+	}
+	if cfg.RegistryRetry.Backoff > 0 {
+		policy.Backoff = cfg.RegistryRetry.Backoff
+	}
+	if cfg.RegistryRetry.MaxBackoff > 0 {
+		policy.MaxBackoff = cfg.RegistryRetry.MaxBackoff
+	}
+	if len(cfg.RegistryRetry.StatusCodes) > 0 {
+		policy.RetryableStatusCodes = cfg.RegistryRetry.StatusCodes
+	}
+	policy.RateLimitBackoff = cfg.RegistryRetry.RateLimitBackoff
+	return policy
 }
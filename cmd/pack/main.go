@@ -6,7 +6,6 @@ import (
 	"github.com/heroku/color"
 
 	"github.com/buildpacks/pack/cmd"
-	"github.com/buildpacks/pack/pkg/client"
 
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -24,9 +23,6 @@ func main() {
 
 	ctx := commands.CreateCancellableContext()
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
-		if _, isSoftError := err.(client.SoftError); isSoftError {
-			os.Exit(2)
-		}
-		os.Exit(1)
+		os.Exit(commands.ExitCode(err))
 	}
 }
@@ -0,0 +1,86 @@
+package build
+
+import (
+	"fmt"
+
+	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Feature is a build option that is only honored by lifecycles supporting a range of Platform APIs.
+type Feature struct {
+	// Name of the option, as presented to the user.
+	Name string
+
+	// MinPlatformAPI is the earliest Platform API supporting the option.
+	MinPlatformAPI *api.Version
+
+	// MaxPlatformAPI is the latest Platform API supporting the option, nil when no Platform API dropped it.
+	MaxPlatformAPI *api.Version
+}
+
+// The build options depending on the Platform API. Every such option is registered here and checked by
+// CheckFeatureSupport before the build.
+var (
+	FeatureSBOMOutput       = Feature{Name: "--sbom-output-dir", MinPlatformAPI: api.MustParse("0.8")}
+	FeatureCreationTime     = Feature{Name: "--creation-time", MinPlatformAPI: api.MustParse("0.9")}
+	FeatureProcessImage     = Feature{Name: "--process-image", MinPlatformAPI: api.MustParse("0.4")}
+	FeatureAnalyzedOverride = Feature{Name: "--analyzed", MinPlatformAPI: api.MustParse("0.7")}
+	FeatureSkipAnalyze      = Feature{Name: "--skip-phases analyze", MinPlatformAPI: api.MustParse("0.3"), MaxPlatformAPI: api.MustParse("0.6")}
+)
+
+// minLifecycleVersions maps each Platform API pack supports to the first lifecycle version implementing it.
+var minLifecycleVersions = map[string]string{
+	"0.3": "0.7.0",
+	"0.4": "0.9.0",
+	"0.5": "0.10.0",
+	"0.6": "0.11.0",
+	"0.7": "0.12.0",
+	"0.8": "0.13.0",
+	"0.9": "0.14.0",
+}
+
+// UnsupportedFeatureError is returned by CheckFeatureSupport when the lifecycle is too old, or too new, for a
+// requested feature.
+type UnsupportedFeatureError struct {
+	Feature     Feature
+	PlatformAPI *api.Version
+}
+
+func (e UnsupportedFeatureError) Error() string {
+	if e.Feature.MaxPlatformAPI != nil && e.PlatformAPI.Compare(e.Feature.MaxPlatformAPI) > 0 {
+		return fmt.Sprintf("%s requires Platform API %s or older, but the builder's lifecycle uses Platform API %s",
+			style.Symbol(e.Feature.Name), e.Feature.MaxPlatformAPI.String(), e.PlatformAPI.String())
+	}
+
+	msg := fmt.Sprintf("%s requires Platform API %s or newer, but the builder's lifecycle only supports Platform API %s",
+		style.Symbol(e.Feature.Name), e.Feature.MinPlatformAPI.String(), e.PlatformAPI.String())
+	if lifecycleVersion, ok := minLifecycleVersions[e.Feature.MinPlatformAPI.String()]; ok {
+		msg += fmt.Sprintf(". Use a builder with lifecycle %s or newer", lifecycleVersion)
+	}
+	return msg
+}
+
+// CheckFeatureSupport verifies that the Platform API pack will use with a lifecycle supporting platformAPIs
+// supports every requested feature. It fails for the first unsupported feature, instead of the feature being
+// silently ignored during the build.
+func CheckFeatureSupport(platformAPIs []*api.Version, features ...Feature) error {
+	if len(features) == 0 {
+		return nil
+	}
+
+	platformAPI, err := findLatestSupported(platformAPIs)
+	if err != nil {
+		return err
+	}
+
+	for _, feature := range features {
+		if platformAPI.Compare(feature.MinPlatformAPI) < 0 ||
+			(feature.MaxPlatformAPI != nil && platformAPI.Compare(feature.MaxPlatformAPI) > 0) {
+			return UnsupportedFeatureError{Feature: feature, PlatformAPI: platformAPI}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package build_test
+
+import (
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCompatibility(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "compatibility", testCompatibility, spec.Report(report.Terminal{}), spec.Parallel())
+}
+
+func testCompatibility(t *testing.T, when spec.G, it spec.S) {
+	when("#CheckFeatureSupport", func() {
+		it("succeeds when the latest common Platform API supports all features", func() {
+			platformAPIs := []*api.Version{api.MustParse("0.8"), api.MustParse("0.9")}
+
+			h.AssertNil(t, build.CheckFeatureSupport(platformAPIs, build.FeatureSBOMOutput, build.FeatureCreationTime))
+		})
+
+		it("names the feature and the minimum lifecycle version", func() {
+			platformAPIs := []*api.Version{api.MustParse("0.7"), api.MustParse("0.8")}
+
+			err := build.CheckFeatureSupport(platformAPIs, build.FeatureSBOMOutput, build.FeatureCreationTime)
+			h.AssertError(t, err, "'--creation-time' requires Platform API 0.9 or newer, but the builder's lifecycle only supports Platform API 0.8. Use a builder with lifecycle 0.14.0 or newer")
+
+			_, ok := err.(build.UnsupportedFeatureError)
+			h.AssertTrue(t, ok)
+		})
+
+		it("names the feature and the maximum Platform API", func() {
+			platformAPIs := []*api.Version{api.MustParse("0.6"), api.MustParse("0.7")}
+
+			err := build.CheckFeatureSupport(platformAPIs, build.FeatureSkipAnalyze)
+			h.AssertError(t, err, "'--skip-phases analyze' requires Platform API 0.6 or older, but the builder's lifecycle uses Platform API 0.7")
+
+			h.AssertNil(t, build.CheckFeatureSupport([]*api.Version{api.MustParse("0.6")}, build.FeatureSkipAnalyze))
+		})
+
+		it("succeeds without features, whatever the Platform API", func() {
+			h.AssertNil(t, build.CheckFeatureSupport(nil))
+		})
+
+		it("fails when no Platform API is supported by pack", func() {
+			err := build.CheckFeatureSupport([]*api.Version{api.MustParse("0.1")}, build.FeatureSBOMOutput)
+			h.AssertError(t, err, "unable to find a supported Platform API version")
+		})
+	})
+}
@@ -1,13 +1,17 @@
 package build
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/platform"
@@ -201,6 +205,41 @@ func WriteProjectMetadata(p string, metadata platform.ProjectMetadata, os string
 	}
 }
 
+// OverrideAnalyzed writes the analyzed.toml at src, as written by the analyzer, to dst with override applied. The
+// phases after the analyzer are given dst with -analyzed, while src is left as the analyzer wrote it.
+func OverrideAnalyzed(src, dst string, override func(*platform.AnalyzedMetadata)) ContainerOperation {
+	return func(ctrClient client.CommonAPIClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		reader, _, err := ctrClient.CopyFromContainer(ctx, containerID, src)
+		if err != nil {
+			return errors.Wrap(err, "reading analyzed.toml")
+		}
+		defer reader.Close()
+
+		_, contents, err := archive.ReadTarEntry(reader, filepath.Base(src))
+		if err != nil {
+			return errors.Wrap(err, "reading analyzed.toml")
+		}
+
+		var analyzed platform.AnalyzedMetadata
+		if _, err := toml.Decode(string(contents), &analyzed); err != nil {
+			return errors.Wrap(err, "parsing analyzed.toml")
+		}
+		override(&analyzed)
+
+		buf := &bytes.Buffer{}
+		if err := toml.NewEncoder(buf).Encode(analyzed); err != nil {
+			return errors.Wrap(err, "marshaling analyzed.toml")
+		}
+
+		tarBuilder := archive.TarBuilder{}
+		tarBuilder.AddFile(dst, 0644, archive.NormalizedDateTime, buf.Bytes())
+		tarReader := tarBuilder.Reader(archive.DefaultTarWriterFactory())
+		defer tarReader.Close()
+
+		return ctrClient.CopyToContainer(ctx, containerID, "/", tarReader, types.CopyToContainerOptions{})
+	}
+}
+
 // WriteStackToml writes a `stack.toml` based on the StackMetadata provided to the destination path.
 func WriteStackToml(dstPath string, stack builder.StackMetadata, os string) ContainerOperation {
 	return func(ctrClient client.CommonAPIClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
@@ -230,7 +269,25 @@ func WriteStackToml(dstPath string, stack builder.StackMetadata, os string) Cont
 	}
 }
 
+// WriteFile writes contents to dstPath in the container, readable by all users. It is only supported by Linux
+// containers.
+func WriteFile(dstPath string, contents []byte) ContainerOperation {
+	return func(ctrClient client.CommonAPIClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		tarBuilder := archive.TarBuilder{}
+		tarBuilder.AddDir(path.Dir(dstPath), 0755, archive.NormalizedDateTime)
+		tarBuilder.AddFile(dstPath, 0644, archive.NormalizedDateTime, contents)
+		reader := tarBuilder.Reader(archive.DefaultTarWriterFactory())
+		defer reader.Close()
+
+		return ctrClient.CopyToContainer(ctx, containerID, "/", reader, types.CopyToContainerOptions{})
+	}
+}
+
 func createReader(src, dst string, uid, gid int, includeRoot bool, fileFilter func(string) bool) (io.ReadCloser, error) {
+	if src == StdinAppPath {
+		return archive.ReadTarStreamAsTar(os.Stdin, dst, uid, gid, -1, false, fileFilter), nil
+	}
+
 	fi, err := os.Stat(src)
 	if err != nil {
 		return nil, err
@@ -245,7 +302,15 @@ func createReader(src, dst string, uid, gid int, includeRoot bool, fileFilter fu
 		return archive.ReadDirAsTar(src, dst, uid, gid, mode, false, includeRoot, fileFilter), nil
 	}
 
-	return archive.ReadZipAsTar(src, dst, uid, gid, -1, false, fileFilter), nil
+	isZip, err := archive.IsZip(src)
+	if err != nil {
+		return nil, err
+	}
+	if isZip {
+		return archive.ReadZipAsTar(src, dst, uid, gid, -1, false, fileFilter), nil
+	}
+
+	return archive.ReadTarAsTar(src, dst, uid, gid, -1, false, fileFilter), nil
 }
 
 // EnsureVolumeAccess grants full access permissions to volumes for UID/GID-based user
@@ -313,3 +378,123 @@ func EnsureVolumeAccess(uid, gid int, os string, volumeNames ...string) Containe
 		)
 	}
 }
+
+// ExcludeLayers marks the layers of the buildpacks matching any of filters as not launch layers, as written by the
+// builder, so that the exporter leaves them out of the app image. Cached layers stay in the cache.
+func ExcludeLayers(layersDir string, filters []LayerFilter, os string) ContainerOperation {
+	return func(ctrClient client.CommonAPIClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		if os == "windows" {
+			return errors.New("excluding layers is not supported for Windows builders")
+		}
+
+		reader, _, err := ctrClient.CopyFromContainer(ctx, containerID, layersDir)
+		if err != nil {
+			return errors.Wrap(err, "reading layers")
+		}
+		defer reader.Close()
+
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		excluded := 0
+		tr := tar.NewReader(reader)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.Wrap(err, "reading layers")
+			}
+			if !isLayerMetadata(header) {
+				continue
+			}
+
+			contents, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return errors.Wrapf(err, "reading %s", header.Name)
+			}
+			contents, ok, err := excludeLayer(contents, filters)
+			if err != nil {
+				return errors.Wrapf(err, "parsing %s", header.Name)
+			}
+			if !ok {
+				continue
+			}
+
+			layer := strings.TrimSuffix(strings.SplitN(header.Name, "/", 2)[1], ".toml")
+			fmt.Fprintf(stdout, "Excluding layer %s from the app image\n", layer)
+
+			header.Name = strings.TrimPrefix(path.Join(path.Dir(layersDir), header.Name), "/")
+			header.Size = int64(len(contents))
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if _, err := tw.Write(contents); err != nil {
+				return err
+			}
+			excluded++
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		if excluded == 0 {
+			return nil
+		}
+
+		return ctrClient.CopyToContainer(ctx, containerID, "/", buf, types.CopyToContainerOptions{})
+	}
+}
+
+// isLayerMetadata returns whether header is the metadata of a layer, at <layers>/<buildpack>/<layer>.toml.
+func isLayerMetadata(header *tar.Header) bool {
+	parts := strings.Split(header.Name, "/")
+	if header.Typeflag != tar.TypeReg || len(parts) != 3 || !strings.HasSuffix(parts[2], ".toml") {
+		return false
+	}
+	switch parts[1] {
+	case "config", "sbom":
+		return false
+	}
+	switch parts[2] {
+	case "launch.toml", "build.toml", "store.toml":
+		return false
+	}
+	return true
+}
+
+// excludeLayer marks the layer, whose metadata is contents, as not a launch layer when its metadata matches any of
+// filters. It returns whether the layer was excluded, along with its updated metadata.
+func excludeLayer(contents []byte, filters []LayerFilter) ([]byte, bool, error) {
+	var layer map[string]interface{}
+	if _, err := toml.Decode(string(contents), &layer); err != nil {
+		return nil, false, err
+	}
+
+	metadata, _ := layer["metadata"].(map[string]interface{})
+	matched := false
+	for _, filter := range filters {
+		if filter.matches(metadata) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return nil, false, nil
+	}
+
+	// Buildpack API 0.6 moved the types of layers to a table
+	layerTypes := layer
+	if table, ok := layer["types"].(map[string]interface{}); ok {
+		layerTypes = table
+	}
+	if launch, _ := layerTypes["launch"].(bool); !launch {
+		return nil, false, nil
+	}
+	layerTypes["launch"] = false
+
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(layer); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
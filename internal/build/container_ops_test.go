@@ -27,6 +27,7 @@ import (
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/archive"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -497,6 +498,44 @@ drwsrwsrwt    2 123      456 (.*) some-vol
 `)
 		})
 	})
+	when("#ExcludeLayers", func() {
+		it.Before(func() {
+			h.SkipIf(t, osType == "windows", "excluding layers is not supported for Windows builders")
+		})
+
+		it("marks the matching launch layers as not launch layers", func() {
+			ctx := context.Background()
+			ctr, err := createContainer(ctx, imageName, "/layers", osType, "cat", "/layers/some-bp/dev.toml", "/layers/some-bp/app.toml")
+			h.AssertNil(t, err)
+			defer cleanupContainer(ctx, ctr.ID)
+
+			tarBuilder := archive.TarBuilder{}
+			tarBuilder.AddDir("layers/some-bp", 0755, archive.NormalizedDateTime)
+			tarBuilder.AddFile("layers/some-bp/dev.toml", 0644, archive.NormalizedDateTime, []byte("[types]\nlaunch = true\ncache = true\n\n[metadata]\ndev-only = true\n"))
+			tarBuilder.AddFile("layers/some-bp/app.toml", 0644, archive.NormalizedDateTime, []byte("[types]\nlaunch = true\n\n[metadata]\ndev-only = false\n"))
+			tarReader := tarBuilder.Reader(archive.DefaultTarWriterFactory())
+			h.AssertNil(t, ctrClient.CopyToContainer(ctx, ctr.ID, "/", tarReader, types.CopyToContainerOptions{}))
+			tarReader.Close()
+
+			var outBuf, errBuf bytes.Buffer
+			excludeOp := build.ExcludeLayers("/layers", []build.LayerFilter{{Key: "dev-only", Value: "true"}}, osType)
+			h.AssertNil(t, excludeOp(ctrClient, ctx, ctr.ID, &outBuf, &errBuf))
+			h.AssertContains(t, outBuf.String(), "Excluding layer some-bp/dev from the app image")
+
+			outBuf.Reset()
+			h.AssertNil(t, container.RunWithHandler(ctx, ctrClient, ctr.ID, container.DefaultHandler(&outBuf, &errBuf)))
+			h.AssertEq(t, errBuf.String(), "")
+			h.AssertContains(t, outBuf.String(), `[metadata]
+  dev-only = true
+
+[types]
+  cache = true
+  launch = false
+`)
+			h.AssertContains(t, outBuf.String(), "[types]\nlaunch = true\n\n[metadata]\ndev-only = false\n")
+		})
+	})
+
 	when("#EnsureVolumeAccess", func() {
 		it("changes owner of volume", func() {
 			h.SkipIf(t, osType != "windows", "no-op for linux")
@@ -0,0 +1,134 @@
+package build
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+const (
+	// daemonCertsDir holds the TLS client certificates of the daemon in the phase containers accessing it over tcp,
+	// as the DOCKER_CERT_PATH of the lifecycle.
+	daemonCertsDir = "/cnb/pack-docker-certs"
+
+	// The files of the TLS client certificates in DOCKER_CERT_PATH.
+	daemonCAFile   = "ca.pem"
+	daemonCertFile = "cert.pem"
+	daemonKeyFile  = "key.pem"
+)
+
+// DaemonTLS is the TLS client configuration of a daemon only reachable over tcp, as configured for the docker CLI by
+// DOCKER_CERT_PATH and DOCKER_TLS_VERIFY.
+type DaemonTLS struct {
+	// CA is the PEM encoded certificate of the authority signing the certificate of the daemon, if any.
+	CA []byte
+	// Cert and Key are the PEM encoded client certificate and key authenticating to the daemon.
+	Cert []byte
+	Key  []byte
+	// Verify is whether the certificate of the daemon is verified.
+	Verify bool
+}
+
+// daemonAccess resolves how the phase containers accessing the daemon reach it. A dockerHost of "inherit" is the
+// DOCKER_HOST of the environment. When no dockerHost is given, and the environment configures a tcp DOCKER_HOST with
+// TLS, the containers use it rather than the socket of the daemon, which daemons exposing only TLS endpoints do not
+// have. The TLS client configuration is returned for tcp hosts, or nil without TLS.
+func daemonAccess(dockerHost string, getenv func(string) string) (string, *DaemonTLS, error) {
+	envHost := getenv("DOCKER_HOST")
+	switch {
+	case dockerHost == "inherit":
+		dockerHost = envHost
+	case dockerHost == "" && strings.HasPrefix(envHost, "tcp://") && daemonCertPath(getenv) != "":
+		dockerHost = envHost
+	}
+
+	if !strings.HasPrefix(dockerHost, "tcp://") || dockerHost != envHost {
+		// the TLS configuration of the environment only applies to its host
+		return dockerHost, nil, nil
+	}
+
+	certPath := daemonCertPath(getenv)
+	if certPath == "" {
+		return dockerHost, nil, nil
+	}
+
+	daemonTLS := &DaemonTLS{Verify: getenv("DOCKER_TLS_VERIFY") != ""}
+	for _, file := range []struct {
+		name     string
+		contents *[]byte
+		optional bool
+	}{
+		{daemonCAFile, &daemonTLS.CA, !daemonTLS.Verify},
+		{daemonCertFile, &daemonTLS.Cert, false},
+		{daemonKeyFile, &daemonTLS.Key, false},
+	} {
+		contents, err := ioutil.ReadFile(filepath.Join(certPath, file.name))
+		if err != nil {
+			if os.IsNotExist(err) && file.optional {
+				continue
+			}
+			return "", nil, errors.Wrapf(err, "reading TLS certificates of docker daemon %s", style.Symbol(dockerHost))
+		}
+		*file.contents = contents
+	}
+	return dockerHost, daemonTLS, nil
+}
+
+// daemonCertPath returns the directory of the TLS client certificates of the daemon, which is DOCKER_CERT_PATH, or
+// ~/.docker when only DOCKER_TLS_VERIFY is set, as for the docker CLI. It returns an empty string without TLS.
+func daemonCertPath(getenv func(string) string) string {
+	if certPath := getenv("DOCKER_CERT_PATH"); certPath != "" {
+		return certPath
+	}
+	if getenv("DOCKER_TLS_VERIFY") == "" {
+		return ""
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker")
+}
+
+// WithDaemonTLS gives the lifecycle the TLS client certificates of the daemon it accesses over tcp, copying them to
+// the container, since they may not be on the host of the daemon. Windows containers do not support them.
+func WithDaemonTLS(daemonTLS *DaemonTLS) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		if daemonTLS == nil || provider.os == "windows" {
+			return
+		}
+
+		provider.ctrConf.Env = append(provider.ctrConf.Env, "DOCKER_CERT_PATH="+daemonCertsDir)
+		if daemonTLS.Verify {
+			provider.ctrConf.Env = append(provider.ctrConf.Env, "DOCKER_TLS_VERIFY=1")
+		}
+		provider.containerOps = append(provider.containerOps, writeDaemonCerts(daemonTLS))
+	}
+}
+
+func writeDaemonCerts(daemonTLS *DaemonTLS) ContainerOperation {
+	return func(ctrClient client.CommonAPIClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		tarBuilder := archive.TarBuilder{}
+		tarBuilder.AddDir(daemonCertsDir, 0755, archive.NormalizedDateTime)
+		if len(daemonTLS.CA) > 0 {
+			tarBuilder.AddFile(path.Join(daemonCertsDir, daemonCAFile), 0644, archive.NormalizedDateTime, daemonTLS.CA)
+		}
+		tarBuilder.AddFile(path.Join(daemonCertsDir, daemonCertFile), 0644, archive.NormalizedDateTime, daemonTLS.Cert)
+		tarBuilder.AddFile(path.Join(daemonCertsDir, daemonKeyFile), 0600, archive.NormalizedDateTime, daemonTLS.Key)
+		reader := tarBuilder.Reader(archive.DefaultTarWriterFactory())
+		defer reader.Close()
+
+		return ctrClient.CopyToContainer(ctx, containerID, "/", reader, types.CopyToContainerOptions{})
+	}
+}
@@ -80,6 +80,10 @@ func (b *FakeBuilder) Name() string {
 	return b.ReturnForImage.Name()
 }
 
+func (b *FakeBuilder) BaseImageName() string {
+	return b.ReturnForImage.Name()
+}
+
 func (b *FakeBuilder) Image() imgutil.Image {
 	return b.ReturnForImage
 }
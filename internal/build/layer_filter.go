@@ -0,0 +1,33 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// LayerFilter matches the layers whose metadata, as written by buildpacks to <layer>.toml, has Key set to Value.
+type LayerFilter struct {
+	Key   string
+	Value string
+}
+
+// ParseLayerFilter parses a filter in the form <key>=<value>, or <key> to match layers where the key is true.
+func ParseLayerFilter(filter string) (LayerFilter, error) {
+	parts := strings.SplitN(filter, "=", 2)
+	if parts[0] == "" {
+		return LayerFilter{}, errors.Errorf("invalid layer filter %s: must be in the form <key>=<value>", style.Symbol(filter))
+	}
+	if len(parts) == 1 {
+		return LayerFilter{Key: parts[0], Value: "true"}, nil
+	}
+	return LayerFilter{Key: parts[0], Value: parts[1]}, nil
+}
+
+func (f LayerFilter) matches(metadata map[string]interface{}) bool {
+	value, ok := metadata[f.Key]
+	return ok && fmt.Sprint(value) == f.Value
+}
@@ -0,0 +1,39 @@
+package build_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLayerFilter(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LayerFilter", testLayerFilter, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLayerFilter(t *testing.T, when spec.G, it spec.S) {
+	when("#ParseLayerFilter", func() {
+		it("parses a key and value", func() {
+			filter, err := build.ParseLayerFilter("stage=test")
+			h.AssertNil(t, err)
+			h.AssertEq(t, filter, build.LayerFilter{Key: "stage", Value: "test"})
+		})
+
+		it("matches a key set to true when there is no value", func() {
+			filter, err := build.ParseLayerFilter("dev-only")
+			h.AssertNil(t, err)
+			h.AssertEq(t, filter, build.LayerFilter{Key: "dev-only", Value: "true"})
+		})
+
+		it("errors without a key", func() {
+			_, err := build.ParseLayerFilter("=true")
+			h.AssertError(t, err, "invalid layer filter '=true'")
+		})
+	})
+}
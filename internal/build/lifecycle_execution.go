@@ -4,17 +4,19 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/buildpacks/lifecycle/api"
-	"github.com/buildpacks/lifecycle/auth"
 	"github.com/docker/docker/client"
-	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/cache"
+	"github.com/buildpacks/pack/internal/faults"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -27,14 +29,17 @@ const (
 )
 
 type LifecycleExecution struct {
-	logger       logging.Logger
-	docker       client.CommonAPIClient
-	platformAPI  *api.Version
-	layersVolume string
-	appVolume    string
-	os           string
-	mountPaths   mountPaths
-	opts         LifecycleOptions
+	logger        logging.Logger
+	docker        client.CommonAPIClient
+	platformAPI   *api.Version
+	layersVolume  string
+	appVolume     string
+	workspaceSync *workspaceSync
+	phases        []PhaseState
+	os            string
+	mountPaths    mountPaths
+	daemonTLS     *DaemonTLS
+	opts          LifecycleOptions
 }
 
 func NewLifecycleExecution(logger logging.Logger, docker client.CommonAPIClient, opts LifecycleOptions) (*LifecycleExecution, error) {
@@ -62,6 +67,23 @@ func NewLifecycleExecution(logger logging.Logger, docker client.CommonAPIClient,
 		mountPaths:   mountPathsForOS(osType, opts.Workspace),
 	}
 
+	if opts.IncrementalSync {
+		exec.appVolume = exec.cacheKey("workspace").VolumeName()
+		if opts.WorkspaceName != "" {
+			exec.appVolume = paths.FilterReservedNames(opts.WorkspaceName)
+		}
+	}
+
+	if !opts.Publish {
+		exec.opts.DockerHost, exec.daemonTLS, err = daemonAccess(opts.DockerHost, os.Getenv)
+		if err != nil {
+			return nil, err
+		}
+		if exec.daemonTLS != nil {
+			logger.Debugf("Accessing docker daemon %s over TLS from the lifecycle", style.Symbol(exec.opts.DockerHost))
+		}
+	}
+
 	if opts.Interactive {
 		exec.logger = opts.Termui
 	}
@@ -122,6 +144,21 @@ func (l *LifecycleExecution) PrevImageName() string {
 }
 
 func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseFactoryCreator) error {
+	if err := l.validateSkipPhases(); err != nil {
+		return err
+	}
+	if err := l.validateOverrideAnalyzed(); err != nil {
+		return err
+	}
+
+	if l.opts.IncrementalSync {
+		sync, err := l.prepareWorkspaceSync(ctx)
+		if err != nil {
+			return errors.Wrap(err, "preparing workspace sync")
+		}
+		l.workspaceSync = sync
+	}
+
 	phaseFactory := phaseFactoryCreator(l)
 	var buildCache Cache
 	if l.opts.CacheImage != "" || (l.opts.Cache.Build.Format == cache.CacheImage) {
@@ -137,7 +174,7 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 	} else {
 		switch l.opts.Cache.Build.Format {
 		case cache.CacheVolume:
-			buildCache = cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Build, "build", l.docker)
+			buildCache = cache.NewVolumeCacheWithKey(l.cacheKey("build"), l.opts.Cache.Build, l.docker)
 			l.logger.Debugf("Using build cache volume %s", style.Symbol(buildCache.Name()))
 		case cache.CacheBind:
 			buildCache = cache.NewBindCache(l.opts.Cache.Build, l.docker)
@@ -152,7 +189,27 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		l.logger.Debugf("Build cache %s cleared", style.Symbol(buildCache.Name()))
 	}
 
-	launchCache := cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Launch, "launch", l.docker)
+	var launchCache Cache
+	switch launchCacheInfo := l.opts.Cache.Launch; launchCacheInfo.Format {
+	case cache.CacheBind:
+		launchCache = cache.NewBindCache(launchCacheInfo, l.docker)
+		l.logger.Debugf("Using launch cache dir %s", style.Symbol(launchCache.Name()))
+	case cache.CacheImage:
+		// the lifecycle only reads the launch cache from a directory, so the default volume is used
+		launchCache = cache.NewVolumeCacheWithKey(l.cacheKey("launch"), cache.CacheInfo{}, l.docker)
+	default:
+		launchCache = cache.NewVolumeCacheWithKey(l.cacheKey("launch"), launchCacheInfo, l.docker)
+	}
+
+	// the daemon creates missing directories of binds owned by root, which CI systems archiving them may not read
+	for _, c := range []Cache{buildCache, launchCache} {
+		if c.Type() != cache.Bind {
+			continue
+		}
+		if err := os.MkdirAll(c.Name(), 0750); err != nil {
+			return errors.Wrapf(err, "creating cache dir %s", style.Symbol(c.Name()))
+		}
+	}
 
 	if !l.opts.UseCreator {
 		if l.platformAPI.LessThan("0.7") {
@@ -162,7 +219,9 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 			}
 
 			l.logger.Info(style.Step("ANALYZING"))
-			if err := l.Analyze(ctx, l.opts.Image.String(), l.opts.Network, l.opts.Publish, l.opts.DockerHost, l.opts.ClearCache, l.opts.RunImage, l.opts.AdditionalTags, buildCache, launchCache, phaseFactory); err != nil {
+			if l.skipsPhase("analyze") {
+				l.logger.Info("Skipping 'analyze' as requested")
+			} else if err := l.Analyze(ctx, l.opts.Image.String(), l.opts.Network, l.opts.Publish, l.opts.DockerHost, l.opts.ClearCache, l.opts.RunImage, l.opts.AdditionalTags, buildCache, launchCache, phaseFactory); err != nil {
 				return err
 			}
 		} else {
@@ -179,6 +238,8 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		l.logger.Info(style.Step("RESTORING"))
 		if l.opts.ClearCache {
 			l.logger.Info("Skipping 'restore' due to clearing cache")
+		} else if l.skipsPhase("restore") {
+			l.logger.Info("Skipping 'restore' as requested")
 		} else if err := l.Restore(ctx, l.opts.Network, buildCache, phaseFactory); err != nil {
 			return err
 		}
@@ -190,10 +251,149 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		}
 
 		l.logger.Info(style.Step("EXPORTING"))
-		return l.Export(ctx, l.opts.Image.String(), l.opts.RunImage, l.opts.Publish, l.opts.DockerHost, l.opts.Network, buildCache, launchCache, l.opts.AdditionalTags, phaseFactory)
+		if err := l.Export(ctx, l.opts.Image.String(), l.opts.RunImage, l.opts.Publish, l.opts.DockerHost, l.opts.Network, buildCache, launchCache, l.opts.AdditionalTags, phaseFactory); err != nil {
+			return err
+		}
+		if err := l.exportProcessImages(ctx, buildCache, launchCache, phaseFactory); err != nil {
+			return err
+		}
+		return l.saveWorkspaceSync(ctx)
+	}
+
+	if err := l.Create(ctx, l.opts.Publish, l.opts.DockerHost, l.opts.ClearCache || l.skipsPhase("restore"), l.opts.RunImage, l.opts.Image.String(), l.opts.Network, buildCache, launchCache, l.opts.AdditionalTags, l.opts.Volumes, phaseFactory); err != nil {
+		return err
+	}
+	if err := l.exportProcessImages(ctx, buildCache, launchCache, phaseFactory); err != nil {
+		return err
 	}
+	return l.saveWorkspaceSync(ctx)
+}
+
+func (l *LifecycleExecution) saveWorkspaceSync(ctx context.Context) error {
+	if l.workspaceSync == nil {
+		return nil
+	}
+	return errors.Wrap(l.saveWorkspace(ctx, l.workspaceSync), "saving workspace state")
+}
 
-	return l.Create(ctx, l.opts.Publish, l.opts.DockerHost, l.opts.ClearCache, l.opts.RunImage, l.opts.Image.String(), l.opts.Network, buildCache, launchCache, l.opts.AdditionalTags, l.opts.Volumes, phaseFactory)
+// appFileFilter returns the filter of the files of the app copied to the workspace.
+func (l *LifecycleExecution) appFileFilter() func(string) bool {
+	if l.workspaceSync == nil {
+		return l.opts.FileFilter
+	}
+	return l.workspaceSync.fileFilter(l.opts.FileFilter)
+}
+
+// skippablePhases are the phases which may be skipped when an external system has already performed them.
+var skippablePhases = []string{"analyze", "restore"}
+
+// validateSkipPhases ensures the requested phases can be skipped safely with the Platform API in use.
+// From Platform API 0.7, the analyzer writes analyzed.toml, which the detector and exporter require, and the creator
+// always runs it, so 'analyze' can only be skipped with older Platform APIs and without the creator.
+func (l *LifecycleExecution) validateSkipPhases() error {
+	for _, phase := range l.opts.SkipPhases {
+		if !stringSliceContains(skippablePhases, phase) {
+			return errors.Errorf("phase %s cannot be skipped; only %s may be skipped",
+				style.Symbol(phase), strings.Join(skippablePhases, ", "))
+		}
+
+		if phase != "analyze" {
+			continue
+		}
+		if l.opts.UseCreator {
+			return errors.Errorf("phase %s cannot be skipped with a trusted builder", style.Symbol(phase))
+		}
+		if !l.platformAPI.LessThan("0.7") {
+			return errors.Errorf("phase %s cannot be skipped with Platform API %s, as later phases require its output",
+				style.Symbol(phase), l.platformAPI.String())
+		}
+	}
+	return nil
+}
+
+// validateOverrideAnalyzed ensures the analysis of the analyzer can be overridden. The override is applied to the
+// output of a separate analyzer, and read by the phases accepting -analyzed from Platform API 0.7, so the build fails
+// instead of silently ignoring it otherwise.
+func (l *LifecycleExecution) validateOverrideAnalyzed() error {
+	if l.opts.OverrideAnalyzed == nil {
+		return nil
+	}
+
+	switch {
+	case l.opts.UseCreator:
+		return errors.New("the analysis of the analyzer cannot be overridden when the creator runs the build")
+	case l.skipsPhase("analyze"):
+		return errors.Errorf("the analysis of the analyzer cannot be overridden when phase %s is skipped", style.Symbol("analyze"))
+	case l.platformAPI.LessThan("0.7"):
+		return errors.Errorf("the analysis of the analyzer cannot be overridden with Platform API %s, as the restorer does not read it", l.platformAPI.String())
+	case l.os == "windows":
+		return errors.New("the analysis of the analyzer cannot be overridden for Windows builders")
+	}
+	return nil
+}
+
+// withOverriddenAnalyzed gives the analysis of the analyzer with the overrides of the build applied to the phase.
+func (l *LifecycleExecution) withOverriddenAnalyzed() PhaseConfigProviderOperation {
+	return If(l.opts.OverrideAnalyzed != nil, WithFlags("-analyzed", l.mountPaths.overriddenAnalyzedPath()))
+}
+
+func (l *LifecycleExecution) skipsPhase(phase string) bool {
+	return stringSliceContains(l.opts.SkipPhases, phase)
+}
+
+func stringSliceContains(slice []string, value string) bool {
+	for _, s := range slice {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LifecycleExecution) cacheKey(scope string) cache.VolumeCacheKey {
+	key := cache.VolumeCacheKey{ImageRef: l.opts.Image, StackID: l.opts.StackID, BuildpacksDigest: l.opts.BuildpacksDigest, Scope: scope}
+	if scope == "build" {
+		// only the build cache is shared, the launch cache holds the layers of the previous image
+		key.Name = l.opts.CacheName
+	}
+	return key
+}
+
+// withLaunchCache mounts the launch cache of daemon exports, which holds the layers of the previous image, unless
+// launch caching is disabled for the build.
+func (l *LifecycleExecution) withLaunchCache(launchCache Cache) PhaseConfigProviderOperation {
+	if l.opts.NoLaunchCache {
+		return NullOp()
+	}
+	return func(provider *PhaseConfigProvider) {
+		WithFlags("-launch-cache", l.mountPaths.launchCacheDir())(provider)
+		WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir()))(provider)
+	}
+}
+
+// runAndCleanup runs the lifecycle and removes its volumes, unless the build failed and its state is to be kept.
+func (l *LifecycleExecution) runAndCleanup(ctx context.Context, phaseFactoryCreator PhaseFactoryCreator) error {
+	if injector := faults.Active(); injector != nil {
+		phaseFactoryCreator = withFaults(phaseFactoryCreator, injector)
+	}
+	if l.opts.Publish && l.opts.RetryPolicy.Attempts > 1 {
+		phaseFactoryCreator = withRetries(phaseFactoryCreator, l.opts.RetryPolicy)
+	}
+	if l.opts.Events != nil {
+		phaseFactoryCreator = withPhaseEvents(phaseFactoryCreator, l.opts.Events, l.plannedPhases())
+	}
+
+	err := l.Run(ctx, phaseFactoryCreator)
+	if err != nil && l.opts.KeepFailedState {
+		keepErr := l.keepState(err)
+		if keepErr == nil {
+			return err
+		}
+		l.logger.Warnf("Unable to keep the state of the failed build: %s", keepErr)
+	}
+
+	l.Cleanup()
+	return err
 }
 
 func (l *LifecycleExecution) Cleanup() error {
@@ -201,6 +401,9 @@ func (l *LifecycleExecution) Cleanup() error {
 	if err := l.docker.VolumeRemove(context.Background(), l.layersVolume, true); err != nil {
 		reterr = errors.Wrapf(err, "failed to clean up layers volume %s", l.layersVolume)
 	}
+	if l.opts.IncrementalSync {
+		return reterr
+	}
 	if err := l.docker.VolumeRemove(context.Background(), l.appVolume, true); err != nil {
 		reterr = errors.Wrapf(err, "failed to clean up app volume %s", l.appVolume)
 	}
@@ -272,7 +475,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, publish bool, dockerHos
 		WithNetwork(networkMode),
 		cacheOpts,
 		WithContainerOperations(WriteProjectMetadata(l.mountPaths.projectPath(), l.opts.ProjectMetadata, l.os)),
-		WithContainerOperations(CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)),
+		WithContainerOperations(CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.appFileFilter())),
 		If(l.opts.SBOMDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.sbomDir(), l.opts.SBOMDestinationDir))),
@@ -283,7 +486,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, publish bool, dockerHos
 	}
 
 	if publish {
-		authConfig, err := auth.BuildEnvVar(authn.DefaultKeychain, repoName, runImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := l.registryAuth(repoName, runImage, l.opts.CacheImage, l.opts.PreviousImage)
 		if err != nil {
 			return err
 		}
@@ -292,8 +495,9 @@ func (l *LifecycleExecution) Create(ctx context.Context, publish bool, dockerHos
 	} else {
 		opts = append(opts,
 			WithDaemonAccess(dockerHost),
-			WithFlags("-daemon", "-launch-cache", l.mountPaths.launchCacheDir()),
-			WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir())),
+			WithDaemonTLS(l.daemonTLS),
+			WithFlags("-daemon"),
+			l.withLaunchCache(launchCache),
 		)
 	}
 
@@ -315,7 +519,7 @@ func (l *LifecycleExecution) Detect(ctx context.Context, networkMode string, vol
 		WithBinds(volumes...),
 		WithContainerOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
-			CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter),
+			CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.appFileFilter()),
 		),
 		WithFlags(flags...),
 	)
@@ -353,6 +557,7 @@ func (l *LifecycleExecution) Restore(ctx context.Context, networkMode string, bu
 		WithNetwork(networkMode),
 		flagsOpt,
 		cacheOpt,
+		l.withOverriddenAnalyzed(),
 	)
 
 	restore := phaseFactory.New(configProvider)
@@ -388,8 +593,7 @@ func (l *LifecycleExecution) newAnalyze(repoName, networkMode string, publish bo
 			args = prependArg("-skip-layers", args)
 		}
 		if !publish {
-			args = append([]string{"-launch-cache", l.mountPaths.launchCacheDir()}, args...)
-			launchCacheOpt = WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir()))
+			launchCacheOpt = l.withLaunchCache(launchCache)
 		}
 	}
 
@@ -449,8 +653,11 @@ func (l *LifecycleExecution) newAnalyze(repoName, networkMode string, publish bo
 		stackOpts = WithContainerOperations(WriteStackToml(l.mountPaths.stackPath(), l.opts.Builder.Stack(), l.os))
 	}
 
+	overrideOpts := If(l.opts.OverrideAnalyzed != nil, WithPostContainerRunOperations(
+		OverrideAnalyzed(l.mountPaths.analyzedPath(), l.mountPaths.overriddenAnalyzedPath(), l.opts.OverrideAnalyzed)))
+
 	if publish {
-		authConfig, err := auth.BuildEnvVar(authn.DefaultKeychain, repoName, runImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := l.registryAuth(repoName, runImage, l.opts.CacheImage, l.opts.PreviousImage)
 		if err != nil {
 			return nil, err
 		}
@@ -468,6 +675,7 @@ func (l *LifecycleExecution) newAnalyze(repoName, networkMode string, publish bo
 			flagsOpt,
 			cacheOpt,
 			stackOpts,
+			overrideOpts,
 		)
 
 		return phaseFactory.New(configProvider), nil
@@ -483,6 +691,7 @@ func (l *LifecycleExecution) newAnalyze(repoName, networkMode string, publish bo
 			fmt.Sprintf("%s=%d", builder.EnvGID, l.opts.Builder.GID()),
 		),
 		WithDaemonAccess(dockerHost),
+		WithDaemonTLS(l.daemonTLS),
 		launchCacheOpt,
 		WithFlags(l.withLogLevel("-daemon")...),
 		WithArgs(args...),
@@ -490,6 +699,7 @@ func (l *LifecycleExecution) newAnalyze(repoName, networkMode string, publish bo
 		WithNetwork(networkMode),
 		cacheOpt,
 		stackOpts,
+		overrideOpts,
 	)
 
 	return phaseFactory.New(configProvider), nil
@@ -505,6 +715,8 @@ func (l *LifecycleExecution) Build(ctx context.Context, networkMode string, volu
 		WithNetwork(networkMode),
 		WithBinds(volumes...),
 		WithFlags(flags...),
+		If(len(l.opts.ExcludeLayers) > 0, WithPostContainerRunOperations(
+			ExcludeLayers(l.mountPaths.layersDir(), l.opts.ExcludeLayers, l.os))),
 	)
 
 	build := phaseFactory.New(configProvider)
@@ -522,7 +734,7 @@ func determineDefaultProcessType(platformAPI *api.Version, providedValue string)
 	return providedValue
 }
 
-func (l *LifecycleExecution) newExport(repoName, runImage string, publish bool, dockerHost, networkMode string, buildCache, launchCache Cache, additionalTags []string, phaseFactory PhaseFactory) (RunnerCleaner, error) {
+func (l *LifecycleExecution) newExport(repoName, runImage string, publish bool, dockerHost, networkMode string, buildCache, launchCache Cache, additionalTags []string, processType string, phaseFactory PhaseFactory) (RunnerCleaner, error) {
 	flags := []string{
 		"-app", l.mountPaths.appDir(),
 		"-cache-dir", l.mountPaths.cacheDir(),
@@ -534,7 +746,7 @@ func (l *LifecycleExecution) newExport(repoName, runImage string, publish bool,
 			"-run-image", runImage,
 		)
 	}
-	processType := determineDefaultProcessType(l.platformAPI, l.opts.DefaultProcessType)
+	processType = determineDefaultProcessType(l.platformAPI, processType)
 	if processType != "" {
 		flags = append(flags, "-process-type", processType)
 	}
@@ -578,10 +790,11 @@ func (l *LifecycleExecution) newExport(repoName, runImage string, publish bool,
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOut(l.opts.Termui.ReadLayers, l.mountPaths.layersDir(), l.mountPaths.appDir()))),
 		withEnv,
+		l.withOverriddenAnalyzed(),
 	}
 
 	if publish {
-		authConfig, err := auth.BuildEnvVar(authn.DefaultKeychain, repoName, runImage, l.opts.CacheImage, l.opts.PreviousImage)
+		authConfig, err := l.registryAuth(repoName, runImage, l.opts.CacheImage, l.opts.PreviousImage)
 		if err != nil {
 			return nil, err
 		}
@@ -595,8 +808,9 @@ func (l *LifecycleExecution) newExport(repoName, runImage string, publish bool,
 		opts = append(
 			opts,
 			WithDaemonAccess(dockerHost),
-			WithFlags("-daemon", "-launch-cache", l.mountPaths.launchCacheDir()),
-			WithBinds(fmt.Sprintf("%s:%s", launchCache.Name(), l.mountPaths.launchCacheDir())),
+			WithDaemonTLS(l.daemonTLS),
+			WithFlags("-daemon"),
+			l.withLaunchCache(launchCache),
 		)
 	}
 
@@ -604,7 +818,7 @@ func (l *LifecycleExecution) newExport(repoName, runImage string, publish bool,
 }
 
 func (l *LifecycleExecution) Export(ctx context.Context, repoName, runImage string, publish bool, dockerHost, networkMode string, buildCache, launchCache Cache, additionalTags []string, phaseFactory PhaseFactory) error {
-	export, err := l.newExport(repoName, runImage, publish, dockerHost, networkMode, buildCache, launchCache, additionalTags, phaseFactory)
+	export, err := l.newExport(repoName, runImage, publish, dockerHost, networkMode, buildCache, launchCache, additionalTags, l.opts.DefaultProcessType, phaseFactory)
 	if err != nil {
 		return err
 	}
@@ -629,3 +843,29 @@ func addTags(flags, additionalTags []string) []string {
 	}
 	return flags
 }
+
+// exportProcessImages exports each of ProcessImages from the layers of the build, with its own default process type.
+// Only the exporter runs again, against the same layers volume.
+func (l *LifecycleExecution) exportProcessImages(ctx context.Context, buildCache, launchCache Cache, phaseFactory PhaseFactory) error {
+	imageNames := make([]string, 0, len(l.opts.ProcessImages))
+	for imageName := range l.opts.ProcessImages {
+		imageNames = append(imageNames, imageName)
+	}
+	sort.Strings(imageNames)
+
+	for _, imageName := range imageNames {
+		processType := l.opts.ProcessImages[imageName]
+		l.logger.Infof("Exporting %s with default process type %s", style.Symbol(imageName), style.Symbol(processType))
+
+		export, err := l.newExport(imageName, l.opts.RunImage, l.opts.Publish, l.opts.DockerHost, l.opts.Network, buildCache, launchCache, nil, processType, phaseFactory)
+		if err != nil {
+			return err
+		}
+		err = export.Run(ctx)
+		export.Cleanup()
+		if err != nil {
+			return errors.Wrapf(err, "exporting image %s", style.Symbol(imageName))
+		}
+	}
+	return nil
+}
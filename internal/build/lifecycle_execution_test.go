@@ -3,6 +3,7 @@ package build_test
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,10 +16,12 @@ import (
 
 	"github.com/buildpacks/pack/internal/cache"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 
 	"github.com/apex/log"
 	"github.com/buildpacks/lifecycle/api"
+	"github.com/buildpacks/lifecycle/platform"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 	"github.com/heroku/color"
@@ -330,6 +333,236 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("skipping phases", func() {
+			runWith := func(opts build.LifecycleOptions) error {
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, opts)
+				h.AssertNil(t, err)
+
+				return lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+					return fakePhaseFactory
+				})
+			}
+
+			phaseNames := func() []string {
+				var names []string
+				for _, entry := range fakePhaseFactory.NewCalledWithProvider {
+					names = append(names, entry.Name())
+				}
+				return names
+			}
+
+			it("skips analyze and restore", func() {
+				err := runWith(build.LifecycleOptions{
+					RunImage:   "test",
+					Image:      imageName,
+					Builder:    fakeBuilder,
+					Termui:     fakeTermui,
+					SkipPhases: []string{"analyze", "restore"},
+				})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, phaseNames(), []string{"detector", "builder", "exporter"})
+				h.AssertContains(t, outBuf.String(), "Skipping 'analyze' as requested")
+				h.AssertContains(t, outBuf.String(), "Skipping 'restore' as requested")
+			})
+
+			it("skips restore with the creator", func() {
+				err := runWith(build.LifecycleOptions{
+					RunImage:   "test",
+					Image:      imageName,
+					Builder:    fakeBuilder,
+					UseCreator: true,
+					Termui:     fakeTermui,
+					SkipPhases: []string{"restore"},
+				})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, phaseNames(), []string{"creator"})
+				h.AssertSliceContains(t, fakePhaseFactory.NewCalledWithProvider[0].ContainerConfig().Cmd, "-skip-restore")
+			})
+
+			it("fails for phases that cannot be skipped", func() {
+				err := runWith(build.LifecycleOptions{
+					RunImage:   "test",
+					Image:      imageName,
+					Builder:    fakeBuilder,
+					Termui:     fakeTermui,
+					SkipPhases: []string{"build"},
+				})
+				h.AssertError(t, err, "phase 'build' cannot be skipped; only analyze, restore may be skipped")
+			})
+
+			it("fails to skip analyze with the creator", func() {
+				err := runWith(build.LifecycleOptions{
+					RunImage:   "test",
+					Image:      imageName,
+					Builder:    fakeBuilder,
+					UseCreator: true,
+					Termui:     fakeTermui,
+					SkipPhases: []string{"analyze"},
+				})
+				h.AssertError(t, err, "phase 'analyze' cannot be skipped with a trusted builder")
+			})
+
+			it("fails to skip analyze with platform >= 0.7", func() {
+				fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.7")}))
+				h.AssertNil(t, err)
+
+				err = runWith(build.LifecycleOptions{
+					RunImage:   "test",
+					Image:      imageName,
+					Builder:    fakeBuilder,
+					Termui:     fakeTermui,
+					SkipPhases: []string{"analyze"},
+				})
+				h.AssertError(t, err, "phase 'analyze' cannot be skipped with Platform API 0.7, as later phases require its output")
+				h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+			})
+		})
+
+		when("the analysis is overridden", func() {
+			runWith := func(opts build.LifecycleOptions) error {
+				opts.RunImage = "test"
+				opts.Image = imageName
+				opts.Termui = fakeTermui
+				opts.OverrideAnalyzed = func(*platform.AnalyzedMetadata) {}
+				if opts.Builder == nil {
+					opts.Builder = fakeBuilder
+				}
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, opts)
+				h.AssertNil(t, err)
+
+				return lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+					return fakePhaseFactory
+				})
+			}
+
+			it("passes the overridden analysis to the restorer and exporter", func() {
+				fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.7")}))
+				h.AssertNil(t, err)
+
+				h.AssertNil(t, runWith(build.LifecycleOptions{Builder: fakeBuilder}))
+
+				for _, provider := range fakePhaseFactory.NewCalledWithProvider {
+					switch provider.Name() {
+					case "restorer", "exporter":
+						h.AssertIncludeAllExpectedPatterns(t, provider.ContainerConfig().Cmd, []string{"-analyzed", "/layers/pack-analyzed.toml"})
+					case "detector", "builder":
+						h.AssertSliceNotContains(t, provider.ContainerConfig().Cmd, "-analyzed")
+					}
+				}
+			})
+
+			it("fails with the creator", func() {
+				err := runWith(build.LifecycleOptions{UseCreator: true})
+				h.AssertError(t, err, "the analysis of the analyzer cannot be overridden when the creator runs the build")
+				h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+			})
+
+			it("fails when analyze is skipped", func() {
+				err := runWith(build.LifecycleOptions{SkipPhases: []string{"analyze"}})
+				h.AssertError(t, err, "the analysis of the analyzer cannot be overridden when phase 'analyze' is skipped")
+				h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+			})
+
+			it("fails with platform < 0.7", func() {
+				fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.6")}))
+				h.AssertNil(t, err)
+
+				err = runWith(build.LifecycleOptions{Builder: fakeBuilder})
+				h.AssertError(t, err, "the analysis of the analyzer cannot be overridden with Platform API 0.6, as the restorer does not read it")
+			})
+		})
+
+		when("process images are provided", func() {
+			it("runs the exporter again for each image with its default process type", func() {
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, build.LifecycleOptions{
+					RunImage:      "test",
+					Image:         imageName,
+					Builder:       fakeBuilder,
+					UseCreator:    true,
+					Termui:        fakeTermui,
+					ProcessImages: map[string]string{"some-org/worker": "worker", "some-org/cli": "cli"},
+				})
+				h.AssertNil(t, err)
+
+				err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+					return fakePhaseFactory
+				})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 3)
+				h.AssertEq(t, fakePhaseFactory.NewCalledWithProvider[0].Name(), "creator")
+
+				cliExport := fakePhaseFactory.NewCalledWithProvider[1]
+				h.AssertEq(t, cliExport.Name(), "exporter")
+				h.AssertIncludeAllExpectedPatterns(t, cliExport.ContainerConfig().Cmd, []string{"-process-type", "cli"})
+				h.AssertSliceContains(t, cliExport.ContainerConfig().Cmd, "some-org/cli")
+
+				workerExport := fakePhaseFactory.NewCalledWithProvider[2]
+				h.AssertEq(t, workerExport.Name(), "exporter")
+				h.AssertIncludeAllExpectedPatterns(t, workerExport.ContainerConfig().Cmd, []string{"-process-type", "worker"})
+				h.AssertSliceContains(t, workerExport.ContainerConfig().Cmd, "some-org/worker")
+			})
+		})
+
+		when("incremental sync", func() {
+			it("keeps the workspace volume between builds", func() {
+				opts := build.LifecycleOptions{
+					RunImage:        "test",
+					Image:           imageName,
+					Builder:         fakeBuilder,
+					Termui:          fakeTermui,
+					IncrementalSync: true,
+				}
+
+				first, err := build.NewLifecycleExecution(logger, docker, opts)
+				h.AssertNil(t, err)
+				second, err := build.NewLifecycleExecution(logger, docker, opts)
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, first.AppVolume(), second.AppVolume())
+				h.AssertContains(t, first.AppVolume(), ".workspace")
+			})
+
+			it("names the workspace volume after the workspace name", func() {
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, build.LifecycleOptions{
+					RunImage:        "test",
+					Image:           imageName,
+					Builder:         fakeBuilder,
+					Termui:          fakeTermui,
+					IncrementalSync: true,
+					WorkspaceName:   "some-project",
+				})
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, lifecycle.AppVolume(), "some-project")
+			})
+
+			it("requires the app to be a directory", func() {
+				appFile, err := ioutil.TempFile("", "incremental-sync-app")
+				h.AssertNil(t, err)
+				h.AssertNil(t, appFile.Close())
+				defer os.Remove(appFile.Name())
+
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, build.LifecycleOptions{
+					AppPath:         appFile.Name(),
+					RunImage:        "test",
+					Image:           imageName,
+					Builder:         fakeBuilder,
+					Termui:          fakeTermui,
+					IncrementalSync: true,
+				})
+				h.AssertNil(t, err)
+
+				err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+					return fakePhaseFactory
+				})
+				h.AssertError(t, err, "incremental sync requires the app to be a directory")
+				h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+			})
+		})
+
 		when("Error cases", func() {
 			when("passed invalid", func() {
 				it("fails for cache-image", func() {
@@ -1486,6 +1719,55 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode(expectedNetworkMode))
 			})
 
+			it("resolves the credentials of each image from its keychain", func() {
+				lifecycle := newTestLifecycleExec(t, false, func(options *build.LifecycleOptions) {
+					options.CacheImage = "other.example.com/some/cache"
+					options.Keychain = staticKeychain{registry: "registry.example.com", username: "user", password: "pass"}
+					options.ReferenceKeychains = map[string]authn.Keychain{
+						"other.example.com/some/cache": staticKeychain{registry: "other.example.com", username: "other-user", password: "other-pass"},
+					}
+				})
+				fakePhaseFactory := fakes.NewFakePhaseFactory()
+
+				err := lifecycle.Analyze(context.Background(), "registry.example.com/some/app", "", true, "", false, "test", []string{}, fakeCache, nil, fakePhaseFactory)
+				h.AssertNil(t, err)
+
+				configProvider := fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Env,
+					fmt.Sprintf(`CNB_REGISTRY_AUTH={"other.example.com":"Basic %s","registry.example.com":"Basic %s"}`,
+						base64.StdEncoding.EncodeToString([]byte("other-user:other-pass")),
+						base64.StdEncoding.EncodeToString([]byte("user:pass"))))
+			})
+
+			it("passes the registry auth provided by the user as is", func() {
+				lifecycle := newTestLifecycleExec(t, false, func(options *build.LifecycleOptions) {
+					options.Keychain = staticKeychain{registry: "registry.example.com", username: "user", password: "pass"}
+					options.RegistryAuth = `{"registry.example.com":"Bearer some-token"}`
+				})
+				fakePhaseFactory := fakes.NewFakePhaseFactory()
+
+				err := lifecycle.Analyze(context.Background(), "registry.example.com/some/app", "", true, "", false, "test", []string{}, fakeCache, nil, fakePhaseFactory)
+				h.AssertNil(t, err)
+
+				configProvider := fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Env, `CNB_REGISTRY_AUTH={"registry.example.com":"Bearer some-token"}`)
+			})
+
+			it("fails when images of the same registry use different credentials", func() {
+				lifecycle := newTestLifecycleExec(t, false, func(options *build.LifecycleOptions) {
+					options.Image = name.MustParseReference("registry.example.com/some/app")
+					options.PreviousImage = "registry.example.com/some/app:previous"
+					options.Keychain = staticKeychain{registry: "registry.example.com", username: "user", password: "pass"}
+					options.ReferenceKeychains = map[string]authn.Keychain{
+						"registry.example.com/some/app:previous": staticKeychain{registry: "registry.example.com", username: "other-user", password: "other-pass"},
+					}
+				})
+				fakePhaseFactory := fakes.NewFakePhaseFactory()
+
+				err := lifecycle.Analyze(context.Background(), "registry.example.com/some/app", "", true, "", false, "test", []string{}, fakeCache, nil, fakePhaseFactory)
+				h.AssertError(t, err, "images 'registry.example.com/some/app' and 'registry.example.com/some/app:previous' are in registry 'registry.example.com', but use different credentials")
+			})
+
 			it("configures the phase with root", func() {
 				lifecycle := newTestLifecycleExec(t, false)
 				fakePhaseFactory := fakes.NewFakePhaseFactory()
@@ -2549,6 +2831,24 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				)
 			})
 
+			it("does not mount the launch cache when launch caching is disabled", func() {
+				lifecycle := newTestLifecycleExec(t, false, func(opts *build.LifecycleOptions) {
+					opts.NoLaunchCache = true
+				})
+				fakePhaseFactory := fakes.NewFakePhaseFactory()
+
+				err := lifecycle.Export(context.Background(), "test", "test", false, "", "test", fakeBuildCache, fakeLaunchCache, []string{}, fakePhaseFactory)
+				h.AssertNil(t, err)
+
+				lastCallIndex := len(fakePhaseFactory.NewCalledWithProvider) - 1
+				h.AssertNotEq(t, lastCallIndex, -1)
+
+				configProvider := fakePhaseFactory.NewCalledWithProvider[lastCallIndex]
+				h.AssertSliceContains(t, configProvider.ContainerConfig().Cmd, "-daemon")
+				h.AssertSliceNotContains(t, configProvider.ContainerConfig().Cmd, "-launch-cache")
+				h.AssertSliceNotContains(t, configProvider.HostConfig().Binds, "some-launch-cache:/launch-cache")
+			})
+
 			it("configures the phase with the expected network mode", func() {
 				lifecycle := newTestLifecycleExec(t, false)
 				fakePhaseFactory := fakes.NewFakePhaseFactory()
@@ -2836,6 +3136,18 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 	})
 }
 
+// staticKeychain resolves the same credentials for every image of a registry.
+type staticKeychain struct {
+	registry, username, password string
+}
+
+func (k staticKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	if resource.RegistryStr() != k.registry {
+		return authn.Anonymous, nil
+	}
+	return &authn.Basic{Username: k.username, Password: k.password}, nil
+}
+
 func newTestLifecycleExecErr(t *testing.T, logVerbose bool, ops ...func(*build.LifecycleOptions)) (*build.LifecycleExecution, error) {
 	docker, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.38"))
 	h.AssertNil(t, err)
@@ -10,12 +10,16 @@ import (
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/cache"
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/buildevents"
+	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/phase"
 )
 
 var (
@@ -31,8 +35,12 @@ var (
 	}
 )
 
+// StdinAppPath is the AppPath that streams the application, as a tar archive, from standard input.
+const StdinAppPath = "-"
+
 type Builder interface {
 	Name() string
+	BaseImageName() string
 	UID() int
 	GID() int
 	LifecycleDescriptor() builder.LifecycleDescriptor
@@ -67,6 +75,9 @@ type LifecycleOptions struct {
 	AppPath            string
 	Image              name.Reference
 	Builder            Builder
+	BuilderID          string
+	StackID            string
+	BuildpacksDigest   string
 	LifecycleImage     string
 	RunImage           string
 	ProjectMetadata    platform.ProjectMetadata
@@ -83,6 +94,7 @@ type LifecycleOptions struct {
 	HTTPSProxy         string
 	NoProxy            string
 	Network            string
+	HostGateway        bool
 	AdditionalTags     []string
 	Volumes            []string
 	DefaultProcessType string
@@ -92,6 +104,24 @@ type LifecycleOptions struct {
 	PreviousImage      string
 	SBOMDestinationDir string
 	CreationTime       *time.Time
+	SkipPhases         []string
+	IncrementalSync    bool
+	WorkspaceName      string
+	CacheName          string
+	NoLaunchCache      bool
+	ProfileDir         string
+	Keychain           authn.Keychain
+	ReferenceKeychains map[string]authn.Keychain
+	RegistryAuth       string
+	ProcessImages      map[string]string
+	KeepFailedState    bool
+	OverrideAnalyzed   func(*platform.AnalyzedMetadata)
+	Events             *buildevents.Stream
+	OnPhase            func(phase.Spec)
+	ExcludeLayers      []LayerFilter
+	InsecureRegistries []string
+	CACertificates     []byte
+	RetryPolicy        image.RetryPolicy
 }
 
 func NewLifecycleExecutor(logger logging.Logger, docker client.CommonAPIClient) *LifecycleExecutor {
@@ -105,12 +135,10 @@ func (l *LifecycleExecutor) Execute(ctx context.Context, opts LifecycleOptions)
 	}
 
 	if !opts.Interactive {
-		defer lifecycleExec.Cleanup()
-		return lifecycleExec.Run(ctx, NewDefaultPhaseFactory)
+		return lifecycleExec.runAndCleanup(ctx, NewDefaultPhaseFactory)
 	}
 
 	return opts.Termui.Run(func() {
-		defer lifecycleExec.Cleanup()
-		lifecycleExec.Run(ctx, NewDefaultPhaseFactory)
+		lifecycleExec.runAndCleanup(ctx, NewDefaultPhaseFactory)
 	})
 }
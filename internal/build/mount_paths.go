@@ -38,6 +38,15 @@ func (m mountPaths) stackPath() string {
 	return m.join(m.layersDir(), "stack.toml")
 }
 
+func (m mountPaths) analyzedPath() string {
+	return m.join(m.layersDir(), "analyzed.toml")
+}
+
+// overriddenAnalyzedPath is where the analysis of the analyzer is written with the overrides of the build applied.
+func (m mountPaths) overriddenAnalyzedPath() string {
+	return m.join(m.layersDir(), "pack-analyzed.toml")
+}
+
 func (m mountPaths) projectPath() string {
 	return m.join(m.layersDir(), "project-metadata.toml")
 }
@@ -3,6 +3,7 @@ package build
 import (
 	"context"
 	"io"
+	"path/filepath"
 
 	"github.com/docker/docker/api/types"
 	dcontainer "github.com/docker/docker/api/types/container"
@@ -10,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/profile"
 )
 
 type Phase struct {
@@ -26,10 +28,10 @@ type Phase struct {
 	containerOps        []ContainerOperation
 	postContainerRunOps []ContainerOperation
 	fileFilter          func(string) bool
+	profileDir          string
 }
 
-func (p *Phase) Run(ctx context.Context) error {
-	var err error
+func (p *Phase) Run(ctx context.Context) (err error) {
 	p.ctr, err = p.docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, nil, "")
 	if err != nil {
 		return errors.Wrapf(err, "failed to create '%s' container", p.name)
@@ -41,6 +43,18 @@ func (p *Phase) Run(ctx context.Context) error {
 		}
 	}
 
+	if p.profileDir != "" {
+		stopSampling, sampleErr := profile.SampleContainer(ctx, p.docker, p.ctr.ID, filepath.Join(p.profileDir, p.name+".stats.jsonl"))
+		if sampleErr != nil {
+			return sampleErr
+		}
+		defer func() {
+			if stopErr := stopSampling(); stopErr != nil && err == nil {
+				err = stopErr
+			}
+		}()
+	}
+
 	handler := container.DefaultHandler(p.infoWriter, p.errorWriter)
 	if p.handler != nil {
 		handler = p.handler
@@ -10,6 +10,7 @@ import (
 
 	pcontainer "github.com/buildpacks/pack/internal/container"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildevents"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -17,6 +18,23 @@ const (
 	linuxContainerAdmin   = "root"
 	windowsContainerAdmin = "ContainerAdministrator"
 	platformAPIEnvVar     = "CNB_PLATFORM_API"
+
+	// insecureRegistriesEnvVar lists the registries the lifecycle accesses over plain HTTP, separated by commas.
+	insecureRegistriesEnvVar = "CNB_INSECURE_REGISTRIES"
+
+	// caCertificatesDir holds the additional root certificates of the phase containers, trusted by the lifecycle as
+	// it is listed by SSL_CERT_DIR. The certificates of the system are still trusted, as they are read from files.
+	caCertificatesDir = "/cnb/pack-ca-certificates"
+)
+
+const (
+	// HostGatewayName resolves, in phase containers, to the host running the docker daemon, when
+	// LifecycleOptions.HostGateway is set.
+	HostGatewayName = "host.docker.internal"
+
+	// HostGatewayEnvVar is set in the build environment to HostGatewayName when it is mapped, so that buildpacks can
+	// reach services on the host without knowing the platform they run on.
+	HostGatewayEnvVar = "PACK_HOST_ADDRESS"
 )
 
 type PhaseConfigProviderOperation func(*PhaseConfigProvider)
@@ -53,6 +71,8 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 	ops = append(ops,
 		WithEnv(fmt.Sprintf("%s=%s", platformAPIEnvVar, lifecycleExec.platformAPI.String())),
 		WithLifecycleProxy(lifecycleExec),
+		WithInsecureRegistries(lifecycleExec.opts.InsecureRegistries...),
+		WithCACertificates(lifecycleExec.opts.CACertificates),
 		WithBinds([]string{
 			fmt.Sprintf("%s:%s", lifecycleExec.layersVolume, lifecycleExec.mountPaths.layersDir()),
 			fmt.Sprintf("%s:%s", lifecycleExec.appVolume, lifecycleExec.mountPaths.appDir()),
@@ -63,19 +83,34 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		op(provider)
 	}
 
+	if events := lifecycleExec.opts.Events; events != nil {
+		provider.infoWriter = io.MultiWriter(provider.infoWriter, events.Writer(name, buildevents.Stdout))
+		provider.errorWriter = io.MultiWriter(provider.errorWriter, events.Writer(name, buildevents.Stderr))
+	}
+
+	// Containers sharing the network of the host, or of another container, cannot have mappings.
+	networkMode := provider.hostConf.NetworkMode
+	if lifecycleExec.opts.HostGateway && provider.os != "windows" && string(networkMode) != "host" && !networkMode.IsContainer() {
+		provider.hostConf.ExtraHosts = append(provider.hostConf.ExtraHosts, HostGatewayName+":host-gateway")
+	}
+
 	provider.ctrConf.Cmd = append([]string{"/cnb/lifecycle/" + name}, provider.ctrConf.Cmd...)
 
-	lifecycleExec.logger.Debugf("Running the %s on OS %s with:", style.Symbol(provider.Name()), style.Symbol(provider.os))
+	spec := provider.Spec()
+	lifecycleExec.logger.Debugf("Running the %s on OS %s with:", style.Symbol(spec.Name), style.Symbol(provider.os))
 	lifecycleExec.logger.Debug("Container Settings:")
-	lifecycleExec.logger.Debugf("  Args: %s", style.Symbol(strings.Join(provider.ctrConf.Cmd, " ")))
-	lifecycleExec.logger.Debugf("  System Envs: %s", style.Symbol(strings.Join(sanitized(provider.ctrConf.Env), " ")))
-	lifecycleExec.logger.Debugf("  Image: %s", style.Symbol(provider.ctrConf.Image))
-	lifecycleExec.logger.Debugf("  User: %s", style.Symbol(provider.ctrConf.User))
-	lifecycleExec.logger.Debugf("  Labels: %s", style.Symbol(fmt.Sprintf("%s", provider.ctrConf.Labels)))
+	lifecycleExec.logger.Debugf("  Args: %s", style.Symbol(strings.Join(spec.Cmd, " ")))
+	lifecycleExec.logger.Debugf("  System Envs: %s", style.Symbol(strings.Join(spec.Env, " ")))
+	lifecycleExec.logger.Debugf("  Image: %s", style.Symbol(spec.Image))
+	lifecycleExec.logger.Debugf("  User: %s", style.Symbol(spec.User))
+	lifecycleExec.logger.Debugf("  Labels: %s", style.Symbol(fmt.Sprintf("%s", spec.Labels)))
 
 	lifecycleExec.logger.Debug("Host Settings:")
-	lifecycleExec.logger.Debugf("  Binds: %s", style.Symbol(strings.Join(provider.hostConf.Binds, " ")))
-	lifecycleExec.logger.Debugf("  Network Mode: %s", style.Symbol(string(provider.hostConf.NetworkMode)))
+	lifecycleExec.logger.Debugf("  Binds: %s", style.Symbol(strings.Join(spec.Binds, " ")))
+	lifecycleExec.logger.Debugf("  Network Mode: %s", style.Symbol(spec.NetworkMode))
+	lifecycleExec.logger.Debugf("  Extra Hosts: %s", style.Symbol(strings.Join(spec.ExtraHosts, " ")))
+
+	lifecycleExec.recordPhase(spec)
 
 	if lifecycleExec.opts.Interactive {
 		provider.handler = lifecycleExec.opts.Termui.Handler()
@@ -233,6 +268,29 @@ func WithRegistryAccess(authConfig string) PhaseConfigProviderOperation {
 	}
 }
 
+// WithInsecureRegistries lets the lifecycle access the registries over plain HTTP. Lifecycles implementing Platform
+// APIs without support for insecure registries ignore them.
+func WithInsecureRegistries(registries ...string) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		if len(registries) > 0 {
+			provider.ctrConf.Env = append(provider.ctrConf.Env, fmt.Sprintf("%s=%s", insecureRegistriesEnvVar, strings.Join(registries, ",")))
+		}
+	}
+}
+
+// WithCACertificates makes the lifecycle trust the PEM encoded root certificates of bundle, in addition to those of
+// the system. Windows containers use the certificate store of the system, and do not trust them.
+func WithCACertificates(bundle []byte) PhaseConfigProviderOperation {
+	return func(provider *PhaseConfigProvider) {
+		if len(bundle) == 0 || provider.os == "windows" {
+			return
+		}
+
+		provider.ctrConf.Env = append(provider.ctrConf.Env, "SSL_CERT_DIR="+caCertificatesDir)
+		provider.containerOps = append(provider.containerOps, WriteFile(caCertificatesDir+"/ca-certificates.pem", bundle))
+	}
+}
+
 func WithRoot() PhaseConfigProviderOperation {
 	return func(provider *PhaseConfigProvider) {
 		if provider.os == "windows" {
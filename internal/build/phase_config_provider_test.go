@@ -63,9 +63,42 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			h.AssertSliceContainsMatch(t, phaseConfigProvider.HostConfig().Binds, "pack-app-.*:/workspace")
 
 			h.AssertEq(t, phaseConfigProvider.HostConfig().Isolation, container.IsolationEmpty)
+			h.AssertEq(t, len(phaseConfigProvider.HostConfig().ExtraHosts), 0)
+		})
+
+		when("the host gateway is mapped", func() {
+			it("maps the host gateway", func() {
+				lifecycle := newTestLifecycleExec(t, false, withHostGateway)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertEq(t, phaseConfigProvider.HostConfig().ExtraHosts, []string{"host.docker.internal:host-gateway"})
+			})
+		})
+
+		when("using the network of the host", func() {
+			it("does not map the host gateway", func() {
+				lifecycle := newTestLifecycleExec(t, false, withHostGateway)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle, build.WithNetwork("host"))
+
+				h.AssertEq(t, len(phaseConfigProvider.HostConfig().ExtraHosts), 0)
+			})
 		})
 
 		when("building for Windows", func() {
+			it("does not map the host gateway", func() {
+				fakeBuilderImage := ifakes.NewImage("fake-builder", "", nil)
+				h.AssertNil(t, fakeBuilderImage.SetOS("windows"))
+				fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithImage(fakeBuilderImage))
+				h.AssertNil(t, err)
+				lifecycle := newTestLifecycleExec(t, false, fakes.WithBuilder(fakeBuilder), withHostGateway)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertEq(t, len(phaseConfigProvider.HostConfig().ExtraHosts), 0)
+			})
+
 			it("sets process isolation", func() {
 				fakeBuilderImage := ifakes.NewImage("fake-builder", "", nil)
 				h.AssertNil(t, fakeBuilderImage.SetOS("windows"))
@@ -177,6 +210,37 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("called with WithDaemonTLS", func() {
+			it("points the lifecycle to the certificates copied to the container", func() {
+				lifecycle := newTestLifecycleExec(t, false)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithDaemonAccess("tcp://docker.example.com:2376"),
+					build.WithDaemonTLS(&build.DaemonTLS{CA: []byte("ca"), Cert: []byte("cert"), Key: []byte("key"), Verify: true}),
+				)
+
+				h.AssertSliceContains(t, phaseConfigProvider.ContainerConfig().Env, "DOCKER_HOST=tcp://docker.example.com:2376")
+				h.AssertSliceContains(t, phaseConfigProvider.ContainerConfig().Env, "DOCKER_CERT_PATH=/cnb/pack-docker-certs")
+				h.AssertSliceContains(t, phaseConfigProvider.ContainerConfig().Env, "DOCKER_TLS_VERIFY=1")
+				h.AssertEq(t, len(phaseConfigProvider.ContainerOps()), 1)
+			})
+
+			it("does nothing without TLS", func() {
+				lifecycle := newTestLifecycleExec(t, false)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider(
+					"some-name",
+					lifecycle,
+					build.WithDaemonTLS(nil),
+				)
+
+				h.AssertSliceNotContains(t, phaseConfigProvider.ContainerConfig().Env, "DOCKER_CERT_PATH=/cnb/pack-docker-certs")
+				h.AssertEq(t, len(phaseConfigProvider.ContainerOps()), 0)
+			})
+		})
+
 		when("called with WithEnv", func() {
 			it("sets the environment on the config", func() {
 				lifecycle := newTestLifecycleExec(t, false)
@@ -243,6 +307,49 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the lifecycle has insecure registries", func() {
+			it("lists them in the environment", func() {
+				lifecycle := newTestLifecycleExec(t, false, func(options *build.LifecycleOptions) {
+					options.InsecureRegistries = []string{"localhost:5000", "registry.cluster.local"}
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertSliceContains(
+					t,
+					phaseConfigProvider.ContainerConfig().Env,
+					"CNB_INSECURE_REGISTRIES=localhost:5000,registry.cluster.local",
+				)
+			})
+		})
+
+		when("the lifecycle has CA certificates", func() {
+			it("writes them to the container and trusts them", func() {
+				lifecycle := newTestLifecycleExec(t, false, func(options *build.LifecycleOptions) {
+					options.CACertificates = []byte("some-certificates")
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertSliceContains(t, phaseConfigProvider.ContainerConfig().Env, "SSL_CERT_DIR=/cnb/pack-ca-certificates")
+				h.AssertEq(t, len(phaseConfigProvider.ContainerOps()), 1)
+			})
+
+			it("does not trust them in Windows containers", func() {
+				fakeBuilderImage := ifakes.NewImage("fake-builder", "", nil)
+				h.AssertNil(t, fakeBuilderImage.SetOS("windows"))
+				fakeBuilder, err := fakes.NewFakeBuilder(fakes.WithImage(fakeBuilderImage))
+				h.AssertNil(t, err)
+				lifecycle := newTestLifecycleExec(t, false, fakes.WithBuilder(fakeBuilder), func(options *build.LifecycleOptions) {
+					options.CACertificates = []byte("some-certificates")
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertEq(t, len(phaseConfigProvider.ContainerOps()), 0)
+			})
+		})
+
 		when("called with WithRoot", func() {
 			when("building for non-Windows", func() {
 				it("sets root user on the config", func() {
@@ -328,6 +435,7 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 				h.AssertContains(t, outBuf.String(), "Labels: 'map[author:pack]'")
 				h.AssertContainsMatch(t, outBuf.String(), `Binds: \'\S+:\S+layers \S+:\S+workspace'`)
 				h.AssertContains(t, outBuf.String(), "Network Mode: ''")
+				h.AssertContains(t, outBuf.String(), "Extra Hosts: ''")
 			})
 
 			when("there is registry auth", func() {
@@ -363,3 +471,7 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 }
+
+func withHostGateway(opts *build.LifecycleOptions) {
+	opts.HostGateway = true
+}
@@ -0,0 +1,68 @@
+package build
+
+import (
+	"context"
+
+	"github.com/buildpacks/pack/pkg/buildevents"
+)
+
+// withPhaseEvents decorates the phases created by the factories of phaseFactoryCreator, so that they emit their
+// state, and the progress of the build, to events.
+func withPhaseEvents(phaseFactoryCreator PhaseFactoryCreator, events *buildevents.Stream, total int) PhaseFactoryCreator {
+	return func(l *LifecycleExecution) PhaseFactory {
+		return &eventsPhaseFactory{
+			factory: phaseFactoryCreator(l),
+			events:  events,
+			total:   total,
+		}
+	}
+}
+
+type eventsPhaseFactory struct {
+	factory   PhaseFactory
+	events    *buildevents.Stream
+	completed int
+	total     int
+}
+
+func (f *eventsPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
+	return &eventsPhase{
+		RunnerCleaner: f.factory.New(provider),
+		name:          provider.Name(),
+		factory:       f,
+	}
+}
+
+type eventsPhase struct {
+	RunnerCleaner
+	name    string
+	factory *eventsPhaseFactory
+}
+
+func (p *eventsPhase) Run(ctx context.Context) error {
+	p.factory.events.PhaseStarted(p.name)
+	err := p.RunnerCleaner.Run(ctx)
+	p.factory.events.PhaseFinished(p.name, err)
+	if err == nil {
+		p.factory.completed++
+		p.factory.events.Progress(p.factory.completed, p.factory.total)
+	}
+	return err
+}
+
+// plannedPhases estimates the number of phases the build runs, for reporting its progress.
+func (l *LifecycleExecution) plannedPhases() int {
+	phases := len(l.opts.ProcessImages)
+	if l.opts.UseCreator {
+		return phases + 1
+	}
+
+	phases += 5
+	if l.platformAPI.LessThan("0.7") && l.skipsPhase("analyze") {
+		phases--
+	}
+	if l.opts.ClearCache || l.skipsPhase("restore") {
+		phases--
+	}
+	return phases
+}
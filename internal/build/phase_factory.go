@@ -36,5 +36,6 @@ func (m *DefaultPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
 		containerOps:        provider.containerOps,
 		postContainerRunOps: provider.postContainerRunOps,
 		fileFilter:          m.lifecycleExec.opts.FileFilter,
+		profileDir:          m.lifecycleExec.opts.ProfileDir,
 	}
 }
@@ -0,0 +1,63 @@
+package build
+
+import (
+	"context"
+
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/faults"
+)
+
+// withFaults decorates the phases created by the factories of phaseFactoryCreator, so that injector can make them
+// crash or lose the connection to the daemon instead of running. Their errors are those of actual crashes and
+// disconnects, so that they are handled the same way.
+func withFaults(phaseFactoryCreator PhaseFactoryCreator, injector *faults.Injector) PhaseFactoryCreator {
+	return func(l *LifecycleExecution) PhaseFactory {
+		return &faultsPhaseFactory{
+			factory:  phaseFactoryCreator(l),
+			injector: injector,
+		}
+	}
+}
+
+type faultsPhaseFactory struct {
+	factory  PhaseFactory
+	injector *faults.Injector
+}
+
+func (f *faultsPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
+	return &faultsPhase{
+		RunnerCleaner: f.factory.New(provider),
+		name:          provider.Name(),
+		injector:      f.injector,
+	}
+}
+
+type faultsPhase struct {
+	RunnerCleaner
+	name     string
+	injector *faults.Injector
+	ran      bool
+}
+
+func (p *faultsPhase) Run(ctx context.Context) error {
+	if p.injector.Inject(faults.PhaseCrash, p.name) {
+		return errors.Wrap(container.ExitError{StatusCode: 1}, faults.ErrInjected.Error())
+	}
+	if p.injector.Inject(faults.DaemonDisconnect, p.name) {
+		return errors.Wrap(errors.Wrap(client.ErrorConnectionFailed(""), faults.ErrInjected.Error()), "container start")
+	}
+
+	p.ran = true
+	return p.RunnerCleaner.Run(ctx)
+}
+
+// Cleanup removes the container of the phase, which was not created when a fault was injected.
+func (p *faultsPhase) Cleanup() error {
+	if !p.ran {
+		return nil
+	}
+	return p.RunnerCleaner.Cleanup()
+}
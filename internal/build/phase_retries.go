@@ -0,0 +1,79 @@
+package build
+
+import (
+	"context"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// withRetries decorates the phases created by the factories of phaseFactoryCreator, so that the analyzer and the
+// exporter, which access registries when publishing, run again in a new container when they fail with the generic
+// error of their phase, as allowed by policy.
+// The lifecycle does not report whether the failure was transient, so failures such as denied access are retried too.
+func withRetries(phaseFactoryCreator PhaseFactoryCreator, policy image.RetryPolicy) PhaseFactoryCreator {
+	return func(l *LifecycleExecution) PhaseFactory {
+		exiter := platform.NewExiter(l.platformAPI.String())
+		return &retriesPhaseFactory{
+			factory:   phaseFactoryCreator(l),
+			lifecycle: l,
+			policy:    policy,
+			retryableCodes: map[string]int64{
+				"analyzer": int64(exiter.CodeFor(platform.AnalyzeError)),
+				"exporter": int64(exiter.CodeFor(platform.ExportError)),
+			},
+		}
+	}
+}
+
+type retriesPhaseFactory struct {
+	factory        PhaseFactory
+	lifecycle      *LifecycleExecution
+	policy         image.RetryPolicy
+	retryableCodes map[string]int64
+}
+
+func (f *retriesPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
+	phase := f.factory.New(provider)
+	code, ok := f.retryableCodes[provider.Name()]
+	if !ok {
+		return phase
+	}
+
+	return &retriesPhase{
+		RunnerCleaner: phase,
+		name:          provider.Name(),
+		code:          code,
+		factory:       f,
+	}
+}
+
+type retriesPhase struct {
+	RunnerCleaner
+	name    string
+	code    int64
+	factory *retriesPhaseFactory
+}
+
+func (p *retriesPhase) Run(ctx context.Context) error {
+	attempt := 0
+	return p.factory.policy.DoWhen(ctx, p.factory.lifecycle.logger, "Phase "+style.Symbol(p.name), p.retryable, func() error {
+		attempt++
+		if attempt > 1 {
+			// the container of the failed attempt is replaced by a new one
+			if err := p.RunnerCleaner.Cleanup(); err != nil {
+				p.factory.lifecycle.logger.Debugf("Unable to remove the container of phase %s: %s", style.Symbol(p.name), err)
+			}
+		}
+		return p.RunnerCleaner.Run(ctx)
+	})
+}
+
+func (p *retriesPhase) retryable(err error) bool {
+	var exitErr container.ExitError
+	return errors.As(err, &exitErr) && exitErr.StatusCode == p.code
+}
@@ -0,0 +1,28 @@
+package build
+
+import (
+	"github.com/buildpacks/pack/pkg/phase"
+)
+
+// Spec returns the specification of the container of the phase, as the PhaseConfigProvider of the phase produced it.
+func (p *PhaseConfigProvider) Spec() phase.Spec {
+	var labels map[string]string
+	if len(p.ctrConf.Labels) > 0 {
+		labels = make(map[string]string, len(p.ctrConf.Labels))
+		for k, v := range p.ctrConf.Labels {
+			labels[k] = v
+		}
+	}
+
+	return phase.Spec{
+		Name:        p.name,
+		Image:       p.ctrConf.Image,
+		Cmd:         append([]string(nil), p.ctrConf.Cmd...),
+		Env:         sanitized(p.ctrConf.Env),
+		User:        p.ctrConf.User,
+		Binds:       append([]string(nil), p.hostConf.Binds...),
+		NetworkMode: string(p.hostConf.NetworkMode),
+		ExtraHosts:  append([]string(nil), p.hostConf.ExtraHosts...),
+		Labels:      labels,
+	}
+}
@@ -0,0 +1,133 @@
+package build_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/internal/cache"
+	"github.com/buildpacks/pack/pkg/phase"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the phase specs")
+
+func TestPhaseSpec(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "PhaseSpec", testPhaseSpec, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPhaseSpec(t *testing.T, when spec.G, it spec.S) {
+	var (
+		lifecycle        *build.LifecycleExecution
+		fakePhaseFactory *fakes.FakePhaseFactory
+		buildCache       *fakes.FakeCache
+		launchCache      *fakes.FakeCache
+	)
+
+	it.Before(func() {
+		lifecycle = newTestLifecycleExec(t, false, func(options *build.LifecycleOptions) {
+			options.GID = -1
+		})
+		fakePhaseFactory = fakes.NewFakePhaseFactory()
+
+		buildCache = fakes.NewFakeCache()
+		buildCache.ReturnForType = cache.Volume
+		buildCache.ReturnForName = "some-cache"
+
+		launchCache = fakes.NewFakeCache()
+		launchCache.ReturnForType = cache.Volume
+		launchCache.ReturnForName = "some-launch-cache"
+	})
+
+	// assertGolden compares the spec of the last phase created to testdata/phase-specs/<name>.json, in which the
+	// randomly named volumes of the build are replaced by placeholders.
+	assertGolden := func(name string) {
+		t.Helper()
+
+		h.AssertNotEq(t, len(fakePhaseFactory.NewCalledWithProvider), 0)
+		provider := fakePhaseFactory.NewCalledWithProvider[len(fakePhaseFactory.NewCalledWithProvider)-1]
+		contents, err := json.MarshalIndent(provider.Spec(), "", "  ")
+		h.AssertNil(t, err)
+
+		actual := strings.NewReplacer(
+			lifecycle.LayersVolume(), "<layers-volume>",
+			lifecycle.AppVolume(), "<app-volume>",
+		).Replace(string(contents)) + "\n"
+
+		golden := filepath.Join("testdata", "phase-specs", name+".json")
+		if *updateGolden {
+			h.AssertNil(t, ioutil.WriteFile(golden, []byte(actual), 0644))
+		}
+		expected, err := ioutil.ReadFile(golden)
+		h.AssertNil(t, err)
+		h.AssertEq(t, actual, string(expected))
+	}
+
+	when("#Spec", func() {
+		it("resolves the spec of the creator", func() {
+			err := lifecycle.Create(context.Background(), false, "", false, "some-run-image", "some-repo", "some-network", buildCache, launchCache, []string{"some-tag"}, nil, fakePhaseFactory)
+			h.AssertNil(t, err)
+
+			assertGolden("creator")
+		})
+
+		it("resolves the spec of the detector", func() {
+			err := lifecycle.Detect(context.Background(), "some-network", []string{"some-host-dir:/some-dir"}, fakePhaseFactory)
+			h.AssertNil(t, err)
+
+			assertGolden("detector")
+		})
+
+		it("produces the same spec for the same configuration", func() {
+			h.AssertNil(t, lifecycle.Detect(context.Background(), "some-network", nil, fakePhaseFactory))
+			h.AssertNil(t, lifecycle.Detect(context.Background(), "some-network", nil, fakePhaseFactory))
+
+			h.AssertEq(t, fakePhaseFactory.NewCalledWithProvider[0].Spec(), fakePhaseFactory.NewCalledWithProvider[1].Spec())
+		})
+
+		it("redacts registry credentials", func() {
+			provider := build.NewPhaseConfigProvider("some-phase", lifecycle, build.WithRegistryAccess("some-secret"))
+
+			h.AssertSliceContains(t, provider.Spec().Env, "CNB_REGISTRY_AUTH=<redacted>")
+			h.AssertSliceNotContains(t, provider.Spec().Env, "CNB_REGISTRY_AUTH=some-secret")
+		})
+
+		it("is not changed by later changes to the container", func() {
+			provider := build.NewPhaseConfigProvider("some-phase", lifecycle)
+			spec := provider.Spec()
+			provider.ContainerConfig().Cmd[0] = "some-other-cmd"
+
+			h.AssertEq(t, spec.Cmd[0], "/cnb/lifecycle/some-phase")
+		})
+	})
+
+	when("OnPhase is set", func() {
+		it("is called with the spec of each phase", func() {
+			var specs []phase.Spec
+			lifecycle = newTestLifecycleExec(t, false, func(options *build.LifecycleOptions) {
+				options.OnPhase = func(spec phase.Spec) {
+					specs = append(specs, spec)
+				}
+			})
+
+			h.AssertNil(t, lifecycle.Detect(context.Background(), "some-network", nil, fakePhaseFactory))
+			h.AssertNil(t, lifecycle.Analyze(context.Background(), "some-repo", "some-network", false, "", false, "some-run-image", nil, buildCache, launchCache, fakePhaseFactory))
+
+			h.AssertEq(t, len(specs), 2)
+			h.AssertEq(t, specs[0].Name, "detector")
+			h.AssertEq(t, specs[1].Name, "analyzer")
+		})
+	})
+}
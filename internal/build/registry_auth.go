@@ -0,0 +1,76 @@
+package build
+
+import (
+	"encoding/json"
+
+	"github.com/buildpacks/lifecycle/auth"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// registryAuth returns the value of CNB_REGISTRY_AUTH, with credentials for the registries of refs. The credentials
+// of each reference are resolved from its keychain in ReferenceKeychains, if any, or from Keychain. The lifecycle
+// looks credentials up by registry, so references of the same registry resolving to different credentials are
+// rejected, rather than one of them failing later with an authorization error. A RegistryAuth provided by the user
+// is returned as is, without resolving credentials.
+func (l *LifecycleExecution) registryAuth(refs ...string) (string, error) {
+	if l.opts.RegistryAuth != "" {
+		return l.opts.RegistryAuth, nil
+	}
+
+	overrides := map[string]authn.Keychain{}
+	for ref, keychain := range l.opts.ReferenceKeychains {
+		parsed, err := name.ParseReference(ref, name.WeakValidation)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid image name '%s'", ref)
+		}
+		overrides[parsed.Name()] = keychain
+	}
+
+	defaultKeychain := l.opts.Keychain
+	if defaultKeychain == nil {
+		defaultKeychain = authn.DefaultKeychain
+	}
+
+	composite := map[string]string{}
+	sources := map[string]string{}
+	for _, ref := range refs {
+		if ref == "" {
+			continue
+		}
+
+		keychain := defaultKeychain
+		if parsed, err := name.ParseReference(ref, name.WeakValidation); err == nil {
+			if override, ok := overrides[parsed.Name()]; ok {
+				keychain = override
+			}
+		}
+
+		envVar, err := auth.BuildEnvVar(keychain, ref)
+		if err != nil {
+			return "", err
+		}
+		var refAuth map[string]string
+		if err := json.Unmarshal([]byte(envVar), &refAuth); err != nil {
+			return "", err
+		}
+
+		for registry, header := range refAuth {
+			if existing, ok := composite[registry]; ok && existing != header {
+				return "", errors.Errorf("images %s and %s are in registry %s, but use different credentials",
+					style.Symbol(sources[registry]), style.Symbol(ref), style.Symbol(registry))
+			}
+			composite[registry] = header
+			sources[registry] = ref
+		}
+	}
+
+	envVar, err := json.Marshal(composite)
+	if err != nil {
+		return "", err
+	}
+	return string(envVar), nil
+}
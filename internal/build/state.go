@@ -0,0 +1,139 @@
+package build
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/phase"
+)
+
+// State describes a failed build whose volumes were kept, so that it can be exported and reproduced elsewhere.
+type State struct {
+	// ID of the state, as given to `pack state export`.
+	ID string `json:"id"`
+
+	// Image is the name of the app image which failed to build.
+	Image string `json:"image"`
+
+	// Builder is the name of the builder of the build, and BuilderID its image ID or digest.
+	Builder   string `json:"builder"`
+	BuilderID string `json:"builderId"`
+
+	// OS of the builder, and the user and group owning the files of the volumes.
+	OS  string `json:"os"`
+	UID int    `json:"uid"`
+	GID int    `json:"gid"`
+
+	// PlatformAPI is the Platform API the lifecycle ran with.
+	PlatformAPI string `json:"platformApi"`
+
+	// LayersVolume and AppVolume hold the layers and the workspace of the build.
+	LayersVolume string `json:"layersVolume"`
+	AppVolume    string `json:"appVolume"`
+
+	// LayersDir and AppDir are where the volumes are mounted in the phase containers.
+	LayersDir string `json:"layersDir"`
+	AppDir    string `json:"appDir"`
+
+	// Phases which ran, in order, the last one being the phase which failed.
+	Phases []PhaseState `json:"phases"`
+
+	// Error the build failed with.
+	Error string `json:"error"`
+}
+
+// PhaseState is the specification of a phase which ran, as recorded in a State.
+type PhaseState = phase.Spec
+
+// NewStateID returns a random ID for a build state.
+func NewStateID() string {
+	return randString(10)
+}
+
+// SaveState records the state, replacing any state with the same ID.
+func SaveState(state State) error {
+	contents, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(stateDir(), 0750); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(statePath(state.ID), contents, 0600)
+}
+
+// ReadState reads the state recorded with the given ID.
+func ReadState(id string) (State, error) {
+	contents, err := ioutil.ReadFile(statePath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return State{}, errors.Errorf("no state of a failed build with ID %s", style.Symbol(id))
+		}
+		return State{}, errors.Wrapf(err, "reading state %s", style.Symbol(id))
+	}
+
+	var state State
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return State{}, errors.Wrapf(err, "parsing state %s", style.Symbol(id))
+	}
+	return state, nil
+}
+
+func stateDir() string {
+	return config.StateDir("states")
+}
+
+func statePath(id string) string {
+	return filepath.Join(stateDir(), filepath.Base(id)+".json")
+}
+
+// recordPhase adds the specification of a phase to the state kept when the build fails, and passes it to the
+// OnPhase callback of the build. Phases running the builder record the name of the builder the build was created
+// from, as the builder the phases ran is removed after the build.
+func (l *LifecycleExecution) recordPhase(spec phase.Spec) {
+	if spec.Image == l.opts.Builder.Name() {
+		spec.Image = l.opts.Builder.BaseImageName()
+	}
+
+	l.phases = append(l.phases, spec)
+	if l.opts.OnPhase != nil {
+		l.opts.OnPhase(spec)
+	}
+}
+
+// keepState records the state of the failed build, instead of removing its volumes.
+func (l *LifecycleExecution) keepState(buildErr error) error {
+	state := State{
+		ID:           NewStateID(),
+		Builder:      l.opts.Builder.BaseImageName(),
+		BuilderID:    l.opts.BuilderID,
+		OS:           l.os,
+		UID:          l.opts.Builder.UID(),
+		GID:          l.opts.Builder.GID(),
+		PlatformAPI:  l.platformAPI.String(),
+		LayersVolume: l.layersVolume,
+		AppVolume:    l.appVolume,
+		LayersDir:    l.mountPaths.layersDir(),
+		AppDir:       l.mountPaths.appDir(),
+		Phases:       l.phases,
+		Error:        buildErr.Error(),
+	}
+	if l.opts.Image != nil {
+		state.Image = l.opts.Image.Name()
+	}
+	if err := SaveState(state); err != nil {
+		return err
+	}
+
+	l.logger.Warnf("Kept the volumes of the failed build as state %s", style.Symbol(state.ID))
+	logging.Tip(l.logger, "Export it for a bug report with %s, and remove the volumes %s and %s when done",
+		style.Symbol("pack state export "+state.ID+" <archive>"), style.Symbol(l.layersVolume), style.Symbol(l.appVolume))
+	return nil
+}
@@ -0,0 +1,197 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/snapshot"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// workspaceSync tracks the files of a workspace volume kept between builds, so that only the files of the app which
+// differ from the workspace left by the previous build are copied. The workspace is reset to the app: files the
+// buildpacks changed are copied again, and the volume is recreated when the app or the buildpacks removed or added
+// files. Changes are detected by size and modification time.
+type workspaceSync struct {
+	statePath string
+	changed   map[string]bool
+}
+
+// workspaceState is the state of a workspace volume recorded after a successful build.
+type workspaceState struct {
+	// VolumeCreatedAt is the creation time of the volume, so that a volume recreated since is not trusted.
+	VolumeCreatedAt string `json:"volumeCreatedAt"`
+
+	// Workspace is the snapshot of the contents of the volume.
+	Workspace snapshot.Snapshot `json:"workspace"`
+}
+
+// prepareWorkspaceSync compares the app with the workspace left by the previous build. The state of the previous
+// build is removed, so that a build failing after buildpacks changed the workspace is never trusted. When the state
+// is missing, or the workspace holds files which are not in the app, the workspace volume is recreated and the whole
+// app is copied.
+func (l *LifecycleExecution) prepareWorkspaceSync(ctx context.Context) (*workspaceSync, error) {
+	if fi, err := os.Stat(l.opts.AppPath); err != nil || !fi.IsDir() {
+		return nil, errors.Errorf("incremental sync requires the app to be a directory, but %s is not", style.Symbol(l.opts.AppPath))
+	}
+
+	current, err := snapshot.Take(l.opts.AppPath, l.opts.FileFilter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading app directory %s", style.Symbol(l.opts.AppPath))
+	}
+
+	statePath, err := l.workspaceStatePath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sync := &workspaceSync{
+		statePath: statePath,
+		changed:   map[string]bool{},
+	}
+
+	previous, err := l.previousWorkspace(ctx, statePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "removing workspace state")
+	}
+
+	var changed []string
+	if previous != nil {
+		var extra []string
+		changed, extra = current.Compare(previous)
+		if len(extra) > 0 {
+			l.logger.Debugf("Recreating workspace volume %s, as %s is not in the app", style.Symbol(l.appVolume), style.Symbol(extra[0]))
+			previous = nil
+		}
+	}
+
+	if previous == nil {
+		if err := l.docker.VolumeRemove(ctx, l.appVolume, true); err != nil && !client.IsErrNotFound(err) {
+			return nil, errors.Wrapf(err, "removing workspace volume %s", style.Symbol(l.appVolume))
+		}
+		changed, _ = current.Compare(snapshot.Snapshot{})
+	}
+
+	for _, path := range changed {
+		sync.changed[path] = true
+	}
+	l.logger.Debugf("Copying %d changed files of the app to workspace volume %s", len(changed), style.Symbol(l.appVolume))
+	return sync, nil
+}
+
+// workspaceStatePath returns the path of the state of the workspace volume, keyed by the daemon and the volume, as
+// volumes of the same name on different daemons hold different files.
+func (l *LifecycleExecution) workspaceStatePath(ctx context.Context) (string, error) {
+	info, err := l.docker.Info(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "identifying docker daemon")
+	}
+	daemonID := info.ID
+	if daemonID == "" {
+		daemonID = info.Name
+	}
+	daemonID = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '-'
+		}
+		return r
+	}, daemonID)
+
+	return filepath.Join(config.StateDir("workspaces"), daemonID, l.appVolume+".json"), nil
+}
+
+// previousWorkspace returns the snapshot of the workspace volume recorded by the previous build, or nil when the
+// volume or the state does not exist, or the volume was recreated since.
+func (l *LifecycleExecution) previousWorkspace(ctx context.Context, statePath string) (snapshot.Snapshot, error) {
+	volume, err := l.docker.VolumeInspect(ctx, l.appVolume)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "inspecting workspace volume %s", style.Symbol(l.appVolume))
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Clean(statePath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading workspace state")
+	}
+
+	var state workspaceState
+	if err := json.Unmarshal(contents, &state); err != nil {
+		l.logger.Debugf("Ignoring invalid workspace state %s: %s", style.Symbol(statePath), err)
+		return nil, nil
+	}
+	if state.VolumeCreatedAt != volume.CreatedAt || state.Workspace == nil {
+		l.logger.Debugf("Ignoring workspace state %s, as volume %s was recreated", style.Symbol(statePath), style.Symbol(l.appVolume))
+		return nil, nil
+	}
+	return state.Workspace, nil
+}
+
+// fileFilter restricts fileFilter to the files which changed since the previous build.
+func (s *workspaceSync) fileFilter(fileFilter func(string) bool) func(string) bool {
+	return func(path string) bool {
+		if fileFilter != nil && !fileFilter(path) {
+			return false
+		}
+		return s.changed[filepath.ToSlash(path)]
+	}
+}
+
+// saveWorkspace records the contents of the workspace volume after a successful build, for the next build. The
+// contents are read through a container of the builder, created but never started. The contents of volumes cannot be
+// read from stopped Windows containers, so the volume is recreated by every build on Windows.
+func (l *LifecycleExecution) saveWorkspace(ctx context.Context, sync *workspaceSync) error {
+	if l.os == "windows" {
+		return nil
+	}
+
+	volume, err := l.docker.VolumeInspect(ctx, l.appVolume)
+	if err != nil {
+		return errors.Wrapf(err, "inspecting workspace volume %s", style.Symbol(l.appVolume))
+	}
+
+	ctr, err := l.docker.ContainerCreate(ctx,
+		&container.Config{Image: l.opts.Builder.Name(), Cmd: []string{"/cnb/lifecycle/detector"}},
+		&container.HostConfig{Binds: []string{l.appVolume + ":" + l.mountPaths.appDir()}},
+		nil, nil, "")
+	if err != nil {
+		return errors.Wrapf(err, "creating container to read workspace volume %s", style.Symbol(l.appVolume))
+	}
+	defer l.docker.ContainerRemove(context.Background(), ctr.ID, types.ContainerRemoveOptions{Force: true})
+
+	reader, _, err := l.docker.CopyFromContainer(ctx, ctr.ID, l.mountPaths.appDir())
+	if err != nil {
+		return errors.Wrapf(err, "reading workspace volume %s", style.Symbol(l.appVolume))
+	}
+	defer reader.Close()
+
+	workspace, err := snapshot.FromTar(reader)
+	if err != nil {
+		return errors.Wrapf(err, "reading workspace volume %s", style.Symbol(l.appVolume))
+	}
+
+	contents, err := json.Marshal(workspaceState{VolumeCreatedAt: volume.CreatedAt, Workspace: workspace})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(sync.statePath), 0750); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(sync.statePath, contents, 0600)
+}
@@ -10,6 +10,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -65,8 +66,10 @@ type Builder struct {
 	lifecycle            Lifecycle
 	lifecycleDescriptor  LifecycleDescriptor
 	additionalBuildpacks []buildpack.Buildpack
+	removedBuildpacks    []dist.BuildpackInfo
 	metadata             Metadata
 	mixins               []string
+	runImageMixins       map[string][]string
 	env                  map[string]string
 	uid, gid             int
 	StackID              string
@@ -234,6 +237,52 @@ func (b *Builder) AddBuildpack(bp buildpack.Buildpack) {
 	b.metadata.Buildpacks = append(b.metadata.Buildpacks, bp.Descriptor().Info)
 }
 
+// RemoveBuildpack removes a buildpack from the builder, along with any references to it from the order.
+// If version is empty, the buildpack must be on the builder in a single version.
+func (b *Builder) RemoveBuildpack(id, version string) error {
+	bpInfo, err := b.findBuildpack(id, version)
+	if err != nil {
+		return err
+	}
+
+	if len(b.order) > 0 {
+		order := rewriteOrder(b.order, bpInfo, nil)
+		if len(order) == 0 {
+			return errors.Errorf("removing buildpack %s would leave the builder with an empty order", style.Symbol(bpInfo.FullName()))
+		}
+		b.SetOrder(order)
+	}
+	b.removeBuildpack(bpInfo)
+	return nil
+}
+
+// UpgradeBuildpack replaces every version of a buildpack on the builder with the provided one,
+// and points any references to it from the order at the new version
+func (b *Builder) UpgradeBuildpack(bp buildpack.Buildpack) error {
+	newInfo := bp.Descriptor().Info
+
+	var previous []dist.BuildpackInfo
+	for _, bpInfo := range b.metadata.Buildpacks {
+		if bpInfo.ID == newInfo.ID {
+			previous = append(previous, bpInfo)
+		}
+	}
+	if len(previous) == 0 {
+		return errors.Errorf("buildpack %s is not on the builder", style.Symbol(newInfo.ID))
+	}
+
+	order := b.order
+	for _, bpInfo := range previous {
+		b.removeBuildpack(bpInfo)
+		order = rewriteOrder(order, bpInfo, &newInfo)
+	}
+	b.AddBuildpack(bp)
+	if len(order) > 0 {
+		b.SetOrder(order)
+	}
+	return nil
+}
+
 // SetLifecycle sets the lifecycle of the builder
 func (b *Builder) SetLifecycle(lifecycle Lifecycle) {
 	b.lifecycle = lifecycle
@@ -266,6 +315,12 @@ func (b *Builder) SetStack(stackConfig builder.StackConfig) {
 	}
 }
 
+// SetRunImageMixins sets the mixins of the run images of the builder, keyed by image name.
+// The buildpacks added to the builder are validated against them when the builder is saved.
+func (b *Builder) SetRunImageMixins(runImageMixins map[string][]string) {
+	b.runImageMixins = runImageMixins
+}
+
 // Save saves the builder
 func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) error {
 	logger.Debugf("Creating builder with the following buildpacks:")
@@ -310,11 +365,19 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		return errors.Wrap(err, "validating buildpacks")
 	}
 
+	if err := b.validateRunImageMixins(); err != nil {
+		return err
+	}
+
 	bpLayers := dist.BuildpackLayers{}
 	if _, err := dist.GetLabel(b.image, dist.BuildpackLayersLabel, &bpLayers); err != nil {
 		return errors.Wrapf(err, "getting label %s", dist.BuildpackLayersLabel)
 	}
 
+	if err := b.removeBuildpackLayers(logger, tmpDir, bpLayers); err != nil {
+		return err
+	}
+
 	err = b.addBuildpacks(logger, tmpDir, b.image, b.additionalBuildpacks, bpLayers)
 	if err != nil {
 		return err
@@ -389,6 +452,8 @@ func (b *Builder) addBuildpacks(logger logging.Logger, tmpDir string, image imgu
 		buildpack buildpack.Buildpack
 	}
 
+	// buildpacks are added in the order they were first provided, so that identical inputs produce identical layers
+	var addOrder []string
 	buildpacksToAdd := map[string]buildpackToAdd{}
 	for i, bp := range additionalBuildpacks {
 		// create buildpack directory
@@ -443,6 +508,9 @@ func (b *Builder) addBuildpacks(logger logging.Logger, tmpDir string, image imgu
 		}
 
 		// note: if same id@version is in additionalBuildpacks, last one wins (see warnings above)
+		if _, ok := buildpacksToAdd[bp.Descriptor().Info.FullName()]; !ok {
+			addOrder = append(addOrder, bp.Descriptor().Info.FullName())
+		}
 		buildpacksToAdd[bp.Descriptor().Info.FullName()] = buildpackToAdd{
 			tarPath:   bpLayerTar,
 			diffID:    diffID.String(),
@@ -450,7 +518,8 @@ func (b *Builder) addBuildpacks(logger logging.Logger, tmpDir string, image imgu
 		}
 	}
 
-	for _, bp := range buildpacksToAdd {
+	for _, fullName := range addOrder {
+		bp := buildpacksToAdd[fullName]
 		logger.Debugf("Adding buildpack %s (diffID=%s)", style.Symbol(bp.buildpack.Descriptor().Info.FullName()), bp.diffID)
 		if err := image.AddLayerWithDiffID(bp.tarPath, bp.diffID); err != nil {
 			return errors.Wrapf(err,
@@ -465,6 +534,86 @@ func (b *Builder) addBuildpacks(logger logging.Logger, tmpDir string, image imgu
 	return nil
 }
 
+func (b *Builder) findBuildpack(id, version string) (dist.BuildpackInfo, error) {
+	var matches []dist.BuildpackInfo
+	for _, bpInfo := range b.metadata.Buildpacks {
+		if bpInfo.ID == id && (version == "" || bpInfo.Version == version) {
+			matches = append(matches, bpInfo)
+		}
+	}
+
+	fullName := id
+	if version != "" {
+		fullName = fmt.Sprintf("%s@%s", id, version)
+	}
+	switch len(matches) {
+	case 0:
+		return dist.BuildpackInfo{}, errors.Errorf("buildpack %s is not on the builder", style.Symbol(fullName))
+	case 1:
+		return matches[0], nil
+	default:
+		return dist.BuildpackInfo{}, errors.Errorf("builder has multiple versions of buildpack %s, please specify one of: %s",
+			style.Symbol(id), strings.Join(uniqueVersions(matches), ", "))
+	}
+}
+
+func (b *Builder) removeBuildpack(bpInfo dist.BuildpackInfo) {
+	var remaining []dist.BuildpackInfo
+	for _, existing := range b.metadata.Buildpacks {
+		if existing.ID == bpInfo.ID && existing.Version == bpInfo.Version {
+			continue
+		}
+		remaining = append(remaining, existing)
+	}
+	b.metadata.Buildpacks = remaining
+	b.removedBuildpacks = append(b.removedBuildpacks, bpInfo)
+}
+
+func (b *Builder) removeBuildpackLayers(logger logging.Logger, tmpDir string, bpLayers dist.BuildpackLayers) error {
+	for i, bpInfo := range b.removedBuildpacks {
+		if _, ok := bpLayers[bpInfo.ID][bpInfo.Version]; !ok {
+			continue
+		}
+
+		logger.Debugf("Removing buildpack %s", style.Symbol(bpInfo.FullName()))
+		whiteoutsTar, err := b.whiteoutLayer(filepath.Join(tmpDir, "removed"), i, bpInfo)
+		if err != nil {
+			return err
+		}
+		if err := b.image.AddLayer(whiteoutsTar); err != nil {
+			return errors.Wrapf(err, "adding whiteout layer tar for buildpack %s", style.Symbol(bpInfo.FullName()))
+		}
+
+		delete(bpLayers[bpInfo.ID], bpInfo.Version)
+		if len(bpLayers[bpInfo.ID]) == 0 {
+			delete(bpLayers, bpInfo.ID)
+		}
+	}
+	return nil
+}
+
+// rewriteOrder returns a copy of the order where references to the buildpack are replaced by the replacement,
+// or dropped if there is none. Groups that end up empty are dropped.
+func rewriteOrder(order dist.Order, bpInfo dist.BuildpackInfo, replacement *dist.BuildpackInfo) dist.Order {
+	var rewritten dist.Order
+	for _, entry := range order {
+		var group []dist.BuildpackRef
+		for _, ref := range entry.Group {
+			if ref.ID == bpInfo.ID && (ref.Version == "" || ref.Version == bpInfo.Version) {
+				if replacement == nil {
+					continue
+				}
+				ref.BuildpackInfo = dist.BuildpackInfo{ID: replacement.ID, Version: replacement.Version}
+			}
+			group = append(group, ref)
+		}
+		if len(group) > 0 {
+			rewritten = append(rewritten, dist.OrderEntry{Group: group})
+		}
+	}
+	return rewritten
+}
+
 func processOrder(buildpacks []dist.BuildpackInfo, order dist.Order) (dist.Order, error) {
 	resolvedOrder := dist.Order{}
 
@@ -511,6 +660,33 @@ func hasBuildpackWithVersion(bps []dist.BuildpackInfo, version string) bool {
 	return false
 }
 
+func (b *Builder) validateRunImageMixins() error {
+	var runImageNames []string
+	for name := range b.runImageMixins {
+		runImageNames = append(runImageNames, name)
+	}
+	sort.Strings(runImageNames)
+
+	for _, runImageName := range runImageNames {
+		runMixins := b.runImageMixins[runImageName]
+		if err := stack.ValidateMixins(b.Name(), b.Mixins(), runImageName, runMixins); err != nil {
+			return errors.Wrap(err, "validating run image")
+		}
+
+		mixins := stack.AvailableMixins(b.Mixins(), runMixins)
+		for _, bp := range b.additionalBuildpacks {
+			bpd := bp.Descriptor()
+			if len(bpd.Stacks) == 0 {
+				continue
+			}
+			if err := bpd.EnsureStackSupport(b.StackID, mixins, true); err != nil {
+				return errors.Wrapf(err, "validating buildpacks against run image %s", style.Symbol(runImageName))
+			}
+		}
+	}
+	return nil
+}
+
 func validateBuildpacks(stackID string, mixins []string, lifecycleDescriptor LifecycleDescriptor, allBuildpacks []dist.BuildpackInfo, bpsToValidate []buildpack.Buildpack) error {
 	bpLookup := map[string]interface{}{}
 
@@ -711,6 +887,7 @@ func (b *Builder) embedLifecycleTar(tw archive.TarWriter) error {
 			binaryName := pathMatches[1]
 
 			header.Name = lifecycleDir + "/" + binaryName
+			archive.NormalizeHeader(header, true)
 			err = tw.WriteHeader(header)
 			if err != nil {
 				return errors.Wrapf(err, "failed to write header for '%s'", header.Name)
@@ -782,7 +959,15 @@ func (b *Builder) envLayer(dest string, env map[string]string) (string, error) {
 	lw := b.layerWriterFactory.NewWriter(fh)
 	defer lw.Close()
 
-	for k, v := range env {
+	// write the variables in a stable order, so that identical env produces an identical layer
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := env[k]
 		if err := lw.WriteHeader(&tar.Header{
 			Name:    path.Join(platformDir, "env", k),
 			Size:    int64(len(v)),
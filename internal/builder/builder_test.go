@@ -624,6 +624,23 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 						h.AssertError(t, err, "buildpack 'buildpack-1-id@buildpack-1-version-1' requires missing mixin(s): missing")
 					})
 				})
+
+				when("run image mixins are not satisfied", func() {
+					it("returns an error when the run image is missing mixins of the build image", func() {
+						subject.SetRunImageMixins(map[string][]string{"some/run-image": {"mixinX"}})
+						err := subject.Save(logger, builder.CreatorMetadata{})
+
+						h.AssertError(t, err, "validating run image: 'some/run-image' missing required mixin(s): mixinY")
+					})
+
+					it("returns an error when the run image is missing run mixins of a buildpack", func() {
+						subject.AddBuildpack(bp2v1)
+						subject.SetRunImageMixins(map[string][]string{"some/run-image": {"mixinX", "mixinY"}})
+						err := subject.Save(logger, builder.CreatorMetadata{})
+
+						h.AssertError(t, err, "validating buildpacks against run image 'some/run-image': buildpack 'buildpack-2-id@buildpack-2-version-1' requires missing mixin(s): run:mixinB")
+					})
+				})
 			})
 
 			when("getting layers label", func() {
@@ -903,6 +920,29 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("#RemoveBuildpack", func() {
+			it.Before(func() {
+				subject.AddBuildpack(bp1v1)
+				subject.AddBuildpack(bp1v2)
+				subject.AddBuildpack(bp2v1)
+			})
+
+			it("removes the buildpack metadata", func() {
+				h.AssertNil(t, subject.RemoveBuildpack("buildpack-1-id", "buildpack-1-version-1"))
+				h.AssertEq(t, subject.Buildpacks(), []dist.BuildpackInfo{bp1v2.Descriptor().Info, bp2v1.Descriptor().Info})
+			})
+
+			it("errors when the version is ambiguous", func() {
+				err := subject.RemoveBuildpack("buildpack-1-id", "")
+				h.AssertError(t, err, "builder has multiple versions of buildpack 'buildpack-1-id', please specify one of: buildpack-1-version-1, buildpack-1-version-2")
+			})
+
+			it("errors when the buildpack is not on the builder", func() {
+				err := subject.RemoveBuildpack("buildpack-3-id", "")
+				h.AssertError(t, err, "buildpack 'buildpack-3-id' is not on the builder")
+			})
+		})
+
 		when("#AddBuildpack", func() {
 			it.Before(func() {
 				subject.AddBuildpack(bp1v1)
@@ -26,6 +26,12 @@ type SharedBuilderInfo struct {
 	Name      string `json:"builder_name" yaml:"builder_name" toml:"builder_name"`
 	Trusted   bool   `json:"trusted" yaml:"trusted" toml:"trusted"`
 	IsDefault bool   `json:"default" yaml:"default" toml:"default"`
+
+	// RemoteOnly is set when the builder was only looked up in its registry, never in the docker daemon.
+	RemoteOnly bool `json:"-" yaml:"-" toml:"-"`
+
+	// LocalOnly is set when the builder was only looked up in the docker daemon, never in its registry.
+	LocalOnly bool `json:"-" yaml:"-" toml:"-"`
 }
 
 type BuilderWriterFactory interface {
@@ -76,6 +76,12 @@ func (h *HumanReadable) Print(
 	builderInfo SharedBuilderInfo,
 ) error {
 	if local == nil && remote == nil {
+		if builderInfo.RemoteOnly {
+			return fmt.Errorf("unable to find builder '%s' remotely", builderInfo.Name)
+		}
+		if builderInfo.LocalOnly {
+			return fmt.Errorf("unable to find builder '%s' locally", builderInfo.Name)
+		}
 		return fmt.Errorf("unable to find builder '%s' locally or remotely", builderInfo.Name)
 	}
 
@@ -85,14 +91,17 @@ func (h *HumanReadable) Print(
 		logger.Infof("Inspecting builder: %s\n", style.Symbol(builderInfo.Name))
 	}
 
-	logger.Info("\nREMOTE:\n")
-	err := writeBuilderInfo(logger, localRunImages, remote, remoteErr, builderInfo)
-	if err != nil {
-		return fmt.Errorf("writing remote builder info: %w", err)
+	if !builderInfo.LocalOnly {
+		logger.Info("\nREMOTE:\n")
+		if err := writeBuilderInfo(logger, localRunImages, remote, remoteErr, builderInfo); err != nil {
+			return fmt.Errorf("writing remote builder info: %w", err)
+		}
+	}
+	if builderInfo.RemoteOnly {
+		return nil
 	}
 	logger.Info("\nLOCAL:\n")
-	err = writeBuilderInfo(logger, localRunImages, local, localErr, builderInfo)
-	if err != nil {
+	if err := writeBuilderInfo(logger, localRunImages, local, localErr, builderInfo); err != nil {
 		return fmt.Errorf("writing local builder info: %w", err)
 	}
 
@@ -111,6 +111,12 @@ func (w *StructuredFormat) Print(
 	}
 
 	if outputInfo.LocalInfo == nil && outputInfo.RemoteInfo == nil {
+		if builderInfo.RemoteOnly {
+			return fmt.Errorf("unable to find builder %s remotely", style.Symbol(builderInfo.Name))
+		}
+		if builderInfo.LocalOnly {
+			return fmt.Errorf("unable to find builder %s locally", style.Symbol(builderInfo.Name))
+		}
 		return fmt.Errorf("unable to find builder %s locally or remotely", style.Symbol(builderInfo.Name))
 	}
 
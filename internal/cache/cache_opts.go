@@ -58,7 +58,7 @@ func (c *CacheOpts) Set(value string) error {
 		return err
 	}
 
-	cache := &c.Build
+	cache, bindDir := &c.Build, "build-cache"
 	for _, field := range fields {
 		parts := strings.SplitN(field, "=", 2)
 		if len(parts) != 2 {
@@ -69,9 +69,9 @@ func (c *CacheOpts) Set(value string) error {
 		if key == "type" {
 			switch value {
 			case "build":
-				cache = &c.Build
+				cache, bindDir = &c.Build, "build-cache"
 			case "launch":
-				cache = &c.Launch
+				cache, bindDir = &c.Launch, "launch-cache"
 			default:
 				return errors.Errorf("invalid cache type '%s'", value)
 			}
@@ -85,10 +85,10 @@ func (c *CacheOpts) Set(value string) error {
 			return errors.Errorf("invalid field '%s' must be a key=value pair", field)
 		}
 		key := strings.ToLower(parts[0])
-		value := strings.ToLower(parts[1])
+		value := parts[1]
 		switch key {
 		case "format":
-			switch value {
+			switch strings.ToLower(value) {
 			case "image":
 				cache.Format = CacheImage
 			case "volume":
@@ -105,11 +105,19 @@ func (c *CacheOpts) Set(value string) error {
 		}
 	}
 
-	err = sanitize(c)
-	if err != nil {
-		return err
+	switch {
+	case cache.Format != CacheBind:
+		cache.Source = strings.ToLower(cache.Source)
+	case cache.Source != "":
+		// paths are case sensitive, and only resolved once, as the other cache may be set by another flag
+		resolvedPath, err := filepath.Abs(cache.Source)
+		if err != nil {
+			return errors.Wrap(err, "resolve absolute path")
+		}
+		cache.Source = filepath.Join(resolvedPath, bindDir)
 	}
-	return nil
+
+	return sanitize(c)
 }
 
 func (c *CacheOpts) String() string {
@@ -139,22 +147,5 @@ func sanitize(c *CacheOpts) error {
 		}
 	}
 
-	if c.Build.Format == CacheBind || c.Launch.Format == CacheBind {
-		var (
-			resolvedPath string
-			err          error
-		)
-		if c.Build.Format == CacheBind {
-			if resolvedPath, err = filepath.Abs(c.Build.Source); err != nil {
-				return errors.Wrap(err, "resolve absolute path")
-			}
-			c.Build.Source = filepath.Join(resolvedPath, "build-cache")
-		} else {
-			if resolvedPath, err = filepath.Abs(c.Launch.Source); err != nil {
-				return errors.Wrap(err, "resolve absolute path")
-			}
-			c.Launch.Source = filepath.Join(resolvedPath, "launch-cache")
-		}
-	}
 	return nil
 }
@@ -3,6 +3,7 @@ package cache
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -265,6 +266,24 @@ func testCacheOpts(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 
+		it("keeps the case of the source", func() {
+			var cacheFlags CacheOpts
+			h.AssertNil(t, cacheFlags.Set("type=build;format=bind;source=./Some-Cache"))
+
+			h.AssertEq(t, filepath.Base(filepath.Dir(cacheFlags.Build.Source)), "Some-Cache")
+		})
+
+		it("binds both caches when set by separate flags", func() {
+			var cacheFlags CacheOpts
+			h.AssertNil(t, cacheFlags.Set("type=build;format=bind;source=./some-cache"))
+			h.AssertNil(t, cacheFlags.Set("type=launch;format=bind;source=./some-cache"))
+
+			cwd, err := os.Getwd()
+			h.AssertNil(t, err)
+			h.AssertEq(t, cacheFlags.Build, CacheInfo{Format: CacheBind, Source: filepath.Join(cwd, "some-cache", "build-cache")})
+			h.AssertEq(t, cacheFlags.Launch, CacheInfo{Format: CacheBind, Source: filepath.Join(cwd, "some-cache", "launch-cache")})
+		})
+
 		it("with missing options", func() {
 			successTestCases := []CacheOptTestCase{
 				{
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// CI is a continuous integration service detected from the environment of pack, whose builds keep their build cache
+// where the service can save it between jobs.
+type CI struct {
+	// Name of the service, such as GitHub Actions.
+	Name string
+
+	// CacheDir is the directory the service saves between jobs when configured to, in which the build cache is
+	// bound when the daemon is local.
+	CacheDir string
+
+	// Registry is the registry the service provides, such as to publish the images of the project.
+	Registry string
+
+	// CacheRepository is the repository of Registry keeping the build caches of the images published to Registry,
+	// each in a tag named after the image.
+	CacheRepository string
+}
+
+// DetectCI detects the continuous integration service pack runs in from the environment, as read by getenv.
+func DetectCI(getenv func(string) string) (CI, bool) {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true" && getenv("RUNNER_TEMP") != "":
+		// saved with actions/cache, which runs before the directory is emptied at the end of the job
+		return CI{Name: "GitHub Actions", CacheDir: filepath.Join(getenv("RUNNER_TEMP"), "pack-cache")}, true
+	case getenv("GITLAB_CI") == "true" && getenv("CI_PROJECT_DIR") != "":
+		// GitLab only caches paths in the directory of the project, which are excluded from the app when it is built
+		ci := CI{Name: "GitLab CI", CacheDir: filepath.Join(getenv("CI_PROJECT_DIR"), ".pack-cache")}
+		if registryImage := getenv("CI_REGISTRY_IMAGE"); registryImage != "" && getenv("CI_REGISTRY") != "" {
+			ci.Registry = getenv("CI_REGISTRY")
+			ci.CacheRepository = registryImage + "/pack-cache"
+		}
+		return ci, true
+	case getenv("CIRCLECI") == "true" && getenv("HOME") != "":
+		// saved with save_cache
+		return CI{Name: "CircleCI", CacheDir: filepath.Join(getenv("HOME"), ".cache", "pack")}, true
+	}
+
+	return CI{}, false
+}
+
+// BuildCache returns the build cache of imageName suited to the service: an image of its registry when publishing
+// imageName to it, for which the build has credentials, or a bind to its cache directory when the daemon is local, as
+// bind mounts are made on the host of the daemon. It returns false when the service provides neither, to keep the
+// default volume cache.
+func (ci CI) BuildCache(imageName string, publish bool, dockerHost string) (CacheInfo, bool) {
+	if publish && ci.CacheRepository != "" {
+		if ref, err := name.ParseReference(imageName, name.WeakValidation); err == nil && ref.Context().RegistryStr() == ci.Registry {
+			return CacheInfo{Format: CacheImage, Source: ci.CacheRepository + ":" + cacheTag(ref.Context().RepositoryStr())}, true
+		}
+	}
+
+	if ci.CacheDir != "" && (dockerHost == "" || strings.HasPrefix(dockerHost, "unix://") || strings.HasPrefix(dockerHost, "npipe://")) {
+		return CacheInfo{Format: CacheBind, Source: filepath.Join(ci.CacheDir, "build-cache")}, true
+	}
+
+	return CacheInfo{}, false
+}
+
+// cacheTag returns the tag of the build cache of the images of repository. Tags are limited to 128 characters, so
+// the names of longer repositories are replaced by their hash.
+func cacheTag(repository string) string {
+	tag := strings.ReplaceAll(repository, "/", "_")
+	if len(tag) > 128 {
+		sum := sha256.Sum256([]byte(repository))
+		tag = hex.EncodeToString(sum[:])
+	}
+	return tag
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCI(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CI", testCI, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCI(t *testing.T, when spec.G, it spec.S) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string {
+			return vars[key]
+		}
+	}
+
+	when("#DetectCI", func() {
+		it("detects GitHub Actions", func() {
+			ci, ok := DetectCI(env(map[string]string{"GITHUB_ACTIONS": "true", "RUNNER_TEMP": "/runner/temp"}))
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, ci.Name, "GitHub Actions")
+			h.AssertEq(t, ci.CacheDir, filepath.Join("/runner/temp", "pack-cache"))
+		})
+
+		it("detects the registry of GitLab CI", func() {
+			ci, ok := DetectCI(env(map[string]string{"GITLAB_CI": "true", "CI_PROJECT_DIR": "/builds/group/project", "CI_REGISTRY": "registry.gitlab.com", "CI_REGISTRY_IMAGE": "registry.gitlab.com/group/project"}))
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, ci.CacheDir, filepath.Join("/builds/group/project", ".pack-cache"))
+			h.AssertEq(t, ci.Registry, "registry.gitlab.com")
+			h.AssertEq(t, ci.CacheRepository, "registry.gitlab.com/group/project/pack-cache")
+		})
+
+		it("detects nothing outside of CI", func() {
+			_, ok := DetectCI(env(map[string]string{"HOME": "/home/user"}))
+			h.AssertFalse(t, ok)
+		})
+	})
+
+	when("#BuildCache", func() {
+		var ci CI
+
+		it.Before(func() {
+			ci = CI{Name: "GitLab CI", CacheDir: "/builds/project/.pack-cache", Registry: "registry.gitlab.com", CacheRepository: "registry.gitlab.com/project/pack-cache"}
+		})
+
+		it("keeps the cache of each image in the registry when publishing to it", func() {
+			buildCache, ok := ci.BuildCache("registry.gitlab.com/project/app:1.0", true, "")
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, buildCache, CacheInfo{Format: CacheImage, Source: "registry.gitlab.com/project/pack-cache:project_app"})
+
+			buildCache, ok = ci.BuildCache("registry.gitlab.com/project/worker", true, "")
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, buildCache, CacheInfo{Format: CacheImage, Source: "registry.gitlab.com/project/pack-cache:project_worker"})
+		})
+
+		it("does not keep the cache in the registry when publishing to another registry", func() {
+			buildCache, ok := ci.BuildCache("docker.io/some/app", true, "")
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, buildCache.Format, CacheBind)
+		})
+
+		it("binds the cache directory when the daemon is local", func() {
+			buildCache, ok := ci.BuildCache("registry.gitlab.com/project/app", false, "unix:///var/run/docker.sock")
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, buildCache, CacheInfo{Format: CacheBind, Source: filepath.Join("/builds/project/.pack-cache", "build-cache")})
+		})
+
+		it("keeps the default cache when the daemon is remote", func() {
+			_, ok := ci.BuildCache("registry.gitlab.com/project/app", false, "tcp://docker:2376")
+			h.AssertFalse(t, ok)
+		})
+	})
+}
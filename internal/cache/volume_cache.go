@@ -4,12 +4,14 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/docker/docker/client"
 	"github.com/google/go-containerregistry/pkg/name"
 
 	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/pkg/dist"
 )
 
 type VolumeCache struct {
@@ -17,13 +19,81 @@ type VolumeCache struct {
 	volume string
 }
 
-func NewVolumeCache(imageRef name.Reference, cacheType CacheInfo, suffix string, dockerClient client.CommonAPIClient) *VolumeCache {
-	var volumeName string
-	if cacheType.Source == "" {
-		sum := sha256.Sum256([]byte(imageRef.Name()))
-		vol := paths.FilterReservedNames(fmt.Sprintf("%s-%x", sanitizedRef(imageRef), sum[:6]))
-		volumeName = fmt.Sprintf("pack-cache-%s.%s", vol, suffix)
+// VolumeCacheKey identifies the contents of a cache volume. Caches of the same image built on different stacks or
+// by different buildpacks, or of different scopes, never share a volume, so that layers cached by incompatible
+// buildpacks are never restored, and builds do not need to coordinate access.
+type VolumeCacheKey struct {
+	// ImageRef is the image the cache is used to build.
+	ImageRef name.Reference
+
+	// StackID is the stack of the builder the image is built with, and BuildpacksDigest the digest of its
+	// buildpacks, see BuildpacksDigest. Builders with the same stack and buildpacks share the cache.
+	// When both are empty, the cache is keyed by the image alone, as pack used to, and shared by all builders.
+	StackID          string
+	BuildpacksDigest string
+
+	// Scope is the kind of layers cached, e.g. "build" or "launch".
+	Scope string
+
+	// Name replaces ImageRef, so that builds of different images with the same name share the cache.
+	Name string
+}
+
+// VolumeName returns the name of the volume, derived from a hash of the key.
+func (k VolumeCacheKey) VolumeName() string {
+	subject := sanitizedRef(k.ImageRef)
+	hash := sha256.New()
+	if k.Name != "" {
+		// never the hash of the name of an image
+		subject = k.Name
+		hash.Write([]byte("name\x00"))
+		hash.Write([]byte(k.Name))
 	} else {
+		hash.Write([]byte(k.ImageRef.Name()))
+	}
+	if k.StackID != "" || k.BuildpacksDigest != "" {
+		for _, part := range []string{k.StackID, k.BuildpacksDigest, k.Scope} {
+			hash.Write([]byte{0})
+			hash.Write([]byte(part))
+		}
+	}
+	sum := hash.Sum(nil)
+
+	vol := paths.FilterReservedNames(fmt.Sprintf("%s-%x", subject, sum[:6]))
+	return fmt.Sprintf("pack-cache-%s.%s", vol, k.Scope)
+}
+
+// BuildpacksDigest returns a digest of the ids and versions of buildpacks, whatever their order, so that upgrading,
+// adding or removing a buildpack changes the cache volumes of the builds.
+func BuildpacksDigest(buildpacks []dist.BuildpackInfo) string {
+	ids := make([]string, 0, len(buildpacks))
+	for _, bp := range buildpacks {
+		ids = append(ids, bp.ID+"@"+bp.Version)
+	}
+	sort.Strings(ids)
+
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte(id))
+		hash.Write([]byte{0})
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil))
+}
+
+// VolumeNamePrefix returns the start of the names of the volumes caching the layers of imageRef, whatever the
+// stack, buildpacks and scope, which are followed by a hash of the key and the scope.
+func VolumeNamePrefix(imageRef name.Reference) string {
+	return fmt.Sprintf("pack-cache-%s-", paths.FilterReservedNames(sanitizedRef(imageRef)))
+}
+
+func NewVolumeCache(imageRef name.Reference, cacheType CacheInfo, suffix string, dockerClient client.CommonAPIClient) *VolumeCache {
+	return NewVolumeCacheWithKey(VolumeCacheKey{ImageRef: imageRef, Scope: suffix}, cacheType, dockerClient)
+}
+
+// NewVolumeCacheWithKey returns a cache backed by the volume named after key, unless cacheType names a volume.
+func NewVolumeCacheWithKey(key VolumeCacheKey, cacheType CacheInfo, dockerClient client.CommonAPIClient) *VolumeCache {
+	volumeName := key.VolumeName()
+	if cacheType.Source != "" {
 		volumeName = paths.FilterReservedNames(cacheType.Source)
 	}
 
@@ -17,6 +17,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/internal/cache"
+	"github.com/buildpacks/pack/pkg/dist"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -202,6 +203,95 @@ func testCache(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#NewVolumeCacheWithKey", func() {
+		var ref name.Reference
+
+		it.Before(func() {
+			var err error
+			ref, err = name.ParseReference("my/repo", name.WeakValidation)
+			h.AssertNil(t, err)
+		})
+
+		it("matches the name derived from the image alone when there is no stack or buildpacks", func() {
+			subject := cache.NewVolumeCacheWithKey(cache.VolumeCacheKey{ImageRef: ref, Scope: "build"}, cache.CacheInfo{}, dockerClient)
+			expected := cache.NewVolumeCache(ref, cache.CacheInfo{}, "build", dockerClient)
+			h.AssertEq(t, subject.Name(), expected.Name())
+		})
+
+		it("supplies different volumes for different buildpacks", func() {
+			subject := cache.NewVolumeCacheWithKey(cache.VolumeCacheKey{ImageRef: ref, StackID: "some.stack", BuildpacksDigest: "sha256:aaa", Scope: "build"}, cache.CacheInfo{}, dockerClient)
+			notExpected := cache.NewVolumeCacheWithKey(cache.VolumeCacheKey{ImageRef: ref, StackID: "some.stack", BuildpacksDigest: "sha256:bbb", Scope: "build"}, cache.CacheInfo{}, dockerClient)
+			h.AssertNotEq(t, subject.Name(), notExpected.Name())
+			h.AssertTrue(t, names.RestrictedNamePattern.MatchString(subject.Name()))
+		})
+
+		it("supplies different volumes for different stacks", func() {
+			subject := cache.VolumeCacheKey{ImageRef: ref, StackID: "some.stack", BuildpacksDigest: "sha256:aaa", Scope: "build"}
+			notExpected := cache.VolumeCacheKey{ImageRef: ref, StackID: "other.stack", BuildpacksDigest: "sha256:aaa", Scope: "build"}
+			h.AssertNotEq(t, subject.VolumeName(), notExpected.VolumeName())
+		})
+
+		it("supplies the same volume for the same key", func() {
+			key := cache.VolumeCacheKey{ImageRef: ref, StackID: "some.stack", BuildpacksDigest: "sha256:aaa", Scope: "launch"}
+			subject := cache.NewVolumeCacheWithKey(key, cache.CacheInfo{}, dockerClient)
+			h.AssertEq(t, subject.Name(), key.VolumeName())
+			h.AssertEq(t, subject.Name(), cache.NewVolumeCacheWithKey(key, cache.CacheInfo{}, dockerClient).Name())
+			h.AssertTrue(t, strings.HasSuffix(subject.Name(), ".launch"))
+		})
+
+		it("shares the volume of a named cache between images", func() {
+			other, err := name.ParseReference("my/other-repo", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			key := cache.VolumeCacheKey{ImageRef: ref, StackID: "some.stack", BuildpacksDigest: "sha256:aaa", Scope: "build", Name: "monorepo"}
+			otherKey := cache.VolumeCacheKey{ImageRef: other, StackID: "some.stack", BuildpacksDigest: "sha256:aaa", Scope: "build", Name: "monorepo"}
+			h.AssertEq(t, key.VolumeName(), otherKey.VolumeName())
+			h.AssertTrue(t, strings.HasPrefix(key.VolumeName(), "pack-cache-monorepo-"))
+
+			key.Name = ""
+			h.AssertNotEq(t, otherKey.VolumeName(), key.VolumeName())
+		})
+
+		it("uses the named volume when provided", func() {
+			key := cache.VolumeCacheKey{ImageRef: ref, StackID: "some.stack", BuildpacksDigest: "sha256:aaa", Scope: "build"}
+			subject := cache.NewVolumeCacheWithKey(key, cache.CacheInfo{Format: cache.CacheVolume, Source: "test-volume-name"}, dockerClient)
+			h.AssertEq(t, subject.Name(), "test-volume-name")
+		})
+	})
+
+	when("#BuildpacksDigest", func() {
+		it("does not depend on the order of the buildpacks", func() {
+			digest := cache.BuildpacksDigest([]dist.BuildpackInfo{{ID: "some/bp", Version: "1.0"}, {ID: "other/bp", Version: "2.0"}})
+			h.AssertEq(t, digest, cache.BuildpacksDigest([]dist.BuildpackInfo{{ID: "other/bp", Version: "2.0"}, {ID: "some/bp", Version: "1.0"}}))
+			h.AssertTrue(t, strings.HasPrefix(digest, "sha256:"))
+		})
+
+		it("changes when a buildpack is upgraded", func() {
+			h.AssertNotEq(t,
+				cache.BuildpacksDigest([]dist.BuildpackInfo{{ID: "some/bp", Version: "1.0"}}),
+				cache.BuildpacksDigest([]dist.BuildpackInfo{{ID: "some/bp", Version: "1.1"}}),
+			)
+		})
+	})
+
+	when("#VolumeNamePrefix", func() {
+		it("starts the names of the volumes of the image for any stack, buildpacks and scope", func() {
+			ref, err := name.ParseReference("my/repo", name.WeakValidation)
+			h.AssertNil(t, err)
+			other, err := name.ParseReference("my/repo-other", name.WeakValidation)
+			h.AssertNil(t, err)
+
+			prefix := cache.VolumeNamePrefix(ref)
+			for _, key := range []cache.VolumeCacheKey{
+				{ImageRef: ref, Scope: "build"},
+				{ImageRef: ref, StackID: "some.stack", BuildpacksDigest: "sha256:aaa", Scope: "launch"},
+			} {
+				h.AssertTrue(t, strings.HasPrefix(key.VolumeName(), prefix))
+			}
+			h.AssertFalse(t, strings.HasPrefix(cache.VolumeCacheKey{ImageRef: other, Scope: "build"}.VolumeName(), prefix))
+		})
+	})
+
 	when("#Clear", func() {
 		var (
 			volumeName   string
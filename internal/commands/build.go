@@ -1,6 +1,10 @@
 package commands
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -14,6 +18,9 @@ import (
 
 	"github.com/buildpacks/pack/internal/cache"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/faults"
+	"github.com/buildpacks/pack/internal/logsink"
+	"github.com/buildpacks/pack/internal/profile"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/image"
@@ -44,13 +51,48 @@ type BuildFlags struct {
 	Buildpacks         []string
 	Volumes            []string
 	AdditionalTags     []string
+	Labels             []string
+	ProcessImages      []string
+	InjectLayers       []string
+	SkipPhases         []string
+	IncrementalSync    bool
+	WorkspaceName      string
+	CacheName          string
+	LegacyCacheKey     bool
+	NoLaunchCache      bool
+	ProfileOutput      string
+	Watch              bool
+	WatchInterval      time.Duration
+	Run                bool
+	Ports              []string
 	Workspace          string
 	GID                int
 	PreviousImage      string
 	SBOMDestinationDir string
 	DateTime           string
+	NoProxyForwarding  bool
+	Format             string
+	ExportContainerd   bool
+	Containerd         client.ContainerdExportOptions
+	Emulation          string
+	KeepFailedState    bool
+	CICache            bool
+	Analyzed           client.AnalyzedOptions
+	ExcludeLayers      []string
+	InsecureRegistries []string
+	InjectFaults       []string
+	Lambda             bool
+	LambdaProcessType  string
+	LayerHistory       bool
+	RecordInputs       bool
+	RegistryAuth       string
+	LogSinks           []string
 }
 
+// registryAuthEnv is the environment variable the registry-auth flag defaults to, which the lifecycle reads its
+// credentials from as well.
+const registryAuthEnv = "CNB_REGISTRY_AUTH"
+
 // Build an image from source code
 func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cobra.Command {
 	var flags BuildFlags
@@ -64,14 +106,55 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			"requires an image name, which will be generated from the source code. Build defaults to the current directory, " +
 			"but you can use `--path` to specify another source code directory. Build requires a `builder`, which can either " +
 			"be provided directly to build using `--builder`, or can be set using the `set-default-builder` command. For more " +
-			"on how to use `pack build`, see: https://buildpacks.io/docs/app-developer-guide/build-an-app/.",
-		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			"on how to use `pack build`, see: https://buildpacks.io/docs/app-developer-guide/build-an-app/.\n\nBuild exits with " +
+			"code 3 when detection fails, 4 when the build fails, 5 when exporting or publishing the image fails, and 6 when " +
+			"the docker daemon cannot be reached.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) (buildErr error) {
 			if err := validateBuildFlags(&flags, cfg, packClient, logger); err != nil {
 				return err
 			}
 
+			if len(flags.InjectFaults) > 0 {
+				injector, err := parseFaults(flags.InjectFaults)
+				if err != nil {
+					return err
+				}
+				if err := faults.Install(injector); err != nil {
+					return err
+				}
+				defer faults.Uninstall()
+			}
+
 			imageName := args[0]
 
+			registryAuth := flags.RegistryAuth
+			if registryAuth == "" && flags.Publish {
+				registryAuth = os.Getenv(registryAuthEnv)
+			}
+
+			if flags.CICache && !cmd.Flags().Changed("cache") && flags.CacheImage == "" && flags.CacheName == "" {
+				if ci, ok := cache.DetectCI(os.Getenv); ok {
+					if buildCache, ok := ci.BuildCache(imageName, flags.Publish, os.Getenv("DOCKER_HOST")); ok {
+						flags.Cache.Build = buildCache
+						logger.Infof("Detected %s, keeping the build cache in %s %s", ci.Name, buildCache.Format, style.Symbol(buildCache.Source))
+					}
+				}
+			}
+
+			if flags.ProfileOutput != "" {
+				session, err := profile.Start(flags.ProfileOutput)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if err := session.Stop(); err != nil {
+						logger.Warnf("Unable to write profiles: %s", err)
+						return
+					}
+					logger.Infof("Profiles were written to %s", style.Symbol(flags.ProfileOutput))
+				}()
+			}
+
 			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath)
 			if err != nil {
 				return err
@@ -93,6 +176,29 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return client.NewSoftError()
 			}
 
+			if len(cfg.NativeBuilders) > 0 {
+				arch, err := packClient.DaemonArchitecture(cmd.Context())
+				if err != nil {
+					return err
+				}
+				if alternative := nativeBuilder(cfg, builder, arch); alternative != "" {
+					logger.Infof("Using builder %s, the native %s alternative to builder %s", style.Symbol(alternative), arch, style.Symbol(builder))
+					builder = alternative
+				}
+			}
+
+			if len(flags.LogSinks) > 0 {
+				stopShipping, err := shipLogs(logger, flags.LogSinks, map[string]string{
+					"image":        imageName,
+					"builder":      builder,
+					"pack-version": cmd.Root().Version,
+				})
+				if err != nil {
+					return err
+				}
+				defer func() { stopShipping(buildErr) }()
+			}
+
 			buildpacks := flags.Buildpacks
 
 			env, err := parseEnv(flags.EnvFiles, flags.Env)
@@ -100,6 +206,34 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return err
 			}
 
+			labels, err := parseLabels(flags.Labels)
+			if err != nil {
+				return err
+			}
+
+			var limits config.CacheLimits
+			if cfg.CacheLimits != nil {
+				limits = *cfg.CacheLimits
+			}
+			buildCacheLimits, err := cacheLimits(limits)
+			if err != nil {
+				return err
+			}
+
+			processImages, err := parseProcessImages(flags.ProcessImages)
+			if err != nil {
+				return err
+			}
+
+			var injectedLayers []client.InjectedLayer
+			for _, definitionPath := range flags.InjectLayers {
+				injectedLayer, err := client.ReadInjectedLayer(definitionPath)
+				if err != nil {
+					return err
+				}
+				injectedLayers = append(injectedLayers, injectedLayer)
+			}
+
 			trustBuilder := isTrustedBuilder(cfg, builder) || flags.TrustBuilder
 			if trustBuilder {
 				logger.Debugf("Builder %s is trusted", style.Symbol(builder))
@@ -137,7 +271,19 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			if err != nil {
 				return errors.Wrapf(err, "parsing creation time %s", flags.DateTime)
 			}
-			if err := packClient.Build(cmd.Context(), client.BuildOptions{
+			imageFormat, err := client.ParseImageFormat(flags.Format)
+			if err != nil {
+				return err
+			}
+			emulation, err := client.ParseEmulationPolicy(flags.Emulation)
+			if err != nil {
+				return err
+			}
+			var proxyConfig *client.ProxyConfig
+			if flags.NoProxyForwarding {
+				proxyConfig = &client.ProxyConfig{}
+			}
+			buildOpts := client.BuildOptions{
 				AppPath:           flags.AppPath,
 				Builder:           builder,
 				Registry:          flags.Registry,
@@ -170,7 +316,45 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				Interactive:              flags.Interactive,
 				SBOMDestinationDir:       flags.SBOMDestinationDir,
 				CreationTime:             dateTime,
-			}); err != nil {
+				ProxyConfig:              proxyConfig,
+				ImageFormat:              imageFormat,
+				Labels:                   labels,
+				SkipPhases:               flags.SkipPhases,
+				IncrementalSync:          flags.IncrementalSync,
+				WorkspaceName:            flags.WorkspaceName,
+				CacheName:                flags.CacheName,
+				LegacyCacheKey:           flags.LegacyCacheKey,
+				NoLaunchCache:            flags.NoLaunchCache,
+				ProfileDir:               flags.ProfileOutput,
+				ProcessImages:            processImages,
+				InjectedLayers:           injectedLayers,
+				Emulation:                emulation,
+				KeepFailedState:          flags.KeepFailedState,
+				Analyzed:                 flags.Analyzed,
+				ExcludeLayers:            flags.ExcludeLayers,
+				InsecureRegistries:       flags.InsecureRegistries,
+				LayerHistory:             flags.LayerHistory,
+				RecordInputs:             flags.RecordInputs,
+				RegistryAuth:             registryAuth,
+				CacheLimits:              buildCacheLimits,
+			}
+			if flags.Lambda {
+				buildOpts.Lambda = &client.LambdaOptions{ProcessType: flags.LambdaProcessType}
+			}
+			if flags.ExportContainerd {
+				buildOpts.Containerd = &flags.Containerd
+			}
+
+			if flags.Watch {
+				return packClient.WatchBuild(cmd.Context(), client.WatchBuildOptions{
+					Build:    buildOpts,
+					Interval: flags.WatchInterval,
+					Run:      flags.Run,
+					Ports:    flags.Ports,
+				})
+			}
+
+			if err := packClient.Build(cmd.Context(), buildOpts); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
 			logger.Infof("Successfully built image %s", style.Symbol(imageName))
@@ -182,6 +366,16 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 	return cmd
 }
 
+// nativeBuilder returns the alternative to the builder configured for the architecture, if any
+func nativeBuilder(cfg config.Config, builder, arch string) string {
+	for _, native := range cfg.NativeBuilders {
+		if native.Builder == builder && native.Arch == arch {
+			return native.Alternative
+		}
+	}
+	return ""
+}
+
 func parseTime(providedTime string) (*time.Time, error) {
 	var parsedTime time.Time
 	switch providedTime {
@@ -190,9 +384,13 @@ func parseTime(providedTime string) (*time.Time, error) {
 	case "now":
 		parsedTime = time.Now().UTC()
 	default:
+		if rfcTime, err := time.Parse(time.RFC3339, providedTime); err == nil {
+			parsedTime = rfcTime.UTC()
+			break
+		}
 		intTime, err := strconv.ParseInt(providedTime, 10, 64)
 		if err != nil {
-			return nil, errors.Wrap(err, "parsing unix timestamp")
+			return nil, errors.Wrap(err, "expected 'now', an RFC3339 time or a unix timestamp")
 		}
 		parsedTime = time.Unix(intTime, 0).UTC()
 	}
@@ -200,30 +398,46 @@ func parseTime(providedTime string) (*time.Time, error) {
 }
 
 func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Config) {
-	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
-	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir, zip-formatted file or tar file (optionally gzip compressed), '-' to read a tar from stdin, or URL of a git repository in the form '<url>[#<branch, tag or commit>]' (defaults to current working directory)")
+	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file,\n  a git repository with a buildpack.toml at its root in the form of 'git+<url>[#<ref>]', or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
 	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
 	cmd.Flags().Var(&buildFlags.Cache, "cache",
 		`Cache options used to define cache techniques for build process.
 - Cache as bind: type=<build/launch>;format=bind;source=<path to directory>;
+    - The cache is kept in the build-cache or launch-cache subdirectory of the directory, which CI systems can save and restore. Requires a local daemon.
 - Cache as image: type=<build/launch>;format=image;name=<registry image name>;
 - Cache as volume: type=<build/launch>;format=volume;[name=<volume name>;]
     - If no name is provided, a random name will be generated.
 `)
 	cmd.Flags().StringVar(&buildFlags.CacheImage, "cache-image", "", `Cache build layers in remote registry. Requires --publish`)
+	cmd.Flags().StringVar(&buildFlags.CacheName, "cache-name", "", "Name of the build cache volume to share with the builds of other apps with the same cache name, stack and buildpacks, such as the services of a monorepo, instead of a cache of the image alone.\nBuilds sharing a cache must not run concurrently.")
+	cmd.Flags().BoolVar(&buildFlags.NoLaunchCache, "no-launch-cache", false, "Do not cache the layers of the image for the next build exporting to the daemon, such as for one-off builds")
+	cmd.Flags().BoolVar(&buildFlags.LegacyCacheKey, "legacy-cache-key", false, "Key the cache volumes by the image name alone, instead of also by the stack and buildpacks of the builder, so that switching builders or upgrading buildpacks keeps the cache")
 	cmd.Flags().BoolVar(&buildFlags.ClearCache, "clear-cache", false, "Clear image's associated cache before building")
-	cmd.Flags().StringVar(&buildFlags.DateTime, "creation-time", "", "Desired create time in the output image config. Accepted values are Unix timestamps (e.g., '1641013200'), or 'now'. Platform API version must be at least 0.9 to use this feature.")
+	cmd.Flags().StringVar(&buildFlags.DateTime, "creation-time", "", "Desired create time in the output image config. Accepted values are Unix timestamps (e.g., '1641013200'), RFC3339 times (e.g., '2022-01-01T05:00:00Z'), or 'now'. Platform API version must be at least 0.9 to use this feature.")
 	cmd.Flags().StringVarP(&buildFlags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().StringVar(&buildFlags.Emulation, "emulation", cfg.Emulation, "What to do when the builder is not built for the architecture of the docker daemon, such as an amd64 builder on Apple Silicon. Accepted values are warn, allow and deny (default \"warn\")")
+	cmd.Flags().StringVar(&buildFlags.Format, "format", "", "Media types of the published image. Accepted values are docker and oci. Requires --publish when set to oci (default \"docker\")")
 	cmd.Flags().StringVarP(&buildFlags.DefaultProcessType, "default-process", "D", "", `Set the default process type. (default "web")`)
 	cmd.Flags().StringArrayVarP(&buildFlags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env")+"\nNOTE: These are NOT available at image runtime.")
 	cmd.Flags().StringArrayVar(&buildFlags.EnvFiles, "env-file", []string{}, "Build-time environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed\nNOTE: These are NOT available at image runtime.\"")
-	cmd.Flags().StringVar(&buildFlags.Network, "network", "", "Connect detect and build containers to network")
+	cmd.Flags().StringArrayVar(&buildFlags.Labels, "label", []string{}, "Label to set on the app image, of the form 'key=value'"+stringArrayHelp("label"))
+	cmd.Flags().StringArrayVar(&buildFlags.InjectLayers, "inject-layer", []string{}, "Path to a TOML file defining a layer of files, such as CA certificates, to append to the app image, and to its process images, after they are exported.\nEach [[files]] entry sets a 'source' path on the host, a 'target' path in the image and an optional 'mode'."+stringArrayHelp("inject-layer"))
+	cmd.Flags().StringArrayVar(&buildFlags.ProcessImages, "process-image", []string{}, "Additional image to export from the same build with a different default process, of the form '<image-name>=<process-type>'"+stringArrayHelp("process-image"))
+	cmd.Flags().BoolVar(&buildFlags.Watch, "watch", false, "Rebuild the image whenever the files of the app directory change, until interrupted")
+	cmd.Flags().DurationVar(&buildFlags.WatchInterval, "watch-interval", time.Second, "How often to check the app directory for changes when watching")
+	cmd.Flags().BoolVar(&buildFlags.Run, "run", false, "Run the image after each build, replacing the container of the previous build. Requires --watch")
+	cmd.Flags().StringArrayVar(&buildFlags.Ports, "port", []string{}, "Port of the app to publish when running it, in the form '[[<host ip>:]<host port>:]<container port>[/<protocol>]'.\nA port alone is published on the same port of the host. Defaults to the ports exposed by the image. Requires --run."+stringArrayHelp("port"))
+	cmd.Flags().StringVar(&buildFlags.Network, "network", "", "Connect detect and build containers to network.\nOn Docker Desktop 20.10 or later, where the 'host' network does not reach the host, services on the host are reachable from the containers at 'host.docker.internal', which is provided to buildpacks in $PACK_HOST_ADDRESS")
+	cmd.Flags().BoolVar(&buildFlags.NoProxyForwarding, "no-proxy-forwarding", false, "Do not forward the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the host to the build containers")
 	cmd.Flags().BoolVar(&buildFlags.Publish, "publish", false, "Publish to registry")
 	cmd.Flags().StringVar(&buildFlags.DockerHost, "docker-host", "",
 		`Address to docker daemon that will be exposed to the build container.
-If not set (or set to empty string) the standard socket location will be used.
+If not set (or set to empty string) the socket of a detected podman service, or else the standard socket location will be used.
+A unix DOCKER_HOST whose socket is also at that path in the daemon may be exposed with 'inherit'.
 Special value 'inherit' may be used in which case DOCKER_HOST environment variable will be used.
 This option may set DOCKER_HOST environment variable for the build container if needed.
+When no daemon socket can be exposed to the build container, such as with a remote podman service, use --publish, which does not need it.
 `)
 	cmd.Flags().StringVar(&buildFlags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for analysis, restore, and export when builder is untrusted.`)
 	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
@@ -231,15 +445,38 @@ This option may set DOCKER_HOST environment variable for the build container if
 	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
 	cmd.Flags().StringSliceVarP(&buildFlags.AdditionalTags, "tag", "t", nil, "Additional tags to push the output image to.\nTags should be in the format 'image:tag' or 'repository/image:tag'."+stringSliceHelp("tag"))
 	cmd.Flags().BoolVar(&buildFlags.TrustBuilder, "trust-builder", false, "Trust the provided builder\nAll lifecycle phases will be run in a single container (if supported by the lifecycle).")
-	cmd.Flags().StringArrayVar(&buildFlags.Volumes, "volume", nil, "Mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'.\n- 'host path': Name of the volume or absolute directory path to mount.\n- 'target path': The path where the file or directory is available in the container.\n- 'options' (default \"ro\"): An optional comma separated list of mount options.\n    - \"ro\", volume contents are read-only.\n    - \"rw\", volume contents are readable and writeable.\n    - \"volume-opt=<key>=<value>\", can be specified more than once, takes a key-value pair consisting of the option name and its value."+stringArrayHelp("volume"))
+	cmd.Flags().StringArrayVar(&buildFlags.Volumes, "volume", nil, "Mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'.\n- 'host path': Name of the volume or absolute directory path to mount.\n- 'target path': The path where the file or directory is available in the container. May not be /cnb, /layers, the workspace or a parent of them.\n- 'options' (default \"ro\"): An optional comma separated list of mount options.\n    - \"ro\", volume contents are read-only.\n    - \"rw\", volume contents are readable and writeable.\n    - \"volume-opt=<key>=<value>\", can be specified more than once, takes a key-value pair consisting of the option name and its value."+stringArrayHelp("volume"))
 	cmd.Flags().StringVar(&buildFlags.Workspace, "workspace", "", "Location at which to mount the app dir in the build image")
 	cmd.Flags().IntVar(&buildFlags.GID, "gid", 0, `Override GID of user's group in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
+	cmd.Flags().BoolVar(&buildFlags.ExportContainerd, "export-containerd", false, "Import the image into containerd after building it, using the ctr CLI, for clusters without a registry such as k3s")
+	cmd.Flags().StringVar(&buildFlags.Containerd.Address, "containerd-address", "", "Address of the containerd socket to import the image into, for example /run/k3s/containerd/containerd.sock. Requires --export-containerd")
+	cmd.Flags().StringVar(&buildFlags.Containerd.Namespace, "containerd-namespace", client.DefaultContainerdNamespace, "Containerd namespace to import the image into. Requires --export-containerd")
+	cmd.Flags().StringVar(&buildFlags.Containerd.Snapshotter, "containerd-snapshotter", "", "Snapshotter to unpack the image in containerd with. Requires --export-containerd")
+	cmd.Flags().BoolVar(&buildFlags.Lambda, "lambda", false, "Adapt the image to run as an AWS Lambda function, and fail the build with the list of the requirements of Lambda it does not meet, such as its platform, size, entrypoint, media types and, when publishing, its registry being Amazon ECR")
+	cmd.Flags().StringVar(&buildFlags.LambdaProcessType, "lambda-process-type", "", "Process type the Lambda function runs, which becomes the entrypoint of the image. Requires --lambda (defaults to the default process of the image)")
+	cmd.Flags().BoolVar(&buildFlags.LayerHistory, "layer-history", false, "Describe the buildpack of each layer of the image in its history, as shown by `docker history`. Changes the digest of the image")
+	cmd.Flags().BoolVar(&buildFlags.RecordInputs, "record-inputs", false, "Record the builder, buildpacks, and digests of the source and environment variables of the build in a label of the image, so that `pack verify-image` can check that it reproduces")
+	cmd.Flags().StringVar(&buildFlags.RegistryAuth, "registry-auth", "", "Credentials to pass as is to the lifecycle when publishing, instead of resolving them from the keychain: a JSON object mapping registries to Authorization headers, such as '{\"registry.example.com\": \"Bearer <token>\"}'. Requires --publish (defaults to $"+registryAuthEnv+")")
+	cmd.Flags().StringArrayVar(&buildFlags.LogSinks, "log-sink", cfg.LogSinks, "URL of a log sink to ship the output of the build to, with the image, builder and a build ID attached: syslog://<host>[:<port>], syslog+tcp://<host>[:<port>], fluentd://<host>[:<port>][/<tag>] or an http(s) URL to POST JSON entries to (defaults to the log-sinks of the pack config)"+stringArrayHelp("log-sink"))
+	cmd.Flags().StringVar(&buildFlags.ProfileOutput, "profile-output", "", "Directory to write CPU and heap profiles of pack, and the resource usage of each lifecycle phase container, to")
+	cmd.Flags().BoolVar(&buildFlags.IncrementalSync, "incremental-sync", false, "Keep the workspace of the app between builds, and only copy the files which differ from the workspace left by the previous build.\nThe workspace is reset to the app, discarding the changes of buildpacks. Requires the app to be a directory.")
+	cmd.Flags().StringVar(&buildFlags.WorkspaceName, "workspace-name", "", "Name of the workspace volume to keep between builds of the project, and to only copy the files which changed since the previous build to.\nImplies --incremental-sync. Builds sharing a workspace must not run concurrently.")
+	cmd.Flags().StringVar(&buildFlags.Analyzed.Path, "analyzed", "", "Path of an analyzed.toml replacing the analysis of the previous image made by the lifecycle, for migrations from other platforms.\nEach lifecycle phase runs in its own container when overriding the analysis, which requires a Linux builder with Platform API 0.7 or later.")
+	cmd.Flags().StringVar(&buildFlags.Analyzed.PreviousImage, "analyzed-previous-image", "", "Set the previous image, whose layers are reused, in the analysis made by the lifecycle to a digest reference or (when performing a daemon build) image ID")
+	cmd.Flags().StringVar(&buildFlags.Analyzed.RunImage, "analyzed-run-image", "", "Set the run image in the analysis made by the lifecycle")
+	cmd.Flags().StringArrayVar(&buildFlags.ExcludeLayers, "exclude-layers", nil, "Exclude the layers whose metadata matches <key>=<value>, or <key> for a key set to true, such as dev-only=true, from the app image while keeping them in the cache.\nEach lifecycle phase runs in its own container when excluding layers."+stringArrayHelp("exclude-layers"))
+	cmd.Flags().StringSliceVar(&buildFlags.InsecureRegistries, "insecure-registries", nil, "Registries to access over plain HTTP, in addition to the insecure-registries of the pack config, when fetching the run image and from the lifecycle."+stringSliceHelp("insecure-registries"))
+	cmd.Flags().BoolVar(&buildFlags.CICache, "ci-cache", cfg.CICache == config.CICacheAuto, "When running in GitHub Actions, GitLab CI or CircleCI, keep the build cache where the service saves it between jobs, unless another cache is given.\nThe registry of GitLab is used when publishing the image to it. Set ci-cache = \"auto\" in the pack config to enable by default.")
+	cmd.Flags().BoolVar(&buildFlags.KeepFailedState, "keep-failed-state", false, "Keep the volumes of the build when it fails, so that its state can be exported with 'pack state export' for a bug report")
+	cmd.Flags().StringSliceVar(&buildFlags.SkipPhases, "skip-phases", nil, "Lifecycle phases to skip, when an external system has already performed them. Accepted values are analyze and restore.\nSkipping analyze requires an untrusted builder with Platform API older than 0.7."+stringSliceHelp("skip-phases"))
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
 	if !cfg.Experimental {
 		cmd.Flags().MarkHidden("interactive")
 	}
+	cmd.Flags().StringArrayVar(&buildFlags.InjectFaults, "inject-fault", nil, "Inject a fault into the build, of the form '<kind>=<target>[#<count>]', where kind is phase-crash or daemon-disconnect, targeting a lifecycle phase, or registry-5xx, targeting a registry host.\nRequires pack to be built with the faults build tag."+stringArrayHelp("inject-fault"))
+	cmd.Flags().MarkHidden("inject-fault")
 }
 
 func validateBuildFlags(flags *BuildFlags, cfg config.Config, packClient PackClient, logger logging.Logger) error {
@@ -267,6 +504,22 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, packClient PackCli
 		return errors.New("gid flag must be in the range of 0-2147483647")
 	}
 
+	if flags.Run && !flags.Watch {
+		return errors.New("run flag requires the watch flag")
+	}
+
+	if len(flags.Ports) > 0 && !flags.Run {
+		return errors.New("port flag requires the run flag")
+	}
+
+	if flags.RegistryAuth != "" && !flags.Publish {
+		return errors.New("registry-auth flag requires the publish flag")
+	}
+
+	if flags.LambdaProcessType != "" && !flags.Lambda {
+		return errors.New("lambda-process-type flag requires the lambda flag")
+	}
+
 	if flags.Interactive && !cfg.Experimental {
 		return client.NewExperimentError("Interactive mode is currently experimental.")
 	}
@@ -293,6 +546,91 @@ func parseEnv(envFiles []string, envVars []string) (map[string]string, error) {
 	return env, nil
 }
 
+func parseLabels(labelFlags []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, label := range labelFlags {
+		arr := strings.SplitN(label, "=", 2)
+		if len(arr) != 2 || arr[0] == "" {
+			return nil, errors.Errorf("invalid label '%s': must be of the form 'key=value'", label)
+		}
+		labels[arr[0]] = arr[1]
+	}
+	return labels, nil
+}
+
+func parseProcessImages(processImageFlags []string) (map[string]string, error) {
+	processImages := map[string]string{}
+	for _, processImage := range processImageFlags {
+		arr := strings.SplitN(processImage, "=", 2)
+		if len(arr) != 2 || arr[0] == "" || arr[1] == "" {
+			return nil, errors.Errorf("invalid process image '%s': must be of the form '<image-name>=<process-type>'", processImage)
+		}
+		if _, err := name.ParseReference(arr[0], name.WeakValidation); err != nil {
+			return nil, errors.Wrapf(err, "invalid process image name '%s'", arr[0])
+		}
+		processImages[arr[0]] = arr[1]
+	}
+	return processImages, nil
+}
+
+// teeLogger is implemented by loggers which can copy their output, such as logging.LogWithWriters.
+type teeLogger interface {
+	Tee(out, errOut io.Writer) func()
+}
+
+// shipLogs ships the output of the build, with metadata and a random build ID attached, to the log sinks of targets,
+// until the returned function is called with the error of the build.
+func shipLogs(logger logging.Logger, targets []string, metadata map[string]string) (func(error), error) {
+	tee, ok := logger.(teeLogger)
+	if !ok {
+		return nil, errors.New("shipping logs is not supported by the logger")
+	}
+
+	var sinks []logsink.Sink
+	for _, target := range targets {
+		sink, err := logsink.Open(target)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	buildID := make([]byte, 8)
+	if _, err := rand.Read(buildID); err != nil {
+		return nil, errors.Wrap(err, "generating build ID")
+	}
+	metadata["build-id"] = hex.EncodeToString(buildID)
+	if hostname, err := os.Hostname(); err == nil {
+		metadata["host"] = hostname
+	}
+
+	shipper := logsink.NewShipper(metadata, sinks...)
+	stderr := shipper.Writer(logsink.StreamStderr)
+	untee := tee.Tee(shipper.Writer(logsink.StreamStdout), stderr)
+	return func(buildErr error) {
+		untee()
+		// the error is logged once the command returns, after the logs stop being shipped
+		if buildErr != nil {
+			fmt.Fprintf(stderr, "ERROR: %s\n", buildErr)
+		}
+		if err := shipper.Close(); err != nil {
+			logger.Warnf("Unable to ship the build logs: %s", err)
+		}
+	}, nil
+}
+
+func parseFaults(faultFlags []string) (*faults.Injector, error) {
+	var parsed []faults.Fault
+	for _, faultFlag := range faultFlags {
+		fault, err := faults.Parse(faultFlag)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, fault)
+	}
+	return faults.NewInjector(parsed...), nil
+}
+
 func parseEnvFile(filename string) (map[string]string, error) {
 	out := make(map[string]string)
 	f, err := ioutil.ReadFile(filepath.Clean(filename))
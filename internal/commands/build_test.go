@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -721,6 +722,376 @@ builder = "my-builder"
 			})
 		})
 
+		when("--profile-output", func() {
+			it("profiles pack and forwards the directory to the client", func() {
+				profileDir, err := ioutil.TempDir("", "build-profile-output")
+				h.AssertNil(t, err)
+				defer os.RemoveAll(profileDir)
+
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProfileDir(profileDir)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--profile-output", profileDir})
+				h.AssertNil(t, command.Execute())
+
+				for _, file := range []string{"cpu.pprof", "heap.pprof"} {
+					_, err := os.Stat(filepath.Join(profileDir, file))
+					h.AssertNil(t, err)
+				}
+				h.AssertContains(t, outBuf.String(), "Profiles were written to")
+			})
+		})
+
+		when("--incremental-sync", func() {
+			it("forwards the flag to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithIncrementalSync(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--incremental-sync"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--workspace-name", func() {
+			it("forwards the name to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithWorkspaceName("some-project")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--workspace-name", "some-project"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("phases to skip are specified", func() {
+			it("forwards the phases to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSkipPhases([]string{"analyze", "restore"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--skip-phases", "analyze,restore"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("labels are specified", func() {
+			it("forwards the labels to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLabels(map[string]string{"team": "some-team", "ticket": "ABC-1=2"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--label", "team=some-team", "--label", "ticket=ABC-1=2"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for labels without a value", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--label", "team"})
+				h.AssertError(t, command.Execute(), "invalid label 'team': must be of the form 'key=value'")
+			})
+		})
+
+		when("--export-containerd", func() {
+			it("forwards the containerd options to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithContainerd(&client.ContainerdExportOptions{
+						Address:     "/run/k3s/containerd/containerd.sock",
+						Namespace:   "k8s.io",
+						Snapshotter: "native",
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--export-containerd", "--containerd-address", "/run/k3s/containerd/containerd.sock", "--containerd-snapshotter", "native"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("does not export to containerd by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithContainerd(nil)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--containerd-address", "/run/k3s/containerd/containerd.sock"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--emulation", func() {
+			it("forwards the emulation policy to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithEmulation(client.EmulationDeny)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--emulation", "deny"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for an invalid emulation policy", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--emulation", "sometimes"})
+				h.AssertError(t, command.Execute(), "invalid emulation policy 'sometimes'")
+			})
+		})
+
+		when("--analyzed", func() {
+			it("overrides the analysis of the lifecycle", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithAnalyzed(client.AnalyzedOptions{
+						Path:          "some/analyzed.toml",
+						PreviousImage: "some/image@sha256:abc",
+						RunImage:      "some/run",
+					})).
+					Return(nil)
+
+				command.SetArgs([]string{
+					"image", "--builder", "my-builder",
+					"--analyzed", "some/analyzed.toml",
+					"--analyzed-previous-image", "some/image@sha256:abc",
+					"--analyzed-run-image", "some/run",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--exclude-layers", func() {
+			it("excludes the matching layers from the app image", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithExcludeLayers([]string{"dev-only=true", "stage=test"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--exclude-layers", "dev-only=true", "--exclude-layers", "stage=test"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--insecure-registries", func() {
+			it("accesses the registries over plain HTTP", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithInsecureRegistries([]string{"localhost:5000", "registry.cluster.local"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--insecure-registries", "localhost:5000,registry.cluster.local"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--lambda", func() {
+			it("adapts the image for AWS Lambda", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLambda(&client.LambdaOptions{ProcessType: "handler"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--lambda", "--lambda-process-type", "handler"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when the process type is set without --lambda", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--lambda-process-type", "handler"})
+				h.AssertError(t, command.Execute(), "lambda-process-type flag requires the lambda flag")
+			})
+		})
+
+		when("--registry-auth", func() {
+			it("passes the registry auth to the lifecycle", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithRegistryAuth(`{"registry.example.com":"Bearer some-token"}`)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--publish", "--registry-auth", `{"registry.example.com":"Bearer some-token"}`})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when not publishing", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--registry-auth", `{"registry.example.com":"Bearer some-token"}`})
+				h.AssertError(t, command.Execute(), "registry-auth flag requires the publish flag")
+			})
+		})
+
+		when("--log-sink", func() {
+			it("errors for an invalid log sink", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--log-sink", "ftp://logs.example.com"})
+				h.AssertError(t, command.Execute(), "invalid log sink 'ftp://logs.example.com'")
+			})
+		})
+
+		when("--layer-history", func() {
+			it("describes the layers of the image in its history", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLayerHistory(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--layer-history"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--cache-name", func() {
+			it("shares the named build cache", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithCacheName("some-monorepo")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--cache-name", "some-monorepo"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--legacy-cache-key", func() {
+			it("keys the cache volumes by the image name alone", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLegacyCacheKey(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--legacy-cache-key"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--no-launch-cache", func() {
+			it("disables the launch cache", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithNoLaunchCache(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--no-launch-cache"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--record-inputs", func() {
+			it("records the inputs of the build in the image", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithRecordInputs(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--record-inputs"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("cache-limits are configured", func() {
+			it("prunes the cache volumes exceeding them after the build", func() {
+				cfg.CacheLimits = &config.CacheLimits{MaxSize: "20GB", MaxAge: 720 * time.Hour}
+				command = commands.Build(logger, cfg, mockClient)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithCacheLimits(&client.CacheLimits{MaxSize: 20000000000, MaxAge: 720 * time.Hour})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for an invalid size", func() {
+				cfg.CacheLimits = &config.CacheLimits{MaxSize: "lots"}
+				command = commands.Build(logger, cfg, mockClient)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertError(t, command.Execute(), "invalid maximum cache size 'lots'")
+			})
+		})
+
+		when("a native alternative to the builder is configured", func() {
+			it("builds with the alternative for the architecture of the daemon", func() {
+				cfg.NativeBuilders = []config.NativeBuilder{
+					{Builder: "my-builder", Arch: "amd64", Alternative: "my-builder-amd64"},
+					{Builder: "my-builder", Arch: "arm64", Alternative: "my-builder-arm64"},
+				}
+				command = commands.Build(logger, cfg, mockClient)
+				mockClient.EXPECT().DaemonArchitecture(gomock.Any()).Return("arm64", nil)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBuilder("my-builder-arm64")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Using builder 'my-builder-arm64', the native arm64 alternative to builder 'my-builder'")
+			})
+
+			it("errors when the architecture of the daemon cannot be determined", func() {
+				cfg.NativeBuilders = []config.NativeBuilder{{Builder: "my-builder", Arch: "arm64", Alternative: "my-builder-arm64"}}
+				command = commands.Build(logger, cfg, mockClient)
+				mockClient.EXPECT().DaemonArchitecture(gomock.Any()).Return("", errors.New("getting docker info: daemon unavailable"))
+
+				command.SetArgs([]string{"image", "--builder", "my-builder"})
+				h.AssertError(t, command.Execute(), "daemon unavailable")
+			})
+		})
+
+		when("--inject-layer", func() {
+			it("errors when the layer definition cannot be read", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--inject-layer", "some-missing-layer.toml"})
+				h.AssertError(t, command.Execute(), "reading layer definition 'some-missing-layer.toml'")
+			})
+		})
+
+		when("--process-image", func() {
+			it("forwards the process images to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProcessImages(map[string]string{"image-worker": "worker", "image-cli": "cli"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--process-image", "image-worker=worker", "--process-image", "image-cli=cli"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for process images without a process type", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--process-image", "image-worker"})
+				h.AssertError(t, command.Execute(), "invalid process image 'image-worker': must be of the form '<image-name>=<process-type>'")
+			})
+		})
+
+		when("--inject-fault", func() {
+			it("errors for invalid faults", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--inject-fault", "phase-crash"})
+				h.AssertError(t, command.Execute(), "invalid fault 'phase-crash': must be of the form <kind>=<target>[#<count>]")
+			})
+
+			it("is hidden", func() {
+				h.AssertTrue(t, command.Flags().Lookup("inject-fault").Hidden)
+			})
+		})
+
+		when("--watch", func() {
+			it("watches the app with the build options", func() {
+				mockClient.EXPECT().
+					WatchBuild(gomock.Any(), EqWatchBuildOptions(time.Second, true, "image")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--run"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("uses the provided interval", func() {
+				mockClient.EXPECT().
+					WatchBuild(gomock.Any(), EqWatchBuildOptions(5*time.Second, false, "image")).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--watch-interval", "5s"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("is required by --run", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--run"})
+				h.AssertError(t, command.Execute(), "run flag requires the watch flag")
+			})
+
+			it("publishes the provided ports when running the app", func() {
+				mockClient.EXPECT().
+					WatchBuild(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts client.WatchBuildOptions) error {
+						h.AssertEq(t, opts.Ports, []string{"8080", "127.0.0.1:80:9090"})
+						return nil
+					})
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--run", "--port", "8080", "--port", "127.0.0.1:80:9090"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("requires --run for --port", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--watch", "--port", "8080"})
+				h.AssertError(t, command.Execute(), "port flag requires the run flag")
+			})
+		})
+
 		when("gid flag is provided", func() {
 			when("--gid is a valid value", func() {
 				it("override build option should be set to true", func() {
@@ -822,6 +1193,44 @@ builder = "my-builder"
 			})
 		})
 
+		when("--format", func() {
+			it("passes the image format to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithImageFormat(client.ImageFormatOCI)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--publish", "--format", "oci"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors for an unknown format", func() {
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--format", "v1"})
+				h.AssertError(t, command.Execute(), "invalid image format 'v1'")
+			})
+		})
+
+		when("--no-proxy-forwarding", func() {
+			it("passes an empty proxy config to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProxyConfig(&client.ProxyConfig{})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--no-proxy-forwarding"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("not provided", func() {
+				it("lets the client forward the host's proxy settings", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithProxyConfig(nil)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+		})
+
 		when("--creation-time", func() {
 			when("provided as 'now'", func() {
 				it("passes it to the builder", func() {
@@ -848,6 +1257,26 @@ builder = "my-builder"
 				})
 			})
 
+			when("provided as an RFC3339 time", func() {
+				it("passes it to the builder in UTC", func() {
+					expectedTime, err := time.Parse("2006-01-02T03:04:05Z", "2019-08-19T00:00:01Z")
+					h.AssertNil(t, err)
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithDateTime(&expectedTime)).
+						Return(nil)
+
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--creation-time", "2019-08-19T02:00:01+02:00"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("provided in an unknown format", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"image", "--builder", "my-builder", "--creation-time", "2019-08-19"})
+					h.AssertError(t, command.Execute(), "parsing creation time 2019-08-19: expected 'now', an RFC3339 time or a unix timestamp")
+				})
+			})
+
 			when("not provided", func() {
 				it("is nil", func() {
 					mockClient.EXPECT().
@@ -1028,6 +1457,219 @@ func EqBuildOptionsWithDateTime(t *time.Time) interface{} {
 	}
 }
 
+func EqBuildOptionsWithImageFormat(format client.ImageFormat) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ImageFormat=%s", format),
+		equals: func(o client.BuildOptions) bool {
+			return o.ImageFormat == format
+		},
+	}
+}
+
+func EqBuildOptionsWithProxyConfig(proxyConfig *client.ProxyConfig) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ProxyConfig=%+v", proxyConfig),
+		equals: func(o client.BuildOptions) bool {
+			if proxyConfig == nil {
+				return o.ProxyConfig == nil
+			}
+			return o.ProxyConfig != nil && *o.ProxyConfig == *proxyConfig
+		},
+	}
+}
+
+func EqBuildOptionsWithLabels(labels map[string]string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Labels=%s", labels),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Labels, labels)
+		},
+	}
+}
+
+func EqBuildOptionsWithContainerd(containerd *client.ContainerdExportOptions) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Containerd=%+v", containerd),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Containerd, containerd)
+		},
+	}
+}
+
+func EqBuildOptionsWithAnalyzed(analyzed client.AnalyzedOptions) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Analyzed=%+v", analyzed),
+		equals: func(o client.BuildOptions) bool {
+			return o.Analyzed == analyzed
+		},
+	}
+}
+
+func EqBuildOptionsWithExcludeLayers(filters []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ExcludeLayers=%v", filters),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.ExcludeLayers, filters)
+		},
+	}
+}
+
+func EqBuildOptionsWithInsecureRegistries(registries []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("InsecureRegistries=%v", registries),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.InsecureRegistries, registries)
+		},
+	}
+}
+
+func EqBuildOptionsWithLambda(lambda *client.LambdaOptions) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Lambda=%+v", lambda),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Lambda, lambda)
+		},
+	}
+}
+
+func EqBuildOptionsWithRegistryAuth(registryAuth string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("RegistryAuth=%s", registryAuth),
+		equals: func(o client.BuildOptions) bool {
+			return o.RegistryAuth == registryAuth
+		},
+	}
+}
+
+func EqBuildOptionsWithLayerHistory(layerHistory bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("LayerHistory=%t", layerHistory),
+		equals: func(o client.BuildOptions) bool {
+			return o.LayerHistory == layerHistory
+		},
+	}
+}
+
+func EqBuildOptionsWithCacheName(cacheName string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("CacheName=%s", cacheName),
+		equals: func(o client.BuildOptions) bool {
+			return o.CacheName == cacheName
+		},
+	}
+}
+
+func EqBuildOptionsWithLegacyCacheKey(legacyCacheKey bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("LegacyCacheKey=%t", legacyCacheKey),
+		equals: func(o client.BuildOptions) bool {
+			return o.LegacyCacheKey == legacyCacheKey
+		},
+	}
+}
+
+func EqBuildOptionsWithNoLaunchCache(noLaunchCache bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("NoLaunchCache=%t", noLaunchCache),
+		equals: func(o client.BuildOptions) bool {
+			return o.NoLaunchCache == noLaunchCache
+		},
+	}
+}
+
+func EqBuildOptionsWithRecordInputs(recordInputs bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("RecordInputs=%t", recordInputs),
+		equals: func(o client.BuildOptions) bool {
+			return o.RecordInputs == recordInputs
+		},
+	}
+}
+
+func EqBuildOptionsWithCacheLimits(limits *client.CacheLimits) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("CacheLimits=%+v", limits),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.CacheLimits, limits)
+		},
+	}
+}
+
+func EqBuildOptionsWithEmulation(emulation client.EmulationPolicy) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Emulation=%s", emulation),
+		equals: func(o client.BuildOptions) bool {
+			return o.Emulation == emulation
+		},
+	}
+}
+
+func EqBuildOptionsWithProcessImages(processImages map[string]string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ProcessImages=%s", processImages),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.ProcessImages, processImages)
+		},
+	}
+}
+
+func EqBuildOptionsWithProfileDir(profileDir string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ProfileDir=%s", profileDir),
+		equals: func(o client.BuildOptions) bool {
+			return o.ProfileDir == profileDir
+		},
+	}
+}
+
+func EqBuildOptionsWithWorkspaceName(workspaceName string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("WorkspaceName=%s", workspaceName),
+		equals: func(o client.BuildOptions) bool {
+			return o.WorkspaceName == workspaceName
+		},
+	}
+}
+
+func EqBuildOptionsWithIncrementalSync(incrementalSync bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("IncrementalSync=%t", incrementalSync),
+		equals: func(o client.BuildOptions) bool {
+			return o.IncrementalSync == incrementalSync
+		},
+	}
+}
+
+func EqBuildOptionsWithSkipPhases(phases []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("SkipPhases=%s", phases),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.SkipPhases, phases)
+		},
+	}
+}
+
+func EqWatchBuildOptions(interval time.Duration, run bool, image string) gomock.Matcher {
+	return watchBuildOptionsMatcher{interval: interval, run: run, image: image}
+}
+
+type watchBuildOptionsMatcher struct {
+	interval time.Duration
+	run      bool
+	image    string
+}
+
+func (m watchBuildOptionsMatcher) Matches(x interface{}) bool {
+	if o, ok := x.(client.WatchBuildOptions); ok {
+		return o.Interval == m.interval && o.Run == m.run && o.Build.Image == m.image
+	}
+	return false
+}
+
+func (m watchBuildOptionsMatcher) String() string {
+	return fmt.Sprintf("is a WatchBuildOptions with Interval=%s, Run=%t and Image=%s", m.interval, m.run, m.image)
+}
+
 type buildOptionsMatcher struct {
 	equals      func(client.BuildOptions) bool
 	description string
@@ -8,7 +8,7 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
-func NewBuilderCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+func NewBuilderCommand(logger logging.Logger, cfg config.Config, cfgPath string, client PackClient) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "builder",
 		Aliases: []string{"builders"},
@@ -18,7 +18,10 @@ func NewBuilderCommand(logger logging.Logger, cfg config.Config, client PackClie
 
 	cmd.AddCommand(BuilderCreate(logger, cfg, client))
 	cmd.AddCommand(BuilderInspect(logger, cfg, client, builderwriter.NewFactory()))
-	cmd.AddCommand(BuilderSuggest(logger, client))
+	cmd.AddCommand(BuilderSuggest(logger, cfg, cfgPath, client))
+	cmd.AddCommand(BuilderList(logger, client))
+	cmd.AddCommand(BuilderBuildpack(logger, cfg, client))
+	cmd.AddCommand(experimental(logger, cfg, BuilderAddBuildpack(logger, cfg, client)))
 	AddHelpFlag(cmd, "builder")
 	return cmd
 }
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderAddBuildpackFlags define flags provided to the BuilderAddBuildpack command
+type BuilderAddBuildpackFlags struct {
+	BuilderBuildpackFlags
+	Buildpacks []string
+}
+
+// BuilderAddBuildpack adds buildpacks to an existing builder
+func BuilderAddBuildpack(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderAddBuildpackFlags
+
+	cmd := &cobra.Command{
+		Use:     "add-buildpack <builder-name> --buildpack <buildpack-uri>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Add buildpacks to an existing builder",
+		Example: "pack builder add-buildpack my-builder:bionic --buildpack docker://my/buildpack:1.0.0 --tag my-builder:patched",
+		Long: "Add buildpacks to an existing builder without recreating it from its configuration. " +
+			"Only layers for the new buildpacks are added, and each buildpack is appended to every group of the builder's order.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(flags.Buildpacks) == 0 {
+				return errors.New("at least one --buildpack must be provided")
+			}
+
+			pullPolicy, err := builderBuildpackPullPolicy(flags.BuilderBuildpackFlags, cfg)
+			if err != nil {
+				return err
+			}
+
+			builderName := args[0]
+			if err := pack.AddBuilderBuildpacks(cmd.Context(), client.AddBuilderBuildpacksOptions{
+				BuilderName:   builderName,
+				TargetName:    flags.Tag,
+				BuildpackURIs: flags.Buildpacks,
+				Registry:      flags.Registry,
+				Publish:       flags.Publish,
+				PullPolicy:    pullPolicy,
+			}); err != nil {
+				return err
+			}
+			logger.Infof("Successfully added buildpacks to builder %s", style.Symbol(builderTarget(builderName, flags.Tag)))
+			return nil
+		}),
+	}
+
+	builderBuildpackFlags(cmd, &flags.BuilderBuildpackFlags)
+	cmd.Flags().StringArrayVarP(&flags.Buildpacks, "buildpack", "b", nil, "Buildpack to add to the builder, in any form accepted by `pack builder create`"+stringArrayHelp("buildpack"))
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	if !cfg.Experimental {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+	AddHelpFlag(cmd, "add-buildpack")
+	return cmd
+}
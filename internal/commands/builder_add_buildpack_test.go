@@ -0,0 +1,65 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderAddBuildpackCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderAddBuildpackCommand", testBuilderAddBuildpackCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderAddBuildpackCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderAddBuildpack(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderAddBuildpack", func() {
+		it("adds the buildpacks to the builder", func() {
+			mockClient.EXPECT().AddBuilderBuildpacks(gomock.Any(), client.AddBuilderBuildpacksOptions{
+				BuilderName:   "some/builder",
+				TargetName:    "some/builder:patched",
+				BuildpackURIs: []string{"docker://some/buildpack:1.0.0", "some/other-buildpack.cnb"},
+				PullPolicy:    image.PullAlways,
+			}).Return(nil)
+
+			command.SetArgs([]string{"some/builder", "-b", "docker://some/buildpack:1.0.0", "--buildpack", "some/other-buildpack.cnb", "--tag", "some/builder:patched"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully added buildpacks to builder 'some/builder:patched'")
+		})
+
+		it("requires a buildpack", func() {
+			command.SetArgs([]string{"some/builder"})
+			h.AssertError(t, command.Execute(), "at least one --buildpack must be provided")
+		})
+	})
+}
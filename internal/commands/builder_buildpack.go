@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderBuildpackFlags define flags shared by the builder buildpack commands
+type BuilderBuildpackFlags struct {
+	Tag      string
+	Publish  bool
+	Policy   string
+	Registry string
+}
+
+// BuilderBuildpack groups the commands that modify the buildpacks of an existing builder
+func BuilderBuildpack(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "buildpack",
+		Short: "Modify the buildpacks of an existing builder",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(experimental(logger, cfg, builderBuildpackRemove(logger, cfg, pack)))
+	cmd.AddCommand(experimental(logger, cfg, builderBuildpackUpgrade(logger, cfg, pack)))
+	AddHelpFlag(cmd, "buildpack")
+	return cmd
+}
+
+func builderBuildpackRemove(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderBuildpackFlags
+
+	cmd := &cobra.Command{
+		Use:     "remove <builder-name> <buildpack-id>[@<version>]",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Remove a buildpack from an existing builder",
+		Example: "pack builder buildpack remove my-builder:bionic my/buildpack@1.0.0 --tag my-builder:patched",
+		Long:    "Remove a buildpack from an existing builder, along with any references to it from the builder's order, without recreating the builder from its configuration.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			pullPolicy, err := builderBuildpackPullPolicy(flags, cfg)
+			if err != nil {
+				return err
+			}
+
+			builderName := args[0]
+			id, version := buildpack.ParseIDLocator(args[1])
+			if err := pack.RemoveBuilderBuildpack(cmd.Context(), client.RemoveBuilderBuildpackOptions{
+				BuilderName:      builderName,
+				TargetName:       flags.Tag,
+				BuildpackID:      id,
+				BuildpackVersion: version,
+				Publish:          flags.Publish,
+				PullPolicy:       pullPolicy,
+			}); err != nil {
+				return err
+			}
+			logger.Infof("Successfully removed buildpack %s from builder %s", style.Symbol(args[1]), style.Symbol(builderTarget(builderName, flags.Tag)))
+			return nil
+		}),
+	}
+
+	builderBuildpackFlags(cmd, &flags)
+	AddHelpFlag(cmd, "remove")
+	return cmd
+}
+
+func builderBuildpackUpgrade(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderBuildpackFlags
+
+	cmd := &cobra.Command{
+		Use:     "upgrade <builder-name> <buildpack-uri>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Replace a buildpack on an existing builder with another version",
+		Example: "pack builder buildpack upgrade my-builder:bionic docker://my/buildpack:1.0.1 --tag my-builder:patched",
+		Long:    "Replace every version of a buildpack on an existing builder with the buildpack at the provided URI, and point the builder's order at it, without recreating the builder from its configuration.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			pullPolicy, err := builderBuildpackPullPolicy(flags, cfg)
+			if err != nil {
+				return err
+			}
+
+			builderName := args[0]
+			if err := pack.UpgradeBuilderBuildpack(cmd.Context(), client.UpgradeBuilderBuildpackOptions{
+				BuilderName:  builderName,
+				TargetName:   flags.Tag,
+				BuildpackURI: args[1],
+				Registry:     flags.Registry,
+				Publish:      flags.Publish,
+				PullPolicy:   pullPolicy,
+			}); err != nil {
+				return err
+			}
+			logger.Infof("Successfully upgraded buildpack %s on builder %s", style.Symbol(args[1]), style.Symbol(builderTarget(builderName, flags.Tag)))
+			return nil
+		}),
+	}
+
+	builderBuildpackFlags(cmd, &flags)
+	cmd.Flags().StringVarP(&flags.Registry, "buildpack-registry", "R", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	if !cfg.Experimental {
+		cmd.Flags().MarkHidden("buildpack-registry")
+	}
+	AddHelpFlag(cmd, "upgrade")
+	return cmd
+}
+
+func builderBuildpackFlags(cmd *cobra.Command, flags *BuilderBuildpackFlags) {
+	cmd.Flags().StringVarP(&flags.Tag, "tag", "t", "", "Name of the resulting builder (defaults to the name of the builder being modified)")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Read the builder from and publish the result to a registry")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+}
+
+func builderBuildpackPullPolicy(flags BuilderBuildpackFlags, cfg config.Config) (image.PullPolicy, error) {
+	if flags.Publish && flags.Policy == image.PullNever.String() {
+		return image.PullNever, errors.Errorf("--publish and --pull-policy never cannot be used together. The --publish flag requires the use of remote images.")
+	}
+
+	stringPolicy := flags.Policy
+	if stringPolicy == "" {
+		stringPolicy = cfg.PullPolicy
+	}
+	pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+	if err != nil {
+		return image.PullNever, errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+	}
+	return pullPolicy, nil
+}
+
+func builderTarget(builderName, tag string) string {
+	if tag != "" {
+		return tag
+	}
+	return builderName
+}
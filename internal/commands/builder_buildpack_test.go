@@ -0,0 +1,89 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderBuildpackCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderBuildpackCommand", testBuilderBuildpackCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderBuildpackCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.BuilderBuildpack(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{Experimental: true}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("is experimental", func() {
+		command = commands.BuilderBuildpack(logging.NewLogWithWriters(&outBuf, &outBuf), config.Config{}, mockClient)
+		for _, subcommand := range []string{"remove", "upgrade"} {
+			command.SetArgs([]string{subcommand, "some/builder", "some/buildpack"})
+			h.AssertError(t, command.Execute(), "Command 'buildpack "+subcommand+"' is currently experimental.")
+		}
+	})
+
+	when("remove", func() {
+		it("removes the buildpack from the builder", func() {
+			mockClient.EXPECT().RemoveBuilderBuildpack(gomock.Any(), client.RemoveBuilderBuildpackOptions{
+				BuilderName:      "some/builder",
+				TargetName:       "some/builder:patched",
+				BuildpackID:      "some/buildpack",
+				BuildpackVersion: "1.0.0",
+				PullPolicy:       image.PullAlways,
+			}).Return(nil)
+
+			command.SetArgs([]string{"remove", "some/builder", "some/buildpack@1.0.0", "--tag", "some/builder:patched"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully removed buildpack 'some/buildpack@1.0.0' from builder 'some/builder:patched'")
+		})
+
+		it("errors when publishing with pull policy never", func() {
+			command.SetArgs([]string{"remove", "some/builder", "some/buildpack", "--publish", "--pull-policy", "never"})
+			h.AssertError(t, command.Execute(), "--publish and --pull-policy never cannot be used together")
+		})
+	})
+
+	when("upgrade", func() {
+		it("upgrades the buildpack on the builder", func() {
+			mockClient.EXPECT().UpgradeBuilderBuildpack(gomock.Any(), client.UpgradeBuilderBuildpackOptions{
+				BuilderName:  "some/builder",
+				BuildpackURI: "docker://some/buildpack:1.0.1",
+				Publish:      true,
+				PullPolicy:   image.PullIfNotPresent,
+			}).Return(nil)
+
+			command.SetArgs([]string{"upgrade", "some/builder", "docker://some/buildpack:1.0.1", "--publish", "--pull-policy", "if-not-present"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully upgraded buildpack 'docker://some/buildpack:1.0.1' on builder 'some/builder'")
+		})
+	})
+}
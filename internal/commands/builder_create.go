@@ -21,6 +21,7 @@ type BuilderCreateFlags struct {
 	Publish         bool
 	Registry        string
 	Policy          string
+	Verify          bool
 }
 
 // CreateBuilder creates a builder image, based on a builder config
@@ -67,12 +68,13 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 
 			imageName := args[0]
 			if err := pack.CreateBuilder(cmd.Context(), client.CreateBuilderOptions{
-				RelativeBaseDir: relativeBaseDir,
-				BuilderName:     imageName,
-				Config:          builderConfig,
-				Publish:         flags.Publish,
-				Registry:        flags.Registry,
-				PullPolicy:      pullPolicy,
+				RelativeBaseDir:    relativeBaseDir,
+				BuilderName:        imageName,
+				Config:             builderConfig,
+				Publish:            flags.Publish,
+				Registry:           flags.Registry,
+				PullPolicy:         pullPolicy,
+				VerifyReproducible: flags.Verify,
 			}); err != nil {
 				return err
 			}
@@ -89,6 +91,7 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 	cmd.Flags().StringVarP(&flags.BuilderTomlPath, "config", "c", "", "Path to builder TOML file (required)")
 	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish to registry")
 	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
+	cmd.Flags().BoolVar(&flags.Verify, "verify-reproducible", false, "Create the builder twice and fail if the resulting images differ")
 
 	AddHelpFlag(cmd, "create")
 	return cmd
@@ -15,6 +15,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -153,6 +154,27 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--verify-reproducible", func() {
+			it.Before(func() {
+				h.AssertNil(t, ioutil.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("asks the client to verify the builder is reproducible", func() {
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts client.CreateBuilderOptions) error {
+						h.AssertTrue(t, opts.VerifyReproducible)
+						return nil
+					})
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--verify-reproducible",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("no config provided", func() {
 			it("errors with a descriptive message", func() {
 				command.SetArgs([]string{
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/builder"
@@ -17,6 +18,8 @@ type BuilderInspector interface {
 type BuilderInspectFlags struct {
 	Depth        int
 	OutputFormat string
+	RemoteOnly   bool
+	LocalOnly    bool
 }
 
 func BuilderInspect(logger logging.Logger,
@@ -49,6 +52,8 @@ func BuilderInspect(logger logging.Logger,
 
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", builder.OrderDetectionMaxDepth, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
+	cmd.Flags().BoolVar(&flags.RemoteOnly, "remote-only", false, "Only inspect the builder in its registry, without requiring a docker daemon")
+	cmd.Flags().BoolVar(&flags.LocalOnly, "local-only", false, "Only inspect the builder in the docker daemon, without accessing its registry")
 	AddHelpFlag(cmd, "inspect")
 	return cmd
 }
@@ -61,14 +66,33 @@ func inspectBuilder(
 	inspector BuilderInspector,
 	writerFactory writer.BuilderWriterFactory,
 ) error {
+	if flags.RemoteOnly && flags.LocalOnly {
+		return errors.New("the remote-only and local-only flags cannot be used together")
+	}
+	if flags.RemoteOnly {
+		if err := validateRemoteImageName(imageName); err != nil {
+			return err
+		}
+	}
+
 	builderInfo := writer.SharedBuilderInfo{
-		Name:      imageName,
-		IsDefault: imageName == cfg.DefaultBuilder,
-		Trusted:   isTrustedBuilder(cfg, imageName),
+		Name:       imageName,
+		IsDefault:  imageName == cfg.DefaultBuilder,
+		Trusted:    isTrustedBuilder(cfg, imageName),
+		RemoteOnly: flags.RemoteOnly,
+		LocalOnly:  flags.LocalOnly,
 	}
 
-	localInfo, localErr := inspector.InspectBuilder(imageName, true, client.WithDetectionOrderDepth(flags.Depth))
-	remoteInfo, remoteErr := inspector.InspectBuilder(imageName, false, client.WithDetectionOrderDepth(flags.Depth))
+	var (
+		localInfo, remoteInfo *client.BuilderInfo
+		localErr, remoteErr   error
+	)
+	if !flags.RemoteOnly {
+		localInfo, localErr = inspector.InspectBuilder(imageName, true, client.WithDetectionOrderDepth(flags.Depth))
+	}
+	if !flags.LocalOnly {
+		remoteInfo, remoteErr = inspector.InspectBuilder(imageName, false, client.WithDetectionOrderDepth(flags.Depth))
+	}
 
 	writer, err := writerFactory.Writer(flags.OutputFormat)
 	if err != nil {
@@ -131,6 +131,48 @@ func testBuilderInspectCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("remote-only flag is provided", func() {
+			it("only inspects the remote builder", func() {
+				builderInspector := newDefaultBuilderInspector()
+				writer := newDefaultBuilderWriter()
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newWriterFactory(returnsForWriter(writer)))
+				command.SetArgs([]string{"some/image", "--remote-only"})
+
+				err := command.Execute()
+				assert.Nil(err)
+
+				assert.Equal(builderInspector.ReceivedForLocalName, "")
+				assert.Equal(builderInspector.ReceivedForRemoteName, "some/image")
+				assert.Nil(writer.ReceivedInfoForLocal)
+				assert.Equal(writer.ReceivedInfoForRemote, expectedRemoteInfo)
+				assert.Equal(writer.ReceivedBuilderInfo.RemoteOnly, true)
+			})
+		})
+
+		when("local-only flag is provided", func() {
+			it("only inspects the local builder", func() {
+				builderInspector := newDefaultBuilderInspector()
+				writer := newDefaultBuilderWriter()
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newWriterFactory(returnsForWriter(writer)))
+				command.SetArgs([]string{"some/image", "--local-only"})
+
+				err := command.Execute()
+				assert.Nil(err)
+
+				assert.Equal(builderInspector.ReceivedForLocalName, "some/image")
+				assert.Equal(builderInspector.ReceivedForRemoteName, "")
+				assert.Nil(writer.ReceivedInfoForRemote)
+				assert.Equal(writer.ReceivedInfoForLocal, expectedLocalInfo)
+				assert.Equal(writer.ReceivedBuilderInfo.LocalOnly, true)
+			})
+
+			it("cannot be used with the remote-only flag", func() {
+				command := commands.BuilderInspect(logger, cfg, newDefaultBuilderInspector(), newDefaultWriterFactory())
+				command.SetArgs([]string{"some/image", "--local-only", "--remote-only"})
+				assert.ErrorWithMessage(command.Execute(), "the remote-only and local-only flags cannot be used together")
+			})
+		})
+
 		when("output type is set to json", func() {
 			it("passes json to the writer factory", func() {
 				writerFactory := newDefaultWriterFactory()
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type BuilderListFlags struct {
+	Registries []string
+}
+
+func BuilderList(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags BuilderListFlags
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Args:    cobra.NoArgs,
+		Short:   "List the builders in registry namespaces",
+		Example: "pack builder list --registry gcr.io/my-team",
+		Long: "List the builder images in registry namespaces, with their stack and lifecycle version. " +
+			"Every tag of every repository in the namespaces is inspected, which may take a while for large registries.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(flags.Registries) == 0 {
+				return errors.New("at least one --registry must be provided")
+			}
+
+			builders, err := pack.ListBuilders(cmd.Context(), client.ListBuildersOptions{
+				Namespaces: flags.Registries,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(builders) == 0 {
+				logger.Info("No builders found")
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), 10, 10, 5, ' ', tabwriter.TabIndent)
+			fmt.Fprintln(tw, "BUILDER\tSTACK\tLIFECYCLE\tDESCRIPTION")
+			for _, builder := range builders {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", builder.Image, builder.Stack, builder.LifecycleVersion, builder.Description)
+			}
+			return tw.Flush()
+		}),
+	}
+
+	cmd.Flags().StringSliceVar(&flags.Registries, "registry", nil, "Registry namespace to search for builders, e.g. gcr.io/my-team"+stringSliceHelp("registry"))
+	AddHelpFlag(cmd, "list")
+	return cmd
+}
@@ -0,0 +1,84 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderListCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderListCommand", testBuilderListCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderListCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.BuilderList(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuilderList", func() {
+		it("prints the builders in the namespaces", func() {
+			mockClient.EXPECT().
+				ListBuilders(gomock.Any(), client.ListBuildersOptions{Namespaces: []string{"gcr.io/my-team", "gcr.io/other-team"}}).
+				Return([]client.BuilderSummary{
+					{Image: "gcr.io/my-team/builder:base", Stack: "some.stack.id", LifecycleVersion: "0.14.0", Description: "Some builder"},
+				}, nil)
+
+			command.SetArgs([]string{"--registry", "gcr.io/my-team", "--registry", "gcr.io/other-team"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContainsMatch(t, outBuf.String(), `BUILDER\s+STACK\s+LIFECYCLE\s+DESCRIPTION`)
+			h.AssertContainsMatch(t, outBuf.String(), `gcr.io/my-team/builder:base\s+some.stack.id\s+0.14.0\s+Some builder`)
+		})
+
+		it("reports when no builders are found", func() {
+			mockClient.EXPECT().
+				ListBuilders(gomock.Any(), gomock.Any()).
+				Return(nil, nil)
+
+			command.SetArgs([]string{"--registry", "gcr.io/my-team"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No builders found")
+		})
+
+		it("returns the error of the client", func() {
+			mockClient.EXPECT().
+				ListBuilders(gomock.Any(), gomock.Any()).
+				Return(nil, errors.New("some error"))
+
+			command.SetArgs([]string{"--registry", "gcr.io/my-team"})
+			h.AssertError(t, command.Execute(), "some error")
+		})
+
+		it("requires a registry", func() {
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "at least one --registry must be provided")
+		})
+	})
+}
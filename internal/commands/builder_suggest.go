@@ -1,22 +1,43 @@
 package commands
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
-func BuilderSuggest(logger logging.Logger, inspector BuilderInspector) *cobra.Command {
+func BuilderSuggest(logger logging.Logger, cfg config.Config, cfgPath string, inspector BuilderInspector) *cobra.Command {
+	var setDefault string
+
 	cmd := &cobra.Command{
 		Use:     "suggest",
 		Args:    cobra.NoArgs,
 		Short:   "List the recommended builders",
-		Example: "pack builder suggest",
-		Run: func(cmd *cobra.Command, s []string) {
-			suggestBuilders(logger, inspector)
-		},
+		Example: "pack builder suggest --set-default paketobuildpacks/builder:base",
+		RunE: logError(logger, func(cmd *cobra.Command, s []string) error {
+			if setDefault == "" {
+				suggestBuilders(logger, inspector)
+				return nil
+			}
+
+			if !isSuggestedBuilder(setDefault) {
+				return errors.Errorf("%s is not a suggested builder. Use %s to set any builder as the default",
+					style.Symbol(setDefault), style.Symbol("pack config default-builder"))
+			}
+
+			cfg.DefaultBuilder = setDefault
+			if err := config.Write(cfg, cfgPath); err != nil {
+				return errors.Wrapf(err, "failed to write to config at %s", cfgPath)
+			}
+			logger.Infof("Builder %s is now the default builder", style.Symbol(setDefault))
+			return nil
+		}),
 	}
 
+	cmd.Flags().StringVar(&setDefault, "set-default", "", "Set one of the suggested builders as the default builder, instead of listing them")
 	AddHelpFlag(cmd, "suggest")
 	return cmd
 }
@@ -3,6 +3,9 @@ package commands_test
 import (
 	"bytes"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -13,6 +16,7 @@ import (
 	bldr "github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
@@ -38,6 +42,46 @@ func testSuggestCommand(t *testing.T, when spec.G, it spec.S) {
 		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
 	})
 
+	when("#BuilderSuggest", func() {
+		var (
+			tmpDir     string
+			configPath string
+		)
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "builder-suggest-test")
+			h.AssertNil(t, err)
+			configPath = filepath.Join(tmpDir, "config.toml")
+		})
+
+		it.After(func() {
+			h.AssertNilE(t, os.RemoveAll(tmpDir))
+		})
+
+		when("--set-default", func() {
+			it("sets a suggested builder as the default builder", func() {
+				command := commands.BuilderSuggest(logger, config.Config{}, configPath, mockClient)
+				command.SetArgs([]string{"--set-default", bldr.SuggestedBuilders[0].Image})
+				h.AssertNil(t, command.Execute())
+
+				cfg, err := config.Read(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, cfg.DefaultBuilder, bldr.SuggestedBuilders[0].Image)
+				h.AssertContains(t, outBuf.String(), "is now the default builder")
+			})
+
+			it("rejects builders which are not suggested", func() {
+				command := commands.BuilderSuggest(logger, config.Config{}, configPath, mockClient)
+				command.SetArgs([]string{"--set-default", "some/builder"})
+				h.AssertError(t, command.Execute(), "'some/builder' is not a suggested builder")
+
+				_, err := os.Stat(configPath)
+				h.AssertTrue(t, os.IsNotExist(err))
+			})
+		})
+	})
+
 	when("#WriteSuggestedBuilder", func() {
 		when("description metadata exists", func() {
 			it.Before(func() {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 	"github.com/spf13/cobra"
@@ -17,6 +18,8 @@ import (
 )
 
 func TestBuilderCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
 	spec.Run(t, "BuilderCommand", testBuilderCommand, spec.Parallel(), spec.Report(report.Terminal{}))
 }
 
@@ -31,7 +34,7 @@ func testBuilderCommand(t *testing.T, when spec.G, it spec.S) {
 		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
 		mockController := gomock.NewController(t)
 		mockClient := testmocks.NewMockPackClient(mockController)
-		cmd = commands.NewBuilderCommand(logger, config.Config{}, mockClient)
+		cmd = commands.NewBuilderCommand(logger, config.Config{}, "", mockClient)
 		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
 	})
 
@@ -42,10 +45,15 @@ func testBuilderCommand(t *testing.T, when spec.G, it spec.S) {
 			output := outBuf.String()
 			h.AssertContains(t, output, "Interact with builders")
 			h.AssertContains(t, output, "Usage:")
-			for _, command := range []string{"create", "suggest", "inspect"} {
+			for _, command := range []string{"create", "suggest", "inspect", "list"} {
 				h.AssertContains(t, output, command)
 				h.AssertNotContains(t, output, command+"-builder")
 			}
 		})
+
+		it("gates add-buildpack behind experimental features", func() {
+			cmd.SetArgs([]string{"add-buildpack", "some/builder", "--buildpack", "some/buildpack"})
+			h.AssertError(t, cmd.Execute(), "Command 'builder add-buildpack' is currently experimental.")
+		})
 	})
 }
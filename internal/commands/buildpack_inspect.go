@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/config"
@@ -12,9 +13,10 @@ import (
 )
 
 type BuildpackInspectFlags struct {
-	Depth    int
-	Registry string
-	Verbose  bool
+	Depth        int
+	Registry     string
+	Verbose      bool
+	OutputFormat string
 }
 
 func BuildpackInspect(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
@@ -38,26 +40,41 @@ func BuildpackInspect(logger logging.Logger, cfg config.Config, client PackClien
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", -1, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
 	cmd.Flags().StringVarP(&flags.Registry, "registry", "r", "", "buildpack registry that may be searched")
 	cmd.Flags().BoolVarP(&flags.Verbose, "verbose", "v", false, "show more output")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display buildpack detail (json, human-readable).\nOmission of this flag will display as human-readable.")
 	AddHelpFlag(cmd, "inspect")
 	return cmd
 }
 
 func buildpackInspect(logger logging.Logger, buildpackName, registryName string, flags BuildpackInspectFlags, cfg config.Config, pack PackClient) error {
-	logger.Infof("Inspecting buildpack: %s\n", style.Symbol(buildpackName))
-
-	inspectedBuildpacksOutput, err := inspectAllBuildpacks(
-		pack,
-		flags,
-		client.InspectBuildpackOptions{
+	options := []client.InspectBuildpackOptions{
+		{
 			BuildpackName: buildpackName,
 			Daemon:        true,
 			Registry:      registryName,
 		},
-		client.InspectBuildpackOptions{
+		{
 			BuildpackName: buildpackName,
 			Daemon:        false,
 			Registry:      registryName,
-		})
+		},
+	}
+
+	switch flags.OutputFormat {
+	case "", "human-readable":
+	case "json":
+		output, err := inspectAllBuildpacksJSON(pack, flags, options...)
+		if err != nil {
+			return err
+		}
+		logger.Info(output)
+		return nil
+	default:
+		return errors.Errorf("invalid output format %s, must be one of json, human-readable", style.Symbol(flags.OutputFormat))
+	}
+
+	logger.Infof("Inspecting buildpack: %s\n", style.Symbol(buildpackName))
+
+	inspectedBuildpacksOutput, err := inspectAllBuildpacks(pack, flags, options...)
 	if err != nil {
 		return fmt.Errorf("error writing buildpack output: %q", err)
 	}
@@ -578,6 +578,70 @@ func testBuildpackInspectCommand(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("--output json", func() {
+		it.Before(func() {
+			simpleInfo.Location = buildpack.URILocator
+			mockClient.EXPECT().InspectBuildpack(client.InspectBuildpackOptions{
+				BuildpackName: "/path/to/test/buildpack",
+				Daemon:        true,
+				Registry:      "default-registry",
+			}).Return(simpleInfo, nil)
+		})
+
+		it("displays the buildpack as JSON", func() {
+			command.SetArgs([]string{"/path/to/test/buildpack", "--output", "json"})
+			assert.Nil(command.Execute())
+
+			h.AssertEq(t, outBuf.String(), `[
+  {
+    "location": "LOCAL ARCHIVE",
+    "id": "some/single-buildpack",
+    "version": "0.0.1",
+    "stacks": [
+      {
+        "id": "io.buildpacks.stacks.first-stack"
+      },
+      {
+        "id": "io.buildpacks.stacks.second-stack"
+      }
+    ],
+    "buildpacks": [
+      {
+        "id": "some/single-buildpack",
+        "name": "some",
+        "version": "0.0.1",
+        "homepage": "single-buildpack-homepage",
+        "api": "0.2"
+      },
+      {
+        "id": "some/buildpack-no-homepage",
+        "version": "0.0.2"
+      }
+    ],
+    "detection_order": [
+      {
+        "buildpacks": [
+          {
+            "id": "some/single-buildpack",
+            "version": "0.0.1",
+            "homepage": "single-buildpack-homepage"
+          }
+        ]
+      }
+    ]
+  }
+]
+`)
+		})
+	})
+
+	when("an invalid output format is passed", func() {
+		it("fails", func() {
+			command.SetArgs([]string{"/path/to/test/buildpack", "--output", "xml"})
+			assert.ErrorContains(command.Execute(), "invalid output format 'xml'")
+		})
+	})
+
 	when("verbose flag is passed", func() {
 		it.Before(func() {
 			simpleInfo.Location = buildpack.URILocator
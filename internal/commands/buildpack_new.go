@@ -17,10 +17,11 @@ import (
 
 // BuildpackNewFlags define flags provided to the BuildpackNew command
 type BuildpackNewFlags struct {
-	API     string
-	Path    string
-	Stacks  []string
-	Version string
+	API      string
+	Path     string
+	Stacks   []string
+	Version  string
+	Language string
 }
 
 // BuildpackCreator creates buildpacks
@@ -67,11 +68,12 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 			}
 
 			if err := creator.NewBuildpack(cmd.Context(), client.NewBuildpackOptions{
-				API:     flags.API,
-				ID:      id,
-				Path:    path,
-				Stacks:  stacks,
-				Version: flags.Version,
+				API:      flags.API,
+				ID:       id,
+				Path:     path,
+				Stacks:   stacks,
+				Version:  flags.Version,
+				Language: flags.Language,
 			}); err != nil {
 				return err
 			}
@@ -84,6 +86,7 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 	cmd.Flags().StringVarP(&flags.API, "api", "a", "0.8", "Buildpack API compatibility of the generated buildpack")
 	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the buildpack")
 	cmd.Flags().StringVarP(&flags.Version, "version", "V", "1.0.0", "Version of the generated buildpack")
+	cmd.Flags().StringVarP(&flags.Language, "language", "l", "", "Language of the apps the buildpack builds, to generate detect and build scripts for. Accepted values are go, java, node, python and ruby")
 	cmd.Flags().StringSliceVarP(&flags.Stacks, "stacks", "s", []string{"io.buildpacks.stacks.bionic"}, "Stack(s) this buildpack will be compatible with"+stringSliceHelp("stack"))
 
 	AddHelpFlag(cmd, "new")
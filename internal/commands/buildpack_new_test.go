@@ -74,6 +74,23 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, err)
 		})
 
+		it("forwards the language", func() {
+			mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
+				API:      "0.8",
+				ID:       "example/some-cnb",
+				Path:     filepath.Join(tmpDir, "some-cnb"),
+				Version:  "1.0.0",
+				Language: "python",
+				Stacks: []dist.Stack{{
+					ID:     "io.buildpacks.stacks.bionic",
+					Mixins: []string{},
+				}},
+			}).Return(nil).MaxTimes(1)
+
+			command.SetArgs([]string{"--path", filepath.Join(tmpDir, "some-cnb"), "--language", "python", "example/some-cnb"})
+			h.AssertNil(t, command.Execute())
+		})
+
 		it("stops if the directory already exists", func() {
 			err := os.MkdirAll(tmpDir, 0600)
 			h.AssertNil(t, err)
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackSearchFlags define flags provided to the BuildpackSearch command
+type BuildpackSearchFlags struct {
+	BuildpackRegistry string
+}
+
+// BuildpackSearch searches a buildpack registry for buildpacks
+func BuildpackSearch(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackSearchFlags
+
+	cmd := &cobra.Command{
+		Use:     "search [<query>]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   "Search a buildpack registry for buildpacks",
+		Example: "pack buildpack search paketo-buildpacks/java",
+		Long: "Search a buildpack registry for buildpacks whose ID contains the query, listing the latest version of each which is not yanked.\n" +
+			"Found buildpacks can be used with 'pack build --buildpack urn:cnb:registry:<id>@<version>', or pulled with 'pack buildpack pull'.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			registryName := flags.BuildpackRegistry
+			if registryName == "" {
+				registryName = cfg.DefaultRegistryName
+			}
+
+			var query string
+			if len(args) == 1 {
+				query = args[0]
+			}
+
+			buildpacks, err := pack.SearchBuildpacks(client.SearchBuildpacksOptions{
+				Query:        query,
+				RegistryName: registryName,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(buildpacks) == 0 {
+				logger.Infof("No buildpacks found for %s", style.Symbol(query))
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), 10, 10, 5, ' ', tabwriter.TabIndent)
+			fmt.Fprintln(tw, "ID\tVERSION\tADDRESS")
+			for _, bp := range buildpacks {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", bp.ID, bp.Version, bp.Address)
+			}
+			return tw.Flush()
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	AddHelpFlag(cmd, "search")
+	return cmd
+}
@@ -0,0 +1,80 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackSearchCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackSearchCommand", testBuildpackSearchCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackSearchCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.BuildpackSearch(logger, config.Config{DefaultRegistryName: "some-registry"}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackSearch", func() {
+		it("prints the matching buildpacks of the default registry", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpacksOptions{Query: "java", RegistryName: "some-registry"}).
+				Return([]client.RegistryBuildpack{
+					{ID: "example/java", Version: "1.0.0", Address: "example.com/java@sha256:abc"},
+				}, nil)
+
+			command.SetArgs([]string{"java"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContainsMatch(t, outBuf.String(), `ID\s+VERSION\s+ADDRESS`)
+			h.AssertContainsMatch(t, outBuf.String(), `example/java\s+1.0.0\s+example.com/java@sha256:abc`)
+		})
+
+		it("searches the given registry", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpacksOptions{Query: "java", RegistryName: "other-registry"}).
+				Return(nil, nil)
+
+			command.SetArgs([]string{"java", "--buildpack-registry", "other-registry"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No buildpacks found for 'java'")
+		})
+
+		it("returns the error of the client", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(gomock.Any()).
+				Return(nil, errors.New("some error"))
+
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "some error")
+		})
+	})
+}
@@ -40,8 +40,9 @@ func BuildpackYank(logger logging.Logger, cfg config.Config, pack PackClient) *c
 			opts := client.YankBuildpackOptions{
 				ID:      id,
 				Version: version,
-				Type:    "github",
+				Type:    registry.Type,
 				URL:     registry.URL,
+				Name:    registry.Name,
 				Yank:    !flags.Undo,
 			}
 
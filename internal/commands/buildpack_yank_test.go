@@ -66,6 +66,7 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    true,
 				}
 
@@ -101,6 +102,7 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    true,
 				}
 
@@ -112,12 +114,40 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 				h.AssertNil(t, cmd.Execute())
 			})
 
+			it("yanks from a git registry", func() {
+				cfg = config.Config{
+					Registries: []config.Registry{
+						{
+							Name: "private",
+							Type: "git",
+							URL:  "https://github.com/some-org/registry-index",
+						},
+					},
+				}
+				cmd = commands.BuildpackYank(logger, cfg, mockClient)
+
+				mockClient.EXPECT().
+					YankBuildpack(client.YankBuildpackOptions{
+						ID:      "heroku/rust",
+						Version: "0.0.1",
+						Type:    "git",
+						URL:     "https://github.com/some-org/registry-index",
+						Name:    "private",
+						Yank:    true,
+					}).
+					Return(nil)
+
+				cmd.SetArgs([]string{buildpackIDVersion, "--buildpack-registry", "private"})
+				h.AssertNil(t, cmd.Execute())
+			})
+
 			it("should undo", func() {
 				opts := client.YankBuildpackOptions{
 					ID:      "heroku/rust",
 					Version: "0.0.1",
 					Type:    "github",
 					URL:     "https://github.com/buildpacks/registry-index",
+					Name:    "official",
 					Yank:    false,
 				}
 				mockClient.EXPECT().
@@ -151,6 +181,7 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 						Version: "0.0.1",
 						Type:    "github",
 						URL:     "https://github.com/override/buildpack-registry",
+						Name:    "override",
 						Yank:    true,
 					}
 					mockClient.EXPECT().
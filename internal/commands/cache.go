@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewCacheCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the volumes pack creates to cache the layers of builds",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(CacheList(logger, client))
+	cmd.AddCommand(CacheClear(logger, client))
+	cmd.AddCommand(CacheInspect(logger, cfg, client))
+	cmd.AddCommand(CachePrune(logger, cfg, client))
+	cmd.AddCommand(experimental(logger, cfg, CacheExport(logger, cfg, client)))
+	cmd.AddCommand(experimental(logger, cfg, CacheImport(logger, cfg, client)))
+	AddHelpFlag(cmd, "cache")
+	return cmd
+}
+
+func writeCacheVolumes(w io.Writer, volumes []client.CacheVolume) error {
+	tw := tabwriter.NewWriter(w, 10, 10, 5, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(tw, "NAME\tSCOPE\tSIZE\tLAST USED\tIN USE")
+	for _, vol := range volumes {
+		size := "unknown"
+		if vol.Size >= 0 {
+			size = humanize.Bytes(uint64(vol.Size))
+		}
+		lastUsed := "unknown"
+		if !vol.LastUsed.IsZero() {
+			lastUsed = humanize.Time(vol.LastUsed)
+		}
+		inUse := "no"
+		if vol.InUse {
+			inUse = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", vol.Name, vol.Scope, size, lastUsed, inUse)
+	}
+	return tw.Flush()
+}
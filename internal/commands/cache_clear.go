@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheClear(logger logging.Logger, pack PackClient) *cobra.Command {
+	var (
+		all   bool
+		scope string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "clear [<image-name>]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   "Remove the volumes caching the layers of builds",
+		Example: "pack cache clear my/app",
+		Long: "Remove the volumes pack created to cache the layers of builds of an image, or of every image with `--all`. " +
+			"Use `--scope launch` to only remove the launch caches holding the layers of the images exported to the daemon. " +
+			"Volumes in use by a running build are kept.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			opts := client.ClearCacheOptions{All: all, Scope: scope}
+			if len(args) > 0 {
+				opts.ImageName = args[0]
+			}
+
+			switch {
+			case all && opts.ImageName != "":
+				return errors.New("an image name cannot be given with the all flag")
+			case !all && opts.ImageName == "":
+				return errors.New("an image name or the all flag is required")
+			}
+
+			volumes, err := pack.ClearCacheVolumes(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+
+			if len(volumes) == 0 {
+				logger.Info("No cache volumes to remove")
+				return nil
+			}
+
+			logger.Info("Removed:")
+			return writeCacheVolumes(logger.Writer(), volumes)
+		}),
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Remove the cache volumes of every image")
+	cmd.Flags().StringVar(&scope, "scope", "", "Only remove the volumes of this scope: build, launch or workspace")
+	AddHelpFlag(cmd, "clear")
+	return cmd
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheExport(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var (
+		output  string
+		builder string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "export <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Export the build cache of an image to an archive",
+		Example: "pack cache export my/app -o cache.tgz",
+		Long: "Export the build cache volumes of an image to a gzipped tar archive, which `pack cache import` restores, " +
+			"so that stateless CI providers can keep the cache between runs of a pipeline as an artifact, without a cache " +
+			"image in a registry. The volumes are read by a container of the builder, which is never run.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.ExportCache(cmd.Context(), client.ExportCacheOptions{
+				ImageName:   args[0],
+				Path:        output,
+				HelperImage: builder,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "cache.tgz", "Path of the archive to write")
+	cmd.Flags().StringVarP(&builder, "builder", "B", cfg.DefaultBuilder, "Builder image reading the cache volumes")
+	AddHelpFlag(cmd, "export")
+	return cmd
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheImport(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var builder string
+
+	cmd := &cobra.Command{
+		Use:     "import <archive>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Import the build cache of an image from an archive",
+		Example: "pack cache import cache.tgz",
+		Long: "Restore the build cache volumes of an archive written by `pack cache export`, under the same names, so that " +
+			"the next build of the image with the same stack and buildpacks uses them. Existing volumes with the same " +
+			"names are replaced. The volumes are written by a container of the builder, which is never run.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			volumes, err := pack.ImportCache(cmd.Context(), client.ImportCacheOptions{
+				Path:        args[0],
+				HelperImage: builder,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, vol := range volumes {
+				logger.Infof("Restored cache volume %s", style.Symbol(vol))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&builder, "builder", "B", cfg.DefaultBuilder, "Builder image writing the cache volumes")
+	AddHelpFlag(cmd, "import")
+	return cmd
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheInspect(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var (
+		cacheImage string
+		builder    string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "inspect [<image-name>]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   "Show the layers buildpacks cached for an image",
+		Example: "pack cache inspect my/app",
+		Long: "Show the layers each buildpack cached in the build cache volumes of an image, or in a cache image with " +
+			"`--cache-image`, with their sizes and when they were cached. The volumes are read by a container of the " +
+			"builder, which is never run.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			opts := client.InspectCacheOptions{CacheImage: cacheImage, HelperImage: builder}
+			if len(args) > 0 {
+				opts.ImageName = args[0]
+			}
+
+			switch {
+			case cacheImage != "" && opts.ImageName != "":
+				return errors.New("an image name cannot be given with the cache-image flag")
+			case cacheImage == "" && opts.ImageName == "":
+				return errors.New("an image name or the cache-image flag is required")
+			}
+
+			caches, err := pack.InspectCache(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+
+			if len(caches) == 0 {
+				logger.Info("No cache volumes found")
+				return nil
+			}
+
+			for i, contents := range caches {
+				if i > 0 {
+					logger.Info("")
+				}
+				if err := writeCacheContents(logger, contents); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&cacheImage, "cache-image", "", "Inspect this cache image rather than the cache volumes of an image")
+	cmd.Flags().StringVarP(&builder, "builder", "B", cfg.DefaultBuilder, "Builder image reading the cache volumes")
+	AddHelpFlag(cmd, "inspect")
+	return cmd
+}
+
+func writeCacheContents(logger logging.Logger, contents client.CacheContents) error {
+	lastUsed := "unknown"
+	if !contents.LastUsed.IsZero() {
+		lastUsed = humanize.Time(contents.LastUsed)
+	}
+	logger.Infof("Cache %s (last used %s):", style.Symbol(contents.Name), lastUsed)
+
+	if len(contents.Buildpacks) == 0 {
+		logger.Info("  No cached layers")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(logger.Writer(), 10, 10, 5, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(tw, "  BUILDPACK\tLAYER\tSIZE\tCACHED\tUSE")
+	for _, bp := range contents.Buildpacks {
+		for _, layer := range bp.Layers {
+			size := "unknown"
+			if layer.Size >= 0 {
+				size = humanize.Bytes(uint64(layer.Size))
+			}
+			cached := "unknown"
+			if !layer.Cached.IsZero() {
+				cached = humanize.Time(layer.Cached)
+			}
+			fmt.Fprintf(tw, "  %s@%s\t%s\t%s\t%s\t%s\n", bp.ID, bp.Version, layer.Name, size, cached, layerUse(layer))
+		}
+	}
+	return tw.Flush()
+}
+
+// layerUse describes the phases a cached layer is also available in, besides restoring it from the cache.
+func layerUse(layer client.CachedLayer) string {
+	switch {
+	case layer.Build && layer.Launch:
+		return "build, launch"
+	case layer.Build:
+		return "build"
+	case layer.Launch:
+		return "launch"
+	default:
+		return "cache only"
+	}
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func CacheList(logger logging.Logger, pack PackClient) *cobra.Command {
+	var scope string
+
+	cmd := &cobra.Command{
+		Use:     "list [<image-name>]",
+		Args:    cobra.MaximumNArgs(1),
+		Short:   "List the volumes caching the layers of builds",
+		Example: "pack cache list my/app",
+		Long: "List the volumes pack created to cache the layers of builds, with their sizes. " +
+			"When an image name is given, only the volumes caching the layers of that image are listed. " +
+			"Use `--scope launch` to list the launch caches holding the layers of the images exported to the daemon.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			var imageName string
+			if len(args) > 0 {
+				imageName = args[0]
+			}
+
+			volumes, err := pack.ListCacheVolumes(cmd.Context(), imageName)
+			if err != nil {
+				return err
+			}
+			volumes = filterCacheVolumes(volumes, scope)
+
+			if len(volumes) == 0 {
+				logger.Info("No cache volumes found")
+				return nil
+			}
+			if err := writeCacheVolumes(logger.Writer(), volumes); err != nil {
+				return err
+			}
+
+			var total uint64
+			for _, vol := range volumes {
+				if vol.Size > 0 {
+					total += uint64(vol.Size)
+				}
+			}
+			logger.Infof("Total size: %s", humanize.Bytes(total))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&scope, "scope", "", "Only list the volumes of this scope: build, launch or workspace")
+	AddHelpFlag(cmd, "list")
+	return cmd
+}
+
+// filterCacheVolumes returns the volumes of the given scope, or all volumes when scope is empty.
+func filterCacheVolumes(volumes []client.CacheVolume, scope string) []client.CacheVolume {
+	if scope == "" {
+		return volumes
+	}
+
+	var filtered []client.CacheVolume
+	for _, vol := range volumes {
+		if vol.Scope == scope {
+			filtered = append(filtered, vol)
+		}
+	}
+	return filtered
+}
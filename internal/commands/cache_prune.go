@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CachePruneFlags struct {
+	MaxSize string
+	MaxAge  time.Duration
+	DryRun  bool
+}
+
+func CachePrune(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags CachePruneFlags
+
+	defaults := config.CacheLimits{}
+	if cfg.CacheLimits != nil {
+		defaults = *cfg.CacheLimits
+	}
+
+	cmd := &cobra.Command{
+		Use:     "prune",
+		Args:    cobra.NoArgs,
+		Short:   "Remove the least recently used volumes caching the layers of builds",
+		Example: "pack cache prune --max-size 20GB --max-age 720h",
+		Long: "Remove the least recently used volumes caching the layers of builds until their total size is within " +
+			"`--max-size`, and those unused for longer than `--max-age`. Volumes in use by a running build are kept.\n" +
+			"Builds prune the cache volumes as well when the cache-limits of the pack config are set.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			limits, err := cacheLimits(config.CacheLimits{MaxSize: flags.MaxSize, MaxAge: flags.MaxAge})
+			if err != nil {
+				return err
+			}
+			if limits == nil {
+				return errors.New("a maximum size or age is required")
+			}
+
+			volumes, err := pack.PruneCacheVolumes(cmd.Context(), client.PruneCacheOptions{
+				CacheLimits: *limits,
+				DryRun:      flags.DryRun,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(volumes) == 0 {
+				logger.Info("No cache volumes to remove")
+				return nil
+			}
+
+			if flags.DryRun {
+				logger.Info("Would remove:")
+			} else {
+				logger.Info("Removed:")
+			}
+			return writeCacheVolumes(logger.Writer(), volumes)
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.MaxSize, "max-size", defaults.MaxSize, "Maximum total size of the cache volumes, e.g. 20GB (defaults to the cache-limits of the pack config)")
+	cmd.Flags().DurationVar(&flags.MaxAge, "max-age", defaults.MaxAge, "Maximum time since a cache volume was last used, e.g. 720h (defaults to the cache-limits of the pack config)")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "List the volumes which would be removed, without removing them")
+	AddHelpFlag(cmd, "prune")
+	return cmd
+}
+
+// cacheLimits parses the limits of the cache volumes of the pack config, and returns nil when there are none.
+func cacheLimits(limits config.CacheLimits) (*client.CacheLimits, error) {
+	if limits.MaxSize == "" && limits.MaxAge == 0 {
+		return nil, nil
+	}
+
+	result := &client.CacheLimits{MaxAge: limits.MaxAge}
+	if limits.MaxSize != "" {
+		size, err := humanize.ParseBytes(limits.MaxSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid maximum cache size %s", style.Symbol(limits.MaxSize))
+		}
+		result.MaxSize = int64(size)
+	}
+	return result, nil
+}
@@ -0,0 +1,251 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheCommand", testCacheCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		buildVolume    client.CacheVolume
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		buildVolume = client.CacheVolume{
+			Name:  "pack-cache-some_app_latest-0123456789ab.build",
+			Scope: "build",
+			Size:  2000000,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("list", func() {
+		it("lists the cache volumes of the image with their sizes", func() {
+			mockClient.EXPECT().
+				ListCacheVolumes(gomock.Any(), "some/app").
+				Return([]client.CacheVolume{buildVolume}, nil)
+
+			command := commands.CacheList(logger, mockClient)
+			command.SetArgs([]string{"some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "pack-cache-some_app_latest-0123456789ab.build")
+			h.AssertContains(t, outBuf.String(), "2.0 MB")
+		})
+
+		it("lists only the volumes of the scope with --scope", func() {
+			mockClient.EXPECT().
+				ListCacheVolumes(gomock.Any(), "some/app").
+				Return([]client.CacheVolume{
+					buildVolume,
+					{Name: "pack-cache-some_app_latest-0123456789ab.launch", Scope: "launch", Size: 5000000},
+				}, nil)
+
+			command := commands.CacheList(logger, mockClient)
+			command.SetArgs([]string{"some/app", "--scope", "launch"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "pack-cache-some_app_latest-0123456789ab.launch")
+			h.AssertNotContains(t, outBuf.String(), "pack-cache-some_app_latest-0123456789ab.build")
+			h.AssertContains(t, outBuf.String(), "Total size: 5.0 MB")
+		})
+
+		it("reports when there are no cache volumes", func() {
+			mockClient.EXPECT().ListCacheVolumes(gomock.Any(), "").Return(nil, nil)
+
+			command := commands.CacheList(logger, mockClient)
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No cache volumes found")
+		})
+	})
+
+	when("clear", func() {
+		it("removes the cache volumes of the image", func() {
+			mockClient.EXPECT().
+				ClearCacheVolumes(gomock.Any(), client.ClearCacheOptions{ImageName: "some/app"}).
+				Return([]client.CacheVolume{buildVolume}, nil)
+
+			command := commands.CacheClear(logger, mockClient)
+			command.SetArgs([]string{"some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Removed:")
+			h.AssertContains(t, outBuf.String(), "pack-cache-some_app_latest-0123456789ab.build")
+		})
+
+		it("removes every cache volume with --all", func() {
+			mockClient.EXPECT().
+				ClearCacheVolumes(gomock.Any(), client.ClearCacheOptions{All: true}).
+				Return(nil, nil)
+
+			command := commands.CacheClear(logger, mockClient)
+			command.SetArgs([]string{"--all"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No cache volumes to remove")
+		})
+
+		it("removes only the volumes of the scope with --scope", func() {
+			mockClient.EXPECT().
+				ClearCacheVolumes(gomock.Any(), client.ClearCacheOptions{ImageName: "some/app", Scope: "launch"}).
+				Return(nil, nil)
+
+			command := commands.CacheClear(logger, mockClient)
+			command.SetArgs([]string{"some/app", "--scope", "launch"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("requires an image name or --all", func() {
+			command := commands.CacheClear(logger, mockClient)
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "an image name or the all flag is required")
+		})
+	})
+
+	when("inspect", func() {
+		it("shows the cached layers of each buildpack", func() {
+			mockClient.EXPECT().
+				InspectCache(gomock.Any(), client.InspectCacheOptions{ImageName: "some/app", HelperImage: "default/builder"}).
+				Return([]client.CacheContents{{
+					Name: "pack-cache-some_app_latest-0123456789ab.build",
+					Buildpacks: []client.CachedBuildpack{{
+						ID:      "some/buildpack",
+						Version: "1.0",
+						Layers:  []client.CachedLayer{{Name: "deps", SHA: "sha256:deps", Size: 3000000, Build: true}},
+					}},
+				}}, nil)
+
+			command := commands.CacheInspect(logger, config.Config{DefaultBuilder: "default/builder"}, mockClient)
+			command.SetArgs([]string{"some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Cache 'pack-cache-some_app_latest-0123456789ab.build' (last used unknown):")
+			h.AssertContains(t, outBuf.String(), "some/buildpack@1.0")
+			h.AssertContains(t, outBuf.String(), "deps")
+			h.AssertContains(t, outBuf.String(), "3.0 MB")
+		})
+
+		it("inspects a cache image", func() {
+			mockClient.EXPECT().
+				InspectCache(gomock.Any(), client.InspectCacheOptions{CacheImage: "registry.example.com/some/cache"}).
+				Return([]client.CacheContents{{Name: "registry.example.com/some/cache"}}, nil)
+
+			command := commands.CacheInspect(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"--cache-image", "registry.example.com/some/cache"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No cached layers")
+		})
+
+		it("requires an image name or a cache image", func() {
+			command := commands.CacheInspect(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "an image name or the cache-image flag is required")
+		})
+	})
+
+	when("prune", func() {
+		it("lists the volumes which would be removed on a dry run", func() {
+			mockClient.EXPECT().
+				PruneCacheVolumes(gomock.Any(), client.PruneCacheOptions{
+					CacheLimits: client.CacheLimits{MaxSize: 20000000000, MaxAge: 24 * time.Hour},
+					DryRun:      true,
+				}).
+				Return([]client.CacheVolume{buildVolume}, nil)
+
+			command := commands.CachePrune(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"--max-size", "20GB", "--max-age", "24h", "--dry-run"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Would remove:")
+			h.AssertContains(t, outBuf.String(), "pack-cache-some_app_latest-0123456789ab.build")
+		})
+
+		it("defaults to the cache-limits of the config", func() {
+			mockClient.EXPECT().
+				PruneCacheVolumes(gomock.Any(), client.PruneCacheOptions{
+					CacheLimits: client.CacheLimits{MaxAge: 720 * time.Hour},
+				}).
+				Return(nil, nil)
+
+			command := commands.CachePrune(logger, config.Config{CacheLimits: &config.CacheLimits{MaxAge: 720 * time.Hour}}, mockClient)
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No cache volumes to remove")
+		})
+
+		it("requires a limit", func() {
+			command := commands.CachePrune(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "a maximum size or age is required")
+		})
+	})
+
+	when("export", func() {
+		it("exports the build cache of the image", func() {
+			mockClient.EXPECT().
+				ExportCache(gomock.Any(), client.ExportCacheOptions{ImageName: "some/app", Path: "some-cache.tgz", HelperImage: "default/builder"}).
+				Return(nil)
+
+			command := commands.CacheExport(logger, config.Config{DefaultBuilder: "default/builder"}, mockClient)
+			command.SetArgs([]string{"some/app", "-o", "some-cache.tgz"})
+			h.AssertNil(t, command.Execute())
+		})
+	})
+
+	when("export and import", func() {
+		it("are experimental", func() {
+			command := commands.NewCacheCommand(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"export", "some/app"})
+			h.AssertError(t, command.Execute(), "Command 'cache export' is currently experimental.")
+			h.AssertContains(t, outBuf.String(), "To enable experimental features")
+		})
+
+		it("warn when experimental features are enabled", func() {
+			mockClient.EXPECT().
+				ImportCache(gomock.Any(), client.ImportCacheOptions{Path: "some-cache.tgz", HelperImage: "default/builder"}).
+				Return(nil, nil)
+
+			command := commands.NewCacheCommand(logger, config.Config{Experimental: true, DefaultBuilder: "default/builder"}, mockClient)
+			command.SetArgs([]string{"import", "some-cache.tgz"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Command 'cache import' is experimental")
+		})
+	})
+
+	when("import", func() {
+		it("lists the restored volumes", func() {
+			mockClient.EXPECT().
+				ImportCache(gomock.Any(), client.ImportCacheOptions{Path: "some-cache.tgz", HelperImage: "some/builder"}).
+				Return([]string{"pack-cache-some_app_latest-0123456789ab.build"}, nil)
+
+			command := commands.CacheImport(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"some-cache.tgz", "--builder", "some/builder"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Restored cache volume 'pack-cache-some_app_latest-0123456789ab.build'")
+		})
+	})
+}
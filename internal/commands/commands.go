@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
@@ -21,15 +22,39 @@ type PackClient interface {
 	InspectBuilder(string, bool, ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
 	InspectImage(string, bool) (*client.ImageInfo, error)
 	Rebase(context.Context, client.RebaseOptions) error
+	RebaseImages(context.Context, client.RebaseImagesOptions) ([]client.RebaseResult, error)
 	CreateBuilder(context.Context, client.CreateBuilderOptions) error
 	NewBuildpack(context.Context, client.NewBuildpackOptions) error
+	NewApp(context.Context, client.NewAppOptions) error
 	PackageBuildpack(ctx context.Context, opts client.PackageBuildpackOptions) error
 	Build(context.Context, client.BuildOptions) error
 	RegisterBuildpack(context.Context, client.RegisterBuildpackOptions) error
 	YankBuildpack(client.YankBuildpackOptions) error
+	SearchBuildpacks(client.SearchBuildpacksOptions) ([]client.RegistryBuildpack, error)
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
 	PullBuildpack(context.Context, client.PullBuildpackOptions) error
 	DownloadSBOM(name string, options client.DownloadSBOMOptions) error
+	WatchRunImage(context.Context, client.WatchRunImageOptions) error
+	TagImage(context.Context, client.TagImageOptions) error
+	UntagImage(context.Context, client.UntagImageOptions) error
+	ListBuilders(context.Context, client.ListBuildersOptions) ([]client.BuilderSummary, error)
+	WatchBuild(context.Context, client.WatchBuildOptions) error
+	RunApp(context.Context, client.RunAppOptions) error
+	AddBuilderBuildpacks(context.Context, client.AddBuilderBuildpacksOptions) error
+	RemoveBuilderBuildpack(context.Context, client.RemoveBuilderBuildpackOptions) error
+	UpgradeBuilderBuildpack(context.Context, client.UpgradeBuilderBuildpackOptions) error
+	ListEphemeralImages(context.Context, client.EphemeralImageFilter) ([]client.EphemeralImage, error)
+	PruneEphemeralImages(context.Context, client.PruneEphemeralImagesOptions) ([]client.EphemeralImage, error)
+	ListCacheVolumes(context.Context, string) ([]client.CacheVolume, error)
+	ClearCacheVolumes(context.Context, client.ClearCacheOptions) ([]client.CacheVolume, error)
+	PruneCacheVolumes(context.Context, client.PruneCacheOptions) ([]client.CacheVolume, error)
+	InspectCache(context.Context, client.InspectCacheOptions) ([]client.CacheContents, error)
+	ExportCache(context.Context, client.ExportCacheOptions) error
+	ImportCache(context.Context, client.ImportCacheOptions) ([]string, error)
+	VerifyImage(context.Context, client.VerifyImageOptions) ([]client.LayerVerification, error)
+	ExportBuildState(context.Context, client.ExportBuildStateOptions) error
+	ImportBuildState(context.Context, client.ImportBuildStateOptions) (build.State, error)
+	DaemonArchitecture(context.Context) (string, error)
 }
 
 func AddHelpFlag(cmd *cobra.Command, commandName string) {
@@ -39,11 +64,11 @@ func AddHelpFlag(cmd *cobra.Command, commandName string) {
 func CreateCancellableContext() context.Context {
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := client.WithCancellationReason(context.Background())
 
 	go func() {
 		<-signals
-		cancel()
+		cancel(client.CancellationReasonSignal)
 	}()
 
 	return ctx
@@ -105,3 +130,21 @@ func isTrustedBuilder(cfg config.Config, builder string) bool {
 func deprecationWarning(logger logging.Logger, oldCmd, replacementCmd string) {
 	logger.Warnf("Command %s has been deprecated, please use %s instead", style.Symbol("pack "+oldCmd), style.Symbol("pack "+replacementCmd))
 }
+
+// experimental gates cmd behind experimental features, so that commands can ship before they are stable: unless
+// experimental features are enabled in the config, it fails with an ExperimentError, and otherwise warns that it
+// may change.
+func experimental(logger logging.Logger, cfg config.Config, cmd *cobra.Command) *cobra.Command {
+	cmd.Short += " (experimental)"
+	runE := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if !cfg.Experimental {
+			return logError(logger, func(*cobra.Command, []string) error {
+				return client.NewExperimentError(fmt.Sprintf("Command %s is currently experimental.", style.Symbol(c.CommandPath())))
+			})(c, args)
+		}
+		logger.Warnf("Command %s is experimental and may change in future versions of pack", style.Symbol(c.CommandPath()))
+		return runE(c, args)
+	}
+	return cmd
+}
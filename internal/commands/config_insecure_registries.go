@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ConfigInsecureRegistries(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "insecure-registries",
+		Short: "List, add and remove registries accessed over plain HTTP",
+		Long: "Insecure registries are accessed over plain HTTP, both by pack and by the lifecycle, such as registries " +
+			"running in a cluster without TLS. Images pulled by the Docker daemon are accessed as configured in the daemon.",
+		Aliases: []string{"insecure-registry"},
+		Args:    cobra.MaximumNArgs(2),
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			listInsecureRegistries(args, logger, cfg)
+			return nil
+		}),
+	}
+
+	listCmd := generateListCmd(cmd.Use, logger, cfg, listInsecureRegistries)
+	listCmd.Long = "List all insecure registries."
+	listCmd.Example = "pack config insecure-registries list"
+	cmd.AddCommand(listCmd)
+
+	addCmd := generateAdd("registry accessed over plain HTTP", logger, cfg, cfgPath, addInsecureRegistry)
+	addCmd.Use = "add <registry>"
+	addCmd.Long = "Access a registry over plain HTTP."
+	addCmd.Example = "pack config insecure-registries add registry.cluster.local:5000"
+	cmd.AddCommand(addCmd)
+
+	rmCmd := generateRemove("registry accessed over plain HTTP", logger, cfg, cfgPath, removeInsecureRegistry)
+	rmCmd.Use = "remove <registry>"
+	rmCmd.Long = "Stop accessing a registry over plain HTTP."
+	rmCmd.Example = "pack config insecure-registries remove registry.cluster.local:5000"
+	cmd.AddCommand(rmCmd)
+
+	AddHelpFlag(cmd, "insecure-registries")
+	return cmd
+}
+
+func addInsecureRegistry(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	registry := args[0]
+	for _, insecureRegistry := range cfg.InsecureRegistries {
+		if insecureRegistry == registry {
+			logger.Infof("Registry %s is already insecure", style.Symbol(registry))
+			return nil
+		}
+	}
+
+	cfg.InsecureRegistries = append(cfg.InsecureRegistries, registry)
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "failed to write to %s", cfgPath)
+	}
+
+	logger.Infof("Registry %s is now accessed over plain HTTP", style.Symbol(registry))
+	return nil
+}
+
+func removeInsecureRegistry(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	registry := args[0]
+
+	existingRegistries := cfg.InsecureRegistries
+	cfg.InsecureRegistries = []string{}
+	for _, insecureRegistry := range existingRegistries {
+		if insecureRegistry == registry {
+			continue
+		}
+		cfg.InsecureRegistries = append(cfg.InsecureRegistries, insecureRegistry)
+	}
+
+	if len(existingRegistries) == len(cfg.InsecureRegistries) {
+		logger.Infof("Registry %s wasn't insecure", style.Symbol(registry))
+		return nil
+	}
+
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "failed to write to %s", cfgPath)
+	}
+
+	logger.Infof("Registry %s is no longer accessed over plain HTTP", style.Symbol(registry))
+	return nil
+}
+
+func listInsecureRegistries(args []string, logger logging.Logger, cfg config.Config) {
+	if len(cfg.InsecureRegistries) == 0 {
+		logger.Info("No insecure registries have been set")
+		return
+	}
+
+	logger.Info("Insecure Registries:")
+	for _, registry := range cfg.InsecureRegistries {
+		logger.Infof("  %s", registry)
+	}
+}
@@ -0,0 +1,108 @@
+package commands_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigInsecureRegistries(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigInsecureRegistriesCommand", testConfigInsecureRegistriesCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigInsecureRegistriesCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd          *cobra.Command
+		logger       logging.Logger
+		outBuf       bytes.Buffer
+		tempPackHome string
+		configPath   string
+		testCfg      = config.Config{
+			InsecureRegistries: []string{"registry.cluster.local:5000"},
+		}
+	)
+
+	it.Before(func() {
+		var err error
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		tempPackHome, err = ioutil.TempDir("", "pack-home")
+		h.AssertNil(t, err)
+		configPath = filepath.Join(tempPackHome, "config.toml")
+
+		cmd = commands.ConfigInsecureRegistries(logger, testCfg, configPath)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tempPackHome))
+	})
+
+	when("no arguments", func() {
+		it("lists insecure registries", func() {
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Insecure Registries:")
+			h.AssertContains(t, outBuf.String(), "registry.cluster.local:5000")
+		})
+
+		it("prints a clear message when none were set", func() {
+			cmd = commands.ConfigInsecureRegistries(logger, config.Config{}, configPath)
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "No insecure registries have been set")
+		})
+	})
+
+	when("add", func() {
+		it("adds the registry to the config", func() {
+			cmd.SetArgs([]string{"add", "localhost:5000"})
+			h.AssertNil(t, cmd.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.InsecureRegistries, []string{"registry.cluster.local:5000", "localhost:5000"})
+		})
+
+		it("does not add a registry twice", func() {
+			cmd.SetArgs([]string{"add", "registry.cluster.local:5000"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Registry 'registry.cluster.local:5000' is already insecure")
+		})
+
+		it("fails without a registry", func() {
+			cmd.SetArgs([]string{"add"})
+			h.AssertError(t, cmd.Execute(), "accepts 1 arg")
+		})
+	})
+
+	when("remove", func() {
+		it("removes the registry from the config", func() {
+			cmd.SetArgs([]string{"remove", "registry.cluster.local:5000"})
+			h.AssertNil(t, cmd.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(cfg.InsecureRegistries), 0)
+		})
+
+		it("prints a clear message when the registry wasn't insecure", func() {
+			cmd.SetArgs([]string{"remove", "some-registry"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "Registry 'some-registry' wasn't insecure")
+		})
+	})
+}
@@ -54,6 +54,19 @@ func ConfigRegistries(logger logging.Logger, cfg config.Config, cfgPath string)
 	rmCmd.Long = bpRegistryExplanation + "Users can remove registries from the config by using `pack config registries remove <registry>`"
 	cmd.AddCommand(rmCmd)
 
+	renameCmd := &cobra.Command{
+		Use:     "rename <registry> <new-name>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Rename a registry",
+		Long:    bpRegistryExplanation + "Rename a registry saved in the pack config, keeping it the default registry if it is.",
+		Example: "pack config registries rename my-registry internal-registry",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return renameRegistry(args, logger, cfg, cfgPath)
+		}),
+	}
+	AddHelpFlag(renameCmd, "rename")
+	cmd.AddCommand(renameCmd)
+
 	cmd.AddCommand(ConfigRegistriesDefault(logger, cfg, cfgPath))
 
 	AddHelpFlag(cmd, "registries")
@@ -125,6 +138,37 @@ func removeRegistry(args []string, logger logging.Logger, cfg config.Config, cfg
 	return nil
 }
 
+func renameRegistry(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	registryName, newName := args[0], args[1]
+
+	for _, name := range []string{registryName, newName} {
+		if name == config.OfficialRegistryName {
+			return errors.Errorf("%s is a reserved registry name, please provide a different registry",
+				style.Symbol(config.OfficialRegistryName))
+		}
+	}
+
+	index := findRegistryIndex(cfg.Registries, registryName)
+	if index < 0 {
+		return errors.Errorf("registry %s does not exist", style.Symbol(registryName))
+	}
+	if registriesContains(cfg.Registries, newName) {
+		return errors.Errorf("Buildpack registry %s already exists.", style.Symbol(newName))
+	}
+
+	cfg.Registries[index].Name = newName
+	if cfg.DefaultRegistryName == registryName {
+		cfg.DefaultRegistryName = newName
+	}
+
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "writing config to %s", cfgPath)
+	}
+
+	logger.Infof("Successfully renamed registry %s to %s", style.Symbol(registryName), style.Symbol(newName))
+	return nil
+}
+
 func listRegistries(args []string, logger logging.Logger, cfg config.Config) {
 	for _, currRegistry := range config.GetRegistries(cfg) {
 		isDefaultRegistry := (currRegistry.Name == cfg.DefaultRegistryName) ||
@@ -335,4 +335,44 @@ func testConfigRegistries(t *testing.T, when spec.G, it spec.S) {
 			assert.ErrorContains(cmd.Execute(), "writing config to")
 		})
 	})
+
+	when("rename", func() {
+		it.Before(func() {
+			cmd = commands.ConfigRegistries(logger, cfgWithRegistries, configPath)
+		})
+
+		it("renames the registry and the matching default registry name", func() {
+			cmd.SetArgs([]string{"rename", "private registry", "internal registry"})
+			assert.Succeeds(cmd.Execute())
+
+			newCfg, err := config.Read(configPath)
+			assert.Nil(err)
+
+			assert.Equal(newCfg.DefaultRegistryName, "internal registry")
+			assert.Equal(newCfg.Registries[1], config.Registry{
+				Name: "internal registry",
+				Type: "github",
+				URL:  "https://github.com/buildpacks/private-registry",
+			})
+			assert.Contains(outBuf.String(), "Successfully renamed registry 'private registry' to 'internal registry'")
+		})
+
+		it("should return error when the new name is taken", func() {
+			cmd.SetArgs([]string{"rename", "private registry", "public registry"})
+			assert.Error(cmd.Execute())
+			assert.Contains(outBuf.String(), "Buildpack registry 'public registry' already exists.")
+		})
+
+		it("should return error when registry does NOT already exist", func() {
+			cmd.SetArgs([]string{"rename", "missing-registry", "other-registry"})
+			assert.Error(cmd.Execute())
+			assert.Contains(outBuf.String(), "registry 'missing-registry' does not exist")
+		})
+
+		it("should throw error when registry name is official", func() {
+			cmd.SetArgs([]string{"rename", "public registry", "official"})
+			assert.Error(cmd.Execute())
+			assert.Contains(outBuf.String(), "'official' is a reserved registry name, please provide a different registry")
+		})
+	})
 }
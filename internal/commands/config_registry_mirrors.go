@@ -45,7 +45,7 @@ func ConfigRegistryMirrors(logger logging.Logger, cfg config.Config, cfgPath str
 	rmCmd.Example = "pack config registry-mirrors remove index.docker.io"
 	cmd.AddCommand(rmCmd)
 
-	AddHelpFlag(cmd, "run-image-mirrors")
+	AddHelpFlag(cmd, "registry-mirrors")
 	return cmd
 }
 
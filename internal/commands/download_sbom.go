@@ -25,6 +25,12 @@ func DownloadSBOM(
 		Example: "pack sbom download buildpacksio/pack",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			img := args[0]
+			if flags.Remote {
+				if err := validateRemoteImageName(img); err != nil {
+					return err
+				}
+			}
+
 			options := cpkg.DownloadSBOMOptions{
 				Daemon:         !flags.Remote,
 				DestinationDir: flags.DestinationDir,
@@ -35,6 +41,7 @@ func DownloadSBOM(
 	}
 	AddHelpFlag(cmd, "download")
 	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Download SBoM of image in remote registry (without pulling image)")
+	cmd.Flags().BoolVar(&flags.Remote, "remote-only", false, "Download SBoM of image in remote registry, without requiring a docker daemon (same as --remote)")
 	cmd.Flags().StringVarP(&flags.DestinationDir, "output-dir", "o", ".", "Path to export SBoM contents.\nIt defaults export to the current working directory.")
 	return cmd
 }
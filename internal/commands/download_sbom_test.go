@@ -71,6 +71,26 @@ func testDownloadSBOMCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the remote-only flag is specified", func() {
+			it("downloads from the registry", func() {
+				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
+					Daemon:         false,
+					DestinationDir: ".",
+				})
+				command.SetArgs([]string{"some/image", "--remote-only"})
+
+				err := command.Execute()
+				h.AssertNil(t, err)
+			})
+
+			it("errors when given an image ID", func() {
+				command.SetArgs([]string{"4a5b1bb4b7a4a8f5a2d5e8bb1f5bb4f1a6f6e2d36ddb0e3c9f4f8c8a6b5f0c2d", "--remote-only"})
+
+				err := command.Execute()
+				h.AssertError(t, err, "is an image ID, which is only known to the docker daemon")
+			})
+		})
+
 		when("the output-dir flag is specified", func() {
 			it("respects the output-dir flag", func() {
 				mockClient.EXPECT().DownloadSBOM("some/image", cpkg.DownloadSBOMOptions{
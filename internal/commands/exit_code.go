@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/client"
+)
+
+// Exit codes of the pack process. They are documented for use in CI pipelines, so existing codes must not change.
+const (
+	// ExitCodeFailure is returned for any failure without a more specific exit code.
+	ExitCodeFailure = 1
+	// ExitCodeSoftError is returned for failures whose message has already been displayed.
+	ExitCodeSoftError = 2
+	// ExitCodeDetectFailure is returned when no group of buildpacks passed detection.
+	ExitCodeDetectFailure = 3
+	// ExitCodeBuildFailure is returned when a buildpack failed during the build phase.
+	ExitCodeBuildFailure = 4
+	// ExitCodeExportFailure is returned when the app image could not be exported or published.
+	ExitCodeExportFailure = 5
+	// ExitCodeDaemonFailure is returned when the docker daemon could not be reached.
+	ExitCodeDaemonFailure = 6
+)
+
+// ExitCode returns the code the pack process exits with when a command fails with err.
+func ExitCode(err error) int {
+	if _, isSoftError := err.(client.SoftError); isSoftError {
+		return ExitCodeSoftError
+	}
+
+	var failureErr *client.FailureError
+	if errors.As(err, &failureErr) {
+		switch failureErr.Class {
+		case client.FailureDetect:
+			return ExitCodeDetectFailure
+		case client.FailureBuild:
+			return ExitCodeBuildFailure
+		case client.FailureExport:
+			return ExitCodeExportFailure
+		case client.FailureDaemon:
+			return ExitCodeDaemonFailure
+		}
+	}
+
+	return ExitCodeFailure
+}
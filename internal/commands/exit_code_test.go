@@ -0,0 +1,44 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/pkg/client"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExitCode(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExitCode", testExitCode, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExitCode(t *testing.T, when spec.G, it spec.S) {
+	when("#ExitCode", func() {
+		it("returns a distinct code for each failure class", func() {
+			for class, code := range map[client.FailureClass]int{
+				client.FailureDetect: commands.ExitCodeDetectFailure,
+				client.FailureBuild:  commands.ExitCodeBuildFailure,
+				client.FailureExport: commands.ExitCodeExportFailure,
+				client.FailureDaemon: commands.ExitCodeDaemonFailure,
+			} {
+				err := errors.Wrap(&client.FailureError{Class: class, Err: errors.New("some error")}, "failed to build")
+				h.AssertEq(t, commands.ExitCode(err), code)
+			}
+		})
+
+		it("returns the soft error code for soft errors", func() {
+			h.AssertEq(t, commands.ExitCode(client.NewSoftError()), commands.ExitCodeSoftError)
+		})
+
+		it("returns the generic failure code for other errors", func() {
+			h.AssertEq(t, commands.ExitCode(errors.New("some error")), commands.ExitCodeFailure)
+		})
+	})
+}
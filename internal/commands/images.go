@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type EphemeralImageFlags struct {
+	OlderThan time.Duration
+	Target    string
+}
+
+func NewImagesCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "images",
+		Short: "Manage the images pack creates on the daemon for its own use",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(ImagesList(logger, client))
+	cmd.AddCommand(experimental(logger, cfg, ImagesPrune(logger, client)))
+	AddHelpFlag(cmd, "images")
+	return cmd
+}
+
+func addEphemeralImageFlags(cmd *cobra.Command, flags *EphemeralImageFlags, olderThan time.Duration) {
+	cmd.Flags().DurationVar(&flags.OlderThan, "older-than", olderThan, "Only include images created at least this long ago, e.g. 24h")
+	cmd.Flags().StringVar(&flags.Target, "target", "", "Only include images created while building this app image")
+}
+
+func (f EphemeralImageFlags) filter() client.EphemeralImageFilter {
+	return client.EphemeralImageFilter{
+		OlderThan: f.OlderThan,
+		Target:    f.Target,
+	}
+}
+
+func writeEphemeralImages(w io.Writer, images []client.EphemeralImage) error {
+	tw := tabwriter.NewWriter(w, 10, 10, 5, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(tw, "ID\tKIND\tTARGET\tCREATED\tSIZE\tTAGS")
+	for _, img := range images {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			shortImageID(img.ID), img.Kind, img.Target, humanize.Time(img.Created), humanize.Bytes(uint64(img.Size)), strings.Join(img.Tags, ", "))
+	}
+	return tw.Flush()
+}
+
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
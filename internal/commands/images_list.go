@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func ImagesList(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags EphemeralImageFlags
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Args:    cobra.NoArgs,
+		Short:   "List the images pack created on the daemon for its own use",
+		Example: "pack images list --older-than 24h",
+		Long: "List the images pack created on the daemon for its own use, such as the builders it creates for a " +
+			"single build. These images are removed once they are no longer needed, but remain when pack is interrupted.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			images, err := pack.ListEphemeralImages(cmd.Context(), flags.filter())
+			if err != nil {
+				return err
+			}
+
+			if len(images) == 0 {
+				logger.Info("No images found")
+				return nil
+			}
+			return writeEphemeralImages(logger.Writer(), images)
+		}),
+	}
+
+	addEphemeralImageFlags(cmd, &flags, 0)
+	AddHelpFlag(cmd, "list")
+	return cmd
+}
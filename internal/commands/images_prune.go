@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type ImagesPruneFlags struct {
+	EphemeralImageFlags
+	DryRun bool
+}
+
+func ImagesPrune(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ImagesPruneFlags
+
+	cmd := &cobra.Command{
+		Use:     "prune",
+		Args:    cobra.NoArgs,
+		Short:   "Remove the images pack created on the daemon for its own use",
+		Example: "pack images prune --older-than 24h",
+		Long: "Remove the images pack created on the daemon for its own use, and left behind because pack was interrupted. " +
+			"Only images created at least an hour ago are removed by default, and images used by containers are kept. " +
+			"Use `--dry-run` to list the images which would be removed.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			images, err := pack.PruneEphemeralImages(cmd.Context(), client.PruneEphemeralImagesOptions{
+				EphemeralImageFilter: flags.filter(),
+				DryRun:               flags.DryRun,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(images) == 0 {
+				logger.Info("No images to remove")
+				return nil
+			}
+
+			if flags.DryRun {
+				logger.Info("Would remove:")
+			} else {
+				logger.Info("Removed:")
+			}
+			return writeEphemeralImages(logger.Writer(), images)
+		}),
+	}
+
+	addEphemeralImageFlags(cmd, &flags.EphemeralImageFlags, client.DefaultEphemeralImageMinAge)
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "List the images which would be removed, without removing them")
+	AddHelpFlag(cmd, "prune")
+	return cmd
+}
@@ -0,0 +1,113 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImagesCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ImagesCommand", testImagesCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImagesCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		builderImage   client.EphemeralImage
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		builderImage = client.EphemeralImage{
+			ID:      "sha256:0123456789abcdef",
+			Tags:    []string{"pack.local/builder/abc:latest"},
+			Kind:    client.EphemeralKindBuilder,
+			Target:  "index.docker.io/some/app:latest",
+			Created: time.Now().Add(-48 * time.Hour),
+			Size:    1000,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("list", func() {
+		it("lists the images matching the filters", func() {
+			mockClient.EXPECT().
+				ListEphemeralImages(gomock.Any(), client.EphemeralImageFilter{OlderThan: 24 * time.Hour, Target: "some/app"}).
+				Return([]client.EphemeralImage{builderImage}, nil)
+
+			command := commands.ImagesList(logger, mockClient)
+			command.SetArgs([]string{"--older-than", "24h", "--target", "some/app"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "0123456789ab")
+			h.AssertContains(t, outBuf.String(), "pack.local/builder/abc:latest")
+			h.AssertContains(t, outBuf.String(), "2 days ago")
+		})
+
+		it("reports when there are no images", func() {
+			mockClient.EXPECT().ListEphemeralImages(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			command := commands.ImagesList(logger, mockClient)
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No images found")
+		})
+	})
+
+	when("prune", func() {
+		it("is experimental", func() {
+			command := commands.NewImagesCommand(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"prune"})
+			h.AssertError(t, command.Execute(), "Command 'images prune' is currently experimental.")
+		})
+
+		it("lists the images which would be removed on a dry run", func() {
+			mockClient.EXPECT().
+				PruneEphemeralImages(gomock.Any(), client.PruneEphemeralImagesOptions{
+					EphemeralImageFilter: client.EphemeralImageFilter{OlderThan: time.Hour},
+					DryRun:               true,
+				}).
+				Return([]client.EphemeralImage{builderImage}, nil)
+
+			command := commands.ImagesPrune(logger, mockClient)
+			command.SetArgs([]string{"--older-than", "1h", "--dry-run"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Would remove:")
+			h.AssertContains(t, outBuf.String(), "0123456789ab")
+		})
+
+		it("only removes images older than an hour by default", func() {
+			mockClient.EXPECT().
+				PruneEphemeralImages(gomock.Any(), client.PruneEphemeralImagesOptions{
+					EphemeralImageFilter: client.EphemeralImageFilter{OlderThan: time.Hour},
+				}).
+				Return(nil, nil)
+
+			command := commands.ImagesPrune(logger, mockClient)
+			command.SetArgs([]string{})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No images to remove")
+		})
+	})
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -11,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/config"
 	strs "github.com/buildpacks/pack/internal/strings"
 	"github.com/buildpacks/pack/pkg/buildpack"
@@ -82,6 +85,7 @@ func InspectBuildpack(logger logging.Logger, cfg config.Config, client PackClien
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", -1, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
 	cmd.Flags().StringVarP(&flags.Registry, "registry", "r", "", "buildpack registry that may be searched")
 	cmd.Flags().BoolVarP(&flags.Verbose, "verbose", "v", false, "show more output")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display buildpack detail (json, human-readable).\nOmission of this flag will display as human-readable.")
 	AddHelpFlag(cmd, "inspect-buildpack")
 	return cmd
 }
@@ -117,6 +121,78 @@ func inspectAllBuildpacks(client PackClient, flags BuildpackInspectFlags, option
 	return buf.String(), nil
 }
 
+// buildpackInspectOutput is the structured output of inspecting a buildpack, for scripting.
+type buildpackInspectOutput struct {
+	Location       string                        `json:"location"`
+	ID             string                        `json:"id"`
+	Version        string                        `json:"version,omitempty"`
+	Stacks         []dist.Stack                  `json:"stacks"`
+	Buildpacks     []buildpackInspectOutputEntry `json:"buildpacks"`
+	DetectionOrder pubbldr.DetectionOrder        `json:"detection_order"`
+}
+
+type buildpackInspectOutputEntry struct {
+	dist.BuildpackInfo
+	API string `json:"api,omitempty"`
+}
+
+// inspectAllBuildpacksJSON inspects the buildpack with each of the options, as inspectAllBuildpacks does, and returns
+// the results as a JSON array.
+func inspectAllBuildpacksJSON(client PackClient, flags BuildpackInspectFlags, options ...client.InspectBuildpackOptions) (string, error) {
+	results := []buildpackInspectOutput{}
+	var errs []error
+	for _, option := range options {
+		info, err := client.InspectBuildpack(option)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		results = append(results, buildpackInspectJSON(info, determinePrefix(option.BuildpackName, info.Location, option.Daemon), flags))
+		if info.Location != buildpack.PackageLocator {
+			break
+		}
+	}
+	if len(errs) == len(options) {
+		return "", joinErrors(errs)
+	}
+
+	output, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "writing buildpack output")
+	}
+	return string(output), nil
+}
+
+func buildpackInspectJSON(info *client.BuildpackInfo, location string, flags BuildpackInspectFlags) buildpackInspectOutput {
+	result := buildpackInspectOutput{
+		Location:   location,
+		ID:         info.BuildpackMetadata.ID,
+		Version:    info.BuildpackMetadata.Version,
+		Stacks:     []dist.Stack{},
+		Buildpacks: []buildpackInspectOutputEntry{},
+	}
+
+	for _, stack := range info.BuildpackMetadata.Stacks {
+		if !flags.Verbose {
+			stack.Mixins = nil
+		}
+		result.Stacks = append(result.Stacks, stack)
+	}
+
+	for _, bp := range info.Buildpacks {
+		entry := buildpackInspectOutputEntry{BuildpackInfo: bp}
+		if layer, ok := info.BuildpackLayers.Get(bp.ID, bp.Version); ok && layer.API != nil {
+			entry.API = layer.API.String()
+		}
+		result.Buildpacks = append(result.Buildpacks, entry)
+	}
+
+	// the calculator never fails
+	result.DetectionOrder, _ = builder.NewDetectionOrderCalculator().Order(info.Order, info.BuildpackLayers, flags.Depth)
+	return result
+}
+
 func inspectBuildpackOutput(info *client.BuildpackInfo, prefix string, flags BuildpackInspectFlags) (output []byte, err error) {
 	tpl := template.Must(template.New("inspect-buildpack").Parse(inspectBuildpackTemplate))
 	bpOutput, err := buildpacksOutput(info.Buildpacks)
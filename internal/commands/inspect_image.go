@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"regexp"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/inspectimage"
@@ -8,17 +11,24 @@ import (
 	"github.com/buildpacks/pack/internal/inspectimage/writer"
 
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
+var imageIDRegex = regexp.MustCompile(`^(sha256:)?[a-f0-9]{64}$`)
+
 //go:generate mockgen -package testmocks -destination testmocks/mock_inspect_image_writer_factory.go github.com/buildpacks/pack/internal/commands InspectImageWriterFactory
 type InspectImageWriterFactory interface {
 	Writer(kind string, BOM bool) (writer.InspectImageWriter, error)
 }
 
 type InspectImageFlags struct {
-	BOM          bool
-	OutputFormat string
+	BOM           bool
+	BOMBuildpacks []string
+	OutputFormat  string
+	RemoteOnly    bool
+	LocalOnly     bool
 }
 
 func InspectImage(
@@ -37,9 +47,24 @@ func InspectImage(
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			img := args[0]
 
+			if flags.RemoteOnly && flags.LocalOnly {
+				return errors.New("the remote-only and local-only flags cannot be used together")
+			}
+			if flags.RemoteOnly {
+				if err := validateRemoteImageName(img); err != nil {
+					return err
+				}
+			}
+
 			sharedImageInfo := inspectimage.GeneralInfo{
 				Name:            img,
 				RunImageMirrors: cfg.RunImages,
+				RemoteOnly:      flags.RemoteOnly,
+				LocalOnly:       flags.LocalOnly,
+			}
+
+			if len(flags.BOMBuildpacks) > 0 {
+				flags.BOM = true
 			}
 
 			w, err := writerFactory.Writer(flags.OutputFormat, flags.BOM)
@@ -47,8 +72,19 @@ func InspectImage(
 				return err
 			}
 
-			remote, remoteErr := client.InspectImage(img, false)
-			local, localErr := client.InspectImage(img, true)
+			var (
+				local, remote       *cpkg.ImageInfo
+				localErr, remoteErr error
+			)
+			if !flags.LocalOnly {
+				remote, remoteErr = client.InspectImage(img, false)
+			}
+			if !flags.RemoteOnly {
+				local, localErr = client.InspectImage(img, true)
+			}
+
+			local = filterBOM(local, flags.BOMBuildpacks)
+			remote = filterBOM(remote, flags.BOMBuildpacks)
 
 			if flags.BOM {
 				logger.Warn("Using the '--bom' flag with 'pack inspect-image <image-name>' is deprecated. Users are encouraged to use 'pack sbom download <image-name>'.")
@@ -62,6 +98,37 @@ func InspectImage(
 	}
 	AddHelpFlag(cmd, "inspect")
 	cmd.Flags().BoolVar(&flags.BOM, "bom", false, "print bill of materials")
+	cmd.Flags().StringSliceVar(&flags.BOMBuildpacks, "bom-buildpack", nil, "Only print the bill of materials contributed by the buildpack with this ID. Implies --bom."+stringSliceHelp("bom-buildpack"))
+	cmd.Flags().BoolVar(&flags.RemoteOnly, "remote-only", false, "Only inspect the image in its registry, without requiring a docker daemon")
+	cmd.Flags().BoolVar(&flags.LocalOnly, "local-only", false, "Only inspect the image in the docker daemon, without accessing its registry")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
 	return cmd
 }
+
+// validateRemoteImageName returns an error if name can only be resolved by the docker daemon.
+func validateRemoteImageName(name string) error {
+	if imageIDRegex.MatchString(name) {
+		return errors.Errorf("%s is an image ID, which is only known to the docker daemon; provide an image reference to inspect it remotely", style.Symbol(name))
+	}
+	return nil
+}
+
+// filterBOM returns info with only the bill of materials entries contributed by the buildpacks with the given IDs,
+// or info as is without IDs.
+func filterBOM(info *cpkg.ImageInfo, buildpackIDs []string) *cpkg.ImageInfo {
+	if info == nil || len(buildpackIDs) == 0 {
+		return info
+	}
+
+	filtered := *info
+	filtered.BOM = nil
+	for _, entry := range info.BOM {
+		for _, id := range buildpackIDs {
+			if entry.Buildpack.ID == id {
+				filtered.BOM = append(filtered.BOM, entry)
+				break
+			}
+		}
+	}
+	return &filtered
+}
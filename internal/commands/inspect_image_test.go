@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/buildpacks/lifecycle/buildpack"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/golang/mock/gomock"
 	"github.com/heroku/color"
@@ -129,6 +130,94 @@ func testInspectImageCommand(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(inspectImageWriter.RecievedGeneralInfo.RunImageMirrors, cfg.RunImages)
 		})
 
+		when("--remote-only", func() {
+			it("only inspects the remote image", func() {
+				inspectImageWriter := newDefaultInspectImageWriter()
+				inspectImageWriterFactory := newImageWriterFactory(inspectImageWriter)
+
+				mockClient.EXPECT().InspectImage("some/image", false).Return(expectedRemoteImageInfo, nil)
+
+				command := commands.InspectImage(logger, inspectImageWriterFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--remote-only"})
+				assert.Succeeds(command.Execute())
+
+				assert.Nil(inspectImageWriter.ReceivedInfoForLocal)
+				assert.Equal(inspectImageWriter.ReceivedInfoForRemote, expectedRemoteImageInfo)
+				assert.Equal(inspectImageWriter.ReceivedErrorForLocal, nil)
+				assert.Equal(inspectImageWriter.RecievedGeneralInfo, inspectimage.GeneralInfo{
+					Name:       "some/image",
+					RemoteOnly: true,
+				})
+			})
+
+			it("errors when given an image ID", func() {
+				inspectImageWriter := newDefaultInspectImageWriter()
+				inspectImageWriterFactory := newImageWriterFactory(inspectImageWriter)
+
+				imageID := "sha256:4a5b1bb4b7a4a8f5a2d5e8bb1f5bb4f1a6f6e2d36ddb0e3c9f4f8c8a6b5f0c2d"
+				command := commands.InspectImage(logger, inspectImageWriterFactory, cfg, mockClient)
+				command.SetArgs([]string{imageID, "--remote-only"})
+				err := command.Execute()
+				assert.ErrorContains(err, "is an image ID, which is only known to the docker daemon")
+			})
+		})
+
+		when("--local-only", func() {
+			it("only inspects the local image", func() {
+				inspectImageWriter := newDefaultInspectImageWriter()
+				inspectImageWriterFactory := newImageWriterFactory(inspectImageWriter)
+
+				mockClient.EXPECT().InspectImage("some/image", true).Return(expectedLocalImageInfo, nil)
+
+				command := commands.InspectImage(logger, inspectImageWriterFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--local-only"})
+				assert.Succeeds(command.Execute())
+
+				assert.Nil(inspectImageWriter.ReceivedInfoForRemote)
+				assert.Equal(inspectImageWriter.ReceivedInfoForLocal, expectedLocalImageInfo)
+				assert.Equal(inspectImageWriter.RecievedGeneralInfo, inspectimage.GeneralInfo{
+					Name:      "some/image",
+					LocalOnly: true,
+				})
+			})
+
+			it("cannot be used with --remote-only", func() {
+				command := commands.InspectImage(logger, newImageWriterFactory(newDefaultInspectImageWriter()), cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--local-only", "--remote-only"})
+				assert.ErrorWithMessage(command.Execute(), "the remote-only and local-only flags cannot be used together")
+			})
+		})
+
+		when("--bom-buildpack", func() {
+			it("only passes the bill of materials of the buildpack to the BOM writer", func() {
+				inspectImageWriter := newDefaultInspectImageWriter()
+				inspectImageWriterFactory := newImageWriterFactory(inspectImageWriter)
+
+				bomEntry := func(name, buildpackID string) buildpack.BOMEntry {
+					return buildpack.BOMEntry{
+						Require:   buildpack.Require{Name: name},
+						Buildpack: buildpack.GroupBuildpack{ID: buildpackID, Version: "1.0"},
+					}
+				}
+				localInfo := &client.ImageInfo{
+					StackID: "local.image.stack",
+					BOM:     []buildpack.BOMEntry{bomEntry("node", "some/node"), bomEntry("npm", "some/npm"), bomEntry("yarn", "some/node")},
+				}
+
+				mockClient.EXPECT().InspectImage("some/image", true).Return(localInfo, nil)
+				mockClient.EXPECT().InspectImage("some/image", false).Return(nil, nil)
+
+				command := commands.InspectImage(logger, inspectImageWriterFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image", "--bom-buildpack", "some/node"})
+				assert.Succeeds(command.Execute())
+
+				assert.Equal(inspectImageWriterFactory.ReceivedForBOM, true)
+				assert.Equal(inspectImageWriter.ReceivedInfoForLocal.BOM, []buildpack.BOMEntry{bomEntry("node", "some/node"), bomEntry("yarn", "some/node")})
+				assert.Equal(len(localInfo.BOM), 3)
+				assert.Nil(inspectImageWriter.ReceivedInfoForRemote)
+			})
+		})
+
 		when("error cases", func() {
 			when("client returns an error when inspecting", func() {
 				it("passes errors to the Writer", func() {
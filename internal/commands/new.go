@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// NewFlags define flags provided to the New command
+type NewFlags struct {
+	Path     string
+	Language string
+	Builder  string
+}
+
+// New generates the scaffolding of an app
+func New(logger logging.Logger, packClient PackClient) *cobra.Command {
+	var flags NewFlags
+	cmd := &cobra.Command{
+		Use:     "new <name>",
+		Short:   "Creates a minimal app to build with buildpacks.",
+		Args:    cobra.ExactArgs(1),
+		Example: "pack new my-app --language go",
+		Long: "new generates a minimal web app in the given language, ready to be built by `pack build`. It creates a new " +
+			"directory `name` in the current directory (or at `path`, if passed as a flag), with the sources of the app, " +
+			"a project.toml configuring a builder suggested for the language, and a .packignore.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if flags.Language == "" {
+				return errors.New("language flag is required")
+			}
+
+			path := flags.Path
+			if path == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				path = filepath.Join(cwd, name)
+			}
+
+			if err := packClient.NewApp(cmd.Context(), client.NewAppOptions{
+				Path:     path,
+				Name:     name,
+				Language: flags.Language,
+				Builder:  flags.Builder,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully created app %s", style.Symbol(name))
+			logging.Tip(logger, "Build it with %s", style.Symbol("pack build "+name+" --path "+path))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the app in")
+	cmd.Flags().StringVarP(&flags.Language, "language", "l", "", "Language of the app. Accepted values are "+strings.Join(client.AppLanguages(), ", "))
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", "", "Builder to set in the project.toml of the app (defaults to a builder suggested for the language)")
+
+	AddHelpFlag(cmd, "new")
+	return cmd
+}
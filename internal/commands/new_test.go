@@ -0,0 +1,68 @@
+package commands_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestNewCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "NewCommand", testNewCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testNewCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.New(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#New", func() {
+		it("generates the app", func() {
+			path := filepath.Join("some", "dir")
+			mockClient.EXPECT().NewApp(gomock.Any(), client.NewAppOptions{
+				Path:     path,
+				Name:     "my-app",
+				Language: "node",
+				Builder:  "some/builder",
+			}).Return(nil)
+
+			command.SetArgs([]string{"my-app", "--language", "node", "--path", path, "--builder", "some/builder"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully created app 'my-app'")
+			h.AssertContains(t, outBuf.String(), "pack build my-app --path "+path)
+		})
+
+		it("requires a language", func() {
+			command.SetArgs([]string{"my-app"})
+			h.AssertError(t, command.Execute(), "language flag is required")
+		})
+	})
+}
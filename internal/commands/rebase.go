@@ -1,6 +1,14 @@
 package commands
 
 import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -16,16 +24,27 @@ import (
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
 	var policy string
+	var imagesFile string
+	var concurrency int
 
 	cmd := &cobra.Command{
-		Use:     "rebase <image-name>",
-		Args:    cobra.ExactArgs(1),
+		Use:     "rebase [<image-name>]",
+		Args:    cobra.MaximumNArgs(1),
 		Short:   "Rebase app image with latest run image",
 		Example: "pack rebase buildpacksio/pack",
 		Long: "Rebase allows you to quickly swap out the underlying OS layers (run image) of an app image generated by `pack build` " +
-			"with a newer version of the run image, without re-building the application.",
+			"with a newer version of the run image, without re-building the application.\n\n" +
+			"To rebase many images, such as when a vulnerability of the run image is patched, list them in a file given " +
+			"with `--images-file`, or on stdin with `--images-file -`.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			opts.RepoName = args[0]
+			switch {
+			case len(args) > 0 && imagesFile != "":
+				return errors.New("an image name cannot be given with the images-file flag")
+			case len(args) == 0 && imagesFile == "":
+				return errors.New("an image name or the images-file flag is required")
+			case len(args) > 0:
+				opts.RepoName = args[0]
+			}
 			opts.AdditionalMirrors = getMirrors(cfg)
 
 			var err error
@@ -38,9 +57,24 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
 			}
 
+			if imagesFile != "" {
+				repoNames, err := readImageList(cmd, imagesFile)
+				if err != nil {
+					return err
+				}
+				return rebaseImages(cmd, logger, pack, client.RebaseImagesOptions{
+					RebaseOptions: opts,
+					RepoNames:     repoNames,
+					Concurrency:   concurrency,
+				})
+			}
+
 			if err := pack.Rebase(cmd.Context(), opts); err != nil {
 				return err
 			}
+			if opts.DryRun {
+				return nil
+			}
 			logger.Infof("Successfully rebased image %s", style.Symbol(opts.RepoName))
 			return nil
 		}),
@@ -48,8 +82,78 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 
 	cmd.Flags().BoolVar(&opts.Publish, "publish", false, "Publish to registry")
 	cmd.Flags().StringVar(&opts.RunImage, "run-image", "", "Run image to use for rebasing")
+	cmd.Flags().StringSliceVarP(&opts.AdditionalTags, "tag", "t", nil, "Additional tags to save the rebased image under.\nTags should be in the format 'image:tag' or 'repository/image:tag'."+stringSliceHelp("tag"))
+	cmd.Flags().BoolVar(&opts.LayerHistory, "layer-history", false, "Describe the buildpack of each layer of the rebased image in its history, as shown by `docker history`")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Report the current and target run images, the layers replaced and whether the rebase is safe, without saving the image")
+	cmd.Flags().StringVar(&imagesFile, "images-file", "", "File listing the images to rebase, one per line, or '-' to read them from stdin")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Number of images rebased at once with --images-file")
 	cmd.Flags().StringVar(&policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 
 	AddHelpFlag(cmd, "rebase")
 	return cmd
 }
+
+// readImageList reads the image names listed in path, or on stdin for "-", one per line. Blank lines and lines
+// starting with # are skipped.
+func readImageList(cmd *cobra.Command, path string) ([]string, error) {
+	var r io.Reader = cmd.InOrStdin()
+	if path != "-" {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening images file %s", style.Symbol(path))
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var repoNames []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		repoNames = append(repoNames, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading images file %s", style.Symbol(path))
+	}
+	if len(repoNames) == 0 {
+		return nil, errors.Errorf("no images listed in %s", style.Symbol(path))
+	}
+	return repoNames, nil
+}
+
+// rebaseImages rebases many images, and reports the outcome of each. It errors when any image failed to rebase.
+func rebaseImages(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseImagesOptions) error {
+	results, err := pack.RebaseImages(cmd.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	tw := tabwriter.NewWriter(logger.Writer(), 10, 10, 5, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(tw, "IMAGE\tRESULT")
+	for _, result := range results {
+		outcome := "rebased"
+		switch {
+		case result.Err != nil:
+			failed++
+			outcome = "failed: " + result.Err.Error()
+		case opts.DryRun:
+			outcome = "safe to rebase"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", result.RepoName, outcome)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed to rebase %d of %d images", failed, len(results))
+	}
+	if !opts.DryRun {
+		logger.Infof("Successfully rebased %d images", len(results))
+	}
+	return nil
+}
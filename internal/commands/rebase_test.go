@@ -2,9 +2,11 @@ package commands_test
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/heroku/color"
+	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/image"
@@ -51,7 +53,7 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 		when("no image is provided", func() {
 			it("fails to run", func() {
 				err := command.Execute()
-				h.AssertError(t, err, "accepts 1 arg")
+				h.AssertError(t, err, "an image name or the images-file flag is required")
 			})
 		})
 
@@ -116,6 +118,68 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("--tag", func() {
+				it("saves the rebased image under the additional tags", func() {
+					opts.AdditionalTags = []string{"test/repo-image:v1", "test/repo-image:stable"}
+					mockClient.EXPECT().
+						Rebase(gomock.Any(), opts).
+						Return(nil)
+
+					command.SetArgs([]string{repoName, "--tag", "test/repo-image:v1", "-t", "test/repo-image:stable"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
+			when("--dry-run", func() {
+				it("does not report the image as rebased", func() {
+					opts.DryRun = true
+					mockClient.EXPECT().
+						Rebase(gomock.Any(), opts).
+						Return(nil)
+
+					command.SetArgs([]string{repoName, "--dry-run"})
+					h.AssertNil(t, command.Execute())
+					h.AssertNotContains(t, outBuf.String(), "Successfully rebased")
+				})
+			})
+
+			when("--images-file", func() {
+				it("rebases the images listed on stdin", func() {
+					opts.RepoName = ""
+					mockClient.EXPECT().
+						RebaseImages(gomock.Any(), client.RebaseImagesOptions{
+							RebaseOptions: opts,
+							RepoNames:     []string{"some/app", "other/app"},
+							Concurrency:   2,
+						}).
+						Return([]client.RebaseResult{{RepoName: "some/app"}, {RepoName: "other/app"}}, nil)
+
+					command.SetIn(strings.NewReader("some/app\n\n# retired\nother/app\n"))
+					command.SetArgs([]string{"--images-file", "-", "--concurrency", "2"})
+					h.AssertNil(t, command.Execute())
+					h.AssertContains(t, outBuf.String(), "Successfully rebased 2 images")
+				})
+
+				it("reports the images which failed to rebase", func() {
+					mockClient.EXPECT().
+						RebaseImages(gomock.Any(), gomock.Any()).
+						Return([]client.RebaseResult{
+							{RepoName: "some/app"},
+							{RepoName: "other/app", Err: errors.New("incompatible stack")},
+						}, nil)
+
+					command.SetIn(strings.NewReader("some/app\nother/app\n"))
+					command.SetArgs([]string{"--images-file", "-"})
+					h.AssertError(t, command.Execute(), "failed to rebase 1 of 2 images")
+					h.AssertContains(t, outBuf.String(), "failed: incompatible stack")
+				})
+
+				it("cannot be given with an image name", func() {
+					command.SetArgs([]string{repoName, "--images-file", "-"})
+					h.AssertError(t, command.Execute(), "an image name cannot be given with the images-file flag")
+				})
+			})
+
 			when("--pull-policy unknown-policy", func() {
 				it("fails to run", func() {
 					command.SetArgs([]string{repoName, "--pull-policy", "unknown-policy"})
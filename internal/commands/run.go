@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type RunFlags struct {
+	AppPath        string
+	Builder        string
+	DescriptorPath string
+	Policy         string
+	TrustBuilder   bool
+	Buildpacks     []string
+	BuildEnv       []string
+	Env            []string
+	EnvFiles       []string
+	Ports          []string
+}
+
+// Run builds an app image, then runs it
+func Run(logger logging.Logger, cfg config.Config, packClient PackClient) *cobra.Command {
+	var flags RunFlags
+
+	cmd := &cobra.Command{
+		Use:     "run <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Build an app image and run it",
+		Example: "pack run my-app --path apps/my-app --port 8080 --env PORT=8080",
+		Long: "Pack Run builds an app image in the docker daemon, like `pack build`, then runs it, publishing the ports " +
+			"given with `--port` and streaming its output until the app exits or pack is interrupted. The container " +
+			"is removed afterwards.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			imageName := args[0]
+
+			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath)
+			if err != nil {
+				return err
+			}
+
+			builder := flags.Builder
+			if !cmd.Flags().Changed("builder") && descriptor.Build.Builder != "" {
+				builder = descriptor.Build.Builder
+			}
+			if builder == "" {
+				suggestSettingBuilder(logger, packClient)
+				return client.NewSoftError()
+			}
+
+			buildEnv, err := parseEnv(nil, flags.BuildEnv)
+			if err != nil {
+				return err
+			}
+			env, err := parseEnv(flags.EnvFiles, flags.Env)
+			if err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			trustBuilder := isTrustedBuilder(cfg, builder) || flags.TrustBuilder
+			if trustBuilder {
+				logger.Debugf("Builder %s is trusted", style.Symbol(builder))
+			}
+
+			return packClient.RunApp(cmd.Context(), client.RunAppOptions{
+				Build: client.BuildOptions{
+					AppPath:           flags.AppPath,
+					Builder:           builder,
+					AdditionalMirrors: getMirrors(cfg),
+					Env:               buildEnv,
+					Image:             imageName,
+					PullPolicy:        pullPolicy,
+					TrustBuilder: func(string) bool {
+						return trustBuilder
+					},
+					Buildpacks:               flags.Buildpacks,
+					ProjectDescriptorBaseDir: filepath.Dir(actualDescriptorPath),
+					ProjectDescriptor:        descriptor,
+				},
+				Ports: flags.Ports,
+				Env:   env,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to app dir, zip-formatted file or tar file (optionally gzip compressed) (defaults to current working directory)")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringSliceVarP(&flags.Buildpacks, "buildpack", "b", nil, "Buildpack to use, in any of the forms accepted by 'pack build'"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringVarP(&flags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().StringArrayVar(&flags.BuildEnv, "build-env", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'"+stringArrayHelp("build-env"))
+	cmd.Flags().StringArrayVarP(&flags.Env, "env", "e", []string{}, "Runtime environment variable of the app, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed."+stringArrayHelp("env"))
+	cmd.Flags().StringArrayVar(&flags.EnvFiles, "env-file", []string{}, "Runtime environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'")
+	cmd.Flags().StringArrayVar(&flags.Ports, "port", []string{}, "Port of the app to publish, in the form '[[<host ip>:]<host port>:]<container port>[/<protocol>]'.\nA port alone is published on the same port of the host."+stringArrayHelp("port"))
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
+	cmd.Flags().BoolVar(&flags.TrustBuilder, "trust-builder", false, "Trust the provided builder\nAll lifecycle phases will be run in a single container (if supported by the lifecycle).")
+	AddHelpFlag(cmd, "run")
+	return cmd
+}
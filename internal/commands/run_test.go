@@ -0,0 +1,75 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRunCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RunCommand", testRunCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRunCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.Run(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#Run", func() {
+		it("builds and runs the app with the ports and env", func() {
+			mockClient.EXPECT().
+				RunApp(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, opts client.RunAppOptions) error {
+					h.AssertEq(t, opts.Build.Image, "my-app")
+					h.AssertEq(t, opts.Build.Builder, "my-builder")
+					h.AssertEq(t, opts.Build.Env, map[string]string{"BP_DEBUG": "true"})
+					h.AssertEq(t, opts.Ports, []string{"8080", "9000:9090"})
+					h.AssertEq(t, opts.Env, map[string]string{"PORT": "8080"})
+					return nil
+				})
+
+			command.SetArgs([]string{"my-app", "--builder", "my-builder",
+				"--port", "8080", "--port", "9000:9090", "--env", "PORT=8080", "--build-env", "BP_DEBUG=true"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("returns a soft error without a builder", func() {
+			mockClient.EXPECT().
+				InspectBuilder(gomock.Any(), false).
+				Return(&client.BuilderInfo{Description: ""}, nil).
+				AnyTimes()
+
+			command.SetArgs([]string{"my-app"})
+			h.AssertError(t, command.Execute(), client.NewSoftError().Error())
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewStateCommand(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Export and import the state of failed builds, to reproduce them elsewhere",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(StateExport(logger, client))
+	cmd.AddCommand(experimental(logger, cfg, StateImport(logger, cfg, client)))
+	AddHelpFlag(cmd, "state")
+	return cmd
+}
+
+// writePhaseCommands writes, for each phase of a build state, the docker command running the phase again
+func writePhaseCommands(w io.Writer, state build.State) {
+	for _, phase := range state.Phases {
+		args := []string{"docker", "run", "--rm", "-it"}
+		if phase.User != "" {
+			args = append(args, "--user", phase.User)
+		}
+		if phase.NetworkMode != "" {
+			args = append(args, "--network", phase.NetworkMode)
+		}
+		for _, bind := range phase.Binds {
+			args = append(args, "-v", bind)
+		}
+		for _, env := range phase.Env {
+			args = append(args, "-e", env)
+		}
+		args = append(args, phase.Image)
+		args = append(args, phase.Cmd...)
+
+		fmt.Fprintf(w, "  %s:\n    %s\n", phase.Name, strings.Join(args, " "))
+	}
+}
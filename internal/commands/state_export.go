@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func StateExport(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export <state-id> <archive>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Export the state of a failed build to an archive",
+		Example: "pack state export abcdefghij build-state.tgz",
+		Long: "Export the state of a failed build to an archive, including the layers and workspace volumes of the build " +
+			"and the configuration of the lifecycle phases which ran. " +
+			"The state of a build is kept when it fails with `--keep-failed-state`, under the ID logged by the build.\n\n" +
+			"The archive contains the app and the environment of the build, excluding registry credentials.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.ExportBuildState(cmd.Context(), client.ExportBuildStateOptions{
+				ID:   args[0],
+				Path: args[1],
+			})
+		}),
+	}
+
+	AddHelpFlag(cmd, "export")
+	return cmd
+}
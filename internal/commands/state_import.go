@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type StateImportFlags struct {
+	Policy string
+}
+
+func StateImport(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags StateImportFlags
+
+	cmd := &cobra.Command{
+		Use:     "import <archive>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Import the state of a failed build from an archive",
+		Example: "pack state import build-state.tgz",
+		Long: "Import the state of a failed build from an archive written by `pack state export`, restoring the layers " +
+			"and workspace volumes of the build into new volumes. " +
+			"The docker commands running each lifecycle phase of the build again, against the restored volumes, are listed.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			state, err := pack.ImportBuildState(cmd.Context(), client.ImportBuildStateOptions{
+				Path:       args[0],
+				PullPolicy: pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Builder: %s", style.Symbol(state.Builder))
+			logger.Infof("Layers volume: %s", style.Symbol(state.LayersVolume))
+			logger.Infof("Workspace volume: %s", style.Symbol(state.AppVolume))
+			logger.Infof("Error: %s", state.Error)
+			if len(state.Phases) > 0 {
+				logger.Info("Run the phases of the build again with:")
+				writePhaseCommands(logger.Writer(), state)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use for the builder of the build. Accepted values are always, never, and if-not-present. The default is always")
+	AddHelpFlag(cmd, "import")
+	return cmd
+}
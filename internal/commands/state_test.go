@@ -0,0 +1,100 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestStateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "StateCommand", testStateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testStateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("export", func() {
+		it("exports the state to the archive", func() {
+			mockClient.EXPECT().
+				ExportBuildState(gomock.Any(), client.ExportBuildStateOptions{ID: "some-state-id", Path: "state.tgz"}).
+				Return(nil)
+
+			command := commands.StateExport(logger, mockClient)
+			command.SetArgs([]string{"some-state-id", "state.tgz"})
+			h.AssertNil(t, command.Execute())
+		})
+	})
+
+	when("import", func() {
+		it("imports the state and lists the commands running the phases again", func() {
+			mockClient.EXPECT().
+				ImportBuildState(gomock.Any(), client.ImportBuildStateOptions{Path: "state.tgz", PullPolicy: image.PullIfNotPresent}).
+				Return(build.State{
+					ID:           "some-state-id",
+					Builder:      "some/builder",
+					LayersVolume: "pack-layers-abc",
+					AppVolume:    "pack-app-abc",
+					Error:        "failed with status code: 51",
+					Phases: []build.PhaseState{{
+						Name:  "detector",
+						Image: "some/builder",
+						Cmd:   []string{"/cnb/lifecycle/detector", "-app", "/workspace"},
+						Env:   []string{"CNB_PLATFORM_API=0.9"},
+						User:  "1000:1000",
+						Binds: []string{"pack-layers-abc:/layers", "pack-app-abc:/workspace"},
+					}},
+				}, nil)
+
+			command := commands.StateImport(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"state.tgz", "--pull-policy", "if-not-present"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Layers volume: 'pack-layers-abc'")
+			h.AssertContains(t, outBuf.String(), "Workspace volume: 'pack-app-abc'")
+			h.AssertContains(t, outBuf.String(), "Error: failed with status code: 51")
+			h.AssertContains(t, outBuf.String(), "  detector:\n    docker run --rm -it --user 1000:1000 -v pack-layers-abc:/layers -v pack-app-abc:/workspace -e CNB_PLATFORM_API=0.9 some/builder /cnb/lifecycle/detector -app /workspace\n")
+		})
+
+		it("errors for an invalid pull policy", func() {
+			command := commands.StateImport(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"state.tgz", "--pull-policy", "sometimes"})
+			h.AssertError(t, command.Execute(), "parsing pull policy sometimes")
+		})
+
+		it("is experimental", func() {
+			command := commands.NewStateCommand(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"import", "state.tgz"})
+			h.AssertError(t, command.Execute(), "Command 'state import' is currently experimental.")
+		})
+	})
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewTagCommand(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Manage tags of app images",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(TagAdd(logger, client))
+	cmd.AddCommand(TagRemove(logger, client))
+	AddHelpFlag(cmd, "tag")
+	return cmd
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type TagAddFlags struct {
+	Publish bool
+}
+
+func TagAdd(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags TagAddFlags
+
+	cmd := &cobra.Command{
+		Use:     "add <image-name> <tag>...",
+		Args:    cobra.MinimumNArgs(2),
+		Short:   "Add tags to an image",
+		Example: "pack tag add registry.example.com/my-app:1.2.3 registry.example.com/my-app:stable --publish",
+		Long: "Add tags to an image, in the docker daemon or, with `--publish`, in its registry. " +
+			"The tags refer to the same manifest as the image, so its digest, CNB metadata and any attached SBOMs " +
+			"or signatures are kept. Registry tags must be in the repository of the image.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.TagImage(cmd.Context(), client.TagImageOptions{
+				Image:   args[0],
+				Tags:    args[1:],
+				Publish: flags.Publish,
+			})
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Tag the image in its registry")
+	AddHelpFlag(cmd, "add")
+	return cmd
+}
@@ -0,0 +1,70 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTagAddCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "TagAddCommand", testTagAddCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTagAddCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.TagAdd(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#TagAdd", func() {
+		it("passes the tags to the client", func() {
+			mockClient.EXPECT().
+				TagImage(gomock.Any(), client.TagImageOptions{Image: "some/image", Tags: []string{"some/image:stable", "some/image:latest"}, Publish: true}).
+				Return(nil)
+
+			command.SetArgs([]string{"some/image", "some/image:stable", "some/image:latest", "--publish"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("returns the error of the client", func() {
+			mockClient.EXPECT().
+				TagImage(gomock.Any(), gomock.Any()).
+				Return(errors.New("some error"))
+
+			command.SetArgs([]string{"some/image", "some/image:stable", "some/image:latest", "--publish"})
+			h.AssertError(t, command.Execute(), "some error")
+		})
+
+		it("requires tags", func() {
+			command.SetArgs([]string{"some/image"})
+			h.AssertError(t, command.Execute(), "requires at least 2 arg(s), only received 1")
+		})
+	})
+}
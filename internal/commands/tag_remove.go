@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func TagRemove(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <tag>...",
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Remove tags of an image",
+		Example: "pack tag remove my-app:stable",
+		Long: "Remove tags of an image in the docker daemon. The image is kept as long as other tags refer to it. " +
+			"Tags cannot be removed from registries, which only support deleting the image itself.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.UntagImage(cmd.Context(), client.UntagImageOptions{
+				Tags: args,
+			})
+		}),
+	}
+
+	AddHelpFlag(cmd, "remove")
+	return cmd
+}
@@ -0,0 +1,70 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTagRemoveCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "TagRemoveCommand", testTagRemoveCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTagRemoveCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.TagRemove(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#TagRemove", func() {
+		it("passes the tags to the client", func() {
+			mockClient.EXPECT().
+				UntagImage(gomock.Any(), client.UntagImageOptions{Tags: []string{"some/image:stable"}}).
+				Return(nil)
+
+			command.SetArgs([]string{"some/image:stable"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("returns the error of the client", func() {
+			mockClient.EXPECT().
+				UntagImage(gomock.Any(), gomock.Any()).
+				Return(errors.New("some error"))
+
+			command.SetArgs([]string{"some/image:stable"})
+			h.AssertError(t, command.Execute(), "some error")
+		})
+
+		it("requires tags", func() {
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "requires at least 1 arg(s), only received 0")
+		})
+	})
+}
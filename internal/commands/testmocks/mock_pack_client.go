@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	build "github.com/buildpacks/pack/internal/build"
 	client "github.com/buildpacks/pack/pkg/client"
 )
 
@@ -36,6 +37,20 @@ func (m *MockPackClient) EXPECT() *MockPackClientMockRecorder {
 	return m.recorder
 }
 
+// AddBuilderBuildpacks mocks base method.
+func (m *MockPackClient) AddBuilderBuildpacks(arg0 context.Context, arg1 client.AddBuilderBuildpacksOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBuilderBuildpacks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBuilderBuildpacks indicates an expected call of AddBuilderBuildpacks.
+func (mr *MockPackClientMockRecorder) AddBuilderBuildpacks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBuilderBuildpacks", reflect.TypeOf((*MockPackClient)(nil).AddBuilderBuildpacks), arg0, arg1)
+}
+
 // Build mocks base method.
 func (m *MockPackClient) Build(arg0 context.Context, arg1 client.BuildOptions) error {
 	m.ctrl.T.Helper()
@@ -50,6 +65,21 @@ func (mr *MockPackClientMockRecorder) Build(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockPackClient)(nil).Build), arg0, arg1)
 }
 
+// ClearCacheVolumes mocks base method.
+func (m *MockPackClient) ClearCacheVolumes(arg0 context.Context, arg1 client.ClearCacheOptions) ([]client.CacheVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCacheVolumes", arg0, arg1)
+	ret0, _ := ret[0].([]client.CacheVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearCacheVolumes indicates an expected call of ClearCacheVolumes.
+func (mr *MockPackClientMockRecorder) ClearCacheVolumes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCacheVolumes", reflect.TypeOf((*MockPackClient)(nil).ClearCacheVolumes), arg0, arg1)
+}
+
 // CreateBuilder mocks base method.
 func (m *MockPackClient) CreateBuilder(arg0 context.Context, arg1 client.CreateBuilderOptions) error {
 	m.ctrl.T.Helper()
@@ -64,6 +94,21 @@ func (mr *MockPackClientMockRecorder) CreateBuilder(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBuilder", reflect.TypeOf((*MockPackClient)(nil).CreateBuilder), arg0, arg1)
 }
 
+// DaemonArchitecture mocks base method.
+func (m *MockPackClient) DaemonArchitecture(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DaemonArchitecture", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DaemonArchitecture indicates an expected call of DaemonArchitecture.
+func (mr *MockPackClientMockRecorder) DaemonArchitecture(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DaemonArchitecture", reflect.TypeOf((*MockPackClient)(nil).DaemonArchitecture), arg0)
+}
+
 // DownloadSBOM mocks base method.
 func (m *MockPackClient) DownloadSBOM(arg0 string, arg1 client.DownloadSBOMOptions) error {
 	m.ctrl.T.Helper()
@@ -78,6 +123,64 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// ExportBuildState mocks base method.
+func (m *MockPackClient) ExportBuildState(arg0 context.Context, arg1 client.ExportBuildStateOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBuildState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportBuildState indicates an expected call of ExportBuildState.
+func (mr *MockPackClientMockRecorder) ExportBuildState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBuildState", reflect.TypeOf((*MockPackClient)(nil).ExportBuildState), arg0, arg1)
+}
+
+// ExportCache mocks base method.
+func (m *MockPackClient) ExportCache(arg0 context.Context, arg1 client.ExportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCache indicates an expected call of ExportCache.
+func (mr *MockPackClientMockRecorder) ExportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCache", reflect.TypeOf((*MockPackClient)(nil).ExportCache), arg0, arg1)
+}
+
+// ImportBuildState mocks base method.
+func (m *MockPackClient) ImportBuildState(arg0 context.Context, arg1 client.ImportBuildStateOptions) (build.State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBuildState", arg0, arg1)
+	ret0, _ := ret[0].(build.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBuildState indicates an expected call of ImportBuildState.
+func (mr *MockPackClientMockRecorder) ImportBuildState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBuildState", reflect.TypeOf((*MockPackClient)(nil).ImportBuildState), arg0, arg1)
+}
+
+// ImportCache mocks base method.
+func (m *MockPackClient) ImportCache(arg0 context.Context, arg1 client.ImportCacheOptions) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCache", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCache indicates an expected call of ImportCache.
+func (mr *MockPackClientMockRecorder) ImportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCache", reflect.TypeOf((*MockPackClient)(nil).ImportCache), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +216,21 @@ func (mr *MockPackClientMockRecorder) InspectBuildpack(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuildpack", reflect.TypeOf((*MockPackClient)(nil).InspectBuildpack), arg0)
 }
 
+// InspectCache mocks base method.
+func (m *MockPackClient) InspectCache(arg0 context.Context, arg1 client.InspectCacheOptions) ([]client.CacheContents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectCache", arg0, arg1)
+	ret0, _ := ret[0].([]client.CacheContents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectCache indicates an expected call of InspectCache.
+func (mr *MockPackClientMockRecorder) InspectCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectCache", reflect.TypeOf((*MockPackClient)(nil).InspectCache), arg0, arg1)
+}
+
 // InspectImage mocks base method.
 func (m *MockPackClient) InspectImage(arg0 string, arg1 bool) (*client.ImageInfo, error) {
 	m.ctrl.T.Helper()
@@ -128,6 +246,65 @@ func (mr *MockPackClientMockRecorder) InspectImage(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockPackClient)(nil).InspectImage), arg0, arg1)
 }
 
+// ListBuilders mocks base method.
+func (m *MockPackClient) ListBuilders(arg0 context.Context, arg1 client.ListBuildersOptions) ([]client.BuilderSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBuilders", arg0, arg1)
+	ret0, _ := ret[0].([]client.BuilderSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBuilders indicates an expected call of ListBuilders.
+func (mr *MockPackClientMockRecorder) ListBuilders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBuilders", reflect.TypeOf((*MockPackClient)(nil).ListBuilders), arg0, arg1)
+}
+
+// ListCacheVolumes mocks base method.
+func (m *MockPackClient) ListCacheVolumes(arg0 context.Context, arg1 string) ([]client.CacheVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCacheVolumes", arg0, arg1)
+	ret0, _ := ret[0].([]client.CacheVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCacheVolumes indicates an expected call of ListCacheVolumes.
+func (mr *MockPackClientMockRecorder) ListCacheVolumes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCacheVolumes", reflect.TypeOf((*MockPackClient)(nil).ListCacheVolumes), arg0, arg1)
+}
+
+// ListEphemeralImages mocks base method.
+func (m *MockPackClient) ListEphemeralImages(arg0 context.Context, arg1 client.EphemeralImageFilter) ([]client.EphemeralImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEphemeralImages", arg0, arg1)
+	ret0, _ := ret[0].([]client.EphemeralImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEphemeralImages indicates an expected call of ListEphemeralImages.
+func (mr *MockPackClientMockRecorder) ListEphemeralImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEphemeralImages", reflect.TypeOf((*MockPackClient)(nil).ListEphemeralImages), arg0, arg1)
+}
+
+// NewApp mocks base method.
+func (m *MockPackClient) NewApp(arg0 context.Context, arg1 client.NewAppOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewApp", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NewApp indicates an expected call of NewApp.
+func (mr *MockPackClientMockRecorder) NewApp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewApp", reflect.TypeOf((*MockPackClient)(nil).NewApp), arg0, arg1)
+}
+
 // NewBuildpack mocks base method.
 func (m *MockPackClient) NewBuildpack(arg0 context.Context, arg1 client.NewBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -156,6 +333,36 @@ func (mr *MockPackClientMockRecorder) PackageBuildpack(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PackageBuildpack", reflect.TypeOf((*MockPackClient)(nil).PackageBuildpack), arg0, arg1)
 }
 
+// PruneCacheVolumes mocks base method.
+func (m *MockPackClient) PruneCacheVolumes(arg0 context.Context, arg1 client.PruneCacheOptions) ([]client.CacheVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneCacheVolumes", arg0, arg1)
+	ret0, _ := ret[0].([]client.CacheVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneCacheVolumes indicates an expected call of PruneCacheVolumes.
+func (mr *MockPackClientMockRecorder) PruneCacheVolumes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneCacheVolumes", reflect.TypeOf((*MockPackClient)(nil).PruneCacheVolumes), arg0, arg1)
+}
+
+// PruneEphemeralImages mocks base method.
+func (m *MockPackClient) PruneEphemeralImages(arg0 context.Context, arg1 client.PruneEphemeralImagesOptions) ([]client.EphemeralImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneEphemeralImages", arg0, arg1)
+	ret0, _ := ret[0].([]client.EphemeralImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneEphemeralImages indicates an expected call of PruneEphemeralImages.
+func (mr *MockPackClientMockRecorder) PruneEphemeralImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneEphemeralImages", reflect.TypeOf((*MockPackClient)(nil).PruneEphemeralImages), arg0, arg1)
+}
+
 // PullBuildpack mocks base method.
 func (m *MockPackClient) PullBuildpack(arg0 context.Context, arg1 client.PullBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -184,6 +391,21 @@ func (mr *MockPackClientMockRecorder) Rebase(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebase", reflect.TypeOf((*MockPackClient)(nil).Rebase), arg0, arg1)
 }
 
+// RebaseImages mocks base method.
+func (m *MockPackClient) RebaseImages(arg0 context.Context, arg1 client.RebaseImagesOptions) ([]client.RebaseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebaseImages", arg0, arg1)
+	ret0, _ := ret[0].([]client.RebaseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebaseImages indicates an expected call of RebaseImages.
+func (mr *MockPackClientMockRecorder) RebaseImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebaseImages", reflect.TypeOf((*MockPackClient)(nil).RebaseImages), arg0, arg1)
+}
+
 // RegisterBuildpack mocks base method.
 func (m *MockPackClient) RegisterBuildpack(arg0 context.Context, arg1 client.RegisterBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -198,6 +420,134 @@ func (mr *MockPackClientMockRecorder) RegisterBuildpack(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBuildpack", reflect.TypeOf((*MockPackClient)(nil).RegisterBuildpack), arg0, arg1)
 }
 
+// RemoveBuilderBuildpack mocks base method.
+func (m *MockPackClient) RemoveBuilderBuildpack(arg0 context.Context, arg1 client.RemoveBuilderBuildpackOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBuilderBuildpack", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBuilderBuildpack indicates an expected call of RemoveBuilderBuildpack.
+func (mr *MockPackClientMockRecorder) RemoveBuilderBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBuilderBuildpack", reflect.TypeOf((*MockPackClient)(nil).RemoveBuilderBuildpack), arg0, arg1)
+}
+
+// RunApp mocks base method.
+func (m *MockPackClient) RunApp(arg0 context.Context, arg1 client.RunAppOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunApp", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunApp indicates an expected call of RunApp.
+func (mr *MockPackClientMockRecorder) RunApp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunApp", reflect.TypeOf((*MockPackClient)(nil).RunApp), arg0, arg1)
+}
+
+// SearchBuildpacks mocks base method.
+func (m *MockPackClient) SearchBuildpacks(arg0 client.SearchBuildpacksOptions) ([]client.RegistryBuildpack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBuildpacks", arg0)
+	ret0, _ := ret[0].([]client.RegistryBuildpack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBuildpacks indicates an expected call of SearchBuildpacks.
+func (mr *MockPackClientMockRecorder) SearchBuildpacks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBuildpacks", reflect.TypeOf((*MockPackClient)(nil).SearchBuildpacks), arg0)
+}
+
+// TagImage mocks base method.
+func (m *MockPackClient) TagImage(arg0 context.Context, arg1 client.TagImageOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagImage indicates an expected call of TagImage.
+func (mr *MockPackClientMockRecorder) TagImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagImage", reflect.TypeOf((*MockPackClient)(nil).TagImage), arg0, arg1)
+}
+
+// UntagImage mocks base method.
+func (m *MockPackClient) UntagImage(arg0 context.Context, arg1 client.UntagImageOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagImage indicates an expected call of UntagImage.
+func (mr *MockPackClientMockRecorder) UntagImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagImage", reflect.TypeOf((*MockPackClient)(nil).UntagImage), arg0, arg1)
+}
+
+// UpgradeBuilderBuildpack mocks base method.
+func (m *MockPackClient) UpgradeBuilderBuildpack(arg0 context.Context, arg1 client.UpgradeBuilderBuildpackOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeBuilderBuildpack", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeBuilderBuildpack indicates an expected call of UpgradeBuilderBuildpack.
+func (mr *MockPackClientMockRecorder) UpgradeBuilderBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeBuilderBuildpack", reflect.TypeOf((*MockPackClient)(nil).UpgradeBuilderBuildpack), arg0, arg1)
+}
+
+// VerifyImage mocks base method.
+func (m *MockPackClient) VerifyImage(arg0 context.Context, arg1 client.VerifyImageOptions) ([]client.LayerVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyImage", arg0, arg1)
+	ret0, _ := ret[0].([]client.LayerVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyImage indicates an expected call of VerifyImage.
+func (mr *MockPackClientMockRecorder) VerifyImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyImage", reflect.TypeOf((*MockPackClient)(nil).VerifyImage), arg0, arg1)
+}
+
+// WatchBuild mocks base method.
+func (m *MockPackClient) WatchBuild(arg0 context.Context, arg1 client.WatchBuildOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchBuild", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchBuild indicates an expected call of WatchBuild.
+func (mr *MockPackClientMockRecorder) WatchBuild(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchBuild", reflect.TypeOf((*MockPackClient)(nil).WatchBuild), arg0, arg1)
+}
+
+// WatchRunImage mocks base method.
+func (m *MockPackClient) WatchRunImage(arg0 context.Context, arg1 client.WatchRunImageOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchRunImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchRunImage indicates an expected call of WatchRunImage.
+func (mr *MockPackClientMockRecorder) WatchRunImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRunImage", reflect.TypeOf((*MockPackClient)(nil).WatchRunImage), arg0, arg1)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
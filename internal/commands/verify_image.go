@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type VerifyImageFlags struct {
+	AppPath        string
+	DescriptorPath string
+	Env            []string
+	EnvFiles       []string
+	Daemon         bool
+	Policy         string
+}
+
+func VerifyImage(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags VerifyImageFlags
+
+	cmd := &cobra.Command{
+		Use:     "verify-image <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Rebuild an app image from its recorded inputs and check which layers reproduce",
+		Example: "pack verify-image my/app --path ./app",
+		Long: "Rebuild an app image built with `--record-inputs` on the daemon, with the builder, buildpacks and run image " +
+			"it was built with, and compare the layers of the rebuild to those of the image.\n" +
+			"The source of the app and the environment variables given must be those of the build.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			imageName := args[0]
+
+			descriptor, _, err := parseProjectToml(flags.AppPath, flags.DescriptorPath)
+			if err != nil {
+				return err
+			}
+
+			env, err := parseEnv(flags.EnvFiles, flags.Env)
+			if err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			layers, err := pack.VerifyImage(cmd.Context(), client.VerifyImageOptions{
+				Image:             imageName,
+				Daemon:            flags.Daemon,
+				AppPath:           flags.AppPath,
+				Env:               env,
+				ProjectDescriptor: descriptor,
+				PullPolicy:        pullPolicy,
+			})
+			if err != nil {
+				return err
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), 10, 10, 5, ' ', tabwriter.TabIndent)
+			fmt.Fprintln(tw, "LAYER\tEXPECTED\tACTUAL\tREPRODUCED")
+			var failed int
+			for _, layer := range layers {
+				reproduced := "yes"
+				if !layer.Reproduced() {
+					reproduced = "no"
+					failed++
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", layer.Layer, shortDiffID(layer.Expected), shortDiffID(layer.Actual), reproduced)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			if failed > 0 {
+				return errors.Errorf("%d of %d layers of image %s did not reproduce", failed, len(layers), style.Symbol(imageName))
+			}
+			logger.Infof("All %d layers of image %s reproduced", len(layers), style.Symbol(imageName))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to the source the image was built from (defaults to current working directory)")
+	cmd.Flags().StringVarP(&flags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().StringArrayVarP(&flags.Env, "env", "e", []string{}, "Build-time environment variable the image was built with, in the form 'VAR=VALUE' or 'VAR'"+stringArrayHelp("env"))
+	cmd.Flags().StringArrayVar(&flags.EnvFiles, "env-file", []string{}, "Build-time environment variables file the image was built with")
+	cmd.Flags().BoolVar(&flags.Daemon, "daemon", false, "Verify the image in the daemon, rather than in its registry")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy of the builder, buildpacks and run image. Accepted values are always, never, and if-not-present. The default is always")
+	AddHelpFlag(cmd, "verify-image")
+	return cmd
+}
+
+// shortDiffID returns the first 12 characters of the hex of diffID, or - when there is none.
+func shortDiffID(diffID string) string {
+	if diffID == "" {
+		return "-"
+	}
+	return shortImageID(diffID)
+}
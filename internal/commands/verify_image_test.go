@@ -0,0 +1,81 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVerifyImageCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "VerifyImageCommand", testVerifyImageCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVerifyImageCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		launcherLayer  client.LayerVerification
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		launcherLayer = client.LayerVerification{
+			Layer:    "lifecycle launcher",
+			Expected: "sha256:0123456789abcdef",
+			Actual:   "sha256:0123456789abcdef",
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("reports that every layer reproduced", func() {
+		mockClient.EXPECT().
+			VerifyImage(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, opts client.VerifyImageOptions) ([]client.LayerVerification, error) {
+				h.AssertEq(t, opts.Image, "some/app")
+				h.AssertEq(t, opts.AppPath, "testdata")
+				h.AssertEq(t, opts.Env, map[string]string{"KEY": "value"})
+				h.AssertEq(t, opts.PullPolicy, image.PullIfNotPresent)
+				return []client.LayerVerification{launcherLayer}, nil
+			})
+
+		command := commands.VerifyImage(logger, config.Config{}, mockClient)
+		command.SetArgs([]string{"some/app", "--path", "testdata", "--env", "KEY=value", "--pull-policy", "if-not-present"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "lifecycle launcher")
+		h.AssertContains(t, outBuf.String(), "0123456789ab")
+		h.AssertContains(t, outBuf.String(), "All 1 layers of image 'some/app' reproduced")
+	})
+
+	it("errors when layers did not reproduce", func() {
+		appLayer := client.LayerVerification{Layer: "lifecycle app", Expected: "sha256:aaaaaaaaaaaaaaaa", Actual: "sha256:bbbbbbbbbbbbbbbb"}
+		mockClient.EXPECT().
+			VerifyImage(gomock.Any(), gomock.Any()).
+			Return([]client.LayerVerification{launcherLayer, appLayer}, nil)
+
+		command := commands.VerifyImage(logger, config.Config{}, mockClient)
+		command.SetArgs([]string{"some/app", "--path", "testdata"})
+		h.AssertError(t, command.Execute(), "1 of 2 layers of image 'some/app' did not reproduce")
+		h.AssertContains(t, outBuf.String(), "bbbbbbbbbbbb")
+	})
+}
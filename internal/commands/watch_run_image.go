@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type WatchRunImageFlags struct {
+	Interval   time.Duration
+	Images     []string
+	Publish    bool
+	WebhookURL string
+	Policy     string
+}
+
+func WatchRunImage(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags WatchRunImageFlags
+
+	cmd := &cobra.Command{
+		Use:     "watch-run-image <run-image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Watch a run image for updates and rebase app images onto it",
+		Example: "pack watch-run-image cnbs/sample-stack-run:bionic --rebase buildpacksio/pack --publish",
+		Long: "Watch polls the registry for changes to the digest of a run image tag. Whenever the run image is updated, " +
+			"the app images provided with `--rebase` are rebased onto it and the webhook provided with `--webhook` is notified, " +
+			"so that patches to the run image are adopted without manual intervention. Watch runs until interrupted.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(flags.Images) == 0 && flags.WebhookURL == "" {
+				return errors.New("at least one of --rebase or --webhook must be provided")
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
+			}
+
+			return pack.WatchRunImage(cmd.Context(), client.WatchRunImageOptions{
+				RunImage:          args[0],
+				Interval:          flags.Interval,
+				Images:            flags.Images,
+				Publish:           flags.Publish,
+				PullPolicy:        pullPolicy,
+				AdditionalMirrors: getMirrors(cfg),
+				WebhookURL:        flags.WebhookURL,
+			})
+		}),
+	}
+
+	cmd.Flags().DurationVar(&flags.Interval, "interval", 5*time.Minute, "How often to check the run image for updates")
+	cmd.Flags().StringArrayVar(&flags.Images, "rebase", nil, "App image to rebase when the run image is updated"+stringArrayHelp("rebase"))
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish rebased images to their registry")
+	cmd.Flags().StringVar(&flags.WebhookURL, "webhook", "", "URL to notify, with a JSON POST request, when the run image is updated")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use when rebasing. Accepted values are always, never, and if-not-present. The default is always")
+
+	AddHelpFlag(cmd, "watch-run-image")
+	return experimental(logger, cfg, cmd)
+}
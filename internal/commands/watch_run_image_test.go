@@ -0,0 +1,109 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestWatchRunImageCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "WatchRunImageCommand", testWatchRunImageCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testWatchRunImageCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		cfg            config.Config
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		cfg = config.Config{
+			Experimental: true,
+			RunImages: []config.RunImage{{
+				Image:   "some/run",
+				Mirrors: []string{"example.com/some/run"},
+			}},
+		}
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.WatchRunImage(logger, cfg, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#WatchRunImage", func() {
+		it("watches the run image with the provided options", func() {
+			mockClient.EXPECT().WatchRunImage(gomock.Any(), client.WatchRunImageOptions{
+				RunImage:          "some/run",
+				Interval:          30 * time.Second,
+				Images:            []string{"some/app", "other/app"},
+				Publish:           true,
+				PullPolicy:        image.PullAlways,
+				AdditionalMirrors: map[string][]string{"some/run": {"example.com/some/run"}},
+				WebhookURL:        "https://example.com/hook",
+			}).Return(nil)
+
+			command.SetArgs([]string{
+				"some/run",
+				"--interval", "30s",
+				"--rebase", "some/app",
+				"--rebase", "other/app",
+				"--publish",
+				"--webhook", "https://example.com/hook",
+			})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("defaults the interval to five minutes", func() {
+			mockClient.EXPECT().WatchRunImage(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ interface{}, opts client.WatchRunImageOptions) error {
+					h.AssertEq(t, opts.Interval, 5*time.Minute)
+					return nil
+				})
+
+			command.SetArgs([]string{"some/run", "--rebase", "some/app"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("errors when no action is provided", func() {
+			command.SetArgs([]string{"some/run"})
+			h.AssertError(t, command.Execute(), "at least one of --rebase or --webhook must be provided")
+		})
+
+		it("errors when the pull policy is invalid", func() {
+			command.SetArgs([]string{"some/run", "--rebase", "some/app", "--pull-policy", "unknown"})
+			h.AssertError(t, command.Execute(), "parsing pull policy unknown")
+		})
+
+		it("is experimental", func() {
+			command = commands.WatchRunImage(logger, config.Config{}, mockClient)
+			command.SetArgs([]string{"some/run", "--rebase", "some/app"})
+			h.AssertError(t, command.Execute(), "Command 'watch-run-image' is currently experimental.")
+		})
+	})
+}
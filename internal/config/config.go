@@ -1,8 +1,10 @@
 package config
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/pkg/errors"
@@ -22,6 +24,15 @@ type Config struct {
 	Registries          []Registry        `toml:"registries,omitempty"`
 	LifecycleImage      string            `toml:"lifecycle-image,omitempty"`
 	RegistryMirrors     map[string]string `toml:"registry-mirrors,omitempty"`
+	InsecureRegistries  []string          `toml:"insecure-registries,omitempty"`
+	CACertificates      []string          `toml:"registry-ca-certificates,omitempty"`
+	RegistryKeychains   []string          `toml:"registry-keychains,omitempty"`
+	RegistryRetry       *RegistryRetry    `toml:"registry-retry,omitempty"`
+	Emulation           string            `toml:"emulation,omitempty"`
+	NativeBuilders      []NativeBuilder   `toml:"native-builders,omitempty"`
+	CICache             string            `toml:"ci-cache,omitempty"`
+	LogSinks            []string          `toml:"log-sinks,omitempty"`
+	CacheLimits         *CacheLimits      `toml:"cache-limits,omitempty"`
 }
 
 type Registry struct {
@@ -30,6 +41,22 @@ type Registry struct {
 	URL  string `toml:"url"`
 }
 
+// RegistryRetry overrides the retries of registry operations failing transiently. Unset fields keep their defaults.
+type RegistryRetry struct {
+	Attempts         int           `toml:"attempts,omitempty"`
+	Backoff          time.Duration `toml:"backoff,omitempty"`
+	MaxBackoff       time.Duration `toml:"max-backoff,omitempty"`
+	StatusCodes      []int         `toml:"status-codes,omitempty"`
+	RateLimitBackoff time.Duration `toml:"rate-limit-backoff,omitempty"`
+}
+
+// CacheLimits bound the disk usage of the cache volumes, which builds prune once exceeded. Unset fields are unbounded.
+type CacheLimits struct {
+	// MaxSize is the maximum total size of the cache volumes, e.g. 20GB.
+	MaxSize string        `toml:"max-size,omitempty"`
+	MaxAge  time.Duration `toml:"max-age,omitempty"`
+}
+
 type RunImage struct {
 	Image   string   `toml:"image"`
 	Mirrors []string `toml:"mirrors"`
@@ -39,8 +66,19 @@ type TrustedBuilder struct {
 	Name string `toml:"name"`
 }
 
+// NativeBuilder is an alternative to a builder, built for another architecture.
+// It is used instead of the builder when the Docker daemon runs on that architecture.
+type NativeBuilder struct {
+	Builder     string `toml:"builder"`
+	Arch        string `toml:"arch"`
+	Alternative string `toml:"alternative"`
+}
+
 const OfficialRegistryName = "official"
 
+// CICacheAuto is the value of ci-cache choosing the build cache suited to the detected CI service by default.
+const CICacheAuto = "auto"
+
 func DefaultRegistry() Registry {
 	return Registry{
 		OfficialRegistryName,
@@ -57,16 +95,67 @@ func DefaultConfigPath() (string, error) {
 	return filepath.Join(home, "config.toml"), nil
 }
 
+// PackHome returns the directory holding the config of pack, such as its config file. It is PACK_HOME when set, so
+// that isolated profiles of pack can coexist, ~/.pack when it exists or XDG base directories are not configured, and
+// otherwise the pack directory of XDG_CONFIG_HOME.
 func PackHome() (string, error) {
-	packHome := os.Getenv("PACK_HOME")
-	if packHome == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return "", errors.Wrap(err, "getting user home")
-		}
-		packHome = filepath.Join(home, ".pack")
+	dirs, err := packDirs()
+	return dirs.config, err
+}
+
+// PackCacheHome returns the directory holding the state pack can recreate, such as downloads and registry caches. It
+// is the same directory as PackHome, except with XDG base directories, where it is the pack directory of
+// XDG_CACHE_HOME.
+func PackCacheHome() (string, error) {
+	dirs, err := packDirs()
+	return dirs.cache, err
+}
+
+// StateDir returns the directory of the state named name which pack keeps across its runs for the user, such as the
+// state of failed builds, in the cache directory of the user, or in the temporary directory when it has none.
+func StateDir(name string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
 	}
-	return packHome, nil
+	return filepath.Join(dir, "pack", name)
+}
+
+type packHomeDirs struct {
+	config string
+	cache  string
+}
+
+// packDirs resolves the directories of pack together, so that using the cache directory cannot change the directory
+// the config is read from: with XDG base directories, ~/.pack is never created.
+func packDirs() (packHomeDirs, error) {
+	if packHome := os.Getenv("PACK_HOME"); packHome != "" {
+		return packHomeDirs{config: packHome, cache: packHome}, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return packHomeDirs{}, errors.Wrap(err, "getting user home")
+	}
+	packHome := filepath.Join(home, ".pack")
+	legacy := packHomeDirs{config: packHome, cache: packHome}
+
+	// an existing ~/.pack is kept, so that setting up XDG directories does not lose the config of pack
+	if _, err := os.Stat(packHome); err == nil {
+		return legacy, nil
+	}
+
+	configHome, cacheHome := os.Getenv("XDG_CONFIG_HOME"), os.Getenv("XDG_CACHE_HOME")
+	if !filepath.IsAbs(configHome) && !filepath.IsAbs(cacheHome) {
+		return legacy, nil
+	}
+	if !filepath.IsAbs(configHome) {
+		configHome = filepath.Join(home, ".config")
+	}
+	if !filepath.IsAbs(cacheHome) {
+		cacheHome = filepath.Join(home, ".cache")
+	}
+	return packHomeDirs{config: filepath.Join(configHome, "pack"), cache: filepath.Join(cacheHome, "pack")}, nil
 }
 
 func Read(path string) (Config, error) {
@@ -79,17 +168,50 @@ func Read(path string) (Config, error) {
 	return cfg, nil
 }
 
+// Write replaces the config file at path with cfg. The file is replaced at once, so that commands reading it
+// concurrently, or an interrupted write, never see a partial config. A symlinked config file is followed to the file
+// it links to, and the mode of an existing file is kept.
 func Write(cfg Config, path string) error {
-	if err := MkdirAll(filepath.Dir(path)); err != nil {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	dir := filepath.Dir(path)
+	if err := MkdirAll(dir); err != nil {
 		return err
 	}
-	w, err := os.Create(path)
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+
+		// renaming over a file succeeds even when it cannot be written to
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	w, err := ioutil.TempFile(dir, filepath.Base(path)+".*.tmp")
 	if err != nil {
 		return err
 	}
-	defer w.Close()
+	defer os.Remove(w.Name())
 
-	return toml.NewEncoder(w).Encode(cfg)
+	if err := toml.NewEncoder(w).Encode(cfg); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(w.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(w.Name(), path)
 }
 
 func MkdirAll(path string) error {
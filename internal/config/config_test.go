@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/heroku/color"
@@ -107,6 +108,48 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 				h.AssertContains(t, string(b), `default-builder-image = "some/builder"`)
 				h.AssertNotContains(t, string(b), "some-old-contents")
 			})
+
+			it("keeps the mode of the file and leaves no temporary file", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "file modes are not supported on Windows")
+				h.AssertNil(t, os.Chmod(configPath, 0600))
+
+				h.AssertNil(t, config.Write(config.Config{DefaultBuilder: "some/builder"}, configPath))
+
+				info, err := os.Stat(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, info.Mode().Perm(), os.FileMode(0600))
+
+				entries, err := ioutil.ReadDir(filepath.Dir(configPath))
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(entries), 1)
+			})
+
+			it("replaces the file a symlinked config links to", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "creating symlinks requires privileges on Windows")
+				linkPath := filepath.Join(tmpDir, "linked-config.toml")
+				h.AssertNil(t, os.Symlink(configPath, linkPath))
+
+				h.AssertNil(t, config.Write(config.Config{DefaultBuilder: "some/builder"}, linkPath))
+
+				info, err := os.Lstat(linkPath)
+				h.AssertNil(t, err)
+				h.AssertTrue(t, info.Mode()&os.ModeSymlink != 0)
+				b, err := ioutil.ReadFile(configPath)
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(b), `default-builder-image = "some/builder"`)
+			})
+
+			it("fails when the file cannot be written to", func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "file modes are not supported on Windows")
+				h.SkipIf(t, os.Getuid() == 0, "root can write to any file")
+				h.AssertNil(t, os.Chmod(configPath, 0444))
+
+				h.AssertNotNil(t, config.Write(config.Config{DefaultBuilder: "some/builder"}, configPath))
+
+				b, err := ioutil.ReadFile(configPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(b), "some-old-contents")
+			})
 		})
 
 		when("directories are missing", func() {
@@ -266,3 +309,104 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 }
+
+func TestPackHome(t *testing.T) {
+	// the environment is shared by the tests, so they are not run in parallel
+	spec.Run(t, "PackHome", testPackHome, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testPackHome(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir  string
+		homeEnv string
+		home    string
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "pack.config.test.")
+		h.AssertNil(t, err)
+
+		homeEnv = "HOME"
+		if runtime.GOOS == "windows" {
+			homeEnv = "USERPROFILE"
+		}
+		home = os.Getenv(homeEnv)
+		h.AssertNil(t, os.Setenv(homeEnv, filepath.Join(tmpDir, "home")))
+		h.AssertNil(t, os.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config")))
+		h.AssertNil(t, os.Setenv("XDG_CACHE_HOME", filepath.Join(tmpDir, "cache")))
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.Setenv(homeEnv, home))
+		h.AssertNil(t, os.Unsetenv("XDG_CONFIG_HOME"))
+		h.AssertNil(t, os.Unsetenv("XDG_CACHE_HOME"))
+		h.AssertNil(t, os.Unsetenv("PACK_HOME"))
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	it("uses the XDG directories when there is no ~/.pack", func() {
+		packHome, err := config.PackHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, packHome, filepath.Join(tmpDir, "config", "pack"))
+
+		cacheHome, err := config.PackCacheHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, cacheHome, filepath.Join(tmpDir, "cache", "pack"))
+	})
+
+	it("keeps the config in the XDG directory when only XDG_CONFIG_HOME is set", func() {
+		h.AssertNil(t, os.Unsetenv("XDG_CACHE_HOME"))
+
+		packHome, err := config.PackHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, packHome, filepath.Join(tmpDir, "config", "pack"))
+
+		cacheHome, err := config.PackCacheHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, cacheHome, filepath.Join(tmpDir, "home", ".cache", "pack"))
+
+		// using the cache does not create ~/.pack, which would move the config
+		h.AssertNil(t, os.MkdirAll(cacheHome, 0750))
+		packHome, err = config.PackHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, packHome, filepath.Join(tmpDir, "config", "pack"))
+	})
+
+	it("uses ~/.pack without XDG directories", func() {
+		h.AssertNil(t, os.Unsetenv("XDG_CONFIG_HOME"))
+		h.AssertNil(t, os.Unsetenv("XDG_CACHE_HOME"))
+
+		packHome, err := config.PackHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, packHome, filepath.Join(tmpDir, "home", ".pack"))
+
+		cacheHome, err := config.PackCacheHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, cacheHome, filepath.Join(tmpDir, "home", ".pack"))
+	})
+
+	it("keeps an existing ~/.pack", func() {
+		h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "home", ".pack"), 0750))
+
+		packHome, err := config.PackHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, packHome, filepath.Join(tmpDir, "home", ".pack"))
+
+		cacheHome, err := config.PackCacheHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, cacheHome, filepath.Join(tmpDir, "home", ".pack"))
+	})
+
+	it("prefers PACK_HOME", func() {
+		h.AssertNil(t, os.Setenv("PACK_HOME", filepath.Join(tmpDir, "profile")))
+
+		packHome, err := config.PackHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, packHome, filepath.Join(tmpDir, "profile"))
+
+		cacheHome, err := config.PackCacheHome()
+		h.AssertNil(t, err)
+		h.AssertEq(t, cacheHome, filepath.Join(tmpDir, "profile"))
+	})
+}
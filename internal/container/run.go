@@ -12,6 +12,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ExitError is returned by DefaultHandler when the container exits with a non-zero status code.
+type ExitError struct {
+	StatusCode int64
+}
+
+func (e ExitError) Error() string {
+	return fmt.Sprintf("failed with status code: %d", e.StatusCode)
+}
+
 type Handler func(bodyChan <-chan dcontainer.ContainerWaitOKBody, errChan <-chan error, reader io.Reader) error
 
 func RunWithHandler(ctx context.Context, docker client.CommonAPIClient, ctrID string, handler Handler) error {
@@ -48,7 +57,7 @@ func DefaultHandler(out, errOut io.Writer) Handler {
 		select {
 		case body := <-bodyChan:
 			if body.StatusCode != 0 {
-				return fmt.Errorf("failed with status code: %d", body.StatusCode)
+				return ExitError{StatusCode: body.StatusCode}
 			}
 		case err := <-errChan:
 			return err
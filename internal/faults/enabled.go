@@ -0,0 +1,7 @@
+//go:build faults
+// +build faults
+
+package faults
+
+// Enabled reports whether pack was built with the faults build tag, which faults can only be injected with.
+const Enabled = true
@@ -0,0 +1,163 @@
+// Package faults injects failures into builds, such as crashes of lifecycle phases, disconnects from the daemon and
+// errors of registries, so that the way pack recovers from them can be verified systematically. Faults are only
+// injected by binaries built with the faults build tag.
+package faults
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Kind is the kind of failure a Fault injects.
+type Kind string
+
+const (
+	// PhaseCrash makes the lifecycle phase named by the target exit with status code 1, without running it.
+	PhaseCrash Kind = "phase-crash"
+
+	// DaemonDisconnect makes the connection to the daemon drop when starting the lifecycle phase named by the target.
+	DaemonDisconnect Kind = "daemon-disconnect"
+
+	// RegistryError makes the registry host named by the target, such as localhost:5000, respond to requests of pack
+	// with 503 Service Unavailable.
+	RegistryError Kind = "registry-5xx"
+)
+
+var kinds = []Kind{PhaseCrash, DaemonDisconnect, RegistryError}
+
+// ErrInjected is the cause of the errors of injected faults.
+var ErrInjected = errors.New("injected fault")
+
+// Fault is a failure injected into the operations on its target.
+type Fault struct {
+	Kind   Kind
+	Target string
+
+	// Count is the number of times the fault is injected, after which the operations succeed again. Faults with a
+	// Count of 0 are injected every time.
+	Count int
+}
+
+// Parse parses a fault of the form <kind>=<target>[#<count>], such as phase-crash=exporter#1.
+func Parse(s string) (Fault, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return Fault{}, errors.Errorf("invalid fault %s: must be of the form <kind>=<target>[#<count>]", style.Symbol(s))
+	}
+
+	fault := Fault{Kind: Kind(parts[0]), Target: parts[1]}
+	if !isKind(fault.Kind) {
+		var names []string
+		for _, k := range kinds {
+			names = append(names, string(k))
+		}
+		return Fault{}, errors.Errorf("invalid fault %s: kind must be one of %s", style.Symbol(s), strings.Join(names, ", "))
+	}
+
+	if i := strings.LastIndex(fault.Target, "#"); i >= 0 {
+		count, err := strconv.Atoi(fault.Target[i+1:])
+		if err != nil || count < 1 {
+			return Fault{}, errors.Errorf("invalid fault %s: count must be a positive number", style.Symbol(s))
+		}
+		fault.Target = fault.Target[:i]
+		fault.Count = count
+	}
+
+	return fault, nil
+}
+
+func (f Fault) String() string {
+	if f.Count == 0 {
+		return string(f.Kind) + "=" + f.Target
+	}
+	return string(f.Kind) + "=" + f.Target + "#" + strconv.Itoa(f.Count)
+}
+
+// Injector decides which operations faults are injected into. The zero value and nil inject no fault.
+type Injector struct {
+	mu       sync.Mutex
+	faults   []Fault
+	injected []int
+}
+
+// NewInjector creates an injector of faults.
+func NewInjector(faults ...Fault) *Injector {
+	return &Injector{
+		faults:   faults,
+		injected: make([]int, len(faults)),
+	}
+}
+
+// Inject returns whether a fault of kind is to be injected into an operation on target, counting it when it is.
+func (i *Injector) Inject(kind Kind, target string) bool {
+	if i == nil {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for n, fault := range i.faults {
+		if fault.Kind != kind || fault.Target != target {
+			continue
+		}
+		if fault.Count != 0 && i.injected[n] >= fault.Count {
+			continue
+		}
+		i.injected[n]++
+		return true
+	}
+	return false
+}
+
+var (
+	activeMu sync.RWMutex
+	active   *Injector
+)
+
+// Install makes injector the one faults are injected by, for the whole process, until Uninstall is called. It fails
+// when pack was not built with the faults build tag.
+func Install(injector *Injector) error {
+	if !Enabled {
+		return errors.New("injecting faults requires pack to be built with the faults build tag")
+	}
+
+	activeMu.Lock()
+	active = injector
+	activeMu.Unlock()
+
+	registerTransports()
+	return nil
+}
+
+// Uninstall stops injecting faults.
+func Uninstall() {
+	activeMu.Lock()
+	active = nil
+	activeMu.Unlock()
+}
+
+// Active returns the installed injector, or nil when no fault is injected.
+func Active() *Injector {
+	if !Enabled {
+		return nil
+	}
+
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	return active
+}
+
+func isKind(kind Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
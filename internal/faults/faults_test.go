@@ -0,0 +1,77 @@
+package faults_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/faults"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestFaults(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Faults", testFaults, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testFaults(t *testing.T, when spec.G, it spec.S) {
+	when("#Parse", func() {
+		it("parses faults injected every time", func() {
+			fault, err := faults.Parse("phase-crash=exporter")
+			h.AssertNil(t, err)
+			h.AssertEq(t, fault, faults.Fault{Kind: faults.PhaseCrash, Target: "exporter"})
+		})
+
+		it("parses the count of faults", func() {
+			fault, err := faults.Parse("registry-5xx=localhost:5000#2")
+			h.AssertNil(t, err)
+			h.AssertEq(t, fault, faults.Fault{Kind: faults.RegistryError, Target: "localhost:5000", Count: 2})
+			h.AssertEq(t, fault.String(), "registry-5xx=localhost:5000#2")
+		})
+
+		it("errors for faults without a target", func() {
+			_, err := faults.Parse("daemon-disconnect=")
+			h.AssertError(t, err, "must be of the form <kind>=<target>[#<count>]")
+		})
+
+		it("errors for unknown kinds", func() {
+			_, err := faults.Parse("oom=builder")
+			h.AssertError(t, err, "kind must be one of phase-crash, daemon-disconnect, registry-5xx")
+		})
+
+		it("errors for invalid counts", func() {
+			_, err := faults.Parse("phase-crash=builder#0")
+			h.AssertError(t, err, "count must be a positive number")
+		})
+	})
+
+	when("Injector", func() {
+		when("#Inject", func() {
+			it("injects faults into operations on their target", func() {
+				injector := faults.NewInjector(faults.Fault{Kind: faults.PhaseCrash, Target: "builder"})
+				h.AssertTrue(t, injector.Inject(faults.PhaseCrash, "builder"))
+				h.AssertTrue(t, injector.Inject(faults.PhaseCrash, "builder"))
+				h.AssertFalse(t, injector.Inject(faults.PhaseCrash, "exporter"))
+				h.AssertFalse(t, injector.Inject(faults.DaemonDisconnect, "builder"))
+			})
+
+			it("stops injecting faults after their count", func() {
+				injector := faults.NewInjector(
+					faults.Fault{Kind: faults.DaemonDisconnect, Target: "exporter", Count: 1},
+					faults.Fault{Kind: faults.DaemonDisconnect, Target: "exporter", Count: 1},
+				)
+				h.AssertTrue(t, injector.Inject(faults.DaemonDisconnect, "exporter"))
+				h.AssertTrue(t, injector.Inject(faults.DaemonDisconnect, "exporter"))
+				h.AssertFalse(t, injector.Inject(faults.DaemonDisconnect, "exporter"))
+			})
+
+			it("injects no fault when nil", func() {
+				var injector *faults.Injector
+				h.AssertFalse(t, injector.Inject(faults.PhaseCrash, "builder"))
+			})
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package faults
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+var registerOnce sync.Once
+
+// registerTransports makes the default transports of pack, which registries are accessed through, respond on behalf of
+// registries with injected errors. Requests to other hosts fall through to the transports.
+func registerTransports() {
+	registerOnce.Do(func() {
+		for _, transport := range []*http.Transport{http.DefaultTransport.(*http.Transport), remote.DefaultTransport} {
+			transport.RegisterProtocol("https", registryErrorTransport{})
+			transport.RegisterProtocol("http", registryErrorTransport{})
+		}
+	})
+}
+
+type registryErrorTransport struct{}
+
+func (registryErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !Active().Inject(RegistryError, req.URL.Host) {
+		return nil, http.ErrSkipAltProtocol
+	}
+
+	return registryErrorResponse(req), nil
+}
+
+// registryErrorResponse is the response to req of a registry with an injected error.
+func registryErrorResponse(req *http.Request) *http.Response {
+	body := `{"errors":[{"code":"UNAVAILABLE","message":"` + ErrInjected.Error() + `"}]}`
+	return &http.Response{
+		Status:        "503 Service Unavailable",
+		StatusCode:    http.StatusServiceUnavailable,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
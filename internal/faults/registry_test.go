@@ -0,0 +1,71 @@
+//go:build faults
+// +build faults
+
+package faults_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/faults"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRegistryFaults(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RegistryFaults", testRegistryFaults, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testRegistryFaults(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server *httptest.Server
+		host   string
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		serverURL, err := url.Parse(server.URL)
+		h.AssertNil(t, err)
+		host = serverURL.Host
+	})
+
+	it.After(func() {
+		faults.Uninstall()
+		server.Close()
+	})
+
+	when("#Install", func() {
+		it("makes registries respond with injected errors", func() {
+			h.AssertNil(t, faults.Install(faults.NewInjector(faults.Fault{Kind: faults.RegistryError, Target: host, Count: 1})))
+
+			client := &http.Client{Transport: remote.DefaultTransport}
+			resp, err := client.Get(server.URL + "/v2/")
+			h.AssertNil(t, err)
+			resp.Body.Close()
+			h.AssertEq(t, resp.StatusCode, http.StatusServiceUnavailable)
+
+			resp, err = client.Get(server.URL + "/v2/")
+			h.AssertNil(t, err)
+			resp.Body.Close()
+			h.AssertEq(t, resp.StatusCode, http.StatusOK)
+		})
+
+		it("injects no error once uninstalled", func() {
+			h.AssertNil(t, faults.Install(faults.NewInjector(faults.Fault{Kind: faults.RegistryError, Target: host})))
+			faults.Uninstall()
+
+			resp, err := http.Get(server.URL + "/v2/")
+			h.AssertNil(t, err)
+			resp.Body.Close()
+			h.AssertEq(t, resp.StatusCode, http.StatusOK)
+		})
+	})
+}
@@ -13,6 +13,12 @@ import (
 type GeneralInfo struct {
 	Name            string
 	RunImageMirrors []config.RunImage
+
+	// RemoteOnly is set when the image was only looked up in its registry, never in the docker daemon.
+	RemoteOnly bool
+
+	// LocalOnly is set when the image was only looked up in the docker daemon, never in its registry.
+	LocalOnly bool
 }
 
 type RunImageMirrorDisplay struct {
@@ -26,12 +32,13 @@ type StackDisplay struct {
 }
 
 type ProcessDisplay struct {
-	Type    string   `json:"type" yaml:"type" toml:"type"`
-	Shell   string   `json:"shell" yaml:"shell" toml:"shell"`
-	Command string   `json:"command" yaml:"command" toml:"command"`
-	Default bool     `json:"default" yaml:"default" toml:"default"`
-	Args    []string `json:"args" yaml:"args" toml:"args"`
-	WorkDir string   `json:"working-dir" yaml:"working-dir" toml:"working-dir"`
+	Type        string   `json:"type" yaml:"type" toml:"type"`
+	Shell       string   `json:"shell" yaml:"shell" toml:"shell"`
+	Command     string   `json:"command" yaml:"command" toml:"command"`
+	Default     bool     `json:"default" yaml:"default" toml:"default"`
+	Args        []string `json:"args" yaml:"args" toml:"args"`
+	WorkDir     string   `json:"working-dir" yaml:"working-dir" toml:"working-dir"`
+	BuildpackID string   `json:"buildpack_id,omitempty" yaml:"buildpack_id,omitempty" toml:"buildpack_id,omitempty"`
 }
 
 type BaseDisplay struct {
@@ -162,12 +169,13 @@ func convertToDisplay(proc launch.Process, isDefault bool) ProcessDisplay {
 		shell = "bash"
 	}
 	result := ProcessDisplay{
-		Type:    proc.Type,
-		Shell:   shell,
-		Command: proc.Command,
-		Default: isDefault,
-		Args:    proc.Args,
-		WorkDir: proc.WorkingDirectory,
+		Type:        proc.Type,
+		Shell:       shell,
+		Command:     proc.Command,
+		Default:     isDefault,
+		Args:        proc.Args,
+		WorkDir:     proc.WorkingDirectory,
+		BuildpackID: proc.BuildpackID,
 	}
 
 	return result
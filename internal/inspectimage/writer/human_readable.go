@@ -28,6 +28,12 @@ func (h *HumanReadable) Print(
 	localErr, remoteErr error,
 ) error {
 	if local == nil && remote == nil {
+		if generalInfo.RemoteOnly {
+			return fmt.Errorf("unable to find image '%s' remotely", generalInfo.Name)
+		}
+		if generalInfo.LocalOnly {
+			return fmt.Errorf("unable to find image '%s' locally", generalInfo.Name)
+		}
 		return fmt.Errorf("unable to find image '%s' locally or remotely", generalInfo.Name)
 	}
 
@@ -36,14 +42,17 @@ func (h *HumanReadable) Print(
 
 	logger.Infof("Inspecting image: %s\n", style.Symbol(generalInfo.Name))
 
-	logger.Info("\nREMOTE:\n")
-	err := writeImageInfo(logger, remoteDisplay, remoteErr)
-	if err != nil {
-		return fmt.Errorf("writing remote builder info: %w", err)
+	if !generalInfo.LocalOnly {
+		logger.Info("\nREMOTE:\n")
+		if err := writeImageInfo(logger, remoteDisplay, remoteErr); err != nil {
+			return fmt.Errorf("writing remote builder info: %w", err)
+		}
+	}
+	if generalInfo.RemoteOnly {
+		return nil
 	}
 	logger.Info("\nLOCAL:\n")
-	err = writeImageInfo(logger, localDisplay, localErr)
-	if err != nil {
+	if err := writeImageInfo(logger, localDisplay, localErr); err != nil {
 		return fmt.Errorf("writing local builder info: %w", err)
 	}
 
@@ -298,6 +298,60 @@ Processes:
 				assert.Contains(outBuf.String(), expectedRemoteOutput)
 			})
 
+			when("the image was only inspected remotely", func() {
+				it("omits the local section", func() {
+					runImageMirrors := []config.RunImage{
+						{
+							Image:   "un-used-run-image",
+							Mirrors: []string{"un-used"},
+						},
+						{
+							Image:   "some-local-run-image",
+							Mirrors: []string{"user-configured-mirror-for-local"},
+						},
+						{
+							Image:   "some-remote-run-image",
+							Mirrors: []string{"user-configured-mirror-for-remote"},
+						},
+					}
+					humanReadableWriter := writer.NewHumanReadable()
+
+					logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+					err := humanReadableWriter.Print(logger, inspectimage.GeneralInfo{Name: "test-image", RunImageMirrors: runImageMirrors, RemoteOnly: true}, nil, remoteInfo, nil, nil)
+					assert.Nil(err)
+
+					assert.Contains(outBuf.String(), expectedRemoteOutput)
+					assert.NotContains(outBuf.String(), "LOCAL:")
+				})
+			})
+
+			when("the image was only inspected locally", func() {
+				it("omits the remote section", func() {
+					runImageMirrors := []config.RunImage{
+						{
+							Image:   "un-used-run-image",
+							Mirrors: []string{"un-used"},
+						},
+						{
+							Image:   "some-local-run-image",
+							Mirrors: []string{"user-configured-mirror-for-local"},
+						},
+						{
+							Image:   "some-remote-run-image",
+							Mirrors: []string{"user-configured-mirror-for-remote"},
+						},
+					}
+					humanReadableWriter := writer.NewHumanReadable()
+
+					logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+					err := humanReadableWriter.Print(logger, inspectimage.GeneralInfo{Name: "test-image", RunImageMirrors: runImageMirrors, LocalOnly: true}, localInfo, nil, nil, nil)
+					assert.Nil(err)
+
+					assert.Contains(outBuf.String(), expectedLocalOutput)
+					assert.NotContains(outBuf.String(), "REMOTE:")
+				})
+			})
+
 			when("buildpack metadata is missing", func() {
 				it.Before(func() {
 					remoteInfo.Buildpacks = []buildpack.GroupBuildpack{}
@@ -419,6 +473,22 @@ Processes:
 						err := humanReadableWriter.Print(logger, inspectimage.GeneralInfo{Name: "missing-image"}, nil, nil, nil, nil)
 						assert.ErrorWithMessage(err, fmt.Sprintf("unable to find image '%s' locally or remotely", "missing-image"))
 					})
+
+					it("only mentions the registry when the image was only inspected remotely", func() {
+						humanReadableWriter := writer.NewHumanReadable()
+
+						logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+						err := humanReadableWriter.Print(logger, inspectimage.GeneralInfo{Name: "missing-image", RemoteOnly: true}, nil, nil, nil, nil)
+						assert.ErrorWithMessage(err, "unable to find image 'missing-image' remotely")
+					})
+
+					it("only mentions the daemon when the image was only inspected locally", func() {
+						humanReadableWriter := writer.NewHumanReadable()
+
+						logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+						err := humanReadableWriter.Print(logger, inspectimage.GeneralInfo{Name: "missing-image", LocalOnly: true}, nil, nil, nil, nil)
+						assert.ErrorWithMessage(err, "unable to find image 'missing-image' locally")
+					})
 				})
 			})
 		})
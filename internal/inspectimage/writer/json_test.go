@@ -343,6 +343,21 @@ func testJSON(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("processes were contributed by buildpacks", func() {
+			it("includes the buildpack of each process", func() {
+				localInfo.Processes.DefaultProcess.BuildpackID = "test.bp.one.local"
+				sharedImageInfo := inspectimage.GeneralInfo{Name: "test-image"}
+				jsonWriter := writer.NewJSON()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := jsonWriter.Print(logger, sharedImageInfo, localInfo, nil, nil, nil)
+				assert.Nil(err)
+
+				assert.ContainsJSON(outBuf.String(), `{"type": "some-local-type", "default": true, "buildpack_id": "test.bp.one.local"}`)
+				assert.NotContains(outBuf.String(), `"buildpack_id": ""`)
+			})
+		})
+
 		when("only remote image exists", func() {
 			it("prints remote image info in JSON format", func() {
 				runImageMirrors := []config.RunImage{
@@ -22,6 +22,12 @@ func (w *StructuredBOMFormat) Print(
 	localErr, remoteErr error,
 ) error {
 	if local == nil && remote == nil {
+		if generalInfo.RemoteOnly {
+			return fmt.Errorf("unable to find image '%s' remotely", generalInfo.Name)
+		}
+		if generalInfo.LocalOnly {
+			return fmt.Errorf("unable to find image '%s' locally", generalInfo.Name)
+		}
 		return fmt.Errorf("unable to find image '%s' locally or remotely", generalInfo.Name)
 	}
 	if localErr != nil && remoteErr != nil {
@@ -22,6 +22,12 @@ func (w *StructuredFormat) Print(
 ) error {
 	// synthesize all objects here using methods
 	if local == nil && remote == nil {
+		if generalInfo.RemoteOnly {
+			return fmt.Errorf("unable to find image '%s' remotely", generalInfo.Name)
+		}
+		if generalInfo.LocalOnly {
+			return fmt.Errorf("unable to find image '%s' locally", generalInfo.Name)
+		}
 		return fmt.Errorf("unable to find image '%s' locally or remotely", generalInfo.Name)
 	}
 	if localErr != nil {
@@ -41,7 +47,7 @@ func (w *StructuredFormat) Print(
 		Local:     localInfo,
 	})
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("preparing output for %s: %w", style.Symbol(generalInfo.Name), err)
 	}
 
 	_, err = logger.Writer().Write(out)
@@ -93,6 +93,25 @@ func testStructuredFormat(t *testing.T, when spec.G, it spec.S) {
 					assert.ErrorWithMessage(err, "preparing output for 'remoteErr-image': a remote error occurred")
 				})
 			})
+
+			when("the output cannot be marshalled", func() {
+				it("returns the error", func() {
+					sharedImageInfo := inspectimage.GeneralInfo{
+						Name:            "some-image",
+						RunImageMirrors: []config.RunImage{},
+					}
+					structuredWriter := writer.StructuredFormat{
+						MarshalFunc: func(interface{}) ([]byte, error) {
+							return nil, errors.New("unsupported value")
+						},
+					}
+
+					logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+					err := structuredWriter.Print(logger, sharedImageInfo, localInfo, remoteInfo, nil, nil)
+					assert.ErrorWithMessage(err, "preparing output for 'some-image': unsupported value")
+					assert.Equal(outBuf.String(), "")
+				})
+			})
 		})
 	})
 }
@@ -0,0 +1,135 @@
+package logsink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// fluentdSink sends entries in the forward mode of the forward protocol of fluentd, as a msgpack array of the tag and
+// the time and record of each entry. The record holds the message, the stream and the metadata of the entry.
+type fluentdSink struct {
+	address string
+	tag     string
+	conn    net.Conn
+}
+
+func newFluentdSink(address, tag string) *fluentdSink {
+	return &fluentdSink{address: address, tag: tag}
+}
+
+func (s *fluentdSink) Send(entries []Entry) error {
+	var buf bytes.Buffer
+	writeMsgpackArrayHeader(&buf, 2)
+	writeMsgpackString(&buf, s.tag)
+	writeMsgpackArrayHeader(&buf, len(entries))
+	for _, entry := range entries {
+		record := map[string]string{"message": entry.Message, "stream": entry.Stream}
+		for key, value := range entry.Metadata {
+			if _, ok := record[key]; !ok {
+				record[key] = value
+			}
+		}
+
+		writeMsgpackArrayHeader(&buf, 2)
+		writeMsgpackInt(&buf, entry.Time.Unix())
+		writeMsgpackMap(&buf, record)
+	}
+
+	if err := s.write(buf.Bytes()); err != nil {
+		return errors.Wrapf(err, "sending logs to fluentd %s", s.address)
+	}
+	return nil
+}
+
+// write writes message to the connection, reconnecting once if the connection was closed.
+func (s *fluentdSink) write(message []byte) error {
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			conn, err := net.DialTimeout("tcp", s.address, dialTimeout)
+			if err != nil {
+				return err
+			}
+			s.conn = conn
+		}
+
+		_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		_, err := s.conn.Write(message)
+		if err == nil || attempt > 0 {
+			return err
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *fluentdSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// The subset of msgpack needed to encode entries: arrays, maps of strings, strings and 64-bit integers.
+
+func writeMsgpackArrayHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x90 | byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xdc)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdd)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func writeMsgpackMap(buf *bytes.Buffer, m map[string]string) {
+	switch n := len(m); {
+	case n < 16:
+		buf.WriteByte(0x80 | byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xde)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdf)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeMsgpackString(buf, key)
+		writeMsgpackString(buf, m[key])
+	}
+}
+
+func writeMsgpackString(buf *bytes.Buffer, s string) {
+	switch n := len(s); {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= 0xff:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xda)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+func writeMsgpackInt(buf *bytes.Buffer, i int64) {
+	buf.WriteByte(0xd3)
+	_ = binary.Write(buf, binary.BigEndian, i)
+}
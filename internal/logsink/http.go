@@ -0,0 +1,58 @@
+package logsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// httpSink POSTs entries as a JSON array of records, each holding the time, message and stream of an entry and its
+// metadata, as accepted by the HTTP inputs of fluentd, fluent-bit, Logstash and Vector.
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPSink(url string) *httpSink {
+	return &httpSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *httpSink) Send(entries []Entry) error {
+	records := make([]map[string]string, 0, len(entries))
+	for _, entry := range entries {
+		record := map[string]string{
+			"time":    entry.Time.UTC().Format(time.RFC3339Nano),
+			"message": entry.Message,
+			"stream":  entry.Stream,
+		}
+		for key, value := range entry.Metadata {
+			if _, ok := record[key]; !ok {
+				record[key] = value
+			}
+		}
+		records = append(records, record)
+	}
+
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "sending logs")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.Errorf("sending logs: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
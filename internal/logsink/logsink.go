@@ -0,0 +1,256 @@
+// Package logsink ships the output of builds to centralized logging, such as syslog, fluentd or an HTTP endpoint.
+package logsink
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// StreamStdout and StreamStderr are the streams of the lines of output.
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+
+	// bufferSize is how many entries are buffered before new entries are dropped, so that an unavailable sink
+	// never slows a build down.
+	bufferSize = 4096
+	// batchSize and batchInterval bound how many entries are sent at once, and how long an entry waits to be sent.
+	batchSize     = 100
+	batchInterval = time.Second
+	// closeTimeout bounds how long Close waits for the remaining entries to be sent.
+	closeTimeout = 5 * time.Second
+)
+
+// Entry is a line of output, with the metadata of the build it belongs to.
+type Entry struct {
+	Time     time.Time
+	Stream   string
+	Message  string
+	Metadata map[string]string
+}
+
+// Sink sends entries to centralized logging.
+type Sink interface {
+	Send(entries []Entry) error
+	Close() error
+}
+
+// Open returns the sink of target, one of:
+//   - syslog://<host>[:<port>] or syslog+tcp://<host>[:<port>], for RFC 5424 syslog over UDP or TCP
+//   - fluentd://<host>[:<port>][/<tag>], for the forward protocol of fluentd and fluent-bit
+//   - http://<url> or https://<url>, to POST JSON arrays of entries to
+func Open(target string) (Sink, error) {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return nil, errors.Errorf("invalid log sink %s: must be a URL with a host", style.Symbol(target))
+	}
+
+	switch u.Scheme {
+	case "syslog":
+		return newSyslogSink("udp", withDefaultPort(u.Host, "514")), nil
+	case "syslog+tcp":
+		return newSyslogSink("tcp", withDefaultPort(u.Host, "514")), nil
+	case "fluentd":
+		tag := strings.Trim(u.Path, "/")
+		if tag == "" {
+			tag = "pack"
+		}
+		return newFluentdSink(withDefaultPort(u.Host, "24224"), tag), nil
+	case "http", "https":
+		return newHTTPSink(target), nil
+	}
+
+	return nil, errors.Errorf("invalid log sink %s: scheme must be one of syslog, syslog+tcp, fluentd, http or https", style.Symbol(target))
+}
+
+func withDefaultPort(host, port string) string {
+	if strings.LastIndex(host, ":") > strings.LastIndex(host, "]") {
+		return host
+	}
+	return host + ":" + port
+}
+
+// Shipper sends the lines written to its writers to sinks in the background, with metadata attached. Entries are
+// dropped rather than slowing the build down when the sinks cannot keep up.
+type Shipper struct {
+	sinks    []Sink
+	metadata map[string]string
+	entries  chan Entry
+	done     chan struct{}
+
+	mu      sync.Mutex
+	writers map[string]*lineWriter
+	closed  bool
+	dropped int
+	err     error
+}
+
+// NewShipper starts shipping entries, with metadata attached, to sinks.
+func NewShipper(metadata map[string]string, sinks ...Sink) *Shipper {
+	s := &Shipper{
+		sinks:    sinks,
+		metadata: metadata,
+		entries:  make(chan Entry, bufferSize),
+		done:     make(chan struct{}),
+		writers:  map[string]*lineWriter{},
+	}
+	go s.ship()
+	return s
+}
+
+// Writer returns the writer of the lines of stream. Writers of the same stream share the line written partially.
+func (s *Shipper) Writer(stream string) io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.writers[stream]
+	if !ok {
+		w = &lineWriter{shipper: s, stream: stream}
+		s.writers[stream] = w
+	}
+	return w
+}
+
+// Close sends the remaining entries, including lines written partially, and closes the sinks. It returns the first
+// error sending entries, if any.
+func (s *Shipper) Close() error {
+	s.mu.Lock()
+	writers := make([]*lineWriter, 0, len(s.writers))
+	for _, w := range s.writers {
+		writers = append(writers, w)
+	}
+	s.mu.Unlock()
+
+	for _, w := range writers {
+		w.flush()
+	}
+
+	s.mu.Lock()
+	s.closed = true
+	close(s.entries)
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+	case <-time.After(closeTimeout):
+		s.fail(errors.New("timed out sending remaining entries"))
+	}
+
+	for _, sink := range s.sinks {
+		if err := sink.Close(); err != nil {
+			s.fail(err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dropped > 0 {
+		return errors.Errorf("dropped %d lines, as the log sinks could not keep up", s.dropped)
+	}
+	return s.err
+}
+
+func (s *Shipper) add(stream, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+
+	entry := Entry{Time: time.Now(), Stream: stream, Message: message, Metadata: s.metadata}
+	select {
+	case s.entries <- entry:
+	default:
+		s.dropped++
+	}
+}
+
+func (s *Shipper) ship() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(batchInterval)
+	defer ticker.Stop()
+
+	var batch []Entry
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		for _, sink := range s.sinks {
+			if err := sink.Send(batch); err != nil {
+				s.fail(err)
+			}
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case entry, ok := <-s.entries:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (s *Shipper) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// lineWriter ships each complete line written to it as an entry.
+type lineWriter struct {
+	mu      sync.Mutex
+	shipper *Shipper
+	stream  string
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimRight(string(w.partial[:i]), "\r")
+		w.partial = w.partial[i+1:]
+		if line != "" {
+			w.shipper.add(w.stream, line)
+		}
+	}
+	return len(p), nil
+}
+
+// flush ships the line written partially, if any.
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if line := strings.TrimRight(string(w.partial), "\r"); line != "" {
+		w.shipper.add(w.stream, line)
+	}
+	w.partial = nil
+}
@@ -0,0 +1,168 @@
+package logsink_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/logsink"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLogSink(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LogSink", testLogSink, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLogSink(t *testing.T, when spec.G, it spec.S) {
+	metadata := map[string]string{"image": "some/app", "builder": "some/builder"}
+
+	when("#Open", func() {
+		it("errors for an unknown scheme", func() {
+			_, err := logsink.Open("ftp://logs.example.com")
+			h.AssertError(t, err, "invalid log sink 'ftp://logs.example.com': scheme must be one of syslog, syslog+tcp, fluentd, http or https")
+		})
+
+		it("errors without a host", func() {
+			_, err := logsink.Open("syslog")
+			h.AssertError(t, err, "invalid log sink 'syslog': must be a URL with a host")
+		})
+	})
+
+	when("shipping to an http sink", func() {
+		var (
+			server  *httptest.Server
+			mu      sync.Mutex
+			records []map[string]string
+		)
+
+		it.Before(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var batch []map[string]string
+				h.AssertNil(t, json.NewDecoder(r.Body).Decode(&batch))
+				mu.Lock()
+				records = append(records, batch...)
+				mu.Unlock()
+			}))
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("ships each line with the metadata of the build", func() {
+			sink, err := logsink.Open(server.URL)
+			h.AssertNil(t, err)
+
+			shipper := logsink.NewShipper(metadata, sink)
+			fmt.Fprint(shipper.Writer(logsink.StreamStdout), "===> DETECTING\nsome/buildpack 1.0")
+			fmt.Fprint(shipper.Writer(logsink.StreamStdout), "\n")
+			fmt.Fprintln(shipper.Writer(logsink.StreamStderr), "ERROR: failed to build")
+			h.AssertNil(t, shipper.Close())
+
+			mu.Lock()
+			defer mu.Unlock()
+			h.AssertEq(t, len(records), 3)
+			h.AssertEq(t, records[0]["message"], "===> DETECTING")
+			h.AssertEq(t, records[0]["stream"], "stdout")
+			h.AssertEq(t, records[0]["image"], "some/app")
+			h.AssertEq(t, records[0]["builder"], "some/builder")
+			h.AssertEq(t, records[1]["message"], "some/buildpack 1.0")
+			h.AssertEq(t, records[2]["message"], "ERROR: failed to build")
+			h.AssertEq(t, records[2]["stream"], "stderr")
+		})
+
+		it("ships the last line when it is not terminated", func() {
+			sink, err := logsink.Open(server.URL)
+			h.AssertNil(t, err)
+
+			shipper := logsink.NewShipper(metadata, sink)
+			fmt.Fprint(shipper.Writer(logsink.StreamStdout), "Successfully built image")
+			h.AssertNil(t, shipper.Close())
+
+			mu.Lock()
+			defer mu.Unlock()
+			h.AssertEq(t, len(records), 1)
+			h.AssertEq(t, records[0]["message"], "Successfully built image")
+		})
+
+		it("reports failures to send", func() {
+			server.Close()
+
+			sink, err := logsink.Open(server.URL)
+			h.AssertNil(t, err)
+
+			shipper := logsink.NewShipper(metadata, sink)
+			fmt.Fprintln(shipper.Writer(logsink.StreamStdout), "some line")
+			h.AssertError(t, shipper.Close(), "sending logs")
+		})
+	})
+
+	when("shipping to a syslog sink", func() {
+		it("sends RFC 5424 messages with the metadata as structured data", func() {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			h.AssertNil(t, err)
+			defer conn.Close()
+
+			sink, err := logsink.Open("syslog://" + conn.LocalAddr().String())
+			h.AssertNil(t, err)
+
+			shipper := logsink.NewShipper(metadata, sink)
+			fmt.Fprintln(shipper.Writer(logsink.StreamStderr), `failed "quoted"`)
+			h.AssertNil(t, shipper.Close())
+
+			buf := make([]byte, 1024)
+			n, _, err := conn.ReadFrom(buf)
+			h.AssertNil(t, err)
+			message := string(buf[:n])
+			h.AssertContains(t, message, "<11>1 ")
+			h.AssertContains(t, message, ` pack `)
+			h.AssertContains(t, message, `[pack@32473 builder="some/builder" image="some/app"] failed "quoted"`)
+		})
+	})
+
+	when("shipping to a fluentd sink", func() {
+		it("sends a forward mode message with the tag and records", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			h.AssertNil(t, err)
+			defer listener.Close()
+
+			received := make(chan []byte, 1)
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					received <- nil
+					return
+				}
+				defer conn.Close()
+				data, _ := ioutil.ReadAll(conn)
+				received <- data
+			}()
+
+			sink, err := logsink.Open("fluentd://" + listener.Addr().String() + "/pack.builds")
+			h.AssertNil(t, err)
+
+			shipper := logsink.NewShipper(metadata, sink)
+			fmt.Fprintln(shipper.Writer(logsink.StreamStdout), "some line")
+			h.AssertNil(t, shipper.Close())
+
+			data := <-received
+			// an array of the tag and an array of one entry
+			h.AssertEq(t, data[0], byte(0x92))
+			h.AssertEq(t, string(data[1:13]), "\xabpack.builds")
+			h.AssertEq(t, data[13], byte(0x91))
+			h.AssertContains(t, string(data), "\xa7message\xa9some line")
+			h.AssertContains(t, string(data), "\xa5image\xa8some/app")
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package logsink
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// syslogFacility is the user-level facility.
+	syslogFacility = 1
+	// syslogSeverityInfo and syslogSeverityError are the severities of stdout and stderr entries.
+	syslogSeverityInfo  = 6
+	syslogSeverityError = 3
+	// syslogSDID is the ID of the structured data carrying the metadata of entries, under the private enterprise
+	// number for documentation.
+	syslogSDID = "pack@32473"
+
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 5 * time.Second
+)
+
+// syslogSink sends entries as RFC 5424 messages, one per datagram over UDP, and framed by octet counting over TCP.
+type syslogSink struct {
+	network  string
+	address  string
+	hostname string
+	conn     net.Conn
+}
+
+func newSyslogSink(network, address string) *syslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{network: network, address: address, hostname: hostname}
+}
+
+func (s *syslogSink) Send(entries []Entry) error {
+	for _, entry := range entries {
+		message := s.format(entry)
+		if s.network == "tcp" {
+			message = fmt.Sprintf("%d %s", len(message), message)
+		}
+		if err := s.write([]byte(message)); err != nil {
+			return errors.Wrapf(err, "sending logs to syslog %s", s.address)
+		}
+	}
+	return nil
+}
+
+// write writes message to the connection, reconnecting once if the connection was closed.
+func (s *syslogSink) write(message []byte) error {
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			conn, err := net.DialTimeout(s.network, s.address, dialTimeout)
+			if err != nil {
+				return err
+			}
+			s.conn = conn
+		}
+
+		_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		_, err := s.conn.Write(message)
+		if err == nil || attempt > 0 {
+			return err
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *syslogSink) format(entry Entry) string {
+	severity := syslogSeverityInfo
+	if entry.Stream == StreamStderr {
+		severity = syslogSeverityError
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s pack %d - %s %s",
+		syslogFacility*8+severity,
+		entry.Time.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		os.Getpid(),
+		syslogStructuredData(entry.Metadata),
+		entry.Message,
+	)
+}
+
+func syslogStructuredData(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return "-"
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, fmt.Sprintf(`%s="%s"`, key, escaper.Replace(metadata[key])))
+	}
+	return fmt.Sprintf("[%s %s]", syslogSDID, strings.Join(params, " "))
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
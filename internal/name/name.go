@@ -28,7 +28,12 @@ func TranslateRegistry(name string, registryMirrors map[string]string, logger Lo
 		return name, nil
 	}
 
-	refName := fmt.Sprintf("%s/%s:%s", registryMirror, srcContext.RepositoryStr(), srcRef.Identifier())
+	separator := ":"
+	if _, isDigest := srcRef.(gname.Digest); isDigest {
+		separator = "@"
+	}
+
+	refName := fmt.Sprintf("%s/%s%s%s", registryMirror, srcContext.RepositoryStr(), separator, srcRef.Identifier())
 	_, err = gname.ParseReference(refName, gname.WeakValidation)
 	if err != nil {
 		return "", err
@@ -45,5 +50,16 @@ func getMirror(repo gname.Repository, registryMirrors map[string]string) (string
 	}
 
 	mirror, ok = registryMirrors[repo.RegistryStr()]
-	return mirror, ok
+	if ok {
+		return mirror, ok
+	}
+
+	// registries may be configured under another name, such as docker.io for index.docker.io
+	for registry, mirror := range registryMirrors {
+		normalized, err := gname.NewRegistry(registry, gname.WeakValidation)
+		if err == nil && normalized.RegistryStr() == repo.RegistryStr() {
+			return mirror, true
+		}
+	}
+	return "", false
 }
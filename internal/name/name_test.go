@@ -54,6 +54,30 @@ func testTranslateRegistry(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(output, expected)
 		})
 
+		it("translates references by digest", func() {
+			input := "index.docker.io/my/buildpack@sha256:a3f5e8d5a1e1c2b0d6a0e2b9f0c5d8e7a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9"
+			expected := "10.0.0.1/my/buildpack@sha256:a3f5e8d5a1e1c2b0d6a0e2b9f0c5d8e7a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9"
+			registryMirrors := map[string]string{
+				"index.docker.io": "10.0.0.1",
+			}
+
+			output, err := name.TranslateRegistry(input, registryMirrors, logger)
+			assert.Nil(err)
+			assert.Equal(output, expected)
+		})
+
+		it("matches registries configured under another name", func() {
+			input := "my/buildpack:0.1"
+			expected := "mirror.internal/dockerhub/my/buildpack:0.1"
+			registryMirrors := map[string]string{
+				"docker.io": "mirror.internal/dockerhub",
+			}
+
+			output, err := name.TranslateRegistry(input, registryMirrors, logger)
+			assert.Nil(err)
+			assert.Equal(output, expected)
+		})
+
 		it("prefers the wildcard mirror translation", func() {
 			input := "index.docker.io/my/buildpack:0.1"
 			expected := "10.0.0.2/my/buildpack:0.1"
@@ -0,0 +1,119 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+)
+
+// Sample is the resource usage of a container at a point in time.
+type Sample struct {
+	Time time.Time `json:"time"`
+
+	// CPUPercent is the usage of the CPUs of the daemon host, where 100 is one CPU fully used.
+	CPUPercent float64 `json:"cpuPercent"`
+
+	MemoryUsage uint64 `json:"memoryUsage"`
+	MemoryLimit uint64 `json:"memoryLimit"`
+	BlockRead   uint64 `json:"blockRead"`
+	BlockWrite  uint64 `json:"blockWrite"`
+}
+
+// SampleContainer records the resource usage of a container to path, one JSON encoded Sample per line, until the
+// returned function is called. Samples are taken roughly every second, as the daemon computes the CPU usage over
+// that period. Samples of a container which is not running are skipped.
+func SampleContainer(ctx context.Context, docker client.CommonAPIClient, containerID, path string) (func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating container stats file")
+	}
+
+	sampleCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		encoder := json.NewEncoder(f)
+		for sampleCtx.Err() == nil {
+			stats, err := docker.ContainerStats(sampleCtx, containerID, false)
+			if err != nil {
+				if sampleCtx.Err() != nil {
+					break
+				}
+				done <- errors.Wrapf(err, "reading stats of container %s", containerID)
+				return
+			}
+
+			var statsJSON types.StatsJSON
+			err = json.NewDecoder(stats.Body).Decode(&statsJSON)
+			stats.Body.Close()
+			if err != nil {
+				if sampleCtx.Err() != nil {
+					break
+				}
+				done <- errors.Wrapf(err, "decoding stats of container %s", containerID)
+				return
+			}
+
+			if statsJSON.MemoryStats.Usage == 0 {
+				// Not running yet, or anymore
+				select {
+				case <-sampleCtx.Done():
+				case <-time.After(100 * time.Millisecond):
+				}
+				continue
+			}
+
+			if err := encoder.Encode(NewSample(statsJSON)); err != nil {
+				done <- errors.Wrap(err, "writing container stats")
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	return func() error {
+		cancel()
+		err := <-done
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
+
+// NewSample summarizes the stats of a container reported by the daemon.
+func NewSample(stats types.StatsJSON) Sample {
+	sample := Sample{
+		Time:        stats.Read,
+		CPUPercent:  cpuPercent(stats),
+		MemoryUsage: stats.MemoryStats.Usage,
+		MemoryLimit: stats.MemoryStats.Limit,
+	}
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch entry.Op {
+		case "Read", "read":
+			sample.BlockRead += entry.Value
+		case "Write", "write":
+			sample.BlockWrite += entry.Value
+		}
+	}
+	return sample
+}
+
+func cpuPercent(stats types.StatsJSON) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
@@ -0,0 +1,65 @@
+// Package profile captures profiles of pack itself, and samples the resource usage of containers, to diagnose
+// slow builds.
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CPUProfileFile is the name of the CPU profile of pack, in the output directory.
+	CPUProfileFile = "cpu.pprof"
+
+	// HeapProfileFile is the name of the heap profile of pack, in the output directory.
+	HeapProfileFile = "heap.pprof"
+)
+
+// Session is a profile of pack in progress.
+type Session struct {
+	dir     string
+	cpuFile *os.File
+}
+
+// Start creates dir, if needed, and starts profiling the CPU usage of pack to it.
+func Start(dir string) (*Session, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, errors.Wrap(err, "creating profile output directory")
+	}
+
+	cpuFile, err := os.Create(filepath.Join(dir, CPUProfileFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating CPU profile")
+	}
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		cpuFile.Close()
+		return nil, errors.Wrap(err, "starting CPU profile")
+	}
+
+	return &Session{dir: dir, cpuFile: cpuFile}, nil
+}
+
+// Stop stops profiling the CPU usage of pack, and writes a profile of its heap.
+func (s *Session) Stop() error {
+	pprof.StopCPUProfile()
+	if err := s.cpuFile.Close(); err != nil {
+		return errors.Wrap(err, "writing CPU profile")
+	}
+
+	heapFile, err := os.Create(filepath.Join(s.dir, HeapProfileFile))
+	if err != nil {
+		return errors.Wrap(err, "creating heap profile")
+	}
+	defer heapFile.Close()
+
+	// Collect garbage first, so the profile reflects live objects
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(heapFile); err != nil {
+		return errors.Wrap(err, "writing heap profile")
+	}
+	return nil
+}
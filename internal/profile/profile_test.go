@@ -0,0 +1,84 @@
+package profile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/profile"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProfile(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Profile", testProfile, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testProfile(t *testing.T, when spec.G, it spec.S) {
+	when("#Start", func() {
+		var tmpDir string
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "profile-test")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNilE(t, os.RemoveAll(tmpDir))
+		})
+
+		it("writes the CPU and heap profiles when stopped", func() {
+			outputDir := filepath.Join(tmpDir, "pprof")
+			session, err := profile.Start(outputDir)
+			h.AssertNil(t, err)
+			h.AssertNil(t, session.Stop())
+
+			for _, file := range []string{profile.CPUProfileFile, profile.HeapProfileFile} {
+				fi, err := os.Stat(filepath.Join(outputDir, file))
+				h.AssertNil(t, err)
+				h.AssertTrue(t, fi.Size() > 0)
+			}
+		})
+	})
+
+	when("#NewSample", func() {
+		it("computes the CPU usage since the previous read", func() {
+			stats := types.StatsJSON{}
+			stats.CPUStats.CPUUsage.TotalUsage = 300
+			stats.CPUStats.SystemUsage = 2000
+			stats.CPUStats.OnlineCPUs = 4
+			stats.PreCPUStats.CPUUsage.TotalUsage = 100
+			stats.PreCPUStats.SystemUsage = 1000
+			stats.MemoryStats.Usage = 1024
+			stats.MemoryStats.Limit = 4096
+			stats.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+				{Op: "Read", Value: 10},
+				{Op: "Write", Value: 20},
+				{Op: "Total", Value: 30},
+			}
+
+			sample := profile.NewSample(stats)
+			h.AssertEq(t, sample.CPUPercent, 80.0)
+			h.AssertEq(t, sample.MemoryUsage, uint64(1024))
+			h.AssertEq(t, sample.MemoryLimit, uint64(4096))
+			h.AssertEq(t, sample.BlockRead, uint64(10))
+			h.AssertEq(t, sample.BlockWrite, uint64(20))
+		})
+
+		it("reports no CPU usage without a previous read", func() {
+			stats := types.StatsJSON{}
+			stats.CPUStats.CPUUsage.TotalUsage = 300
+			stats.CPUStats.SystemUsage = 2000
+
+			h.AssertEq(t, profile.NewSample(stats).CPUPercent, 0.0)
+		})
+	})
+}
@@ -11,6 +11,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -94,19 +96,18 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 
 	if len(entry.Buildpacks) > 0 {
 		if version == "" {
-			highestVersion := entry.Buildpacks[0]
-			if len(entry.Buildpacks) > 1 {
-				for _, bp := range entry.Buildpacks[1:] {
-					if semver.Compare(fmt.Sprintf("v%s", bp.Version), fmt.Sprintf("v%s", highestVersion.Version)) > 0 {
-						highestVersion = bp
-					}
-				}
+			highestVersion, ok := entry.latest()
+			if !ok {
+				return Buildpack{}, fmt.Errorf("all versions of buildpack %s have been yanked", bp)
 			}
 			return highestVersion, Validate(highestVersion)
 		}
 
 		for _, bpIndex := range entry.Buildpacks {
 			if bpIndex.Version == version {
+				if bpIndex.Yanked {
+					r.logger.Warnf("Buildpack %s has been yanked from the registry", style.Symbol(bp))
+				}
 				return bpIndex, Validate(bpIndex)
 			}
 		}
@@ -116,6 +117,70 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 	return Buildpack{}, fmt.Errorf("no entries for buildpack: %s", bp)
 }
 
+// Search returns the latest version, which is not yanked, of the buildpacks of the registry whose ID contains query,
+// sorted by ID. An empty query matches every buildpack.
+func (r *Cache) Search(query string) ([]Buildpack, error) {
+	if err := r.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+
+	query = strings.ToLower(query)
+	var results []Buildpack
+	err := filepath.Walk(r.Root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		parts := strings.SplitN(info.Name(), "_", 2)
+		if len(parts) != 2 || !strings.Contains(parts[0]+"/"+parts[1], query) {
+			return nil
+		}
+		if index, err := IndexPath(r.Root, parts[0], parts[1]); err != nil || index != path {
+			return nil
+		}
+
+		entry, err := r.readEntry(parts[0], parts[1])
+		if err != nil {
+			return err
+		}
+		if latest, ok := entry.latest(); ok {
+			results = append(results, latest)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "searching registry cache")
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Namespace+"/"+results[i].Name < results[j].Namespace+"/"+results[j].Name
+	})
+	return results, nil
+}
+
+// latest returns the highest version of the entry which is not yanked.
+func (e Entry) latest() (Buildpack, bool) {
+	var highestVersion *Buildpack
+	for i, bp := range e.Buildpacks {
+		if bp.Yanked {
+			continue
+		}
+		if highestVersion == nil || semver.Compare(fmt.Sprintf("v%s", bp.Version), fmt.Sprintf("v%s", highestVersion.Version)) > 0 {
+			highestVersion = &e.Buildpacks[i]
+		}
+	}
+	if highestVersion == nil {
+		return Buildpack{}, false
+	}
+	return *highestVersion, true
+}
+
 // Refresh local Registry Cache
 func (r *Cache) Refresh() error {
 	r.logger.Debugf("Refreshing registry cache for %s/%s", r.url.Host, r.url.Path)
@@ -276,6 +341,14 @@ func (r *Cache) writeEntry(b Buildpack) (string, error) {
 		return "", err
 	}
 
+	if b.Yanked {
+		// a version which was not registered is yanked by recording it as yanked, so that it is never resolved to
+		yanked, err := r.yankEntry(b, index)
+		if err != nil || yanked {
+			return index, err
+		}
+	}
+
 	if _, err := os.Stat(index); os.IsNotExist(err) {
 		if err := os.MkdirAll(filepath.Dir(index), 0750); err != nil {
 			return "", errors.Wrapf(err, "creating directory structure for: %s/%s", ns, name)
@@ -303,17 +376,12 @@ func (r *Cache) writeEntry(b Buildpack) (string, error) {
 	}
 	defer f.Close()
 
-	newline := "\n"
-	if runtime.GOOS == "windows" {
-		newline = "\r\n"
-	}
-
 	fileContents, err := json.Marshal(b)
 	if err != nil {
 		return "", errors.Wrapf(err, "converting buildpack file to json: %s/%s", ns, name)
 	}
 
-	fileContentsFormatted := string(fileContents) + newline
+	fileContentsFormatted := string(fileContents) + newline()
 	if _, err := f.WriteString(fileContentsFormatted); err != nil {
 		return "", errors.Wrapf(err, "writing buildpack to file: %s/%s", ns, name)
 	}
@@ -321,6 +389,48 @@ func (r *Cache) writeEntry(b Buildpack) (string, error) {
 	return index, nil
 }
 
+// yankEntry marks the version of b in its index as yanked, rewriting the index. It returns false, without changing
+// the index, when the version is not in it.
+func (r *Cache) yankEntry(b Buildpack, index string) (bool, error) {
+	if _, err := os.Stat(index); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	entry, err := r.readEntry(b.Namespace, b.Name)
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	var contents []byte
+	for _, bp := range entry.Buildpacks {
+		if bp.Version == b.Version {
+			bp.Yanked = true
+			found = true
+		}
+		line, err := json.Marshal(bp)
+		if err != nil {
+			return false, errors.Wrapf(err, "converting buildpack file to json: %s/%s", b.Namespace, b.Name)
+		}
+		contents = append(append(contents, line...), newline()...)
+	}
+	if !found {
+		return false, nil
+	}
+
+	if err := ioutil.WriteFile(filepath.Clean(index), contents, 0644); err != nil {
+		return false, errors.Wrapf(err, "writing buildpack to file: %s/%s", b.Namespace, b.Name)
+	}
+	return true, nil
+}
+
+func newline() string {
+	if runtime.GOOS == "windows" {
+		return "\r\n"
+	}
+	return "\n"
+}
+
 func (r *Cache) readEntry(ns, name string) (Entry, error) {
 	index, err := IndexPath(r.Root, ns, name)
 	if err != nil {
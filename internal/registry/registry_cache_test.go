@@ -144,6 +144,37 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#Search", func() {
+		var registryCache Cache
+
+		it.Before(func() {
+			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+		})
+
+		it("returns the latest version of the matching buildpacks", func() {
+			results, err := registryCache.Search("FO")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(results), 1)
+			h.AssertEq(t, results[0].Name, "foo")
+			h.AssertEq(t, results[0].Version, "1.2.0")
+		})
+
+		it("returns every buildpack for an empty query", func() {
+			results, err := registryCache.Search("")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(results), 2)
+			h.AssertEq(t, results[0].Name, "foo")
+			h.AssertEq(t, results[1].Name, "java")
+		})
+
+		it("returns nothing when no buildpack matches", func() {
+			results, err := registryCache.Search("python")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(results), 0)
+		})
+	})
+
 	when("#Refresh", func() {
 		var (
 			registryCache Cache
@@ -259,6 +290,33 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("a yanked buildpack is passed", func() {
+			it("marks the version as yanked", func() {
+				h.AssertNil(t, registryCache.Commit(bp, username, msg))
+
+				yanked := bp
+				yanked.Yanked = true
+				h.AssertNil(t, registryCache.Commit(yanked, username, msg))
+
+				entry, err := registryCache.readEntry(bp.Namespace, bp.Name)
+				h.AssertNil(t, err)
+				h.AssertEq(t, entry.Buildpacks, []Buildpack{yanked})
+			})
+
+			it("records the yank of a version which was not registered", func() {
+				h.AssertNil(t, registryCache.Commit(bp, username, msg))
+
+				yanked := bp
+				yanked.Version = "2.0.0"
+				yanked.Yanked = true
+				h.AssertNil(t, registryCache.Commit(yanked, username, msg))
+
+				entry, err := registryCache.readEntry(bp.Namespace, bp.Name)
+				h.AssertNil(t, err)
+				h.AssertEq(t, entry.Buildpacks, []Buildpack{bp, yanked})
+			})
+		})
+
 		when("empty commit message is passed", func() {
 			it("fails to create commit", func() {
 				err := registryCache.Commit(bp, username, "")
@@ -0,0 +1,125 @@
+// Package snapshot records the state of the files of a directory, to detect changes between two points in time.
+package snapshot
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileState is the recorded state of a file. Contents are not hashed: a change of contents is detected through the
+// size or modification time of the file.
+type FileState struct {
+	Size    int64
+	ModTime time.Time
+	Mode    os.FileMode
+}
+
+// Snapshot maps the paths of the files of a directory, relative to it, to their state.
+type Snapshot map[string]FileState
+
+// Take records the state of the files of dir. When filter is not nil, only the paths it accepts are recorded,
+// and the contents of rejected directories are skipped.
+func Take(dir string, filter func(string) bool) (Snapshot, error) {
+	snapshot := Snapshot{}
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+
+		if filter != nil && !filter(relPath) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		snapshot[filepath.ToSlash(relPath)] = FileState{Size: fi.Size(), ModTime: fi.ModTime(), Mode: fi.Mode()}
+		return nil
+	})
+	return snapshot, err
+}
+
+// Changed returns the sorted paths which were added, removed or modified between s and newer.
+func (s Snapshot) Changed(newer Snapshot) []string {
+	var changed []string
+	for path, state := range newer {
+		if previous, ok := s[path]; !ok || !previous.ModTime.Equal(state.ModTime) || previous.Size != state.Size || previous.Mode != state.Mode {
+			changed = append(changed, path)
+		}
+	}
+	for path := range s {
+		if _, ok := newer[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// FromTar records the state of the files of the tar archive r, relative to its root directory, as read from a
+// container. Modification times are recorded to the second, as tar archives usually record them.
+func FromTar(r io.Reader) (Snapshot, error) {
+	snapshot := Snapshot{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return snapshot, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parts := strings.SplitN(strings.Trim(path.Clean(filepath.ToSlash(header.Name)), "/"), "/", 2)
+		if len(parts) < 2 {
+			continue
+		}
+		snapshot[parts[1]] = FileState{Size: header.Size, ModTime: header.ModTime.Truncate(time.Second), Mode: header.FileInfo().Mode()}
+	}
+}
+
+// Compare returns the sorted paths of s which are missing from target, or whose regular files differ in size or
+// modification time, and the sorted paths of target which are missing from s or of another file type. Modification
+// times are compared to the second, so that a snapshot read with FromTar can be the target.
+func (s Snapshot) Compare(target Snapshot) ([]string, []string) {
+	var outdated, extra []string
+	for path, state := range s {
+		current, ok := target[path]
+		if !ok {
+			outdated = append(outdated, path)
+			continue
+		}
+		if current.Mode.Type() != state.Mode.Type() {
+			extra = append(extra, path)
+			continue
+		}
+		if state.Mode.IsRegular() && (current.Size != state.Size ||
+			!current.ModTime.Truncate(time.Second).Equal(state.ModTime.Truncate(time.Second))) {
+			outdated = append(outdated, path)
+		}
+	}
+	for path := range target {
+		if _, ok := s[path]; !ok {
+			extra = append(extra, path)
+		}
+	}
+
+	sort.Strings(outdated)
+	sort.Strings(extra)
+	return outdated, extra
+}
@@ -0,0 +1,128 @@
+package snapshot_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/snapshot"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSnapshot(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "snapshot", testSnapshot, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSnapshot(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "snapshot-test")
+		h.AssertNil(t, err)
+		h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, "some-dir"), 0755))
+		h.AssertNil(t, ioutil.WriteFile(filepath.Join(tmpDir, "some-dir", "some-file"), []byte("some-content"), 0644))
+		h.AssertNil(t, ioutil.WriteFile(filepath.Join(tmpDir, "other-file"), []byte("other-content"), 0644))
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#Take", func() {
+		it("records the files relative to the directory", func() {
+			s, err := snapshot.Take(tmpDir, nil)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(s), 3)
+			h.AssertEq(t, s["some-dir/some-file"].Size, int64(len("some-content")))
+		})
+
+		it("skips the paths rejected by the filter", func() {
+			s, err := snapshot.Take(tmpDir, func(path string) bool {
+				return !strings.HasPrefix(path, "some-dir")
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(s), 1)
+			_, ok := s["other-file"]
+			h.AssertTrue(t, ok)
+		})
+	})
+
+	when("#Changed", func() {
+		it("returns nothing when nothing changed", func() {
+			before, err := snapshot.Take(tmpDir, nil)
+			h.AssertNil(t, err)
+			after, err := snapshot.Take(tmpDir, nil)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(before.Changed(after)), 0)
+		})
+
+		it("returns the added, removed and modified paths", func() {
+			before, err := snapshot.Take(tmpDir, nil)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(tmpDir, "new-file"), []byte("new-content"), 0644))
+			h.AssertNil(t, os.Remove(filepath.Join(tmpDir, "other-file")))
+			later := time.Now().Add(time.Minute)
+			h.AssertNil(t, os.Chtimes(filepath.Join(tmpDir, "some-dir", "some-file"), later, later))
+
+			after, err := snapshot.Take(tmpDir, nil)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, before.Changed(after), []string{"new-file", "other-file", "some-dir/some-file"})
+		})
+	})
+
+	when("#FromTar", func() {
+		it("records the files relative to the root directory of the archive", func() {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			modTime := time.Date(2020, 1, 1, 0, 0, 0, 500, time.UTC)
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "workspace/", Typeflag: tar.TypeDir, Mode: 0755, ModTime: modTime}))
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "workspace/some-file", Typeflag: tar.TypeReg, Mode: 0644, Size: 4, ModTime: modTime}))
+			_, err := tw.Write([]byte("data"))
+			h.AssertNil(t, err)
+			h.AssertNil(t, tw.Close())
+
+			s, err := snapshot.FromTar(&buf)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(s), 1)
+			h.AssertEq(t, s["some-file"].Size, int64(4))
+			h.AssertEq(t, s["some-file"].ModTime.Equal(modTime.Truncate(time.Second)), true)
+		})
+	})
+
+	when("#Compare", func() {
+		it("returns the outdated paths of the target and the paths it should not have", func() {
+			source, err := snapshot.Take(tmpDir, nil)
+			h.AssertNil(t, err)
+
+			target := snapshot.Snapshot{}
+			for path, state := range source {
+				state.ModTime = state.ModTime.Truncate(time.Second)
+				target[path] = state
+			}
+			modified := target["other-file"]
+			modified.Size++
+			target["other-file"] = modified
+			delete(target, "some-dir/some-file")
+			target["written-by-buildpack"] = snapshot.FileState{Size: 1}
+
+			outdated, extra := source.Compare(target)
+			h.AssertEq(t, outdated, []string{"other-file", "some-dir/some-file"})
+			h.AssertEq(t, extra, []string{"written-by-buildpack"})
+		})
+	})
+}
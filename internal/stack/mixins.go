@@ -34,6 +34,35 @@ func ValidateMixins(buildImageName string, buildImageMixins []string, runImageNa
 	return nil
 }
 
+// AvailableMixins returns the set of mixins that are common between the two provided sets, plus build-only mixins and run-only mixins.
+func AvailableMixins(buildMixins, runMixins []string) []string {
+	// NOTE: We cannot simply union the two mixin sets, as this could introduce a mixin that is only present on one stack
+	// image but not the other. A buildpack that happens to require the mixin would fail to run properly, even though validation
+	// would pass.
+	//
+	// For example:
+	//
+	//  Incorrect:
+	//    Run image mixins:   [A, B]
+	//    Build image mixins: [A]
+	//    Merged: [A, B]
+	//    Buildpack requires: [A, B]
+	//    Match? Yes
+	//
+	//  Correct:
+	//    Run image mixins:   [A, B]
+	//    Build image mixins: [A]
+	//    Merged: [A]
+	//    Buildpack requires: [A, B]
+	//    Match? No
+
+	buildOnly := FindStageMixins(buildMixins, "build")
+	runOnly := FindStageMixins(runMixins, "run")
+	_, _, common := stringset.Compare(buildMixins, runMixins)
+
+	return append(common, append(buildOnly, runOnly...)...)
+}
+
 func FindStageMixins(mixins []string, stage string) []string {
 	var found []string
 	for _, m := range mixins {
@@ -0,0 +1,96 @@
+package packtest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Kinds of sample application that may be passed to SampleApp.
+const (
+	StaticApp = "static"
+	NodeApp   = "nodejs"
+	GoApp     = "go"
+)
+
+var sampleApps = map[string]map[string]string{
+	StaticApp: {
+		"index.html": "<html><body>Hello from packtest</body></html>\n",
+	},
+	NodeApp: {
+		"package.json": `{
+  "name": "packtest-app",
+  "version": "0.0.1",
+  "main": "index.js",
+  "scripts": {
+    "start": "node index.js"
+  }
+}
+`,
+		"index.js": `const http = require("http");
+
+const port = process.env.PORT || 8080;
+http.createServer((req, res) => res.end("Hello from packtest\n")).listen(port);
+`,
+	},
+	GoApp: {
+		"go.mod": "module packtest/app\n\ngo 1.17\n",
+		"main.go": `package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "Hello from packtest")
+	})
+	http.ListenAndServe(":"+port, nil)
+}
+`,
+	},
+}
+
+// SampleApps returns the kinds of sample application available to SampleApp.
+func SampleApps() []string {
+	var kinds []string
+	for kind := range sampleApps {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// SampleApp writes a minimal application of the given kind to a new temporary directory and returns its path.
+// The directory is removed when the test completes.
+func SampleApp(t testing.TB, kind string) string {
+	t.Helper()
+
+	files, ok := sampleApps[kind]
+	if !ok {
+		t.Fatalf("unknown sample app %q, must be one of: %s", kind, strings.Join(SampleApps(), ", "))
+	}
+
+	appDir, err := ioutil.TempDir("", "packtest-app")
+	if err != nil {
+		t.Fatalf("creating app dir: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(appDir) })
+
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(appDir, name), []byte(contents), 0600); err != nil {
+			t.Fatalf("writing %s: %s", name, err)
+		}
+	}
+
+	return appDir
+}
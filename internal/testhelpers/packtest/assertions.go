@@ -0,0 +1,110 @@
+package packtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/authn"
+
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	processTypeEnv   = "CNB_PROCESS_TYPE"
+	processDirPrefix = "/cnb/process/"
+)
+
+// Deserialize just the subset of fields we need to avoid breaking changes
+type sbomLayersMetadata struct {
+	SBOM *struct {
+		SHA string `json:"sha"`
+	} `json:"sbom"`
+}
+
+// FetchImage fetches the image named ref from its registry.
+func FetchImage(t testing.TB, ref string) imgutil.Image {
+	t.Helper()
+
+	img, err := remote.NewImage(ref, authn.DefaultKeychain, remote.FromBaseImage(ref))
+	if err != nil {
+		t.Fatalf("fetching image %s: %s", ref, err)
+	}
+	if !img.Found() {
+		t.Fatalf("image %s does not exist in registry", ref)
+	}
+	return img
+}
+
+// AssertLabel fails the test unless img has label key set to expected.
+func AssertLabel(t testing.TB, img imgutil.Image, key, expected string) {
+	t.Helper()
+
+	actual, err := img.Label(key)
+	if err != nil {
+		t.Fatalf("reading label %s of %s: %s", key, img.Name(), err)
+	}
+	if actual != expected {
+		t.Fatalf("expected label %s of %s to be %q, got %q", key, img.Name(), expected, actual)
+	}
+}
+
+// AssertProcess fails the test unless img declares a process of type processType. The process is returned.
+func AssertProcess(t testing.TB, img imgutil.Image, processType string) launch.Process {
+	t.Helper()
+
+	var buildMD platform.BuildMetadata
+	if _, err := dist.GetLabel(img, platform.BuildMetadataLabel, &buildMD); err != nil {
+		t.Fatalf("reading label %s of %s: %s", platform.BuildMetadataLabel, img.Name(), err)
+	}
+
+	var types []string
+	for _, proc := range buildMD.Processes {
+		if proc.Type == processType {
+			return proc
+		}
+		types = append(types, proc.Type)
+	}
+
+	t.Fatalf("expected %s to have process %q, found: [%s]", img.Name(), processType, strings.Join(types, ", "))
+	return launch.Process{}
+}
+
+// AssertDefaultProcess fails the test unless processType is the process img runs by default.
+func AssertDefaultProcess(t testing.TB, img imgutil.Image, processType string) {
+	t.Helper()
+
+	AssertProcess(t, img, processType)
+
+	entrypoint, err := img.Entrypoint()
+	if err != nil {
+		t.Fatalf("reading entrypoint of %s: %s", img.Name(), err)
+	}
+
+	var actual string
+	if len(entrypoint) > 0 && strings.HasPrefix(entrypoint[0], processDirPrefix) {
+		actual = strings.TrimPrefix(entrypoint[0], processDirPrefix)
+	} else if actual, err = img.Env(processTypeEnv); err != nil {
+		t.Fatalf("reading env %s of %s: %s", processTypeEnv, img.Name(), err)
+	}
+
+	if actual != processType {
+		t.Fatalf("expected default process of %s to be %q, got %q", img.Name(), processType, actual)
+	}
+}
+
+// AssertSBOM fails the test unless img contains the SBOM layer written by the lifecycle during export.
+func AssertSBOM(t testing.TB, img imgutil.Image) {
+	t.Helper()
+
+	var layersMD sbomLayersMetadata
+	if _, err := dist.GetLabel(img, platform.LayerMetadataLabel, &layersMD); err != nil {
+		t.Fatalf("reading label %s of %s: %s", platform.LayerMetadataLabel, img.Name(), err)
+	}
+	if layersMD.SBOM == nil || layersMD.SBOM.SHA == "" {
+		t.Fatalf("expected %s to have an SBOM layer", img.Name())
+	}
+}
@@ -0,0 +1,210 @@
+package packtest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/google/go-containerregistry/pkg/authn"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/stack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	builderMetadataLabel = "io.buildpacks.builder.metadata"
+	stackIDLabel         = "io.buildpacks.stack.id"
+
+	// DefaultStackID is the stack used by CreateMetadataBuilder when MetadataBuilderConfig.StackID is empty.
+	DefaultStackID = "io.buildpacks.stacks.packtest"
+
+	// DefaultLifecycleVersion is the lifecycle version recorded by CreateMetadataBuilder when MetadataBuilderConfig.LifecycleVersion is empty.
+	DefaultLifecycleVersion = "0.14.2"
+)
+
+// MetadataBuilderConfig describes the metadata-only builder fixture created by CreateMetadataBuilder.
+// Zero values are replaced with defaults suitable for most tests.
+type MetadataBuilderConfig struct {
+	// Repository name, relative to the registry, of the builder image. Defaults to "packtest/builder".
+	Name string
+
+	// Stack the builder and run image belong to. Defaults to DefaultStackID.
+	StackID string
+
+	// Mixins declared by both the builder and run image.
+	Mixins []string
+
+	// Lifecycle version recorded in the builder metadata. Defaults to DefaultLifecycleVersion.
+	LifecycleVersion string
+
+	// Platform APIs the recorded lifecycle supports. Defaults to the latest Platform API supported by pack.
+	PlatformAPIs []string
+
+	// Buildpack APIs the recorded lifecycle supports. Defaults to "0.8".
+	BuildpackAPIs []string
+
+	// Buildpacks recorded as present on the builder, each in an order group of its own.
+	Buildpacks []dist.BuildpackInfo
+
+	// User and group the builder runs as. Both default to 1000.
+	UID, GID int
+}
+
+// BuilderFixture references the images pushed by CreateMetadataBuilder.
+type BuilderFixture struct {
+	// Fully qualified name of the builder image.
+	Image string
+
+	// Fully qualified name of the run image referenced by the builder.
+	RunImage string
+
+	// Stack shared by the builder and run image.
+	StackID string
+}
+
+// CreateMetadataBuilder pushes a metadata-only builder, along with the run image it references, to registry.
+//
+// The builder carries all of the metadata pack reads from a builder (stack, lifecycle, buildpacks and order)
+// but no lifecycle or buildpack layers. It is therefore suitable for exercising builder resolution, inspection
+// and validation, but cannot execute the lifecycle.
+func CreateMetadataBuilder(t testing.TB, registry *Registry, config MetadataBuilderConfig) BuilderFixture {
+	t.Helper()
+
+	config = withBuilderDefaults(config)
+	fixture := BuilderFixture{
+		Image:    registry.RepoName(config.Name),
+		RunImage: registry.RepoName(config.Name + "-run"),
+		StackID:  config.StackID,
+	}
+
+	runImage := newRemoteImage(t, fixture.RunImage)
+	setLabel(t, runImage, stackIDLabel, config.StackID)
+	setJSONLabel(t, runImage, stack.MixinsLabel, config.Mixins)
+	saveImage(t, runImage)
+
+	var order dist.Order
+	bpLayers := dist.BuildpackLayers{}
+	for _, bp := range config.Buildpacks {
+		order = append(order, dist.OrderEntry{Group: []dist.BuildpackRef{{BuildpackInfo: bp}}})
+		if _, ok := bpLayers[bp.ID]; !ok {
+			bpLayers[bp.ID] = map[string]dist.BuildpackLayerInfo{}
+		}
+		bpLayers[bp.ID][bp.Version] = dist.BuildpackLayerInfo{
+			API:    api.MustParse(config.BuildpackAPIs[len(config.BuildpackAPIs)-1]),
+			Stacks: []dist.Stack{{ID: config.StackID}},
+		}
+	}
+
+	builderImage := newRemoteImage(t, fixture.Image)
+	setLabel(t, builderImage, stackIDLabel, config.StackID)
+	setJSONLabel(t, builderImage, stack.MixinsLabel, config.Mixins)
+	setJSONLabel(t, builderImage, builder.OrderLabel, order)
+	setJSONLabel(t, builderImage, dist.BuildpackLayersLabel, bpLayers)
+	setJSONLabel(t, builderImage, builderMetadataLabel, builder.Metadata{
+		Description: "packtest builder fixture",
+		Buildpacks:  config.Buildpacks,
+		Stack: builder.StackMetadata{
+			RunImage: builder.RunImageMetadata{Image: fixture.RunImage},
+		},
+		Lifecycle: builder.LifecycleMetadata{
+			LifecycleInfo: builder.LifecycleInfo{
+				Version: builder.VersionMustParse(config.LifecycleVersion),
+			},
+			APIs: builder.LifecycleAPIs{
+				Buildpack: builder.APIVersions{Supported: apiSet(t, config.BuildpackAPIs)},
+				Platform:  builder.APIVersions{Supported: apiSet(t, config.PlatformAPIs)},
+			},
+		},
+		CreatedBy: builder.CreatorMetadata{Name: "packtest"},
+	})
+	setEnv(t, builderImage, builder.EnvUID, strconv.Itoa(config.UID))
+	setEnv(t, builderImage, builder.EnvGID, strconv.Itoa(config.GID))
+	saveImage(t, builderImage)
+
+	return fixture
+}
+
+func withBuilderDefaults(config MetadataBuilderConfig) MetadataBuilderConfig {
+	if config.Name == "" {
+		config.Name = "packtest/builder"
+	}
+	if config.StackID == "" {
+		config.StackID = DefaultStackID
+	}
+	if config.LifecycleVersion == "" {
+		config.LifecycleVersion = DefaultLifecycleVersion
+	}
+	if len(config.PlatformAPIs) == 0 {
+		config.PlatformAPIs = []string{build.SupportedPlatformAPIVersions.Latest().String()}
+	}
+	if len(config.BuildpackAPIs) == 0 {
+		config.BuildpackAPIs = []string{"0.8"}
+	}
+	if config.UID == 0 {
+		config.UID = 1000
+	}
+	if config.GID == 0 {
+		config.GID = 1000
+	}
+	return config
+}
+
+func apiSet(t testing.TB, versions []string) builder.APISet {
+	t.Helper()
+
+	var set builder.APISet
+	for _, v := range versions {
+		version, err := api.NewVersion(v)
+		if err != nil {
+			t.Fatalf("parsing api version %s: %s", v, err)
+		}
+		set = append(set, version)
+	}
+	return set
+}
+
+func newRemoteImage(t testing.TB, name string) imgutil.Image {
+	t.Helper()
+
+	img, err := remote.NewImage(name, authn.DefaultKeychain)
+	if err != nil {
+		t.Fatalf("creating image %s: %s", name, err)
+	}
+	return img
+}
+
+func setLabel(t testing.TB, img imgutil.Image, key, value string) {
+	t.Helper()
+
+	if err := img.SetLabel(key, value); err != nil {
+		t.Fatalf("setting label %s on %s: %s", key, img.Name(), err)
+	}
+}
+
+func setJSONLabel(t testing.TB, img imgutil.Image, key string, value interface{}) {
+	t.Helper()
+
+	if err := dist.SetLabel(img, key, value); err != nil {
+		t.Fatalf("setting label %s on %s: %s", key, img.Name(), err)
+	}
+}
+
+func setEnv(t testing.TB, img imgutil.Image, key, value string) {
+	t.Helper()
+
+	if err := img.SetEnv(key, value); err != nil {
+		t.Fatalf("setting env %s on %s: %s", key, img.Name(), err)
+	}
+}
+
+func saveImage(t testing.TB, img imgutil.Image) {
+	t.Helper()
+
+	if err := img.Save(); err != nil {
+		t.Fatalf("saving image %s: %s", img.Name(), err)
+	}
+}
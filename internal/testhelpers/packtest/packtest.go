@@ -0,0 +1,16 @@
+/*
+Package packtest provides helpers for the tests of pack that exercise its client against images in a registry,
+such as builder resolution, inspection and validation. It provides:
+
+  - an in-memory OCI registry that lives for the duration of a test (see NewRegistry),
+  - a metadata-only builder fixture, along with its run image, pushed to that registry (see CreateMetadataBuilder),
+  - sample applications written to temporary directories (see SampleApp), and
+  - assertions on images produced by a build: labels, processes and SBOM (see FetchImage, AssertLabel,
+    AssertProcess, AssertDefaultProcess and AssertSBOM).
+
+The builder fixture has no lifecycle or buildpacks, so it cannot run builds: tests building images need a real
+builder, whose images the assertions then check.
+
+All helpers accept a testing.TB and fail the test on error.
+*/
+package packtest
@@ -0,0 +1,103 @@
+package packtest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/testhelpers/packtest"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPacktest(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "packtest", testPacktest, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPacktest(t *testing.T, when spec.G, it spec.S) {
+	var registry *packtest.Registry
+
+	it.Before(func() {
+		registry = packtest.NewRegistry(t)
+	})
+
+	when("#CreateMetadataBuilder", func() {
+		it("pushes a builder and run image with defaults", func() {
+			fixture := packtest.CreateMetadataBuilder(t, registry, packtest.MetadataBuilderConfig{})
+
+			h.AssertEq(t, fixture.Image, registry.RepoName("packtest/builder"))
+			h.AssertEq(t, fixture.RunImage, registry.RepoName("packtest/builder-run"))
+			h.AssertEq(t, fixture.StackID, packtest.DefaultStackID)
+
+			runImage := packtest.FetchImage(t, fixture.RunImage)
+			packtest.AssertLabel(t, runImage, "io.buildpacks.stack.id", packtest.DefaultStackID)
+
+			builderImage := packtest.FetchImage(t, fixture.Image)
+			packtest.AssertLabel(t, builderImage, "io.buildpacks.stack.id", packtest.DefaultStackID)
+
+			uid, err := builderImage.Env(builder.EnvUID)
+			h.AssertNil(t, err)
+			h.AssertEq(t, uid, "1000")
+		})
+
+		it("records the configured buildpacks and lifecycle", func() {
+			bp := dist.BuildpackInfo{ID: "some/bp", Version: "1.2.3"}
+			fixture := packtest.CreateMetadataBuilder(t, registry, packtest.MetadataBuilderConfig{
+				Name:             "some/builder",
+				LifecycleVersion: "0.13.0",
+				Buildpacks:       []dist.BuildpackInfo{bp},
+			})
+
+			var md builder.Metadata
+			_, err := dist.GetLabel(packtest.FetchImage(t, fixture.Image), "io.buildpacks.builder.metadata", &md)
+			h.AssertNil(t, err)
+			h.AssertEq(t, md.Lifecycle.Version.String(), "0.13.0")
+			h.AssertEq(t, md.Buildpacks, []dist.BuildpackInfo{bp})
+			h.AssertEq(t, md.Stack.RunImage.Image, fixture.RunImage)
+		})
+	})
+
+	when("#AssertProcess", func() {
+		it("returns the process declared by the image", func() {
+			fixture := packtest.CreateMetadataBuilder(t, registry, packtest.MetadataBuilderConfig{})
+			img := packtest.FetchImage(t, fixture.RunImage)
+			h.AssertNil(t, dist.SetLabel(img, platform.BuildMetadataLabel, map[string]interface{}{
+				"processes": []map[string]interface{}{
+					{"type": "web", "command": "some-command"},
+				},
+			}))
+			h.AssertNil(t, img.SetEntrypoint("/cnb/process/web"))
+
+			proc := packtest.AssertProcess(t, img, "web")
+			h.AssertEq(t, proc.Command, "some-command")
+			packtest.AssertDefaultProcess(t, img, "web")
+		})
+	})
+
+	when("#SampleApp", func() {
+		it("writes each sample app to a directory", func() {
+			for _, kind := range packtest.SampleApps() {
+				appDir := packtest.SampleApp(t, kind)
+
+				files, err := ioutil.ReadDir(appDir)
+				h.AssertNil(t, err)
+				h.AssertNotEq(t, len(files), 0)
+			}
+		})
+
+		it("writes the node app manifest", func() {
+			appDir := packtest.SampleApp(t, packtest.NodeApp)
+			_, err := os.Stat(filepath.Join(appDir, "package.json"))
+			h.AssertNil(t, err)
+		})
+	})
+}
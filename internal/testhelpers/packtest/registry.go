@@ -0,0 +1,50 @@
+package packtest
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+)
+
+// Registry is an in-memory OCI registry served over plain HTTP on the loopback interface.
+type Registry struct {
+	server *httptest.Server
+	host   string
+}
+
+// NewRegistry starts a Registry that is shut down when the test completes.
+func NewRegistry(t testing.TB) *Registry {
+	t.Helper()
+
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(ioutil.Discard, "", 0))))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parsing registry url %s: %s", server.URL, err)
+	}
+
+	return &Registry{
+		server: server,
+		host:   u.Host,
+	}
+}
+
+// Host returns the host and port the registry is listening on.
+func (r *Registry) Host() string {
+	return r.host
+}
+
+// RepoName returns the fully qualified name of repository name in the registry.
+func (r *Registry) RepoName(name string) string {
+	return r.host + "/" + name
+}
+
+// Close shuts down the registry before the end of the test.
+func (r *Registry) Close() {
+	r.server.Close()
+}
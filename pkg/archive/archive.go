@@ -4,10 +4,15 @@ package archive // import "github.com/buildpacks/pack/pkg/archive"
 import (
 	"archive/tar"
 	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
@@ -54,6 +59,26 @@ func ReadZipAsTar(srcPath, basePath string, uid, gid int, mode int64, normalizeM
 	})
 }
 
+// ReadTarAsTar reads the tar, optionally gzip compressed, at srcPath and re-writes its entries below basePath.
+func ReadTarAsTar(srcPath, basePath string, uid, gid int, mode int64, normalizeModTime bool, fileFilter func(string) bool) io.ReadCloser {
+	return GenerateTar(func(tw TarWriter) error {
+		f, err := os.Open(filepath.Clean(srcPath))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return WriteTarToTar(tw, f, basePath, uid, gid, mode, normalizeModTime, fileFilter)
+	})
+}
+
+// ReadTarStreamAsTar is like ReadTarAsTar, but reads the source tar from r as it is consumed.
+func ReadTarStreamAsTar(r io.Reader, basePath string, uid, gid int, mode int64, normalizeModTime bool, fileFilter func(string) bool) io.ReadCloser {
+	return GenerateTar(func(tw TarWriter) error {
+		return WriteTarToTar(tw, r, basePath, uid, gid, mode, normalizeModTime, fileFilter)
+	})
+}
+
 func GenerateTar(genFn func(TarWriter) error) io.ReadCloser {
 	return GenerateTarWithWriter(genFn, DefaultTarWriterFactory())
 }
@@ -330,6 +355,79 @@ func WriteZipToTar(tw TarWriter, srcZip, basePath string, uid, gid int, mode int
 	return nil
 }
 
+// WriteTarToTar writes the entries of a tar, optionally gzip compressed, read from r to a tar writer.
+// Entry names are made relative to basePath; entries that would escape it are rejected.
+func WriteTarToTar(tw TarWriter, r io.Reader, basePath string, uid, gid int, mode int64, normalizeModTime bool, fileFilter func(string) bool) error {
+	src, err := decompressedReader(r)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tr := tar.NewReader(src)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading tar")
+		}
+
+		name, err := relativeEntryName(header.Name)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		if fileFilter != nil && !fileFilter(name) {
+			continue
+		}
+
+		header.Name = filepath.ToSlash(filepath.Join(basePath, name))
+		if header.Typeflag == tar.TypeLink {
+			linkName, err := relativeEntryName(header.Linkname)
+			if err != nil {
+				return err
+			}
+			header.Linkname = filepath.ToSlash(filepath.Join(basePath, linkName))
+		}
+		finalizeHeader(header, uid, gid, mode, normalizeModTime)
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		// only regular files have content, reading any other entry yields nothing
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// relativeEntryName cleans the name of a tar entry, returning an error if it points outside of the archive root.
+func relativeEntryName(name string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(filepath.ToSlash(name), "/"))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", errors.Errorf("tar entry %q is outside of the archive root", name)
+	}
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+// decompressedReader returns a reader of the contents of r, transparently decompressing gzip data.
+func decompressedReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return gzip.NewReader(br)
+	}
+	return ioutil.NopCloser(br), nil
+}
+
 func isFatFile(header zip.FileHeader) bool {
 	var (
 		creatorFAT  uint16 = 0 // nolint:revive
@@ -382,3 +480,21 @@ func IsZip(path string) (bool, error) {
 		return false, err
 	}
 }
+
+// IsTar detects whether or not a File is a tar archive, optionally gzip compressed
+func IsTar(path string) (bool, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	src, err := decompressedReader(f)
+	if err != nil {
+		return false, nil
+	}
+	defer src.Close()
+
+	_, err = tar.NewReader(src).Next()
+	return err == nil, nil
+}
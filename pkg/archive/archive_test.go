@@ -2,6 +2,8 @@ package archive_test
 
 import (
 	"archive/tar"
+	"bytes"
+	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"net"
@@ -106,6 +108,64 @@ func testArchive(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#ReadTarAsTar", func() {
+		for desc, createArchive := range map[string]func(t *testing.T, srcDir, tarDir string, mode int64) string{
+			"tar":    h.CreateTAR,
+			"tar.gz": h.CreateTGZ,
+		} {
+			createArchive := createArchive
+
+			it(fmt.Sprintf("returns a TarReader of the %s", desc), func() {
+				src := createArchive(t, filepath.Join("testdata", "dir-to-tar"), ".", 0777)
+				defer os.Remove(src)
+
+				rc := archive.ReadTarAsTar(src, "/nested/dir/dir-in-archive", 1234, 2345, -1, true, nil)
+
+				tr := tar.NewReader(rc)
+				verify := h.NewTarVerifier(t, tr, 1234, 2345)
+				verify.NextFile("/nested/dir/dir-in-archive/some-file.txt", "some-content", 0777)
+				verify.NextDirectory("/nested/dir/dir-in-archive/sub-dir", 0777)
+				verify.NextSymLink("/nested/dir/dir-in-archive/sub-dir/link-file", "../some-file.txt")
+
+				verify.NoMoreFilesExist()
+				h.AssertNil(t, rc.Close())
+			})
+		}
+	})
+
+	when("#WriteTarToTar", func() {
+		it("applies the file filter to paths relative to the archive root", func() {
+			src := h.CreateTAR(t, filepath.Join("testdata", "dir-to-tar"), "./", 0777)
+			defer os.Remove(src)
+
+			f, err := os.Open(src)
+			h.AssertNil(t, err)
+			defer f.Close()
+
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			h.AssertNil(t, archive.WriteTarToTar(tw, f, "/workspace", 1234, 2345, -1, true, func(path string) bool {
+				return path != "some-file.txt"
+			}))
+			h.AssertNil(t, tw.Close())
+
+			verify := h.NewTarVerifier(t, tar.NewReader(&buf), 1234, 2345)
+			verify.NextDirectory("/workspace/sub-dir", 0777)
+			verify.NextSymLink("/workspace/sub-dir/link-file", "../some-file.txt")
+			verify.NoMoreFilesExist()
+		})
+
+		it("rejects entries outside of the archive root", func() {
+			var src bytes.Buffer
+			tw := tar.NewWriter(&src)
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "../escaped.txt", Typeflag: tar.TypeReg, Mode: 0644}))
+			h.AssertNil(t, tw.Close())
+
+			err := archive.WriteTarToTar(tar.NewWriter(ioutil.Discard), &src, "/workspace", 0, 0, -1, true, nil)
+			h.AssertError(t, err, `tar entry "../escaped.txt" is outside of the archive root`)
+		})
+	})
+
 	when("#ReadTarEntry", func() {
 		var (
 			err     error
@@ -570,6 +630,42 @@ func testArchive(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#IsTar", func() {
+		it("returns true for a tar file", func() {
+			path := h.CreateTAR(t, filepath.Join("testdata", "dir-to-tar"), ".", -1)
+			defer os.Remove(path)
+
+			isTar, err := archive.IsTar(path)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, isTar)
+		})
+
+		it("returns true for a gzip compressed tar file", func() {
+			path := h.CreateTGZ(t, filepath.Join("testdata", "dir-to-tar"), ".", -1)
+			defer os.Remove(path)
+
+			isTar, err := archive.IsTar(path)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, isTar)
+		})
+
+		it("returns false for a zip file", func() {
+			isTar, err := archive.IsTar(filepath.Join("testdata", "zip-to-tar.zip"))
+			h.AssertNil(t, err)
+			h.AssertFalse(t, isTar)
+		})
+
+		it("returns false for an empty file", func() {
+			file, err := ioutil.TempFile(tmpDir, "file.txt")
+			h.AssertNil(t, err)
+			h.AssertNil(t, file.Close())
+
+			isTar, err := archive.IsTar(file.Name())
+			h.AssertNil(t, err)
+			h.AssertFalse(t, isTar)
+		})
+	})
+
 	when("#IsZip", func() {
 		when("file is a zip file", func() {
 			it("returns true", func() {
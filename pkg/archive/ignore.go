@@ -0,0 +1,83 @@
+package archive
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// IgnoreFileName is the name of the file, at the root of an app directory, listing the paths to
+// exclude when the directory is copied. Patterns use gitignore syntax.
+const IgnoreFileName = ".packignore"
+
+// ReadIgnoreFile returns the patterns listed in the IgnoreFileName file of dir. Blank lines and
+// comments are dropped. It returns no patterns when dir contains no such file.
+func ReadIgnoreFile(dir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, IgnoreFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "opening %s", IgnoreFileName)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", IgnoreFileName)
+	}
+
+	return patterns, nil
+}
+
+// ExcludeFilter returns a file filter that rejects the paths matched by patterns, given in
+// gitignore syntax, so that negated patterns (`!keep.me`) re-include paths. It returns nil when
+// there are no patterns.
+func ExcludeFilter(patterns []string) func(string) bool {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	excludes := ignore.CompileIgnoreLines(patterns...)
+	return func(path string) bool {
+		return !excludes.MatchesPath(path)
+	}
+}
+
+// CombineFilters returns a file filter that accepts the paths accepted by every non-nil filter.
+// It returns nil when all filters are nil.
+func CombineFilters(filters ...func(string) bool) func(string) bool {
+	var active []func(string) bool
+	for _, filter := range filters {
+		if filter != nil {
+			active = append(active, filter)
+		}
+	}
+
+	switch len(active) {
+	case 0:
+		return nil
+	case 1:
+		return active[0]
+	}
+
+	return func(path string) bool {
+		for _, filter := range active {
+			if !filter(path) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,117 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestIgnore(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Ignore", testIgnore, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testIgnore(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "ignore-test")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ReadIgnoreFile", func() {
+		it("returns the patterns without blank lines and comments", func() {
+			content := "# dependencies\nnode_modules\n\n*.log\n!keep.log\n"
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(tmpDir, archive.IgnoreFileName), []byte(content), 0600))
+
+			patterns, err := archive.ReadIgnoreFile(tmpDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, patterns, []string{"node_modules", "*.log", "!keep.log"})
+		})
+
+		it("returns no patterns when there is no ignore file", func() {
+			patterns, err := archive.ReadIgnoreFile(tmpDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(patterns), 0)
+		})
+	})
+
+	when("#ExcludeFilter", func() {
+		it("rejects matching paths", func() {
+			filter := archive.ExcludeFilter([]string{"node_modules", "*.log", "!keep.log"})
+
+			h.AssertEq(t, filter("node_modules"), false)
+			h.AssertEq(t, filter(filepath.Join("node_modules", "left-pad", "index.js")), false)
+			h.AssertEq(t, filter("debug.log"), false)
+			h.AssertEq(t, filter("keep.log"), true)
+			h.AssertEq(t, filter("index.js"), true)
+		})
+
+		it("returns nil without patterns", func() {
+			h.AssertEq(t, archive.ExcludeFilter(nil) == nil, true)
+		})
+	})
+
+	when("#CombineFilters", func() {
+		it("accepts paths accepted by every filter", func() {
+			filter := archive.CombineFilters(
+				archive.ExcludeFilter([]string{"*.log"}),
+				nil,
+				archive.ExcludeFilter([]string{"tmp"}),
+			)
+
+			h.AssertEq(t, filter("index.js"), true)
+			h.AssertEq(t, filter("debug.log"), false)
+			h.AssertEq(t, filter("tmp"), false)
+		})
+
+		it("returns nil when all filters are nil", func() {
+			h.AssertEq(t, archive.CombineFilters(nil, nil) == nil, true)
+		})
+	})
+
+	when("copying a directory", func() {
+		it("leaves out the ignored paths", func() {
+			src := filepath.Join(tmpDir, "app")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(src, "node_modules", "dep"), 0755))
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(src, "node_modules", "dep", "index.js"), []byte("dep"), 0600))
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(src, "app.js"), []byte("app"), 0600))
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(src, archive.IgnoreFileName), []byte("node_modules\n"), 0600))
+
+			patterns, err := archive.ReadIgnoreFile(src)
+			h.AssertNil(t, err)
+
+			fh, err := os.Create(filepath.Join(tmpDir, "app.tar"))
+			h.AssertNil(t, err)
+
+			tw := tar.NewWriter(fh)
+			h.AssertNil(t, archive.WriteDirToTar(tw, src, "/workspace", 1234, 2345, 0777, true, false, archive.ExcludeFilter(patterns)))
+			h.AssertNil(t, tw.Close())
+			h.AssertNil(t, fh.Close())
+
+			file, err := os.Open(filepath.Join(tmpDir, "app.tar"))
+			h.AssertNil(t, err)
+			defer file.Close()
+
+			verify := h.NewTarVerifier(t, tar.NewReader(file), 1234, 2345)
+			verify.NextFile("/workspace/"+archive.IgnoreFileName, "node_modules\n", int64(os.ModePerm))
+			verify.NextFile("/workspace/app.js", "app", int64(os.ModePerm))
+			verify.NoMoreFilesExist()
+		})
+	})
+}
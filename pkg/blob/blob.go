@@ -3,6 +3,8 @@ package blob
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"os"
 
@@ -62,6 +64,29 @@ func (b blob) Open() (r io.ReadCloser, err error) {
 	return rc, nil
 }
 
+// Digest returns the hex encoded sha256 checksum of the blob as stored, before any decompression.
+// Blobs of a directory are digested as the tar archive returned by Open.
+func Digest(b Blob) (string, error) {
+	open := b.Open
+	if fileBlob, ok := b.(*blob); ok {
+		if fi, err := os.Stat(fileBlob.path); err == nil && !fi.IsDir() {
+			open = func() (io.ReadCloser, error) { return os.Open(fileBlob.path) }
+		}
+	}
+
+	rc, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return "", errors.Wrap(err, "reading blob")
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func isGZip(file io.ReadSeeker) (bool, error) {
 	b := make([]byte, 3)
 	if _, err := file.Seek(0, 0); err != nil {
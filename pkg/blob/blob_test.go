@@ -1,6 +1,9 @@
 package blob_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -59,5 +62,20 @@ func testBlob(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("#Digest", func() {
+			it("digests the compressed archive as stored", func() {
+				blobPath := h.CreateTGZ(t, filepath.Join("testdata", "blob"), ".", -1)
+				defer os.Remove(blobPath)
+
+				contents, err := ioutil.ReadFile(blobPath)
+				h.AssertNil(t, err)
+				expected := sha256.Sum256(contents)
+
+				digest, err := blob.Digest(blob.NewBlob(blobPath))
+				h.AssertNil(t, err)
+				h.AssertEq(t, digest, hex.EncodeToString(expected[:]))
+			})
+		})
 	})
 }
@@ -0,0 +1,258 @@
+// Package buildevents streams the progress of builds as structured events, for platforms embedding the client which
+// forward it to web UIs instead of parsing the logs of pack.
+//
+// Output of the lifecycle is coalesced into Log events of bounded size, emitted at most once per flush interval for
+// each phase and stream. When the consumer falls behind, output is dropped rather than slowing down the build, and the
+// number of dropped bytes is reported by the next Log event. Phase and progress events are never dropped.
+package buildevents
+
+import (
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Type is the kind of an Event.
+type Type string
+
+const (
+	// PhaseStarted is emitted when the container of a lifecycle phase starts.
+	PhaseStarted Type = "phase-started"
+
+	// PhaseFinished is emitted when a lifecycle phase finishes, with the error it failed with, if any.
+	PhaseFinished Type = "phase-finished"
+
+	// Log is emitted with a chunk of the output of a lifecycle phase.
+	Log Type = "log"
+
+	// Progress is emitted with the number of completed lifecycle phases, after each phase succeeds.
+	Progress Type = "progress"
+)
+
+// The streams of the output of Log events.
+const (
+	Stdout = "stdout"
+	Stderr = "stderr"
+)
+
+const (
+	defaultFlushInterval = 100 * time.Millisecond
+	defaultMaxChunkSize  = 16 * 1024
+	defaultBufferSize    = 256
+)
+
+// Event is an event of a build, which encodes to JSON for forwarding to browsers.
+type Event struct {
+	Type Type      `json:"type"`
+	Time time.Time `json:"time"`
+
+	// Phase of the lifecycle the event is about, such as detector or exporter.
+	Phase string `json:"phase,omitempty"`
+
+	// Stream the output of a Log event was written to, Stdout or Stderr.
+	Stream string `json:"stream,omitempty"`
+
+	// Data is the output of a Log event.
+	Data string `json:"data,omitempty"`
+
+	// Dropped is the number of bytes of output dropped since the previous Log event, as the consumer fell behind.
+	Dropped int `json:"dropped,omitempty"`
+
+	// Error a phase failed with, for PhaseFinished events.
+	Error string `json:"error,omitempty"`
+
+	// Completed and Total phases of the build, for Progress events. Total is an estimate, as the phases run depend
+	// on the results of the previous ones.
+	Completed int `json:"completed,omitempty"`
+	Total     int `json:"total,omitempty"`
+}
+
+// Options bounds the rate and size of the events of a Stream.
+type Options struct {
+	// FlushInterval is how long output is held to be coalesced into a single Log event. Defaults to 100ms.
+	FlushInterval time.Duration
+
+	// MaxChunkSize is the maximum size, in bytes, of the output of a Log event. Defaults to 16 KiB.
+	MaxChunkSize int
+
+	// BufferSize is the number of events buffered for a consumer which fell behind, beyond which output is dropped.
+	// Defaults to 256.
+	BufferSize int
+}
+
+// Stream emits the events of a build. Consumers must receive its events until it is closed.
+type Stream struct {
+	opts    Options
+	events  chan Event
+	mu      sync.Mutex
+	pending *Event
+	timer   *time.Timer
+	dropped int
+	closed  bool
+}
+
+// NewStream creates a stream of events bounded by opts.
+func NewStream(opts Options) *Stream {
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultFlushInterval
+	}
+	if opts.MaxChunkSize <= 0 {
+		opts.MaxChunkSize = defaultMaxChunkSize
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBufferSize
+	}
+
+	return &Stream{
+		opts:   opts,
+		events: make(chan Event, opts.BufferSize),
+	}
+}
+
+// Events returns the channel the events are emitted on, which is closed when the stream is closed.
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+// Forward calls handler with each event of the stream, until the stream is closed.
+func (s *Stream) Forward(handler func(Event)) {
+	for event := range s.events {
+		handler(event)
+	}
+}
+
+// Close emits the pending output and closes the channel of the events. The stream must be closed once the build
+// returns, and ignores any later event.
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.flushLocked()
+	s.closed = true
+	close(s.events)
+}
+
+// Writer returns a writer emitting what is written to it as the output of the phase on the stream.
+func (s *Stream) Writer(phase, stream string) io.Writer {
+	return &writer{stream: s, phase: phase, name: stream}
+}
+
+// PhaseStarted emits a PhaseStarted event.
+func (s *Stream) PhaseStarted(phase string) {
+	s.emit(Event{Type: PhaseStarted, Phase: phase})
+}
+
+// PhaseFinished emits a PhaseFinished event, after the pending output of the phase.
+func (s *Stream) PhaseFinished(phase string, err error) {
+	event := Event{Type: PhaseFinished, Phase: phase}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	s.emit(event)
+}
+
+// Progress emits a Progress event.
+func (s *Stream) Progress(completed, total int) {
+	if total < completed {
+		total = completed
+	}
+	s.emit(Event{Type: Progress, Completed: completed, Total: total})
+}
+
+// emit sends the event after the pending output, waiting for the consumer if needed.
+func (s *Stream) emit(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.flushLocked()
+	event.Time = time.Now()
+	s.events <- event
+}
+
+func (s *Stream) write(phase, stream string, p []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	if s.pending != nil && (s.pending.Phase != phase || s.pending.Stream != stream) {
+		s.flushLocked()
+	}
+
+	for len(p) > 0 {
+		if s.pending == nil {
+			s.pending = &Event{Type: Log, Time: time.Now(), Phase: phase, Stream: stream}
+		}
+
+		n := s.opts.MaxChunkSize - len(s.pending.Data)
+		if n >= len(p) {
+			s.pending.Data += string(p)
+			break
+		}
+
+		// chunks are split between runes, so that each of them is valid UTF-8 on its own
+		cut := n
+		for cut > 0 && !utf8.RuneStart(p[cut]) {
+			cut--
+		}
+		if cut == 0 && s.pending.Data == "" {
+			cut = n
+		}
+		s.pending.Data += string(p[:cut])
+		p = p[cut:]
+		s.flushLocked()
+	}
+
+	if s.pending != nil && s.timer == nil {
+		s.timer = time.AfterFunc(s.opts.FlushInterval, s.flush)
+	}
+}
+
+func (s *Stream) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.flushLocked()
+	}
+}
+
+// flushLocked emits the pending output, unless the consumer fell behind, in which case the output is dropped.
+func (s *Stream) flushLocked() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if s.pending == nil {
+		return
+	}
+
+	event := *s.pending
+	s.pending = nil
+	event.Dropped = s.dropped
+	select {
+	case s.events <- event:
+		s.dropped = 0
+	default:
+		s.dropped += len(event.Data)
+	}
+}
+
+type writer struct {
+	stream *Stream
+	phase  string
+	name   string
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.stream.write(w.phase, w.name, p)
+	return len(p), nil
+}
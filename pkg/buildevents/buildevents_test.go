@@ -0,0 +1,141 @@
+package buildevents_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/buildevents"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildEvents(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildEvents", testBuildEvents, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildEvents(t *testing.T, when spec.G, it spec.S) {
+	// collect closes the stream and returns its remaining events
+	collect := func(stream *buildevents.Stream) []buildevents.Event {
+		stream.Close()
+		var events []buildevents.Event
+		stream.Forward(func(event buildevents.Event) {
+			events = append(events, event)
+		})
+		return events
+	}
+
+	when("#Writer", func() {
+		it("coalesces the output of a phase", func() {
+			stream := buildevents.NewStream(buildevents.Options{FlushInterval: time.Hour})
+			w := stream.Writer("detector", buildevents.Stdout)
+			h.AssertNil(t, writeString(w, "some "))
+			h.AssertNil(t, writeString(w, "output"))
+
+			events := collect(stream)
+			h.AssertEq(t, len(events), 1)
+			h.AssertEq(t, events[0].Type, buildevents.Log)
+			h.AssertEq(t, events[0].Phase, "detector")
+			h.AssertEq(t, events[0].Stream, buildevents.Stdout)
+			h.AssertEq(t, events[0].Data, "some output")
+		})
+
+		it("emits the output after the flush interval", func() {
+			stream := buildevents.NewStream(buildevents.Options{FlushInterval: time.Millisecond})
+			h.AssertNil(t, writeString(stream.Writer("builder", buildevents.Stderr), "some output"))
+
+			select {
+			case event := <-stream.Events():
+				h.AssertEq(t, event.Data, "some output")
+				h.AssertEq(t, event.Stream, buildevents.Stderr)
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected the output to be flushed")
+			}
+			stream.Close()
+		})
+
+		it("bounds the size of the output of an event", func() {
+			stream := buildevents.NewStream(buildevents.Options{FlushInterval: time.Hour, MaxChunkSize: 4})
+			h.AssertNil(t, writeString(stream.Writer("builder", buildevents.Stdout), "0123456789"))
+
+			var chunks []string
+			for _, event := range collect(stream) {
+				chunks = append(chunks, event.Data)
+			}
+			h.AssertEq(t, chunks, []string{"0123", "4567", "89"})
+		})
+
+		it("does not split runes", func() {
+			stream := buildevents.NewStream(buildevents.Options{FlushInterval: time.Hour, MaxChunkSize: 4})
+			h.AssertNil(t, writeString(stream.Writer("builder", buildevents.Stdout), "abc€d"))
+
+			var chunks []string
+			for _, event := range collect(stream) {
+				chunks = append(chunks, event.Data)
+			}
+			h.AssertEq(t, chunks, []string{"abc", "€d"})
+		})
+
+		it("separates the output of streams", func() {
+			stream := buildevents.NewStream(buildevents.Options{FlushInterval: time.Hour})
+			h.AssertNil(t, writeString(stream.Writer("builder", buildevents.Stdout), "out"))
+			h.AssertNil(t, writeString(stream.Writer("builder", buildevents.Stderr), "err"))
+
+			events := collect(stream)
+			h.AssertEq(t, len(events), 2)
+			h.AssertEq(t, events[0].Data, "out")
+			h.AssertEq(t, events[1].Data, "err")
+		})
+
+		it("drops output when the consumer falls behind, and reports it", func() {
+			stream := buildevents.NewStream(buildevents.Options{FlushInterval: time.Hour, MaxChunkSize: 2, BufferSize: 1})
+			h.AssertNil(t, writeString(stream.Writer("builder", buildevents.Stdout), "aabbccdd"))
+
+			first := <-stream.Events()
+			h.AssertEq(t, first.Data, "aa")
+
+			events := collect(stream)
+			h.AssertEq(t, len(events), 1)
+			h.AssertEq(t, events[0].Data, "dd")
+			h.AssertEq(t, events[0].Dropped, 4)
+		})
+
+		it("ignores output after the stream is closed", func() {
+			stream := buildevents.NewStream(buildevents.Options{})
+			events := collect(stream)
+			h.AssertNil(t, writeString(stream.Writer("builder", buildevents.Stdout), "late"))
+			h.AssertEq(t, len(events), 0)
+		})
+	})
+
+	when("#PhaseFinished", func() {
+		it("emits the pending output of the phase first", func() {
+			stream := buildevents.NewStream(buildevents.Options{FlushInterval: time.Hour, BufferSize: 10})
+			stream.PhaseStarted("exporter")
+			h.AssertNil(t, writeString(stream.Writer("exporter", buildevents.Stdout), "output"))
+			stream.PhaseFinished("exporter", errors.New("some error"))
+			stream.Progress(3, 2)
+
+			events := collect(stream)
+			h.AssertEq(t, len(events), 4)
+			h.AssertEq(t, events[0].Type, buildevents.PhaseStarted)
+			h.AssertEq(t, events[1].Type, buildevents.Log)
+			h.AssertEq(t, events[2].Type, buildevents.PhaseFinished)
+			h.AssertEq(t, events[2].Error, "some error")
+			h.AssertEq(t, events[3].Type, buildevents.Progress)
+			h.AssertEq(t, events[3].Completed, 3)
+			h.AssertEq(t, events[3].Total, 3)
+		})
+	})
+}
+
+func writeString(w io.Writer, s string) error {
+	_, err := io.WriteString(w, s)
+	return err
+}
@@ -66,6 +66,7 @@ type PackageBuilder struct {
 	buildpack    Buildpack
 	dependencies []Buildpack
 	imageFactory ImageFactory
+	arch         string
 }
 
 // TODO: Rename to PackageBuilder
@@ -79,7 +80,20 @@ func (b *PackageBuilder) SetBuildpack(buildpack Buildpack) {
 	b.buildpack = buildpack
 }
 
+// SetArchitecture sets the architecture recorded in the configuration of the package
+func (b *PackageBuilder) SetArchitecture(arch string) {
+	b.arch = arch
+}
+
+// AddDependency adds a buildpack to the package. Dependencies of nested meta-buildpacks are flattened into the
+// package, so a buildpack which is already part of it is skipped.
 func (b *PackageBuilder) AddDependency(buildpack Buildpack) {
+	info := buildpack.Descriptor().Info
+	for _, bp := range append([]Buildpack{b.buildpack}, b.dependencies...) {
+		if bp != nil && bp.Descriptor().Info.FullName() == info.FullName() {
+			return
+		}
+	}
 	b.dependencies = append(b.dependencies, buildpack)
 }
 
@@ -156,7 +170,7 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string) error {
 		return err
 	}
 
-	layoutImage, err := newLayoutImage(imageOS)
+	layoutImage, err := newLayoutImage(imageOS, b.arch)
 	if err != nil {
 		return errors.Wrap(err, "creating layout image")
 	}
@@ -197,7 +211,7 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string) error {
 	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
 }
 
-func newLayoutImage(imageOS string) (*layoutImage, error) {
+func newLayoutImage(imageOS, arch string) (*layoutImage, error) {
 	i := empty.Image
 
 	configFile, err := i.ConfigFile()
@@ -206,6 +220,7 @@ func newLayoutImage(imageOS string) (*layoutImage, error) {
 	}
 
 	configFile.OS = imageOS
+	configFile.Architecture = arch
 	i, err = mutate.ConfigFile(i, configFile)
 	if err != nil {
 		return nil, err
@@ -241,6 +256,12 @@ func (b *PackageBuilder) SaveAsImage(repoName string, publish bool, imageOS stri
 		return nil, errors.Wrapf(err, "creating image")
 	}
 
+	if b.arch != "" {
+		if err := image.SetArchitecture(b.arch); err != nil {
+			return nil, errors.Wrap(err, "setting architecture")
+		}
+	}
+
 	tmpDir, err := ioutil.TempDir("", "package-buildpack")
 	if err != nil {
 		return nil, err
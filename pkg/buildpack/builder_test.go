@@ -585,6 +585,74 @@ func testPackageBuilder(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, osVal, "linux")
 		})
 
+		it("sets the architecture", func() {
+			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				API:    api.MustParse("0.2"),
+				Info:   dist.BuildpackInfo{ID: "bp.1.id", Version: "bp.1.version"},
+				Stacks: []dist.Stack{{ID: "stack.id.1"}},
+			}, 0644)
+			h.AssertNil(t, err)
+
+			builder := buildpack.NewBuilder(mockImageFactory("linux"))
+			builder.SetBuildpack(buildpack1)
+			builder.SetArchitecture("arm64")
+
+			packageImage, err := builder.SaveAsImage("some/package", false, "linux")
+			h.AssertNil(t, err)
+
+			arch, err := packageImage.Architecture()
+			h.AssertNil(t, err)
+			h.AssertEq(t, arch, "arm64")
+		})
+
+		it("flattens dependencies shared by nested meta-buildpacks", func() {
+			sharedInfo := dist.BuildpackInfo{ID: "bp.shared.id", Version: "bp.shared.version"}
+			newMetaBuildpack := func(id string) buildpack.Buildpack {
+				bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+					API:   api.MustParse("0.2"),
+					Info:  dist.BuildpackInfo{ID: id, Version: "1.0.0"},
+					Order: dist.Order{{Group: []dist.BuildpackRef{{BuildpackInfo: sharedInfo}}}},
+				}, 0644)
+				h.AssertNil(t, err)
+				return bp
+			}
+
+			mainBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				API:  api.MustParse("0.2"),
+				Info: dist.BuildpackInfo{ID: "bp.main.id", Version: "1.0.0"},
+				Order: dist.Order{
+					{Group: []dist.BuildpackRef{{BuildpackInfo: dist.BuildpackInfo{ID: "bp.meta.1", Version: "1.0.0"}}}},
+					{Group: []dist.BuildpackRef{{BuildpackInfo: dist.BuildpackInfo{ID: "bp.meta.2", Version: "1.0.0"}}}},
+				},
+			}, 0644)
+			h.AssertNil(t, err)
+
+			builder := buildpack.NewBuilder(mockImageFactory("linux"))
+			builder.SetBuildpack(mainBP)
+			for _, meta := range []string{"bp.meta.1", "bp.meta.2"} {
+				sharedBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+					API:    api.MustParse("0.2"),
+					Info:   sharedInfo,
+					Stacks: []dist.Stack{{ID: "stack.id.1"}},
+				}, 0644)
+				h.AssertNil(t, err)
+				builder.AddDependency(newMetaBuildpack(meta))
+				builder.AddDependency(sharedBP)
+			}
+
+			packageImage, err := builder.SaveAsImage("some/package", false, "linux")
+			h.AssertNil(t, err)
+
+			var bpLayers dist.BuildpackLayers
+			_, err = dist.GetLabel(packageImage, "io.buildpacks.buildpack.layers", &bpLayers)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(bpLayers), 4)
+			h.AssertEq(t, len(bpLayers["bp.shared.id"]), 1)
+
+			fakePackageImage := packageImage.(*fakes.Image)
+			h.AssertEq(t, fakePackageImage.NumberOfAddedLayers(), 4)
+		})
+
 		it("sets buildpack layers label", func() {
 			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
 				API:    api.MustParse("0.2"),
@@ -731,6 +799,45 @@ func testPackageBuilder(t *testing.T, when spec.G, it spec.S) {
 				}))
 		})
 
+		it("sets the architecture", func() {
+			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				API:    api.MustParse("0.2"),
+				Info:   dist.BuildpackInfo{ID: "bp.1.id", Version: "bp.1.version"},
+				Stacks: []dist.Stack{{ID: "stack.id.1"}},
+			}, 0644)
+			h.AssertNil(t, err)
+
+			builder := buildpack.NewBuilder(mockImageFactory(""))
+			builder.SetBuildpack(buildpack1)
+			builder.SetArchitecture("arm64")
+
+			outputFile := filepath.Join(tmpDir, fmt.Sprintf("package-%s.cnb", h.RandString(10)))
+			h.AssertNil(t, builder.SaveAsFile(outputFile, "linux"))
+
+			withContents := func(fn func(data []byte)) h.TarEntryAssertion {
+				return func(t *testing.T, header *tar.Header, data []byte) {
+					fn(data)
+				}
+			}
+
+			h.AssertOnTarEntry(t, outputFile, "/index.json",
+				withContents(func(data []byte) {
+					index := v1.Index{}
+					h.AssertNil(t, json.Unmarshal(data, &index))
+
+					h.AssertOnTarEntry(t, outputFile, "/blobs/sha256/"+index.Manifests[0].Digest.Hex(),
+						withContents(func(data []byte) {
+							manifest := v1.Manifest{}
+							h.AssertNil(t, json.Unmarshal(data, &manifest))
+
+							h.AssertOnTarEntry(t, outputFile, "/blobs/sha256/"+manifest.Config.Digest.Hex(),
+								h.ContentContains(`"architecture":"arm64"`),
+								h.ContentContains(`"os":"linux"`),
+							)
+						}))
+				}))
+		})
+
 		it("adds buildpack layers", func() {
 			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
 				API:    api.MustParse("0.2"),
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// AnalyzedOptions overrides the analysis of the previous image made by the analyzer, which the restorer and exporter
+// base the reuse of layers on. It enables migrations, such as reusing the layers of an image built by another
+// platform, by forcing the decisions of the lifecycle.
+type AnalyzedOptions struct {
+	// Path of an analyzed.toml replacing the one written by the analyzer.
+	Path string
+
+	// Reference of the previous image, such as a digest reference or a daemon image ID, whose layers are reused.
+	PreviousImage string
+
+	// Reference of the run image.
+	RunImage string
+}
+
+// override returns the function applying the options to the analyzed.toml written by the analyzer, or nil when
+// there is nothing to override.
+func (o AnalyzedOptions) override() (func(*platform.AnalyzedMetadata), error) {
+	if o == (AnalyzedOptions{}) {
+		return nil, nil
+	}
+
+	var replacement *platform.AnalyzedMetadata
+	if o.Path != "" {
+		replacement = &platform.AnalyzedMetadata{}
+		if _, err := toml.DecodeFile(o.Path, replacement); err != nil {
+			return nil, errors.Wrapf(err, "reading analyzed.toml %s", style.Symbol(o.Path))
+		}
+	}
+
+	return func(analyzed *platform.AnalyzedMetadata) {
+		if replacement != nil {
+			*analyzed = *replacement
+		}
+		if o.PreviousImage != "" {
+			analyzed.PreviousImage = &platform.ImageIdentifier{Reference: o.PreviousImage}
+		}
+		if o.RunImage != "" {
+			analyzed.RunImage = &platform.ImageIdentifier{Reference: o.RunImage}
+		}
+	}, nil
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestAnalyzed(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Analyzed", testAnalyzed, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testAnalyzed(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "analyzed-test")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#override", func() {
+		it("returns nil when nothing is overridden", func() {
+			override, err := AnalyzedOptions{}.override()
+			h.AssertNil(t, err)
+			h.AssertEq(t, override == nil, true)
+		})
+
+		it("sets the previous image and run image", func() {
+			override, err := AnalyzedOptions{PreviousImage: "some/image@sha256:abc", RunImage: "some/run"}.override()
+			h.AssertNil(t, err)
+
+			analyzed := platform.AnalyzedMetadata{PreviousImage: &platform.ImageIdentifier{Reference: "other/image"}}
+			override(&analyzed)
+			h.AssertEq(t, analyzed.PreviousImage.Reference, "some/image@sha256:abc")
+			h.AssertEq(t, analyzed.RunImage.Reference, "some/run")
+		})
+
+		it("replaces the analysis with the given file", func() {
+			path := filepath.Join(tmpDir, "analyzed.toml")
+			h.AssertNil(t, ioutil.WriteFile(path, []byte(`[image]
+reference = "some/image@sha256:abc"
+
+[metadata]
+[metadata.stack]
+[metadata.stack.run-image]
+image = "some/run"
+`), 0600))
+
+			override, err := AnalyzedOptions{Path: path, RunImage: "other/run"}.override()
+			h.AssertNil(t, err)
+
+			analyzed := platform.AnalyzedMetadata{PreviousImage: &platform.ImageIdentifier{Reference: "other/image"}}
+			override(&analyzed)
+			h.AssertEq(t, analyzed.PreviousImage.Reference, "some/image@sha256:abc")
+			h.AssertEq(t, analyzed.Metadata.Stack.RunImage.Image, "some/run")
+			h.AssertEq(t, analyzed.RunImage.Reference, "other/run")
+		})
+
+		it("errors when the file cannot be read", func() {
+			_, err := AnalyzedOptions{Path: filepath.Join(tmpDir, "missing.toml")}.override()
+			h.AssertError(t, err, "reading analyzed.toml")
+		})
+	})
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// appContainerOptions configures the container started by startAppContainer.
+type appContainerOptions struct {
+	// Ports to publish, in the form accepted by 'docker run --publish'. A port alone is published on the same
+	// port of the host.
+	Ports []string
+
+	// Environment variables of the app.
+	Env map[string]string
+}
+
+// startAppContainer runs an app image in the daemon, streaming the output of the app to the logger until the
+// container exits or is removed. It returns the ID of the container.
+func (c *Client) startAppContainer(ctx context.Context, imageName string, opts appContainerOptions) (string, error) {
+	exposedPorts, portBindings, err := parsePorts(opts.Ports)
+	if err != nil {
+		return "", err
+	}
+
+	ctr, err := c.docker.ContainerCreate(ctx,
+		&container.Config{Image: imageName, Env: envList(opts.Env), ExposedPorts: exposedPorts},
+		&container.HostConfig{PortBindings: portBindings},
+		nil, nil, "")
+	if err != nil {
+		return "", errors.Wrapf(err, "creating container for image %s", style.Symbol(imageName))
+	}
+
+	if err := c.docker.ContainerStart(ctx, ctr.ID, types.ContainerStartOptions{}); err != nil {
+		c.removeAppContainer(ctr.ID)
+		return "", errors.Wrapf(err, "starting container for image %s", style.Symbol(imageName))
+	}
+
+	logs, err := c.docker.ContainerLogs(ctx, ctr.ID, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true, Follow: true})
+	if err != nil {
+		c.removeAppContainer(ctr.ID)
+		return "", errors.Wrapf(err, "reading logs of container %s", style.Symbol(ctr.ID))
+	}
+	go func() {
+		defer logs.Close()
+		_, _ = stdcopy.StdCopy(c.logger.Writer(), c.logger.Writer(), logs)
+	}()
+
+	c.logger.Infof("Started container %s running %s", style.Symbol(ctr.ID[:12]), style.Symbol(imageName))
+	return ctr.ID, nil
+}
+
+// removeAppContainer stops and removes a container started by startAppContainer.
+func (c *Client) removeAppContainer(id string) {
+	if err := c.docker.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{Force: true}); err != nil {
+		c.logger.Warnf("Unable to remove container %s: %s", style.Symbol(id), err)
+	}
+}
+
+// exposedPorts returns the ports exposed by an image of the daemon, such as "8080/tcp".
+func (c *Client) exposedPorts(ctx context.Context, imageName string) ([]string, error) {
+	inspect, _, err := c.docker.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting image %s", style.Symbol(imageName))
+	}
+	if inspect.Config == nil {
+		return nil, nil
+	}
+
+	var ports []string
+	for port := range inspect.Config.ExposedPorts {
+		ports = append(ports, string(port))
+	}
+	sort.Strings(ports)
+	return ports, nil
+}
+
+// parsePorts parses ports to publish, in the form accepted by 'docker run --publish'.
+func parsePorts(ports []string) (nat.PortSet, nat.PortMap, error) {
+	var specs []string
+	for _, port := range ports {
+		if !strings.Contains(port, ":") {
+			port = fmt.Sprintf("%s:%s", strings.Split(port, "/")[0], port)
+		}
+		specs = append(specs, port)
+	}
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(specs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing ports")
+	}
+	return exposedPorts, portBindings, nil
+}
+
+func envList(env map[string]string) []string {
+	var list []string
+	for key, value := range env {
+		list = append(list, key+"="+value)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -3,10 +3,12 @@ package client
 import (
 	"context"
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -17,7 +19,9 @@ import (
 	"github.com/buildpacks/imgutil/remote"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/docker/docker/api/types"
+	dockerClient "github.com/docker/docker/client"
 	"github.com/docker/docker/volume/mounts"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	ignore "github.com/sabhiram/go-gitignore"
@@ -26,16 +30,18 @@ import (
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/cache"
 	internalConfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/container"
 	pname "github.com/buildpacks/pack/internal/name"
 	"github.com/buildpacks/pack/internal/stack"
-	"github.com/buildpacks/pack/internal/stringset"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/termui"
 	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/buildevents"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/phase"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
 )
@@ -46,6 +52,12 @@ const (
 	minLifecycleVersionSupportingImage   = "0.7.5"
 )
 
+// workspaceNamePattern matches the names the daemon accepts for volumes.
+var workspaceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// cacheNamePattern matches the names of shared caches, which are part of the names of their volumes.
+var cacheNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
 // LifecycleExecutor executes the lifecycle which satisfies the Cloud Native Buildpacks Lifecycle specification.
 // Implementations of the Lifecycle must execute the following phases by calling the
 // phase-specific lifecycle binary in order:
@@ -85,6 +97,11 @@ type BuildOptions struct {
 
 	// AppPath is the path to application bits.
 	// If unset it defaults to current working directory.
+	// It may be a directory, a zip file, or a tar file, optionally gzip compressed.
+	// Set it to "-" to stream the application as a tar from standard input.
+	// It may also be the URL of a git repository, e.g. https://github.com/org/repo.git#ref, in which case the
+	// repository is cloned into a temporary directory and the optional ref names the branch, tag or commit to build.
+	// Paths of a directory that match the gitignore-style patterns in its .packignore file are not copied.
 	AppPath string
 
 	// Specify the run image the Image will be
@@ -93,6 +110,11 @@ type BuildOptions struct {
 
 	// Address of docker daemon exposed to build container
 	// e.g. tcp://example.com:1234, unix:///run/user/1000/podman/podman.sock
+	// When empty, the podman socket detected when DOCKER_HOST is not set and there is no docker socket is exposed, or
+	// else the standard docker socket. Set "inherit" to expose a unix DOCKER_HOST, whose path on the host must also be
+	// the path of the socket where the daemon runs the build containers.
+	// When no daemon socket can be exposed to the build containers, such as with a remote podman service, set
+	// Publish, as only builds to the daemon need the build containers to access it.
 	DockerHost string
 
 	// Used to determine a run-image mirror if Run Image is empty.
@@ -130,14 +152,18 @@ type BuildOptions struct {
 	// List of buildpack images or archives to add to a builder.
 	// These buildpacks may overwrite those on the builder if they
 	// share both an ID and Version with a buildpack on the builder.
+	// Buildpacks in git repositories are given as git+<url>[#<branch, tag or commit>].
 	Buildpacks []string
 
-	// Additional image tags to push to, each will contain contents identical to Image
+	// Additional image tags to push to, each will contain contents identical to Image.
+	// When publishing, all tags are pushed by the exporter and must be in the registry of Image.
 	AdditionalTags []string
 
 	// Configure the proxy environment variables,
 	// These variables will only be set in the build image
 	// and will not be used if proxy env vars are already set.
+	// If nil, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables of the host are forwarded;
+	// set it to an empty ProxyConfig to forward nothing.
 	ProxyConfig *ProxyConfig
 
 	// Configure network and volume mounts for the build containers.
@@ -179,6 +205,113 @@ type BuildOptions struct {
 
 	// Desired create time in the output image config
 	CreationTime *time.Time
+
+	// Media types of the published image. ImageFormatOCI requires Publish to be true.
+	ImageFormat ImageFormat
+
+	// Labels to set on the app image, in addition to those set by the lifecycle.
+	// Labels in the io.buildpacks namespace are reserved. The number and size of the labels are limited, so that
+	// the config of the image stays within the limits of daemons and registries.
+	Labels map[string]string
+
+	// Lifecycle phases to skip, because an external system has already performed them.
+	// Only "analyze" and "restore" may be skipped, and "analyze" only by untrusted builders with Platform API < 0.7.
+	SkipPhases []string
+
+	// Keep the workspace volume of the app between builds of the image, and only copy the files which differ from
+	// the workspace left by the previous build. Files the buildpacks changed are copied again, and the volume is
+	// recreated when files were added or removed since, so that every build starts from the app.
+	// The app must be a directory.
+	IncrementalSync bool
+
+	// Name of the workspace volume kept between builds, instead of a volume derived from the image and builder,
+	// so that builds of the same project reuse it. Implies IncrementalSync. Builds sharing a workspace must not run
+	// concurrently.
+	WorkspaceName string
+
+	// Name of the build cache, shared by the builds of every image with the same cache name, stack and buildpacks,
+	// instead of a cache derived from the image, such as the dependency cache of the services of a monorepo. Requires
+	// a volume build cache. Builds sharing a cache must not run concurrently.
+	CacheName string
+
+	// Key the cache and workspace volumes by the image alone, as pack used to, instead of also by the stack and
+	// buildpacks of the builder. Layers cached by other builders or by previous versions of the buildpacks are then
+	// restored, which the buildpacks must tolerate.
+	LegacyCacheKey bool
+
+	// Do not cache the layers of the image for the next build exporting to the daemon, such as for one-off builds.
+	// Every layer is then exported again. Exclusive with a launch cache named in Cache.
+	NoLaunchCache bool
+
+	// Directory to write the resource usage of the container of each lifecycle phase to, as <phase>.stats.jsonl.
+	ProfileDir string
+
+	// Keychains resolving the credentials of specific image references, such as a PreviousImage in a registry
+	// requiring other credentials than Image, instead of the keychain of the client.
+	// The lifecycle receives credentials per registry, so references in the same registry must resolve to the
+	// same credentials.
+	ReferenceKeychains map[string]authn.Keychain
+
+	// Value of CNB_REGISTRY_AUTH to pass as is to the lifecycle when publishing, a JSON object mapping registries to
+	// Authorization headers, such as {"registry.example.com": "Bearer some-token"}. Credentials are then not resolved
+	// from the keychains, so that they can be injected in headless environments.
+	RegistryAuth string
+
+	// Additional images to export from the same build, mapping the name of each image to its default process type.
+	// Only the exporter runs again for each image, so they differ from Image only in their default process. The
+	// options applied to Image after it is exported, such as Labels, apply to each of them too.
+	ProcessImages map[string]string
+
+	// Import the image, and its additional tags, into containerd after building it, for clusters without a registry.
+	// Requires Publish to be false, since the image is streamed from the daemon.
+	Containerd *ContainerdExportOptions
+
+	// Adapt the app image to run as an AWS Lambda function, and check that it meets the requirements of Lambda,
+	// failing the build with a LambdaError listing those it does not meet. Requires ImageFormat not to be OCI.
+	Lambda *LambdaOptions
+
+	// Describe the buildpack, or other origin, of each layer of the app image in the history of its config, as shown
+	// by `docker history`. Rewriting the config changes the digest of the image.
+	LayerHistory bool
+
+	// Layers of files to append to the app image, and to its process images, after they are exported, in order.
+	InjectedLayers []InjectedLayer
+
+	// What to do when the builder is not built for the architecture of the daemon. Defaults to EmulationWarn.
+	Emulation EmulationPolicy
+
+	// Keep the volumes of the build when it fails, recording them as a state which ExportBuildState can archive
+	// to reproduce the failure elsewhere.
+	KeepFailedState bool
+
+	// Record the builder, buildpacks, and digests of the app and environment of the build in the BuildInputsLabel
+	// of the image, so that VerifyImage can rebuild it.
+	RecordInputs bool
+
+	// Remove the least recently used cache volumes of the daemon exceeding the limits after the build succeeds,
+	// keeping those of the build. See PruneCacheVolumes.
+	CacheLimits *CacheLimits
+
+	// Override the analysis of the previous image made by the analyzer. Every phase runs in its own container
+	// when set, as the creator does not let the analysis be changed. Requires a Linux builder with Platform API
+	// 0.7 or later, and the analyzer not to be skipped.
+	Analyzed AnalyzedOptions
+
+	// Stream receiving the state and output of the lifecycle phases, and the progress of the build, as
+	// coalesced and size-bounded events. Build does not close it.
+	Events *buildevents.Stream
+
+	// Function called with the spec of each lifecycle phase before its container is created, for dry runs and
+	// tools auditing the containers of the build.
+	OnPhase func(phase.Spec)
+
+	// Exclude the layers whose metadata matches any of the filters, in the form <key>=<value>, from the app
+	// image, while keeping them in the cache. Every phase runs in its own container when set.
+	ExcludeLayers []string
+
+	// Registries to access over plain HTTP, in addition to the insecure registries of the client, when fetching
+	// the run image and from the lifecycle.
+	InsecureRegistries []string
 }
 
 // ProxyConfig specifies proxy setting to be set as environment variables in a container.
@@ -202,11 +335,15 @@ type ContainerConfig struct {
 	// For more about volume mounts, and their permissions see:
 	// https://docs.docker.com/storage/volumes/
 	//
-	// It is strongly recommended you do not override any of the
-	// paths with volume mounts at the following locations:
+	// An optional third segment holds comma separated mount options, e.g. /path/in/host:/path/in/container:rw.
+	//
+	// Volumes may not be mounted over, or over a parent of, any of the
+	// following locations:
 	// - /cnb
 	// - /layers
-	// - anything below /cnb/**
+	// - /workspace (or the location set by BuildOptions.Workspace)
+	//
+	// It is strongly recommended you do not mount volumes below these locations either.
 	Volumes []string
 }
 
@@ -223,17 +360,119 @@ var IsSuggestedBuilderFunc = func(b string) bool {
 // It then invokes the lifecycle to build an app image.
 // If any configuration is deemed invalid, or if any lifecycle phases fail,
 // an error will be returned and no image produced.
-func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+// If ctx is done before the lifecycle completes, the error is a *BuildCancelledError recording why.
+// Failures of detection, of the build, of the export and of the connection to the daemon are returned as a *FailureError.
+func (c *Client) Build(ctx context.Context, opts BuildOptions) (err error) {
+	defer func() {
+		err = classifyBuildError(err)
+	}()
+
 	imageRef, err := c.parseTagReference(opts.Image)
 	if err != nil {
 		return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
 	}
 
+	additionalTagRefs, err := c.parseAdditionalTags(imageRef, opts.AdditionalTags, opts.Publish)
+	if err != nil {
+		return err
+	}
+
+	exportedImages, err := c.parseExportedImages(imageRef, opts.AdditionalTags, additionalTagRefs, opts.ProcessImages)
+	if err != nil {
+		return err
+	}
+
+	for key := range opts.Labels {
+		if strings.HasPrefix(key, reservedLabelPrefix) {
+			return errors.Errorf("label %s is reserved: labels in the %s namespace are set by buildpacks", style.Symbol(key), style.Symbol(reservedLabelPrefix))
+		}
+	}
+	if err := validateLabels(opts.Labels); err != nil {
+		return err
+	}
+
+	if opts.ImageFormat == ImageFormatOCI && !opts.Publish {
+		return errors.Errorf("image format %s is only supported when publishing", style.Symbol(string(opts.ImageFormat)))
+	}
+
+	if opts.Containerd != nil && opts.Publish {
+		return errors.New("exporting to containerd is only supported when building in the daemon")
+	}
+
+	if opts.RegistryAuth != "" {
+		var registryAuth map[string]string
+		if err := json.Unmarshal([]byte(opts.RegistryAuth), &registryAuth); err != nil {
+			return errors.Wrap(err, "registry auth must be a JSON object mapping registries to Authorization headers")
+		}
+	}
+
+	if opts.Lambda != nil && opts.ImageFormat == ImageFormatOCI {
+		return errors.Errorf("image format %s is not supported by AWS Lambda", style.Symbol(string(opts.ImageFormat)))
+	}
+
+	for _, injectedLayer := range opts.InjectedLayers {
+		if err := injectedLayer.validate(); err != nil {
+			return err
+		}
+	}
+
+	overrideAnalyzed, err := opts.Analyzed.override()
+	if err != nil {
+		return err
+	}
+
+	if opts.WorkspaceName != "" && !workspaceNamePattern.MatchString(opts.WorkspaceName) {
+		return errors.Errorf("invalid workspace name %s: only %s are allowed", style.Symbol(opts.WorkspaceName), style.Symbol(workspaceNamePattern.String()))
+	}
+
+	if opts.CacheName != "" {
+		if !cacheNamePattern.MatchString(opts.CacheName) {
+			return errors.Errorf("invalid cache name %s: only %s are allowed", style.Symbol(opts.CacheName), style.Symbol(cacheNamePattern.String()))
+		}
+		if opts.CacheImage != "" || opts.Cache.Build.Format != cache.CacheVolume || opts.Cache.Build.Source != "" {
+			return errors.New("a cache name requires a volume build cache without a name")
+		}
+	}
+
+	if opts.NoLaunchCache && (opts.Cache.Launch.Format != cache.CacheVolume || opts.Cache.Launch.Source != "") {
+		return errors.New("a launch cache cannot be given when launch caching is disabled")
+	}
+
+	var excludeLayers []build.LayerFilter
+	for _, filter := range opts.ExcludeLayers {
+		layerFilter, err := build.ParseLayerFilter(filter)
+		if err != nil {
+			return err
+		}
+		excludeLayers = append(excludeLayers, layerFilter)
+	}
+
+	gitSource := isGitURL(opts.AppPath)
+	if gitSource {
+		cloneDir, err := c.cloneGitSource(ctx, opts.AppPath)
+		if err != nil {
+			return errors.Wrapf(err, "invalid app path '%s'", opts.AppPath)
+		}
+		defer os.RemoveAll(cloneDir)
+
+		opts.ProjectDescriptor, opts.ProjectDescriptorBaseDir, err = readClonedProjectDescriptor(cloneDir, opts.ProjectDescriptor, opts.ProjectDescriptorBaseDir)
+		if err != nil {
+			return errors.Wrapf(err, "reading project descriptor of '%s'", opts.AppPath)
+		}
+		opts.AppPath = cloneDir
+	}
+
 	appPath, err := c.processAppPath(opts.AppPath)
 	if err != nil {
 		return errors.Wrapf(err, "invalid app path '%s'", opts.AppPath)
 	}
 
+	removeGitBuildpacks, err := c.cloneGitBuildpacks(ctx, &opts)
+	if err != nil {
+		return err
+	}
+	defer removeGitBuildpacks()
+
 	proxyConfig := c.processProxyConfig(opts.ProxyConfig)
 
 	builderRef, err := c.processBuilderName(opts.Builder)
@@ -251,8 +490,17 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
 	}
 
+	if err := c.checkBuilderArchitecture(ctx, rawBuilderImage, opts.Emulation); err != nil {
+		return err
+	}
+
+	builderID, err := rawBuilderImage.Identifier()
+	if err != nil {
+		return errors.Wrapf(err, "reading identifier of builder %s", style.Symbol(opts.Builder))
+	}
+
 	runImageName := c.resolveRunImage(opts.RunImage, imageRef.Context().RegistryStr(), builderRef.Context().RegistryStr(), bldr.Stack(), opts.AdditionalMirrors, opts.Publish)
-	runImage, err := c.validateRunImage(ctx, runImageName, opts.PullPolicy, opts.Publish, bldr.StackID)
+	runImage, err := c.validateRunImage(ctx, runImageName, opts.PullPolicy, opts.Publish, opts.InsecureRegistries, bldr.StackID)
 	if err != nil {
 		return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
 	}
@@ -280,11 +528,25 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		buildEnvs[k] = v
 	}
 
-	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, buildEnvs, order, fetchedBPs)
+	if err := c.envPolicy.check(buildEnvs); err != nil {
+		return err
+	}
+
+	imgOS, err := rawBuilderImage.OS()
+	if err != nil {
+		return errors.Wrapf(err, "getting builder OS")
+	}
+
+	hostGateway := c.mapsHostGateway(ctx, imgOS, opts.ContainerConfig.Network)
+	if _, ok := buildEnvs[build.HostGatewayEnvVar]; !ok && hostGateway {
+		buildEnvs[build.HostGatewayEnvVar] = build.HostGatewayName
+	}
+
+	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, imageRef.Name(), buildEnvs, order, fetchedBPs)
 	if err != nil {
 		return err
 	}
-	defer c.docker.ImageRemove(context.Background(), ephemeralBuilder.Name(), types.ImageRemoveOptions{Force: true})
+	defer c.removeEphemeralBuilder(ephemeralBuilder.Name())
 
 	var builderPlatformAPIs builder.APISet
 	builderPlatformAPIs = append(builderPlatformAPIs, ephemeralBuilder.LifecycleDescriptor().APIs.Platform.Deprecated...)
@@ -296,12 +558,11 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Errorf("Builder %s is incompatible with this version of pack", style.Symbol(opts.Builder))
 	}
 
-	imgOS, err := rawBuilderImage.OS()
-	if err != nil {
-		return errors.Wrapf(err, "getting builder OS")
+	if err := build.CheckFeatureSupport(builderPlatformAPIs, requestedFeatures(opts)...); err != nil {
+		return err
 	}
 
-	processedVolumes, warnings, err := processVolumes(imgOS, opts.ContainerConfig.Volumes)
+	processedVolumes, warnings, err := processVolumes(imgOS, opts.Workspace, opts.ContainerConfig.Volumes)
 	if err != nil {
 		return err
 	}
@@ -310,10 +571,21 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		c.logger.Warn(warning)
 	}
 
-	fileFilter, err := getFileFilter(opts.ProjectDescriptor)
+	fileFilter, err := getFileFilter(opts.ProjectDescriptor, appPath)
 	if err != nil {
 		return err
 	}
+	fileFilter = archive.CombineFilters(fileFilter, excludeBindCache(appPath, opts.Cache.Build))
+
+	if opts.RecordInputs {
+		inputs, err := buildInputs(opts, appPath, fileFilter, builderID.String())
+		if err != nil {
+			return err
+		}
+		if opts.Labels, err = withBuildInputsLabel(opts.Labels, inputs); err != nil {
+			return err
+		}
+	}
 
 	runImageName, err = pname.TranslateRegistry(runImageName, c.registryMirrors, c.logger)
 	if err != nil {
@@ -324,7 +596,10 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	if c.experimental {
 		version := opts.ProjectDescriptor.Project.Version
 		sourceURL := opts.ProjectDescriptor.Project.SourceURL
-		if version != "" || sourceURL != "" {
+		if gitSource {
+			// the commit which was cloned is more precise than the declared version
+			projectMetadata.Source = v02.GitMetadata(appPath)
+		} else if version != "" || sourceURL != "" {
 			projectMetadata.Source = &platform.ProjectSource{
 				Type:     "project",
 				Version:  map[string]interface{}{"declared": version},
@@ -344,6 +619,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		AppPath:            appPath,
 		Image:              imageRef,
 		Builder:            ephemeralBuilder,
+		BuilderID:          parseDigestFromImageID(builderID),
 		LifecycleImage:     ephemeralBuilder.Name(),
 		RunImage:           runImageName,
 		ProjectMetadata:    projectMetadata,
@@ -351,13 +627,14 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		Publish:            opts.Publish,
 		TrustBuilder:       opts.TrustBuilder(opts.Builder),
 		UseCreator:         false,
-		DockerHost:         opts.DockerHost,
+		DockerHost:         c.buildDockerHost(opts.DockerHost),
 		Cache:              opts.Cache,
 		CacheImage:         opts.CacheImage,
 		HTTPProxy:          proxyConfig.HTTPProxy,
 		HTTPSProxy:         proxyConfig.HTTPSProxy,
 		NoProxy:            proxyConfig.NoProxy,
 		Network:            opts.ContainerConfig.Network,
+		HostGateway:        hostGateway,
 		AdditionalTags:     opts.AdditionalTags,
 		Volumes:            processedVolumes,
 		DefaultProcessType: opts.DefaultProcessType,
@@ -369,6 +646,29 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		Termui:             termui.NewTermui(imageRef.Name(), ephemeralBuilder, runImageName),
 		SBOMDestinationDir: opts.SBOMDestinationDir,
 		CreationTime:       opts.CreationTime,
+		SkipPhases:         opts.SkipPhases,
+		IncrementalSync:    opts.IncrementalSync || opts.WorkspaceName != "",
+		WorkspaceName:      opts.WorkspaceName,
+		CacheName:          opts.CacheName,
+		NoLaunchCache:      opts.NoLaunchCache,
+		ProfileDir:         opts.ProfileDir,
+		Keychain:           c.keychain,
+		ReferenceKeychains: opts.ReferenceKeychains,
+		RegistryAuth:       opts.RegistryAuth,
+		ProcessImages:      opts.ProcessImages,
+		KeepFailedState:    opts.KeepFailedState,
+		OverrideAnalyzed:   overrideAnalyzed,
+		Events:             opts.Events,
+		OnPhase:            opts.OnPhase,
+		ExcludeLayers:      excludeLayers,
+		InsecureRegistries: append(append([]string{}, c.insecureRegistries...), opts.InsecureRegistries...),
+		CACertificates:     c.caCertificates,
+		RetryPolicy:        c.retryPolicy,
+	}
+	if !opts.LegacyCacheKey {
+		// switching builders or upgrading buildpacks must not restore layers cached by incompatible buildpacks
+		lifecycleOpts.StackID = ephemeralBuilder.StackID
+		lifecycleOpts.BuildpacksDigest = cache.BuildpacksDigest(ephemeralBuilder.Buildpacks())
 	}
 
 	lifecycleVersion := ephemeralBuilder.LifecycleDescriptor().Info.Version
@@ -376,14 +676,14 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	// have bugs that make using the creator problematic.
 	lifecycleSupportsCreator := !lifecycleVersion.LessThan(semver.MustParse(minLifecycleVersionSupportingCreator))
 
-	if lifecycleSupportsCreator && opts.TrustBuilder(opts.Builder) {
+	if lifecycleSupportsCreator && opts.TrustBuilder(opts.Builder) && overrideAnalyzed != nil {
+		c.logger.Debug("Running each phase in its own container, to override the analysis of the analyzer")
+	} else if lifecycleSupportsCreator && opts.TrustBuilder(opts.Builder) && len(excludeLayers) > 0 {
+		c.logger.Debug("Running each phase in its own container, to exclude layers from the app image")
+	} else if lifecycleSupportsCreator && opts.TrustBuilder(opts.Builder) {
 		lifecycleOpts.UseCreator = true
 		// no need to fetch a lifecycle image, it won't be used
-		if err := c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
-			return errors.Wrap(err, "executing lifecycle")
-		}
-
-		return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
+		return c.executeLifecycle(ctx, opts, lifecycleOpts, exportedImages)
 	}
 
 	if !opts.TrustBuilder(opts.Builder) {
@@ -413,26 +713,279 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
+	return c.executeLifecycle(ctx, opts, lifecycleOpts, exportedImages)
+}
+
+// executeLifecycle runs the lifecycle, then applies the options of the build which the exporter does not support
+// to each exported image.
+func (c *Client) executeLifecycle(ctx context.Context, opts BuildOptions, lifecycleOpts build.LifecycleOptions, exportedImages []exportedImage) error {
 	if err := c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
+		if reason := CancellationReasonFor(ctx); reason != "" {
+			c.logger.Warnf("Build cancelled, reason: %s", style.Symbol(string(reason)))
+			return &BuildCancelledError{Reason: reason, Err: err}
+		}
+		if lifecycleOpts.UseCreator {
+			return errors.Wrap(err, "executing lifecycle")
+		}
 		return errors.Wrap(err, "executing lifecycle. This may be the result of using an untrusted builder")
 	}
 
-	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
+	if opts.CacheLimits != nil {
+		c.pruneCacheAfterBuild(ctx, opts, lifecycleOpts)
+	}
+
+	if opts.SBOMDestinationDir != "" {
+		c.logger.Infof("SBOM files were written to %s", style.Symbol(opts.SBOMDestinationDir))
+	}
+
+	for _, exported := range exportedImages {
+		if err := c.processExportedImage(ctx, opts, exported); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func getFileFilter(descriptor projectTypes.Descriptor) (func(string) bool, error) {
+// exportedImage is an image written by the exporter, with the additional tags it is saved under.
+type exportedImage struct {
+	ref               name.Reference
+	additionalTags    []string
+	additionalTagRefs []name.Reference
+}
+
+// parseExportedImages returns the images the exporter writes: the app image, followed by the process images in the
+// order of their names.
+func (c *Client) parseExportedImages(imageRef name.Reference, additionalTags []string, additionalTagRefs []name.Reference, processImages map[string]string) ([]exportedImage, error) {
+	exportedImages := []exportedImage{{ref: imageRef, additionalTags: additionalTags, additionalTagRefs: additionalTagRefs}}
+
+	imageNames := make([]string, 0, len(processImages))
+	for imageName := range processImages {
+		imageNames = append(imageNames, imageName)
+	}
+	sort.Strings(imageNames)
+
+	for _, imageName := range imageNames {
+		ref, err := c.parseTagReference(imageName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid process image name '%s'", imageName)
+		}
+		exportedImages = append(exportedImages, exportedImage{ref: ref})
+	}
+	return exportedImages, nil
+}
+
+// processExportedImage applies the options of the build which the exporter does not support to an exported image.
+func (c *Client) processExportedImage(ctx context.Context, opts BuildOptions, exported exportedImage) error {
+	imageRef := exported.ref
+
+	if err := c.guardGeneratedLabels(ctx, imageRef.Name(), exported.additionalTags, opts.Publish); err != nil {
+		return &FailureError{Class: FailureExport, Err: err}
+	}
+
+	if len(opts.InjectedLayers) > 0 {
+		if err := c.injectLayers(ctx, imageRef, exported.additionalTags, opts.InjectedLayers, opts.Publish); err != nil {
+			return &FailureError{Class: FailureExport, Err: err}
+		}
+	}
+
+	if len(opts.Labels) > 0 {
+		if err := c.retryPolicy.Do(ctx, c.logger, "Setting labels", func() error {
+			return c.setLabels(ctx, imageRef, exported.additionalTags, opts.Labels, opts.Publish)
+		}); err != nil {
+			return &FailureError{Class: FailureExport, Err: err}
+		}
+	}
+
+	if opts.Lambda != nil {
+		if err := c.adaptForLambda(ctx, imageRef, exported.additionalTags, *opts.Lambda, opts.Publish); err != nil {
+			return &FailureError{Class: FailureExport, Err: err}
+		}
+	}
+
+	if opts.LayerHistory {
+		if err := c.retryPolicy.Do(ctx, c.logger, "Setting layer history", func() error {
+			return c.setLayerHistory(ctx, imageRef, exported.additionalTags, opts.Publish)
+		}); err != nil {
+			return &FailureError{Class: FailureExport, Err: err}
+		}
+	}
+
+	if opts.ImageFormat == ImageFormatOCI {
+		refs := append([]name.Reference{imageRef}, exported.additionalTagRefs...)
+		if err := c.retryPolicy.Do(ctx, c.logger, "Converting to OCI media types", func() error {
+			return c.convertToOCI(ctx, refs...)
+		}); err != nil {
+			return &FailureError{Class: FailureExport, Err: err}
+		}
+	}
+
+	if err := c.logImageNameAndSha(ctx, opts.Publish, imageRef); err != nil {
+		return err
+	}
+
+	if opts.Containerd != nil {
+		refs := append([]name.Reference{imageRef}, exported.additionalTagRefs...)
+		if err := c.exportToContainerd(ctx, *opts.Containerd, refs...); err != nil {
+			return &FailureError{Class: FailureExport, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// reservedLabelPrefix is the namespace of the labels set by the lifecycle and buildpacks.
+const reservedLabelPrefix = "io.buildpacks."
+
+// setLabels adds labels to the exported app image, and saves it again under its name and additional tags.
+// The lifecycle exporter does not support labels provided by the platform.
+func (c *Client) setLabels(ctx context.Context, imageRef name.Reference, additionalTags []string, labels map[string]string, publish bool) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return errors.Wrapf(err, "fetching image %s", style.Symbol(imageRef.Name()))
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := img.SetLabel(key, labels[key]); err != nil {
+			return errors.Wrapf(err, "setting label %s", style.Symbol(key))
+		}
+		c.logger.Debugf("Setting label %s=%s", key, labels[key])
+	}
+
+	if err := img.Save(additionalTags...); err != nil {
+		return errors.Wrapf(err, "saving image %s", style.Symbol(imageRef.Name()))
+	}
+	return nil
+}
+
+// parseAdditionalTags validates the additional tags before the build starts. When publishing, the exporter
+// pushes every tag in a single operation, which is only possible within the registry of the image.
+func (c *Client) parseAdditionalTags(imageRef name.Reference, tags []string, publish bool) ([]name.Reference, error) {
+	var refs []name.Reference
+	for _, tag := range tags {
+		tagRef, err := c.parseTagReference(tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid additional tag '%s'", tag)
+		}
+		if publish && tagRef.Context().RegistryStr() != imageRef.Context().RegistryStr() {
+			return nil, errors.Errorf("additional tag %s must be in the same registry as image %s when publishing",
+				style.Symbol(tag), style.Symbol(imageRef.Name()))
+		}
+		refs = append(refs, tagRef)
+	}
+	return refs, nil
+}
+
+// requestedFeatures lists the options of opts which require support from the lifecycle.
+func requestedFeatures(opts BuildOptions) []build.Feature {
+	var features []build.Feature
+	if opts.SBOMDestinationDir != "" {
+		features = append(features, build.FeatureSBOMOutput)
+	}
+	if opts.CreationTime != nil {
+		features = append(features, build.FeatureCreationTime)
+	}
+	if len(opts.ProcessImages) > 0 {
+		features = append(features, build.FeatureProcessImage)
+	}
+	if opts.Analyzed != (AnalyzedOptions{}) {
+		features = append(features, build.FeatureAnalyzedOverride)
+	}
+	for _, skipped := range opts.SkipPhases {
+		if skipped == "analyze" {
+			features = append(features, build.FeatureSkipAnalyze)
+		}
+	}
+	return features
+}
+
+// classifyBuildError attributes err to a FailureClass when possible. Lifecycle failures are classified
+// by the exit codes the lifecycle defines since Platform API 0.6.
+func classifyBuildError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		failureErr   *FailureError
+		cancelledErr *BuildCancelledError
+		exitErr      container.ExitError
+	)
+	switch {
+	case errors.As(err, &failureErr), errors.As(err, &cancelledErr):
+		return err
+	case dockerClient.IsErrConnectionFailed(err):
+		return &FailureError{Class: FailureDaemon, Err: err}
+	case errors.As(err, &exitErr):
+		switch code := exitErr.StatusCode; {
+		case code >= 20 && code < 30:
+			return &FailureError{Class: FailureDetect, Err: err}
+		case code >= 50 && code < 60:
+			return &FailureError{Class: FailureBuild, Err: err}
+		case code >= 60 && code < 70:
+			return &FailureError{Class: FailureExport, Err: err}
+		}
+	}
+
+	return err
+}
+
+// excludeBindCache excludes the directory of a bind build cache from the app when it is in the app directory, such as
+// the cache of GitLab CI, which only saves paths in the directory of the project, so that it is not uploaded with the
+// app.
+func excludeBindCache(appPath string, buildCache cache.CacheInfo) func(string) bool {
+	if buildCache.Format != cache.CacheBind {
+		return nil
+	}
+
+	rel, err := filepath.Rel(appPath, buildCache.Source)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	return archive.ExcludeFilter([]string{"/" + filepath.ToSlash(rel)})
+}
+
+func getFileFilter(descriptor projectTypes.Descriptor, appPath string) (func(string) bool, error) {
+	ignoreFileFilter, err := getIgnoreFileFilter(appPath)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(descriptor.Build.Exclude) > 0 {
-		excludes := ignore.CompileIgnoreLines(descriptor.Build.Exclude...)
-		return func(fileName string) bool {
-			return !excludes.MatchesPath(fileName)
-		}, nil
+		return archive.CombineFilters(archive.ExcludeFilter(descriptor.Build.Exclude), ignoreFileFilter), nil
 	}
 	if len(descriptor.Build.Include) > 0 {
 		includes := ignore.CompileIgnoreLines(descriptor.Build.Include...)
-		return includes.MatchesPath, nil
+		return archive.CombineFilters(includes.MatchesPath, ignoreFileFilter), nil
+	}
+
+	return ignoreFileFilter, nil
+}
+
+// getIgnoreFileFilter returns a filter excluding the paths listed in the ignore file of an app directory.
+// Apps provided as archives are not filtered.
+func getIgnoreFileFilter(appPath string) (func(string) bool, error) {
+	if appPath == build.StdinAppPath {
+		return nil, nil
+	}
+
+	fi, err := os.Stat(appPath)
+	if err != nil || !fi.IsDir() {
+		return nil, nil
 	}
 
-	return nil, nil
+	patterns, err := archive.ReadIgnoreFile(appPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return archive.ExcludeFilter(patterns), nil
 }
 
 func lifecycleImageSupported(builderOS string, lifecycleVersion *builder.Version) bool {
@@ -484,11 +1037,11 @@ func (c *Client) getBuilder(img imgutil.Image) (*builder.Builder, error) {
 	return bldr, nil
 }
 
-func (c *Client) validateRunImage(context context.Context, name string, pullPolicy image.PullPolicy, publish bool, expectedStack string) (imgutil.Image, error) {
+func (c *Client) validateRunImage(context context.Context, name string, pullPolicy image.PullPolicy, publish bool, insecureRegistries []string, expectedStack string) (imgutil.Image, error) {
 	if name == "" {
 		return nil, errors.New("run image must be specified")
 	}
-	img, err := c.imageFetcher.Fetch(context, name, image.FetchOptions{Daemon: !publish, PullPolicy: pullPolicy})
+	img, err := c.imageFetcher.Fetch(context, name, image.FetchOptions{Daemon: !publish, PullPolicy: pullPolicy, InsecureRegistries: insecureRegistries})
 	if err != nil {
 		return nil, err
 	}
@@ -511,7 +1064,7 @@ func (c *Client) validateMixins(additionalBuildpacks []buildpack.Buildpack, bldr
 	if err != nil {
 		return err
 	}
-	mixins := assembleAvailableMixins(bldr.Mixins(), runMixins)
+	mixins := stack.AvailableMixins(bldr.Mixins(), runMixins)
 
 	for _, bp := range bps {
 		if err := bp.EnsureStackSupport(bldr.StackID, mixins, true); err != nil {
@@ -521,35 +1074,6 @@ func (c *Client) validateMixins(additionalBuildpacks []buildpack.Buildpack, bldr
 	return nil
 }
 
-// assembleAvailableMixins returns the set of mixins that are common between the two provided sets, plus build-only mixins and run-only mixins.
-func assembleAvailableMixins(buildMixins, runMixins []string) []string {
-	// NOTE: We cannot simply union the two mixin sets, as this could introduce a mixin that is only present on one stack
-	// image but not the other. A buildpack that happens to require the mixin would fail to run properly, even though validation
-	// would pass.
-	//
-	// For example:
-	//
-	//  Incorrect:
-	//    Run image mixins:   [A, B]
-	//    Build image mixins: [A]
-	//    Merged: [A, B]
-	//    Buildpack requires: [A, B]
-	//    Match? Yes
-	//
-	//  Correct:
-	//    Run image mixins:   [A, B]
-	//    Build image mixins: [A]
-	//    Merged: [A]
-	//    Buildpack requires: [A, B]
-	//    Match? No
-
-	buildOnly := stack.FindStageMixins(buildMixins, "build")
-	runOnly := stack.FindStageMixins(runMixins, "run")
-	_, _, common := stringset.Compare(buildMixins, runMixins)
-
-	return append(common, append(buildOnly, runOnly...)...)
-}
-
 // allBuildpacks aggregates all buildpacks declared on the image with additional buildpacks passed in. They are sorted
 // by ID then Version.
 func allBuildpacks(builderImage imgutil.Image, additionalBuildpacks []buildpack.Buildpack) ([]dist.BuildpackDescriptor, error) {
@@ -591,6 +1115,10 @@ func (c *Client) processAppPath(appPath string) (string, error) {
 		err             error
 	)
 
+	if appPath == build.StdinAppPath {
+		return appPath, nil
+	}
+
 	if appPath == "" {
 		if appPath, err = os.Getwd(); err != nil {
 			return "", errors.Wrap(err, "get working dir")
@@ -617,7 +1145,14 @@ func (c *Client) processAppPath(appPath string) (string, error) {
 		}
 
 		if !isZip {
-			return "", errors.New("app path must be a directory or zip")
+			isTar, err := archive.IsTar(resolvedAppPath)
+			if err != nil {
+				return "", errors.Wrap(err, "check tar")
+			}
+
+			if !isTar {
+				return "", errors.New("app path must be a directory, zip or tar")
+			}
 		}
 	}
 
@@ -792,7 +1327,7 @@ func appendBuildpackToOrder(order dist.Order, bpInfo dist.BuildpackInfo) (newOrd
 	return newOrder
 }
 
-func (c *Client) createEphemeralBuilder(rawBuilderImage imgutil.Image, env map[string]string, order dist.Order, buildpacks []buildpack.Buildpack) (*builder.Builder, error) {
+func (c *Client) createEphemeralBuilder(rawBuilderImage imgutil.Image, targetImage string, env map[string]string, order dist.Order, buildpacks []buildpack.Buildpack) (*builder.Builder, error) {
 	origBuilderName := rawBuilderImage.Name()
 	bldr, err := builder.New(rawBuilderImage, fmt.Sprintf("pack.local/builder/%x:latest", randString(10)))
 	if err != nil {
@@ -810,12 +1345,30 @@ func (c *Client) createEphemeralBuilder(rawBuilderImage imgutil.Image, env map[s
 		bldr.SetOrder(order)
 	}
 
+	if err := labelEphemeralImage(bldr.Image(), EphemeralKindBuilder, targetImage); err != nil {
+		return nil, err
+	}
+
 	if err := bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version}); err != nil {
 		return nil, err
 	}
+	c.logger.Debugf("Created ephemeral builder %s from %s", style.Symbol(bldr.Name()), style.Symbol(origBuilderName))
 	return bldr, nil
 }
 
+// removeEphemeralBuilder deletes the builder image created for a single build. Failures are only logged,
+// as they do not affect the outcome of the build. A builder the daemon does not have, or a daemon that cannot be
+// reached, leaves nothing to remove.
+func (c *Client) removeEphemeralBuilder(name string) {
+	if _, err := c.docker.ImageRemove(context.Background(), name, types.ImageRemoveOptions{Force: true}); err != nil {
+		if !dockerClient.IsErrNotFound(err) && !dockerClient.IsErrConnectionFailed(err) {
+			c.logger.Warnf("Unable to remove ephemeral builder %s: %s", style.Symbol(name), err)
+		}
+		return
+	}
+	c.logger.Debugf("Removed ephemeral builder %s", style.Symbol(name))
+}
+
 // Returns a string iwith lowercase a-z, of length n
 func randString(n int) string {
 	b := make([]byte, n)
@@ -829,24 +1382,27 @@ func randString(n int) string {
 	return string(b)
 }
 
-func processVolumes(imgOS string, volumes []string) (processed []string, warnings []string, err error) {
+func processVolumes(imgOS, workspace string, volumes []string) (processed []string, warnings []string, err error) {
 	parserOS := mounts.OSLinux
 	if imgOS == "windows" {
 		parserOS = mounts.OSWindows
 	}
 	parser := mounts.NewParser(parserOS)
+	reservedDirs := reservedMountDirs(imgOS, workspace)
 	for _, v := range volumes {
 		volume, err := parser.ParseMountRaw(v, "")
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "platform volume %q has invalid format", v)
 		}
 
-		sensitiveDirs := []string{"/cnb", "/layers"}
-		if imgOS == "windows" {
-			sensitiveDirs = []string{`c:/cnb`, `c:\cnb`, `c:/layers`, `c:\layers`}
-		}
-		for _, p := range sensitiveDirs {
-			if strings.HasPrefix(strings.ToLower(volume.Spec.Target), p) {
+		target := normalizeMountTarget(imgOS, volume.Spec.Target)
+		for _, dir := range reservedDirs {
+			// mounting onto a reserved directory, or one of its parents, would hide it from the lifecycle
+			if strings.HasPrefix(dir+"/", strings.TrimSuffix(target, "/")+"/") {
+				return nil, nil, errors.Errorf("platform volume %q has invalid target: cannot mount over reserved directory %s", v, style.Symbol(dir))
+			}
+
+			if strings.HasPrefix(target, dir+"/") {
 				warnings = append(warnings, fmt.Sprintf("Mounting to a sensitive directory %s", style.Symbol(volume.Spec.Target)))
 			}
 		}
@@ -856,6 +1412,32 @@ func processVolumes(imgOS string, volumes []string) (processed []string, warning
 	return processed, warnings, nil
 }
 
+// reservedMountDirs returns the directories, normalized by normalizeMountTarget, the lifecycle relies on within the build container.
+func reservedMountDirs(imgOS, workspace string) []string {
+	workspace = strings.Trim(strings.ReplaceAll(workspace, `\`, "/"), "/")
+	if workspace == "" {
+		workspace = "workspace"
+	}
+
+	prefix := ""
+	if imgOS == "windows" {
+		prefix = "c:"
+	}
+
+	var dirs []string
+	for _, dir := range []string{"cnb", "layers", workspace} {
+		dirs = append(dirs, normalizeMountTarget(imgOS, prefix+"/"+dir))
+	}
+	return dirs
+}
+
+func normalizeMountTarget(imgOS, target string) string {
+	if imgOS == "windows" {
+		return strings.ToLower(strings.ReplaceAll(target, `\`, "/"))
+	}
+	return target
+}
+
 func processMode(mode string) string {
 	if mode == "" {
 		return "ro"
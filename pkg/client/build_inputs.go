@@ -0,0 +1,124 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+// BuildInputsLabel records the inputs of the build of an app image, as BuildInputs encoded as JSON, so that
+// VerifyImage can rebuild the image from the same inputs.
+const BuildInputsLabel = "io.buildpacks.pack.build-inputs"
+
+// BuildInputs are the inputs determining the layers of an app image, besides the run image and buildpacks which the
+// lifecycle records.
+type BuildInputs struct {
+	// Builder is the name of the builder the image was built with.
+	Builder string `json:"builder"`
+
+	// BuilderID is the image ID of the builder, which is the digest of its config, the same on every daemon.
+	BuilderID string `json:"builderId"`
+
+	// Buildpacks are the buildpacks given for the build, instead of those of the order of the builder.
+	Buildpacks []string `json:"buildpacks,omitempty"`
+
+	// SourceDigest is the digest of the files of the app, as archived for the build.
+	SourceDigest string `json:"sourceDigest"`
+
+	// EnvDigest is the digest of the environment variables given for the build, which are not recorded as they may
+	// hold secrets. It is empty without environment variables.
+	EnvDigest string `json:"envDigest,omitempty"`
+}
+
+// buildInputs returns the inputs of a build of the app at appPath, whose files are selected by fileFilter.
+func buildInputs(opts BuildOptions, appPath string, fileFilter func(string) bool, builderID string) (BuildInputs, error) {
+	if appPath == build.StdinAppPath {
+		return BuildInputs{}, errors.New("recording the build inputs requires an app path, rather than an app read from stdin")
+	}
+
+	sourceDigest, err := appSourceDigest(appPath, fileFilter)
+	if err != nil {
+		return BuildInputs{}, errors.Wrap(err, "computing digest of app")
+	}
+
+	return BuildInputs{
+		Builder:      opts.Builder,
+		BuilderID:    builderID,
+		Buildpacks:   opts.Buildpacks,
+		SourceDigest: sourceDigest,
+		EnvDigest:    envDigest(opts.Env),
+	}, nil
+}
+
+// withBuildInputsLabel returns labels with the addition of BuildInputsLabel recording inputs.
+func withBuildInputsLabel(labels map[string]string, inputs BuildInputs) (map[string]string, error) {
+	value, err := json.Marshal(inputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling build inputs")
+	}
+
+	result := map[string]string{BuildInputsLabel: string(value)}
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// appSourceDigest returns the digest of the files of the app at appPath, a directory or an archive, as a tar with
+// normalized ownership and times, so that it only depends on the names, modes and contents of the files.
+func appSourceDigest(appPath string, fileFilter func(string) bool) (string, error) {
+	fi, err := os.Stat(appPath)
+	if err != nil {
+		return "", err
+	}
+
+	var reader io.ReadCloser
+	switch {
+	case fi.IsDir():
+		reader = archive.ReadDirAsTar(appPath, "/workspace", 0, 0, -1, true, false, fileFilter)
+	default:
+		isZip, err := archive.IsZip(appPath)
+		if err != nil {
+			return "", err
+		}
+		if isZip {
+			reader = archive.ReadZipAsTar(appPath, "/workspace", 0, 0, -1, true, fileFilter)
+		} else {
+			reader = archive.ReadTarAsTar(appPath, "/workspace", 0, 0, -1, true, fileFilter)
+		}
+	}
+	defer reader.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
+
+// envDigest returns the digest of the sorted environment variables, or an empty string without variables.
+func envDigest(env map[string]string) string {
+	if len(env) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s\x00", key, env[key])
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil))
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/lifecycle/platform"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildInputs(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildInputs", testBuildInputs, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildInputs(t *testing.T, when spec.G, it spec.S) {
+	when("#appSourceDigest", func() {
+		var appDir string
+
+		it.Before(func() {
+			var err error
+			appDir, err = ioutil.TempDir("", "build-inputs-test")
+			h.AssertNil(t, err)
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main"), 0644))
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(appDir, "ignored.log"), []byte("some log"), 0644))
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(appDir))
+		})
+
+		it("depends on the contents of the files, not their times", func() {
+			digest, err := appSourceDigest(appDir, nil)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, strings.HasPrefix(digest, "sha256:"))
+
+			someTime := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+			h.AssertNil(t, os.Chtimes(filepath.Join(appDir, "main.go"), someTime, someTime))
+			touched, err := appSourceDigest(appDir, nil)
+			h.AssertNil(t, err)
+			h.AssertEq(t, touched, digest)
+
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(appDir, "main.go"), []byte("package other"), 0644))
+			changed, err := appSourceDigest(appDir, nil)
+			h.AssertNil(t, err)
+			h.AssertNotEq(t, changed, digest)
+		})
+
+		it("only includes the files selected by the filter", func() {
+			notLogs := func(path string) bool { return !strings.HasSuffix(path, ".log") }
+			digest, err := appSourceDigest(appDir, notLogs)
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(appDir, "ignored.log"), []byte("other log"), 0644))
+			unchanged, err := appSourceDigest(appDir, notLogs)
+			h.AssertNil(t, err)
+			h.AssertEq(t, unchanged, digest)
+		})
+	})
+
+	when("#envDigest", func() {
+		it("is empty without variables", func() {
+			h.AssertEq(t, envDigest(nil), "")
+		})
+
+		it("depends on the names and values of the variables", func() {
+			digest := envDigest(map[string]string{"A": "1", "B": "2"})
+			h.AssertEq(t, envDigest(map[string]string{"B": "2", "A": "1"}), digest)
+			h.AssertNotEq(t, envDigest(map[string]string{"A": "1", "B": "3"}), digest)
+		})
+	})
+
+	when("#withBuildInputsLabel", func() {
+		it("adds the inputs to the labels", func() {
+			inputs := BuildInputs{Builder: "some/builder", BuilderID: "sha256:abc", SourceDigest: "sha256:def"}
+			labels, err := withBuildInputsLabel(map[string]string{"some-label": "some-value"}, inputs)
+			h.AssertNil(t, err)
+			h.AssertEq(t, labels["some-label"], "some-value")
+
+			var recorded BuildInputs
+			h.AssertNil(t, json.Unmarshal([]byte(labels[BuildInputsLabel]), &recorded))
+			h.AssertEq(t, recorded, inputs)
+		})
+	})
+
+	when("#describedLayers", func() {
+		it("numbers the layers sharing a description", func() {
+			configFile := &v1.ConfigFile{}
+			for _, hex := range []string{"a", "b", "c"} {
+				configFile.RootFS.DiffIDs = append(configFile.RootFS.DiffIDs, v1.Hash{Algorithm: "sha256", Hex: strings.Repeat(hex, 64)})
+			}
+			layersMD := platform.LayersMetadata{
+				RunImage: platform.RunImageMetadata{TopLayer: configFile.RootFS.DiffIDs[1].String(), Reference: "some/run@sha256:123"},
+				Launcher: platform.LayerMetadata{SHA: configFile.RootFS.DiffIDs[2].String()},
+			}
+
+			layers := describedLayers(configFile, layersMD)
+			h.AssertEq(t, layers, []describedLayer{
+				{Description: "run image some/run@sha256:123", DiffID: "sha256:" + strings.Repeat("a", 64)},
+				{Description: "run image some/run@sha256:123 (2)", DiffID: "sha256:" + strings.Repeat("b", 64)},
+				{Description: "lifecycle launcher", DiffID: "sha256:" + strings.Repeat("c", 64)},
+			})
+		})
+	})
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// stateArchiveFile is the entry of a build state archive holding the state itself.
+	stateArchiveFile = "state.json"
+
+	// stateLayersDir and stateAppDir are where the volumes of a build state are mounted in the container used to
+	// copy their contents, and the directories of a build state archive holding them.
+	stateLayersDir = "layers"
+	stateAppDir    = "workspace"
+)
+
+// ExportBuildStateOptions is a configuration struct that controls the behavior of ExportBuildState.
+type ExportBuildStateOptions struct {
+	// ID of the state of the failed build, as logged when it failed.
+	ID string
+
+	// Path of the archive to write.
+	Path string
+}
+
+// ImportBuildStateOptions is a configuration struct that controls the behavior of ImportBuildState.
+type ImportBuildStateOptions struct {
+	// Path of an archive written by ExportBuildState.
+	Path string
+
+	// Strategy for fetching the builder of the build, whose image is used to copy the contents of the volumes.
+	PullPolicy image.PullPolicy
+}
+
+// ExportBuildState writes the state of a failed build kept with BuildOptions.KeepFailedState, including the contents
+// of its layers and workspace volumes, to a gzipped tar archive that ImportBuildState restores on another daemon.
+func (c *Client) ExportBuildState(ctx context.Context, opts ExportBuildStateOptions) error {
+	state, err := build.ReadState(opts.ID)
+	if err != nil {
+		return err
+	}
+	if state.OS == "windows" {
+		return errors.New("exporting the state of Windows builds is not supported")
+	}
+
+	contents, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding state")
+	}
+
+	f, err := os.Create(opts.Path)
+	if err != nil {
+		return errors.Wrapf(err, "creating archive %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	if err := tw.WriteHeader(&tar.Header{Name: stateArchiveFile, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(contents); err != nil {
+		return err
+	}
+
+	// the builder, fetched from the daemon for the build, is used as it is the only image known to be there
+	err = c.withVolumesContainer(ctx, state.BuilderID, stateBinds(state), func(containerID string) error {
+		for _, dir := range []string{stateLayersDir, stateAppDir} {
+			rc, _, err := c.docker.CopyFromContainer(ctx, containerID, "/"+dir)
+			if err != nil {
+				return errors.Wrapf(err, "reading volume mounted at %s", style.Symbol("/"+dir))
+			}
+			err = archive.WriteTarToTar(tw, rc, "", state.UID, state.GID, -1, false, nil)
+			rc.Close()
+			if err != nil {
+				return errors.Wrapf(err, "archiving volume mounted at %s", style.Symbol("/"+dir))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gzw.Close(); err != nil {
+		return err
+	}
+
+	c.logger.Infof("Exported the state %s of the failed build of %s to %s", style.Symbol(state.ID), style.Symbol(state.Image), style.Symbol(opts.Path))
+	return nil
+}
+
+// ImportBuildState restores the volumes of a failed build from an archive written by ExportBuildState into new
+// volumes, and records the state of the build under a new ID. The returned state references the new volumes.
+func (c *Client) ImportBuildState(ctx context.Context, opts ImportBuildStateOptions) (build.State, error) {
+	state, err := readArchivedState(opts.Path)
+	if err != nil {
+		return build.State{}, err
+	}
+
+	builderImage, err := c.imageFetcher.Fetch(ctx, state.Builder, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return build.State{}, errors.Wrapf(err, "fetching builder %s", style.Symbol(state.Builder))
+	}
+
+	renamed := map[string]string{
+		state.LayersVolume: paths.FilterReservedNames("pack-layers-" + build.NewStateID()),
+		state.AppVolume:    paths.FilterReservedNames("pack-app-" + build.NewStateID()),
+	}
+	state.ID = build.NewStateID()
+	state.LayersVolume = renamed[state.LayersVolume]
+	state.AppVolume = renamed[state.AppVolume]
+	for i, phase := range state.Phases {
+		state.Phases[i].Binds = renameVolumes(phase.Binds, renamed)
+	}
+
+	f, err := os.Open(filepath.Clean(opts.Path))
+	if err != nil {
+		return build.State{}, errors.Wrapf(err, "opening archive %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	err = c.withVolumesContainer(ctx, builderImage.Name(), stateBinds(state), func(containerID string) error {
+		volumes := archive.ReadTarStreamAsTar(f, "", state.UID, state.GID, -1, false, func(path string) bool {
+			return path != stateArchiveFile
+		})
+		defer volumes.Close()
+
+		if err := c.docker.CopyToContainer(ctx, containerID, "/", volumes, types.CopyToContainerOptions{}); err != nil {
+			return errors.Wrap(err, "copying the contents of the volumes")
+		}
+		return nil
+	})
+	if err != nil {
+		return build.State{}, err
+	}
+
+	if err := build.SaveState(state); err != nil {
+		return build.State{}, errors.Wrap(err, "recording state")
+	}
+
+	c.logger.Infof("Imported the state of the failed build of %s as %s", style.Symbol(state.Image), style.Symbol(state.ID))
+	return state, nil
+}
+
+func readArchivedState(path string) (build.State, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return build.State{}, errors.Wrapf(err, "opening archive %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return build.State{}, errors.Wrapf(err, "reading archive %s", style.Symbol(path))
+	}
+	defer gzr.Close()
+
+	_, contents, err := archive.ReadTarEntry(gzr, stateArchiveFile)
+	if err != nil {
+		return build.State{}, errors.Wrapf(err, "archive %s is not the state of a build", style.Symbol(path))
+	}
+
+	var state build.State
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return build.State{}, errors.Wrapf(err, "parsing state of archive %s", style.Symbol(path))
+	}
+	return state, nil
+}
+
+func stateBinds(state build.State) []string {
+	return []string{
+		state.LayersVolume + ":/" + stateLayersDir,
+		state.AppVolume + ":/" + stateAppDir,
+	}
+}
+
+// renameVolumes replaces the volumes of binds, in the form <volume>:<path>[:<options>], according to renamed.
+func renameVolumes(binds []string, renamed map[string]string) []string {
+	var result []string
+	for _, bind := range binds {
+		parts := strings.SplitN(bind, ":", 2)
+		if name, ok := renamed[parts[0]]; ok && len(parts) == 2 {
+			bind = name + ":" + parts[1]
+		}
+		result = append(result, bind)
+	}
+	return result
+}
+
+// withVolumesContainer calls fn with a container of helperImage, created but never started, mounting volumes as
+// given by binds, so that their contents can be copied from and to the container.
+func (c *Client) withVolumesContainer(ctx context.Context, helperImage string, binds []string, fn func(containerID string) error) error {
+	ctr, err := c.docker.ContainerCreate(ctx,
+		&container.Config{Image: helperImage},
+		&container.HostConfig{Binds: binds},
+		nil, nil, "")
+	if err != nil {
+		return errors.Wrapf(err, "creating container to access volumes %s", style.Symbol(strings.Join(binds, ", ")))
+	}
+	defer c.removeAppContainer(ctr.ID)
+
+	return fn(ctr.ID)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildState(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildState", testBuildState, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildState(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *Client
+		out     bytes.Buffer
+		tmpDir  string
+	)
+
+	it.Before(func() {
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)))
+		h.AssertNil(t, err)
+
+		tmpDir, err = ioutil.TempDir("", "pack.build-state.test.")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ExportBuildState", func() {
+		it("errors when there is no state with the ID", func() {
+			err := subject.ExportBuildState(context.TODO(), ExportBuildStateOptions{ID: "missing-state", Path: filepath.Join(tmpDir, "state.tgz")})
+			h.AssertError(t, err, "no state of a failed build with ID 'missing-state'")
+		})
+	})
+
+	when("#ImportBuildState", func() {
+		it("errors when the archive is not the state of a build", func() {
+			path := filepath.Join(tmpDir, "other.tgz")
+			h.AssertNil(t, archive.CreateSingleFileTar(path, "some-file", "some-content"))
+
+			_, err := subject.ImportBuildState(context.TODO(), ImportBuildStateOptions{Path: path})
+			h.AssertError(t, err, "reading archive")
+		})
+	})
+
+	when("#renameVolumes", func() {
+		it("renames the volumes of the binds", func() {
+			binds := renameVolumes(
+				[]string{"pack-layers-old:/layers", "pack-app-old:/workspace", "/host/dir:/platform/bindings:ro"},
+				map[string]string{"pack-layers-old": "pack-layers-new", "pack-app-old": "pack-app-new"},
+			)
+			h.AssertEq(t, binds, []string{"pack-layers-new:/layers", "pack-app-new:/workspace", "/host/dir:/platform/bindings:ro"})
+		})
+	})
+}
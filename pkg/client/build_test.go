@@ -34,7 +34,9 @@ import (
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/cache"
 	cfg "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/container"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	rg "github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/internal/style"
@@ -306,10 +308,43 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 				})
 			}
 
+			for fileDesc, createArchive := range map[string]func(t *testing.T, srcDir, tarDir string, mode int64) string{
+				"tar":    h.CreateTAR,
+				"tar.gz": h.CreateTGZ,
+			} {
+				fileDesc := fileDesc
+				createArchive := createArchive
+
+				it(fmt.Sprintf("supports %s files", fileDesc), func() {
+					appPath := createArchive(t, filepath.Join("testdata", "some-app"), ".", -1)
+					defer os.Remove(appPath)
+
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						AppPath: appPath,
+					})
+					h.AssertNil(t, err)
+
+					resolvedAppPath, err := filepath.EvalSymlinks(appPath)
+					h.AssertNil(t, err)
+					h.AssertEq(t, fakeLifecycle.Opts.AppPath, resolvedAppPath)
+				})
+			}
+
+			it("streams the app from stdin", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					AppPath: "-",
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.AppPath, "-")
+			})
+
 			for fileDesc, testData := range map[string][]string{
 				"non-existent": {"not/exist/path", "does not exist"},
-				"empty":        {filepath.Join("testdata", "empty-file"), "app path must be a directory or zip"},
-				"non-zip":      {filepath.Join("testdata", "non-zip-file"), "app path must be a directory or zip"},
+				"empty":        {filepath.Join("testdata", "empty-file"), "app path must be a directory, zip or tar"},
+				"non-zip":      {filepath.Join("testdata", "non-zip-file"), "app path must be a directory, zip or tar"},
 			} {
 				fileDesc := fileDesc
 				appPath := testData[0]
@@ -326,6 +361,48 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 				})
 			}
 
+			when("the app dir contains a .packignore file", func() {
+				var appDir string
+
+				it.Before(func() {
+					var err error
+					appDir, err = ioutil.TempDir("", "packignore-app")
+					h.AssertNil(t, err)
+					h.AssertNil(t, ioutil.WriteFile(filepath.Join(appDir, ".packignore"), []byte("node_modules\n*.log\n"), 0600))
+				})
+
+				it.After(func() {
+					h.AssertNil(t, os.RemoveAll(appDir))
+				})
+
+				it("excludes the ignored paths", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						AppPath: appDir,
+					}))
+
+					h.AssertEq(t, fakeLifecycle.Opts.FileFilter("node_modules"), false)
+					h.AssertEq(t, fakeLifecycle.Opts.FileFilter("debug.log"), false)
+					h.AssertEq(t, fakeLifecycle.Opts.FileFilter("index.js"), true)
+				})
+
+				it("combines the ignore file with the project descriptor excludes", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						AppPath: appDir,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{Exclude: []string{"tmp"}},
+						},
+					}))
+
+					h.AssertEq(t, fakeLifecycle.Opts.FileFilter("node_modules"), false)
+					h.AssertEq(t, fakeLifecycle.Opts.FileFilter("tmp"), false)
+					h.AssertEq(t, fakeLifecycle.Opts.FileFilter("index.js"), true)
+				})
+			})
+
 			it("resolves the absolute path", func() {
 				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
 					Image:   "some/app",
@@ -1632,6 +1709,27 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 				h.AssertTarFileContents(t, layerTar, "/platform/env/key1", `value1`)
 				h.AssertTarFileContents(t, layerTar, "/platform/env/key2", `value2`)
 			})
+
+			it("should not override a provided address of the host", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Env:     map[string]string{"PACK_HOST_ADDRESS": "192.168.0.1"},
+				}))
+				layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/env/PACK_HOST_ADDRESS")
+				h.AssertNil(t, err)
+				h.AssertTarFileContents(t, layerTar, "/platform/env/PACK_HOST_ADDRESS", `192.168.0.1`)
+			})
+
+			it("should fail when a variable is not allowed by the environment policy", func() {
+				subject.envPolicy = EnvPolicy{Deny: []string{"CNB_*"}}
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Env:     map[string]string{"key1": "value1", "CNB_PLATFORM_API": "0.1"},
+				})
+				h.AssertError(t, err, "environment variable(s) not allowed by the environment policy: CNB_PLATFORM_API")
+			})
 		})
 
 		when("Publish option", func() {
@@ -1883,6 +1981,282 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the lifecycle fails", func() {
+			for statusCode, class := range map[int64]FailureClass{
+				20: FailureDetect,
+				51: FailureBuild,
+				62: FailureExport,
+			} {
+				statusCode := statusCode
+				class := class
+
+				it(fmt.Sprintf("classifies exit code %d as a %s failure", statusCode, class), func() {
+					subject.lifecycleExecutor = &executeFailsLifecycle{Err: container.ExitError{StatusCode: statusCode}}
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+					})
+
+					var failureErr *FailureError
+					h.AssertTrue(t, errors.As(err, &failureErr))
+					h.AssertEq(t, failureErr.Class, class)
+					h.AssertError(t, err, fmt.Sprintf("failed with status code: %d", statusCode))
+				})
+			}
+
+			it("does not classify other exit codes", func() {
+				subject.lifecycleExecutor = &executeFailsLifecycle{Err: container.ExitError{StatusCode: 1}}
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				})
+
+				var failureErr *FailureError
+				h.AssertFalse(t, errors.As(err, &failureErr))
+			})
+		})
+
+		when("AdditionalTags option", func() {
+			it("passes the tags to the lifecycle", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:          "example.com/some/repo:tag",
+					Builder:        defaultBuilderName,
+					AdditionalTags: []string{"example.com/some/repo:other-tag", "example.com/other/repo"},
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.AdditionalTags, []string{"example.com/some/repo:other-tag", "example.com/other/repo"})
+			})
+
+			it("fails for an invalid tag", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:          "some/app",
+					Builder:        defaultBuilderName,
+					AdditionalTags: []string{"some/app@sha256:invalid"},
+				})
+				h.AssertError(t, err, "invalid additional tag 'some/app@sha256:invalid'")
+			})
+
+			it("fails when publishing to another registry", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:          "example.com/some/repo",
+					Builder:        defaultBuilderName,
+					Publish:        true,
+					AdditionalTags: []string{"other.com/some/repo"},
+				})
+				h.AssertError(t, err, "additional tag 'other.com/some/repo' must be in the same registry as image 'example.com/some/repo:latest' when publishing")
+			})
+
+			it("allows tags in other repositories of the daemon", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:          "example.com/some/repo",
+					Builder:        defaultBuilderName,
+					AdditionalTags: []string{"other.com/some/repo"},
+				}))
+			})
+		})
+
+		when("Labels option", func() {
+			var builtImage *fakes.Image
+
+			it.Before(func() {
+				builtImage = fakes.NewImage("index.docker.io/some/app:latest", "", nil)
+				fakeImageFetcher.LocalImages[builtImage.Name()] = builtImage
+			})
+
+			it.After(func() {
+				h.AssertNilE(t, builtImage.Cleanup())
+			})
+
+			it("sets the labels on the app image and its additional tags", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:          "some/app",
+					Builder:        defaultBuilderName,
+					AdditionalTags: []string{"some/app:other-tag"},
+					Labels:         map[string]string{"org.opencontainers.image.revision": "abc123", "team": "some-team"},
+				}))
+
+				revision, err := builtImage.Label("org.opencontainers.image.revision")
+				h.AssertNil(t, err)
+				h.AssertEq(t, revision, "abc123")
+				team, err := builtImage.Label("team")
+				h.AssertNil(t, err)
+				h.AssertEq(t, team, "some-team")
+				h.AssertEq(t, builtImage.IsSaved(), true)
+				h.AssertContains(t, strings.Join(builtImage.SavedNames(), " "), "some/app:other-tag")
+			})
+
+			it("sets the labels when the builder is trusted", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					Labels:       map[string]string{"team": "some-team"},
+				}))
+
+				h.AssertEq(t, fakeLifecycle.Opts.UseCreator, true)
+				team, err := builtImage.Label("team")
+				h.AssertNil(t, err)
+				h.AssertEq(t, team, "some-team")
+			})
+
+			it("sets the labels on the process images", func() {
+				processImage := fakes.NewImage("index.docker.io/some/app-worker:latest", "", nil)
+				fakeImageFetcher.LocalImages[processImage.Name()] = processImage
+				defer func() { h.AssertNilE(t, processImage.Cleanup()) }()
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:         "some/app",
+					Builder:       defaultBuilderName,
+					ProcessImages: map[string]string{"some/app-worker": "worker"},
+					Labels:        map[string]string{"team": "some-team"},
+				}))
+
+				for _, img := range []*fakes.Image{builtImage, processImage} {
+					team, err := img.Label("team")
+					h.AssertNil(t, err)
+					h.AssertEq(t, team, "some-team")
+					h.AssertEq(t, img.IsSaved(), true)
+				}
+			})
+
+			it("rejects labels in the io.buildpacks namespace", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Labels:  map[string]string{"io.buildpacks.build.metadata": "{}"},
+				})
+				h.AssertError(t, err, "label 'io.buildpacks.build.metadata' is reserved")
+				h.AssertEq(t, builtImage.IsSaved(), false)
+			})
+		})
+
+		when("ImageFormat option", func() {
+			it("requires publishing for the OCI format", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:       "some/app",
+					Builder:     defaultBuilderName,
+					ImageFormat: ImageFormatOCI,
+				})
+				h.AssertError(t, err, "image format 'oci' is only supported when publishing")
+			})
+		})
+
+		when("WorkspaceName option", func() {
+			it("keeps the named workspace between builds", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:         "some/app",
+					Builder:       defaultBuilderName,
+					WorkspaceName: "some-project",
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.WorkspaceName, "some-project")
+				h.AssertEq(t, fakeLifecycle.Opts.IncrementalSync, true)
+			})
+
+			it("rejects names which are not valid volume names", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:         "some/app",
+					Builder:       defaultBuilderName,
+					WorkspaceName: "some/project",
+				})
+				h.AssertError(t, err, "invalid workspace name 'some/project'")
+			})
+		})
+
+		when("CacheName option", func() {
+			it("shares the named build cache", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:     "some/app",
+					Builder:   defaultBuilderName,
+					CacheName: "some-monorepo",
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.CacheName, "some-monorepo")
+			})
+
+			it("rejects invalid names", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:     "some/app",
+					Builder:   defaultBuilderName,
+					CacheName: "Some/Monorepo",
+				})
+				h.AssertError(t, err, "invalid cache name 'Some/Monorepo'")
+			})
+
+			it("requires a volume build cache without a name", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:     "some/app",
+					Builder:   defaultBuilderName,
+					CacheName: "some-monorepo",
+					Cache:     cache.CacheOpts{Build: cache.CacheInfo{Format: cache.CacheVolume, Source: "some-volume"}},
+				})
+				h.AssertError(t, err, "a cache name requires a volume build cache without a name")
+			})
+		})
+
+		when("LegacyCacheKey option", func() {
+			it("keys the cache volumes by the stack and buildpacks of the builder by default", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.StackID, defaultBuilderStackID)
+				h.AssertNotEq(t, fakeLifecycle.Opts.BuildpacksDigest, "")
+			})
+
+			it("keys the cache volumes by the image alone", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:          "some/app",
+					Builder:        defaultBuilderName,
+					LegacyCacheKey: true,
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.StackID, "")
+				h.AssertEq(t, fakeLifecycle.Opts.BuildpacksDigest, "")
+			})
+		})
+
+		when("NoLaunchCache option", func() {
+			it("disables the launch cache", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:         "some/app",
+					Builder:       defaultBuilderName,
+					NoLaunchCache: true,
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.NoLaunchCache, true)
+			})
+
+			it("rejects a launch cache", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:         "some/app",
+					Builder:       defaultBuilderName,
+					NoLaunchCache: true,
+					Cache:         cache.CacheOpts{Launch: cache.CacheInfo{Format: cache.CacheVolume, Source: "some-volume"}},
+				})
+				h.AssertError(t, err, "a launch cache cannot be given when launch caching is disabled")
+			})
+		})
+
+		when("RegistryAuth option", func() {
+			it("requires a JSON object", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:        "example.com/some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					RegistryAuth: "Bearer some-token",
+				})
+				h.AssertError(t, err, "registry auth must be a JSON object mapping registries to Authorization headers")
+			})
+		})
+
+		when("Containerd option", func() {
+			it("requires building in the daemon", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "example.com/some/app",
+					Builder:    defaultBuilderName,
+					Publish:    true,
+					Containerd: &ContainerdExportOptions{},
+				})
+				h.AssertError(t, err, "exporting to containerd is only supported when building in the daemon")
+			})
+		})
+
 		when("ProxyConfig option", func() {
 			when("ProxyConfig is nil", func() {
 				it.Before(func() {
@@ -2280,10 +2654,9 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 					for _, p := range []string{
 						"/cnb/buildpacks",
 						"/cnb/buildpacks/nested",
-						"/cnb",
 						"/cnb/nested",
-						"/layers",
 						"/layers/nested",
+						"/workspace/nested",
 					} {
 						p := p
 						it(fmt.Sprintf("warns when mounting to '%s'", p), func() {
@@ -2300,6 +2673,72 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 						})
 					}
 				})
+
+				when("mounting over a reserved dir", func() {
+					for _, test := range []struct {
+						target   string
+						reserved string
+					}{
+						{"/cnb", "/cnb"},
+						{"/cnb/", "/cnb"},
+						{"/layers", "/layers"},
+						{"/workspace", "/workspace"},
+					} {
+						test := test
+						it(fmt.Sprintf("errors when mounting to '%s'", test.target), func() {
+							volume := fmt.Sprintf("/tmp/path:%s", test.target)
+							err := subject.Build(context.TODO(), BuildOptions{
+								Image:   "some/app",
+								Builder: defaultBuilderName,
+								ContainerConfig: ContainerConfig{
+									Volumes: []string{volume},
+								},
+							})
+
+							h.AssertError(t, err, fmt.Sprintf("platform volume %q has invalid target: cannot mount over reserved directory '%s'", volume, test.reserved))
+						})
+					}
+
+					it("errors when mounting to a custom workspace", func() {
+						err := subject.Build(context.TODO(), BuildOptions{
+							Image:     "some/app",
+							Builder:   defaultBuilderName,
+							Workspace: "app",
+							ContainerConfig: ContainerConfig{
+								Volumes: []string{"/tmp/path:/app"},
+							},
+						})
+
+						h.AssertError(t, err, `platform volume "/tmp/path:/app" has invalid target: cannot mount over reserved directory '/app'`)
+					})
+
+					it("allows mounting to the default workspace when a custom workspace is set", func() {
+						err := subject.Build(context.TODO(), BuildOptions{
+							Image:     "some/app",
+							Builder:   defaultBuilderName,
+							Workspace: "app",
+							ContainerConfig: ContainerConfig{
+								Volumes: []string{"/tmp/path:/workspace"},
+							},
+						})
+
+						h.AssertNil(t, err)
+						h.AssertEq(t, fakeLifecycle.Opts.Volumes, []string{"/tmp/path:/workspace:ro"})
+					})
+
+					it("allows mounting to a sibling with a common prefix", func() {
+						err := subject.Build(context.TODO(), BuildOptions{
+							Image:   "some/app",
+							Builder: defaultBuilderName,
+							ContainerConfig: ContainerConfig{
+								Volumes: []string{"/tmp/path:/cnb-certs"},
+							},
+						})
+
+						h.AssertNil(t, err)
+						h.AssertEq(t, fakeLifecycle.Opts.Volumes, []string{"/tmp/path:/cnb-certs:ro"})
+					})
+				})
 			})
 
 			when("on windows", func() {
@@ -2356,7 +2795,7 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 
 					when("mounting onto cnb spec'd dir", func() {
 						for _, p := range []string{
-							`/cnb`, `/cnb/buildpacks`, `/layers`,
+							`/cnb/buildpacks`, `/layers/nested`,
 						} {
 							p := p
 							it(fmt.Sprintf("warns when mounting to '%s'", p), func() {
@@ -2426,7 +2865,7 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 
 					when("mounting onto cnb spec'd dir", func() {
 						for _, p := range []string{
-							`c:\cnb`, `c:\cnb\buildpacks`, `c:\layers`,
+							`c:\cnb\buildpacks`, `c:\layers\nested`,
 						} {
 							p := p
 							it(fmt.Sprintf("warns when mounting to '%s'", p), func() {
@@ -2444,6 +2883,26 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 							})
 						}
 					})
+
+					when("mounting over a reserved dir", func() {
+						for _, p := range []string{
+							`c:\cnb`, `C:\Layers`, `c:\workspace`,
+						} {
+							p := p
+							it(fmt.Sprintf("errors when mounting to '%s'", p), func() {
+								err := subject.Build(context.TODO(), BuildOptions{
+									Image:   "some/app",
+									Builder: defaultWindowsBuilderName,
+									ContainerConfig: ContainerConfig{
+										Volumes: []string{fmt.Sprintf("c:/Users:%s", p)},
+									},
+									TrustBuilder: func(string) bool { return true },
+								})
+
+								h.AssertError(t, err, "has invalid target: cannot mount over reserved directory")
+							})
+						}
+					})
 				})
 			})
 		})
@@ -2498,16 +2957,82 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 		})
 
 		when("sbom destination dir option", func() {
+			var sbomBuilder *fakes.Image
+
+			it.Before(func() {
+				sbomBuilder = ifakes.NewFakeBuilderImage(t,
+					tmpDir,
+					"sbom-"+defaultBuilderName,
+					defaultBuilderStackID,
+					"1234",
+					"5678",
+					builder.Metadata{
+						Stack: builder.StackMetadata{
+							RunImage: builder.RunImageMetadata{Image: "default/run"},
+						},
+						Lifecycle: builder.LifecycleMetadata{
+							LifecycleInfo: builder.LifecycleInfo{
+								Version: &builder.Version{Version: *semver.MustParse(builder.DefaultLifecycleVersion)},
+							},
+							APIs: builder.LifecycleAPIs{
+								Buildpack: builder.APIVersions{Supported: builder.APISet{api.MustParse("0.2"), api.MustParse("0.3"), api.MustParse("0.4")}},
+								Platform:  builder.APIVersions{Supported: builder.APISet{api.MustParse("0.8")}},
+							},
+						},
+					},
+					nil,
+					nil,
+					newLinuxImage,
+				)
+				fakeImageFetcher.LocalImages[sbomBuilder.Name()] = sbomBuilder
+			})
+
 			it("passthroughs to lifecycle", func() {
 				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
-					Builder:            defaultBuilderName,
+					Builder:            sbomBuilder.Name(),
 					Image:              "example.com/some/repo:tag",
 					SBOMDestinationDir: "some-destination-dir",
 				}))
 				h.AssertEq(t, fakeLifecycle.Opts.SBOMDestinationDir, "some-destination-dir")
+				h.AssertContains(t, outBuf.String(), "SBOM files were written to 'some-destination-dir'")
+			})
+
+			it("fails when the lifecycle does not support it", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Builder:            defaultBuilderName,
+					Image:              "example.com/some/repo:tag",
+					SBOMDestinationDir: "some-destination-dir",
+				})
+				h.AssertError(t, err, "'--sbom-output-dir' requires Platform API 0.8 or newer, but the builder's lifecycle only supports Platform API 0.4")
+			})
+		})
+
+		when("creation time option", func() {
+			it("fails when the lifecycle does not support it", func() {
+				creationTime := time.Now()
+				err := subject.Build(context.TODO(), BuildOptions{
+					Builder:      defaultBuilderName,
+					Image:        "example.com/some/repo:tag",
+					CreationTime: &creationTime,
+				})
+				h.AssertError(t, err, "'--creation-time' requires Platform API 0.9 or newer")
 			})
 		})
 	})
+
+	when("#excludeBindCache", func() {
+		it("excludes a bind cache in the app directory from the app", func() {
+			appPath := filepath.Join("builds", "project")
+			filter := excludeBindCache(appPath, cache.CacheInfo{Format: cache.CacheBind, Source: filepath.Join(appPath, ".pack-cache", "build-cache")})
+			h.AssertFalse(t, filter(".pack-cache/build-cache/committed"))
+			h.AssertTrue(t, filter("src/main.go"))
+		})
+
+		it("keeps the app when the bind cache is outside of it", func() {
+			filter := excludeBindCache(filepath.Join("builds", "project"), cache.CacheInfo{Format: cache.CacheBind, Source: filepath.Join("cache", "build-cache")})
+			h.AssertEq(t, filter == nil, true)
+		})
+	})
 }
 
 func diffIDForFile(t *testing.T, path string) string {
@@ -2617,9 +3142,13 @@ func newFakeBuilderImage(t *testing.T, tmpDir, builderName, defaultBuilderStackI
 
 type executeFailsLifecycle struct {
 	Opts build.LifecycleOptions
+	Err  error
 }
 
 func (f *executeFailsLifecycle) Execute(_ context.Context, opts build.LifecycleOptions) error {
 	f.Opts = opts
+	if f.Err != nil {
+		return f.Err
+	}
 	return errors.New("")
 }
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// AddBuilderBuildpacksOptions is a configuration object used to change the behavior of
+// AddBuilderBuildpacks.
+type AddBuilderBuildpacksOptions struct {
+	// Name of the builder to add the buildpacks to.
+	BuilderName string
+
+	// Name of the resulting builder. Defaults to BuilderName.
+	TargetName string
+
+	// URIs of the buildpacks to add, in any of the forms accepted by `pack builder create`.
+	BuildpackURIs []string
+
+	// Buildpack registry name. Defines where registry buildpacks will be pulled from.
+	Registry string
+
+	// Read the builder from and save the result to a registry, instead of the daemon.
+	Publish bool
+
+	// Strategy for updating images before modifying the builder.
+	PullPolicy image.PullPolicy
+}
+
+// RemoveBuilderBuildpackOptions is a configuration object used to change the behavior of
+// RemoveBuilderBuildpack.
+type RemoveBuilderBuildpackOptions struct {
+	// Name of the builder to remove the buildpack from.
+	BuilderName string
+
+	// Name of the resulting builder. Defaults to BuilderName.
+	TargetName string
+
+	// ID of the buildpack to remove.
+	BuildpackID string
+
+	// Version of the buildpack to remove. May be omitted if the builder has a single version of the buildpack.
+	BuildpackVersion string
+
+	// Read the builder from and save the result to a registry, instead of the daemon.
+	Publish bool
+
+	// Strategy for updating the builder image before modifying it.
+	PullPolicy image.PullPolicy
+}
+
+// UpgradeBuilderBuildpackOptions is a configuration object used to change the behavior of
+// UpgradeBuilderBuildpack.
+type UpgradeBuilderBuildpackOptions struct {
+	// Name of the builder to upgrade the buildpack in.
+	BuilderName string
+
+	// Name of the resulting builder. Defaults to BuilderName.
+	TargetName string
+
+	// URI of the new buildpack, in any of the forms accepted by `pack builder create`.
+	BuildpackURI string
+
+	// Buildpack registry name. Defines where registry buildpacks will be pulled from.
+	Registry string
+
+	// Read the builder from and save the result to a registry, instead of the daemon.
+	Publish bool
+
+	// Strategy for updating images before modifying the builder.
+	PullPolicy image.PullPolicy
+}
+
+// AddBuilderBuildpacks adds buildpacks to an existing builder, appends them to every group of the builder's order,
+// and saves the result as a new builder image. Only layers for the new buildpacks are added to the builder.
+func (c *Client) AddBuilderBuildpacks(ctx context.Context, opts AddBuilderBuildpacksOptions) error {
+	if len(opts.BuildpackURIs) == 0 {
+		return errors.New("at least one buildpack must be provided")
+	}
+
+	bldr, err := c.existingBuilder(ctx, opts.BuilderName, opts.TargetName, opts.Publish, opts.PullPolicy)
+	if err != nil {
+		return err
+	}
+
+	imageOS, err := bldr.Image().OS()
+	if err != nil {
+		return errors.Wrapf(err, "getting OS from %s", style.Symbol(bldr.Image().Name()))
+	}
+
+	order := bldr.Order()
+	for _, uri := range opts.BuildpackURIs {
+		mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, uri, buildpack.DownloadOptions{
+			RegistryName: opts.Registry,
+			ImageOS:      imageOS,
+			Daemon:       !opts.Publish,
+			PullPolicy:   opts.PullPolicy,
+		})
+		if err != nil {
+			return errors.Wrap(err, "downloading buildpack")
+		}
+
+		bpInfo := mainBP.Descriptor().Info
+		for _, existing := range bldr.Buildpacks() {
+			if existing.ID == bpInfo.ID {
+				return errors.Errorf("buildpack %s is already on the builder, use %s to replace it",
+					style.Symbol(bpInfo.ID), style.Symbol("pack builder buildpack upgrade"))
+			}
+		}
+
+		bldr.AddBuildpack(mainBP)
+		for _, bp := range depBPs {
+			if !hasBuildpack(bldr, bp.Descriptor().Info) {
+				bldr.AddBuildpack(bp)
+			}
+		}
+		if len(order) == 0 {
+			order = dist.Order{{}}
+		}
+		order = appendBuildpackToOrder(order, bpInfo)
+	}
+	bldr.SetOrder(order)
+
+	return c.saveExistingBuilder(bldr)
+}
+
+// RemoveBuilderBuildpack removes a buildpack from an existing builder, along with its references from the
+// builder's order, and saves the result as a new builder image.
+func (c *Client) RemoveBuilderBuildpack(ctx context.Context, opts RemoveBuilderBuildpackOptions) error {
+	bldr, err := c.existingBuilder(ctx, opts.BuilderName, opts.TargetName, opts.Publish, opts.PullPolicy)
+	if err != nil {
+		return err
+	}
+
+	if err := bldr.RemoveBuildpack(opts.BuildpackID, opts.BuildpackVersion); err != nil {
+		return err
+	}
+
+	return c.saveExistingBuilder(bldr)
+}
+
+// UpgradeBuilderBuildpack replaces a buildpack on an existing builder with the buildpack at the provided URI,
+// and saves the result as a new builder image. The builder must already contain a buildpack with the same ID.
+func (c *Client) UpgradeBuilderBuildpack(ctx context.Context, opts UpgradeBuilderBuildpackOptions) error {
+	bldr, err := c.existingBuilder(ctx, opts.BuilderName, opts.TargetName, opts.Publish, opts.PullPolicy)
+	if err != nil {
+		return err
+	}
+
+	imageOS, err := bldr.Image().OS()
+	if err != nil {
+		return errors.Wrapf(err, "getting OS from %s", style.Symbol(bldr.Image().Name()))
+	}
+
+	mainBP, depBPs, err := c.buildpackDownloader.Download(ctx, opts.BuildpackURI, buildpack.DownloadOptions{
+		RegistryName: opts.Registry,
+		ImageOS:      imageOS,
+		Daemon:       !opts.Publish,
+		PullPolicy:   opts.PullPolicy,
+	})
+	if err != nil {
+		return errors.Wrap(err, "downloading buildpack")
+	}
+
+	if err := bldr.UpgradeBuildpack(mainBP); err != nil {
+		return err
+	}
+	for _, bp := range depBPs {
+		bldr.AddBuildpack(bp)
+	}
+
+	return c.saveExistingBuilder(bldr)
+}
+
+func hasBuildpack(bldr *builder.Builder, bpInfo dist.BuildpackInfo) bool {
+	for _, existing := range bldr.Buildpacks() {
+		if existing.ID == bpInfo.ID && existing.Version == bpInfo.Version {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) existingBuilder(ctx context.Context, builderName, targetName string, publish bool, pullPolicy image.PullPolicy) (*builder.Builder, error) {
+	img, err := c.imageFetcher.Fetch(ctx, builderName, image.FetchOptions{Daemon: !publish, PullPolicy: pullPolicy})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching builder %s", style.Symbol(builderName))
+	}
+
+	if targetName == "" {
+		targetName = builderName
+	}
+	bldr, err := builder.New(img, targetName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid builder %s", style.Symbol(builderName))
+	}
+	return bldr, nil
+}
+
+func (c *Client) saveExistingBuilder(bldr *builder.Builder) error {
+	if err := bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version}); err != nil {
+		return errors.Wrapf(err, "saving builder %s", style.Symbol(bldr.Name()))
+	}
+	return nil
+}
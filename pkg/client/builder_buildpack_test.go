@@ -0,0 +1,214 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "builder_buildpack", testBuilderBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderBuildpack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		mockController          *gomock.Controller
+		mockImageFetcher        *testmocks.MockImageFetcher
+		mockBuildpackDownloader *testmocks.MockBuildpackDownloader
+		builderImage            *fakes.Image
+		subject                 *client.Client
+		out                     bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		mockBuildpackDownloader = testmocks.NewMockBuildpackDownloader(mockController)
+
+		builderImage = fakes.NewImage("some/builder", "", nil)
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, builderImage.SetEnv("CNB_USER_ID", "1234"))
+		h.AssertNil(t, builderImage.SetEnv("CNB_GROUP_ID", "4321"))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", `{
+  "buildpacks": [{"id": "bp.one", "version": "1.0.0"}, {"id": "bp.two", "version": "2.0.0"}],
+  "stack": {"runImage": {"image": "some/run-image"}},
+  "lifecycle": {"version": "0.13.0", "apis": {"buildpack": {"deprecated": [], "supported": ["0.2", "0.3"]}, "platform": {"deprecated": [], "supported": ["0.4"]}}}
+}`))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.order", `[
+  {"group": [{"id": "bp.one", "version": "1.0.0"}, {"id": "bp.two", "version": "2.0.0", "optional": true}]},
+  {"group": [{"id": "bp.two", "version": "2.0.0"}]}
+]`))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.layers", `{
+  "bp.one": {"1.0.0": {"api": "0.3", "layerDiffID": "sha256:one"}},
+  "bp.two": {"2.0.0": {"api": "0.3", "layerDiffID": "sha256:two"}}
+}`))
+
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+			Return(builderImage, nil)
+
+		var err error
+		subject, err = client.NewClient(
+			client.WithLogger(logging.NewLogWithWriters(&out, &out)),
+			client.WithFetcher(mockImageFetcher),
+			client.WithBuildpackDownloader(mockBuildpackDownloader),
+		)
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, builderImage.Cleanup())
+	})
+
+	savedBuilder := func() *builder.Builder {
+		h.AssertEq(t, builderImage.IsSaved(), true)
+		bldr, err := builder.FromImage(builderImage)
+		h.AssertNil(t, err)
+		return bldr
+	}
+
+	when("#AddBuilderBuildpacks", func() {
+		it("adds the buildpacks and appends them to every group of the order", func() {
+			newBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				API:    api.MustParse("0.3"),
+				Info:   dist.BuildpackInfo{ID: "bp.three", Version: "3.0.0"},
+				Stacks: []dist.Stack{{ID: "some.stack.id"}},
+			}, 0644)
+			h.AssertNil(t, err)
+			mockBuildpackDownloader.EXPECT().
+				Download(gomock.Any(), "docker://some/bp-three:3.0.0", gomock.Any()).
+				Return(newBP, nil, nil)
+
+			h.AssertNil(t, subject.AddBuilderBuildpacks(context.TODO(), client.AddBuilderBuildpacksOptions{
+				BuilderName:   "some/builder",
+				TargetName:    "some/builder:patched",
+				BuildpackURIs: []string{"docker://some/bp-three:3.0.0"},
+				PullPolicy:    image.PullNever,
+			}))
+
+			h.AssertEq(t, builderImage.Name(), "some/builder:patched")
+
+			bldr := savedBuilder()
+			h.AssertEq(t, bldr.Buildpacks(), []dist.BuildpackInfo{
+				{ID: "bp.one", Version: "1.0.0"},
+				{ID: "bp.two", Version: "2.0.0"},
+				{ID: "bp.three", Version: "3.0.0"},
+			})
+			h.AssertEq(t, bldr.Order(), dist.Order{
+				{Group: []dist.BuildpackRef{
+					{BuildpackInfo: dist.BuildpackInfo{ID: "bp.one", Version: "1.0.0"}},
+					{BuildpackInfo: dist.BuildpackInfo{ID: "bp.two", Version: "2.0.0"}, Optional: true},
+					{BuildpackInfo: dist.BuildpackInfo{ID: "bp.three", Version: "3.0.0"}},
+				}},
+				{Group: []dist.BuildpackRef{
+					{BuildpackInfo: dist.BuildpackInfo{ID: "bp.two", Version: "2.0.0"}},
+					{BuildpackInfo: dist.BuildpackInfo{ID: "bp.three", Version: "3.0.0"}},
+				}},
+			})
+		})
+
+		it("errors when the buildpack is already on the builder", func() {
+			newBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				API:    api.MustParse("0.3"),
+				Info:   dist.BuildpackInfo{ID: "bp.two", Version: "2.1.0"},
+				Stacks: []dist.Stack{{ID: "some.stack.id"}},
+			}, 0644)
+			h.AssertNil(t, err)
+			mockBuildpackDownloader.EXPECT().
+				Download(gomock.Any(), "docker://some/bp-two:2.1.0", gomock.Any()).
+				Return(newBP, nil, nil)
+
+			err = subject.AddBuilderBuildpacks(context.TODO(), client.AddBuilderBuildpacksOptions{
+				BuilderName:   "some/builder",
+				BuildpackURIs: []string{"docker://some/bp-two:2.1.0"},
+				PullPolicy:    image.PullNever,
+			})
+			h.AssertError(t, err, "buildpack 'bp.two' is already on the builder, use 'pack builder buildpack upgrade' to replace it")
+			h.AssertEq(t, builderImage.IsSaved(), false)
+		})
+	})
+
+	when("#RemoveBuilderBuildpack", func() {
+		it("removes the buildpack and its order references, and saves the builder under the new name", func() {
+			h.AssertNil(t, subject.RemoveBuilderBuildpack(context.TODO(), client.RemoveBuilderBuildpackOptions{
+				BuilderName: "some/builder",
+				TargetName:  "some/builder:patched",
+				BuildpackID: "bp.two",
+				PullPolicy:  image.PullNever,
+			}))
+
+			h.AssertEq(t, builderImage.Name(), "some/builder:patched")
+
+			bldr := savedBuilder()
+			h.AssertEq(t, bldr.Buildpacks(), []dist.BuildpackInfo{{ID: "bp.one", Version: "1.0.0"}})
+			h.AssertEq(t, bldr.Order(), dist.Order{
+				{Group: []dist.BuildpackRef{{BuildpackInfo: dist.BuildpackInfo{ID: "bp.one", Version: "1.0.0"}}}},
+			})
+
+			layers := dist.BuildpackLayers{}
+			_, err := dist.GetLabel(builderImage, dist.BuildpackLayersLabel, &layers)
+			h.AssertNil(t, err)
+			_, ok := layers["bp.two"]
+			h.AssertEq(t, ok, false)
+		})
+
+		it("errors when the buildpack is not on the builder", func() {
+			err := subject.RemoveBuilderBuildpack(context.TODO(), client.RemoveBuilderBuildpackOptions{
+				BuilderName: "some/builder",
+				BuildpackID: "bp.three",
+				PullPolicy:  image.PullNever,
+			})
+			h.AssertError(t, err, "buildpack 'bp.three' is not on the builder")
+			h.AssertEq(t, builderImage.IsSaved(), false)
+		})
+	})
+
+	when("#UpgradeBuilderBuildpack", func() {
+		it("replaces the buildpack and points the order at the new version", func() {
+			newBP, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				API:    api.MustParse("0.3"),
+				Info:   dist.BuildpackInfo{ID: "bp.two", Version: "2.1.0"},
+				Stacks: []dist.Stack{{ID: "some.stack.id"}},
+			}, 0644)
+			h.AssertNil(t, err)
+			mockBuildpackDownloader.EXPECT().
+				Download(gomock.Any(), "docker://some/bp-two:2.1.0", gomock.Any()).
+				Return(newBP, nil, nil)
+
+			h.AssertNil(t, subject.UpgradeBuilderBuildpack(context.TODO(), client.UpgradeBuilderBuildpackOptions{
+				BuilderName:  "some/builder",
+				BuildpackURI: "docker://some/bp-two:2.1.0",
+				PullPolicy:   image.PullNever,
+			}))
+
+			bldr := savedBuilder()
+			h.AssertEq(t, bldr.Buildpacks(), []dist.BuildpackInfo{{ID: "bp.one", Version: "1.0.0"}, {ID: "bp.two", Version: "2.1.0"}})
+			h.AssertEq(t, bldr.Order(), dist.Order{
+				{Group: []dist.BuildpackRef{
+					{BuildpackInfo: dist.BuildpackInfo{ID: "bp.one", Version: "1.0.0"}},
+					{BuildpackInfo: dist.BuildpackInfo{ID: "bp.two", Version: "2.1.0"}, Optional: true},
+				}},
+				{Group: []dist.BuildpackRef{{BuildpackInfo: dist.BuildpackInfo{ID: "bp.two", Version: "2.1.0"}}}},
+			})
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bytes"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// WithRegistryCACertificates trusts the root certificates of the PEM files, or of the files of the directories,
+// at paths, in addition to those of the system, when the client accesses registries. The certificates are also
+// trusted by the lifecycle phases running in Linux containers. Images read and written through imgutil only trust the
+// certificates of the system.
+func WithRegistryCACertificates(paths ...string) Option {
+	return func(c *Client) {
+		c.caCertificatePaths = append(c.caCertificatePaths, paths...)
+	}
+}
+
+// readCACertificates returns the PEM encoded certificates of the files, or of the files of the directories, at paths.
+func readCACertificates(paths []string) ([]byte, error) {
+	var bundle bytes.Buffer
+	for _, path := range paths {
+		files := []string{path}
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading CA certificates %s", style.Symbol(path))
+		}
+		if fi.IsDir() {
+			entries, err := ioutil.ReadDir(path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading CA certificates %s", style.Symbol(path))
+			}
+
+			files = nil
+			for _, entry := range entries {
+				if entry.Mode().IsRegular() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+
+		for _, file := range files {
+			contents, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading CA certificates %s", style.Symbol(file))
+			}
+			if !x509.NewCertPool().AppendCertsFromPEM(contents) {
+				return nil, errors.Errorf("no PEM encoded certificates in %s", style.Symbol(file))
+			}
+
+			bundle.Write(bytes.TrimSpace(contents))
+			bundle.WriteString("\n")
+		}
+	}
+	return bundle.Bytes(), nil
+}
+
+// certPool returns the certificates of the system, with the certificates of bundle.
+func certPool(bundle []byte) *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	pool.AppendCertsFromPEM(bundle)
+	return pool
+}
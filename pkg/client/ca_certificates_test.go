@@ -0,0 +1,116 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCACertificates(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CACertificates", testCACertificates, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCACertificates(t *testing.T, when spec.G, it spec.S) {
+	var tmpDir string
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "ca-certificates-test")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#readCACertificates", func() {
+		it("reads the certificates of files", func() {
+			certFile := filepath.Join(tmpDir, "some-ca.pem")
+			h.AssertNil(t, ioutil.WriteFile(certFile, newTestCertificate(t, "some-ca"), 0600))
+
+			bundle, err := readCACertificates([]string{certFile})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, countCertificates(t, bundle), 1)
+		})
+
+		it("reads the certificates of the files of directories", func() {
+			certDir := filepath.Join(tmpDir, "certs")
+			h.AssertNil(t, os.Mkdir(certDir, 0700))
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(certDir, "some-ca.pem"), newTestCertificate(t, "some-ca"), 0600))
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(certDir, "other-ca.crt"), newTestCertificate(t, "other-ca"), 0600))
+
+			bundle, err := readCACertificates([]string{certDir})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, countCertificates(t, bundle), 2)
+		})
+
+		it("fails when a file has no certificates", func() {
+			certFile := filepath.Join(tmpDir, "not-a-cert.pem")
+			h.AssertNil(t, ioutil.WriteFile(certFile, []byte("some-content"), 0600))
+
+			_, err := readCACertificates([]string{certFile})
+			h.AssertError(t, err, "no PEM encoded certificates in")
+		})
+
+		it("fails when a path does not exist", func() {
+			_, err := readCACertificates([]string{filepath.Join(tmpDir, "missing.pem")})
+			h.AssertError(t, err, "reading CA certificates")
+		})
+	})
+}
+
+func newTestCertificate(t *testing.T, commonName string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	h.AssertNil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	h.AssertNil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func countCertificates(t *testing.T, bundle []byte) int {
+	t.Helper()
+
+	count := 0
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			return count
+		}
+		_, err := x509.ParseCertificate(block.Bytes)
+		h.AssertNil(t, err)
+		count++
+	}
+}
@@ -0,0 +1,285 @@
+package client
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// cacheArchiveFile is the entry of a cache archive listing the volumes it holds.
+	cacheArchiveFile = "cache.json"
+
+	// cacheArchiveVolumesDir is where the volumes of a cache archive are mounted in the container used to copy their
+	// contents, and the directory of a cache archive holding them, one directory per volume.
+	cacheArchiveVolumesDir = "volumes"
+)
+
+// ExportCacheOptions is a configuration struct that controls the behavior of ExportCache.
+type ExportCacheOptions struct {
+	// Export the build cache volumes of this image.
+	ImageName string
+
+	// Path of the archive to write.
+	Path string
+
+	// Image mounting the cache volumes to copy their contents, such as the builder of the image. It is pulled if not
+	// present, and never run.
+	HelperImage string
+}
+
+// ImportCacheOptions is a configuration struct that controls the behavior of ImportCache.
+type ImportCacheOptions struct {
+	// Path of an archive written by ExportCache.
+	Path string
+
+	// Image mounting the cache volumes to copy their contents, as for ExportCacheOptions.
+	HelperImage string
+}
+
+// cacheArchive describes the contents of a cache archive.
+type cacheArchive struct {
+	// Image the cache was used to build.
+	Image string `json:"image"`
+
+	// Names of the volumes of the archive, which are kept on import, so that builds of the same image with the
+	// same stack and buildpacks use them.
+	Volumes []string `json:"volumes"`
+}
+
+// ExportCache writes the contents of the build cache volumes of an image to a gzipped tar archive that ImportCache
+// restores, such as to keep the cache of builds on stateless CI providers as an artifact of the pipeline, without a
+// cache image in a registry. The owners and modes of the cached files are kept.
+func (c *Client) ExportCache(ctx context.Context, opts ExportCacheOptions) error {
+	if opts.HelperImage == "" {
+		return errors.New("an image to read cache volumes with is required")
+	}
+
+	volumes, err := c.ListCacheVolumes(ctx, opts.ImageName)
+	if err != nil {
+		return err
+	}
+	manifest := cacheArchive{Image: opts.ImageName}
+	for _, vol := range volumes {
+		if vol.Scope == "build" {
+			manifest.Volumes = append(manifest.Volumes, vol.Name)
+		}
+	}
+	if len(manifest.Volumes) == 0 {
+		return errors.Errorf("no build cache volumes found for image %s", style.Symbol(opts.ImageName))
+	}
+
+	if _, err := c.imageFetcher.Fetch(ctx, opts.HelperImage, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}); err != nil {
+		return errors.Wrapf(err, "fetching image %s", style.Symbol(opts.HelperImage))
+	}
+
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding cache archive")
+	}
+
+	f, err := os.Create(opts.Path)
+	if err != nil {
+		return errors.Wrapf(err, "creating archive %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	if err := tw.WriteHeader(&tar.Header{Name: cacheArchiveFile, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(contents); err != nil {
+		return err
+	}
+
+	err = c.withVolumesContainer(ctx, opts.HelperImage, cacheArchiveBinds(manifest), func(containerID string) error {
+		for _, vol := range manifest.Volumes {
+			rc, _, err := c.docker.CopyFromContainer(ctx, containerID, path.Join("/", cacheArchiveVolumesDir, vol))
+			if err != nil {
+				return errors.Wrapf(err, "reading cache volume %s", style.Symbol(vol))
+			}
+			// the entries are named after the directory copied, which is the volume
+			err = copyTarEntries(tw, rc, func(name string) (string, bool) {
+				return path.Join(cacheArchiveVolumesDir, name), true
+			})
+			rc.Close()
+			if err != nil {
+				return errors.Wrapf(err, "archiving cache volume %s", style.Symbol(vol))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gzw.Close(); err != nil {
+		return err
+	}
+
+	c.logger.Infof("Exported %d cache volume(s) of %s to %s", len(manifest.Volumes), style.Symbol(opts.ImageName), style.Symbol(opts.Path))
+	return nil
+}
+
+// ImportCache restores the cache volumes of an archive written by ExportCache, under the same names, and returns
+// their names. Existing volumes with the same names are replaced.
+func (c *Client) ImportCache(ctx context.Context, opts ImportCacheOptions) ([]string, error) {
+	if opts.HelperImage == "" {
+		return nil, errors.New("an image to write cache volumes with is required")
+	}
+
+	manifest, err := readCacheArchive(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, vol := range manifest.Volumes {
+		if _, ok := cacheVolumeScope(vol, cacheVolumePrefix); !ok {
+			return nil, errors.Errorf("archive %s holds %s, which is not a cache volume", style.Symbol(opts.Path), style.Symbol(vol))
+		}
+		known[vol] = true
+	}
+
+	if _, err := c.imageFetcher.Fetch(ctx, opts.HelperImage, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}); err != nil {
+		return nil, errors.Wrapf(err, "fetching image %s", style.Symbol(opts.HelperImage))
+	}
+
+	for _, vol := range manifest.Volumes {
+		if err := c.docker.VolumeRemove(ctx, vol, false); err != nil {
+			switch {
+			case dockerClient.IsErrNotFound(err):
+				// nothing to replace
+			case errdefs.IsConflict(err):
+				return nil, errors.Errorf("cache volume %s is in use", style.Symbol(vol))
+			default:
+				return nil, errors.Wrapf(err, "removing volume %s", style.Symbol(vol))
+			}
+		}
+	}
+
+	f, err := os.Open(filepath.Clean(opts.Path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening archive %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	err = c.withVolumesContainer(ctx, opts.HelperImage, cacheArchiveBinds(manifest), func(containerID string) error {
+		volumes := archive.GenerateTar(func(tw archive.TarWriter) error {
+			gzr, err := gzip.NewReader(f)
+			if err != nil {
+				return err
+			}
+			defer gzr.Close()
+
+			// only the contents of the volumes listed are copied, the container being created from the helper image
+			return copyTarEntries(tw, gzr, func(name string) (string, bool) {
+				name = path.Clean(name)
+				parts := strings.SplitN(name, "/", 3)
+				return name, len(parts) >= 2 && parts[0] == cacheArchiveVolumesDir && known[parts[1]]
+			})
+		})
+		defer volumes.Close()
+
+		if err := c.docker.CopyToContainer(ctx, containerID, "/", volumes, types.CopyToContainerOptions{}); err != nil {
+			return errors.Wrap(err, "copying the contents of the cache volumes")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.recordCacheUsage(time.Now(), manifest.Volumes...); err != nil {
+		c.logger.Debugf("Unable to record the usage of cache volumes: %s", err)
+	}
+
+	c.logger.Infof("Imported %d cache volume(s) of %s", len(manifest.Volumes), style.Symbol(manifest.Image))
+	return manifest.Volumes, nil
+}
+
+func readCacheArchive(path string) (cacheArchive, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return cacheArchive{}, errors.Wrapf(err, "opening archive %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return cacheArchive{}, errors.Wrapf(err, "reading archive %s", style.Symbol(path))
+	}
+	defer gzr.Close()
+
+	_, contents, err := archive.ReadTarEntry(gzr, cacheArchiveFile)
+	if err != nil {
+		return cacheArchive{}, errors.Wrapf(err, "archive %s is not a build cache", style.Symbol(path))
+	}
+
+	var manifest cacheArchive
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return cacheArchive{}, errors.Wrapf(err, "parsing archive %s", style.Symbol(path))
+	}
+	return manifest, nil
+}
+
+func cacheArchiveBinds(manifest cacheArchive) []string {
+	var binds []string
+	for _, vol := range manifest.Volumes {
+		binds = append(binds, vol+":"+path.Join("/", cacheArchiveVolumesDir, vol))
+	}
+	return binds
+}
+
+// copyTarEntries copies the entries of the tar r to tw, keeping their owners and modes, under the names returned by
+// rename, which also renames the targets of hard links. Entries for which rename returns false are skipped.
+func copyTarEntries(tw archive.TarWriter, r io.Reader, rename func(name string) (string, bool)) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading tar")
+		}
+
+		name, ok := rename(header.Name)
+		if !ok {
+			continue
+		}
+		header.Name = name
+		if header.Typeflag == tar.TypeLink {
+			if header.Linkname, ok = rename(header.Linkname); !ok {
+				continue
+			}
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheArchive(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheArchive", testCacheArchive, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheArchive(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		tmpDir           string
+		appBuild         string
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithFetcher(fakeImageFetcher),
+			WithDockerClient(mockDockerClient),
+		)
+		h.AssertNil(t, err)
+
+		tmpDir, err = ioutil.TempDir("", "pack.cache-archive.test.")
+		h.AssertNil(t, err)
+		subject.cacheUsagePath = filepath.Join(tmpDir, cacheUsageFile)
+
+		appBuild, err = CacheVolumeName("some/app", "some.stack", "sha256:buildpacks", "build")
+		h.AssertNil(t, err)
+		appLaunch, err := CacheVolumeName("some/app", "some.stack", "sha256:buildpacks", "launch")
+		h.AssertNil(t, err)
+
+		mockDockerClient.EXPECT().
+			VolumeList(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, args filters.Args) (volume.VolumeListOKBody, error) {
+				var volumes []*types.Volume
+				for _, name := range []string{appBuild, appLaunch} {
+					if args.Match("name", name) {
+						volumes = append(volumes, &types.Volume{Name: name})
+					}
+				}
+				return volume.VolumeListOKBody{Volumes: volumes}, nil
+			}).AnyTimes()
+		mockDockerClient.EXPECT().DiskUsage(gomock.Any()).Return(types.DiskUsage{}, nil).AnyTimes()
+
+		fakeImageFetcher.LocalImages["some/builder"] = fakes.NewImage("some/builder", "", nil)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ExportCache and #ImportCache", func() {
+		it("restores the build cache volumes with the owners of their files", func() {
+			archivePath := filepath.Join(tmpDir, "cache.tgz")
+			bind := appBuild + ":/volumes/" + appBuild
+
+			mockDockerClient.EXPECT().
+				ContainerCreate(gomock.Any(), &container.Config{Image: "some/builder"}, &container.HostConfig{Binds: []string{bind}}, nil, nil, "").
+				Return(container.ContainerCreateCreatedBody{ID: "export-container"}, nil)
+			mockDockerClient.EXPECT().
+				CopyFromContainer(gomock.Any(), "export-container", "/volumes/"+appBuild).
+				Return(volumeTar(t, appBuild), types.ContainerPathStat{}, nil)
+			mockDockerClient.EXPECT().
+				ContainerRemove(gomock.Any(), "export-container", types.ContainerRemoveOptions{Force: true}).
+				Return(nil)
+
+			h.AssertNil(t, subject.ExportCache(context.TODO(), ExportCacheOptions{ImageName: "some/app", Path: archivePath, HelperImage: "some/builder"}))
+
+			var restored bytes.Buffer
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), appBuild, false).Return(nil)
+			mockDockerClient.EXPECT().
+				ContainerCreate(gomock.Any(), &container.Config{Image: "some/builder"}, &container.HostConfig{Binds: []string{bind}}, nil, nil, "").
+				Return(container.ContainerCreateCreatedBody{ID: "import-container"}, nil)
+			mockDockerClient.EXPECT().
+				CopyToContainer(gomock.Any(), "import-container", "/", gomock.Any(), types.CopyToContainerOptions{}).
+				DoAndReturn(func(_ context.Context, _, _ string, r io.Reader, _ types.CopyToContainerOptions) error {
+					_, err := io.Copy(&restored, r)
+					return err
+				})
+			mockDockerClient.EXPECT().
+				ContainerRemove(gomock.Any(), "import-container", types.ContainerRemoveOptions{Force: true}).
+				Return(nil)
+
+			volumes, err := subject.ImportCache(context.TODO(), ImportCacheOptions{Path: archivePath, HelperImage: "some/builder"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, volumes, []string{appBuild})
+
+			header, contents, err := archive.ReadTarEntry(&restored, "volumes/"+appBuild+"/committed/some-layer.tar")
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "some-layer")
+			h.AssertEq(t, header.Uid, 1000)
+			h.AssertEq(t, header.Gid, 1001)
+		})
+
+		it("errors when the image has no build cache", func() {
+			err := subject.ExportCache(context.TODO(), ExportCacheOptions{ImageName: "other/app", Path: filepath.Join(tmpDir, "cache.tgz"), HelperImage: "some/builder"})
+			h.AssertError(t, err, "no build cache volumes found for image 'other/app'")
+		})
+
+		it("rejects archives which are not a build cache", func() {
+			archivePath := filepath.Join(tmpDir, "other.tar")
+			h.AssertNil(t, ioutil.WriteFile(archivePath, []byte("not an archive"), 0600))
+
+			_, err := subject.ImportCache(context.TODO(), ImportCacheOptions{Path: archivePath, HelperImage: "some/builder"})
+			h.AssertError(t, err, "reading archive")
+		})
+	})
+}
+
+// volumeTar returns a tar of a volume as the daemon copies it from a container, named after the volume.
+func volumeTar(t *testing.T, volumeName string) io.ReadCloser {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: volumeName + "/committed/", Typeflag: tar.TypeDir, Mode: 0755, Uid: 1000, Gid: 1001}))
+	h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: volumeName + "/committed/some-layer.tar", Typeflag: tar.TypeReg, Mode: 0644, Uid: 1000, Gid: 1001, Size: 10}))
+	_, err := tw.Write([]byte("some-layer"))
+	h.AssertNil(t, err)
+	h.AssertNil(t, tw.Close())
+	return ioutil.NopCloser(&buf)
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/buildpacks/lifecycle/cache"
+	"github.com/buildpacks/lifecycle/platform"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// cacheMountPath is where a build cache volume is mounted in the container used to read its contents.
+const cacheMountPath = "/cache"
+
+// InspectCacheOptions select the cache inspected by InspectCache.
+type InspectCacheOptions struct {
+	// Inspect the build cache volumes of this image.
+	ImageName string
+
+	// Inspect this cache image, as given to build with --cache-image, rather than the cache volumes of ImageName.
+	CacheImage string
+
+	// Image mounting the cache volumes to read them, such as the builder of the image. It is pulled if not present,
+	// and never run. Not needed to inspect a cache image.
+	HelperImage string
+}
+
+// CacheContents describes the layers cached in a cache volume or image.
+type CacheContents struct {
+	// Name of the cache volume or image.
+	Name string
+
+	// LastUsed is when a build last used the cache, as for CacheVolume. It is zero when unknown.
+	LastUsed time.Time
+
+	// Buildpacks caching layers, sorted by id.
+	Buildpacks []CachedBuildpack
+}
+
+// CachedBuildpack describes the cached layers of a buildpack.
+type CachedBuildpack struct {
+	ID      string
+	Version string
+
+	// Layers cached by the buildpack, sorted by name.
+	Layers []CachedLayer
+}
+
+// CachedLayer describes a layer cached by a buildpack.
+type CachedLayer struct {
+	// Name of the layer, which is the name of its directory in the layers directory of the buildpack.
+	Name string
+
+	// SHA is the diff ID of the layer.
+	SHA string
+
+	// Size of the layer in bytes, or -1 when unknown. Layers of cache images are counted compressed.
+	Size int64
+
+	// Build and Launch are whether the layer is also available to later buildpacks and in the app image.
+	Build  bool
+	Launch bool
+
+	// Cached is when the layer was first cached. It is zero when unknown, as for cache images.
+	Cached time.Time
+}
+
+// InspectCache reports the layers buildpacks cached for an image, by reading the metadata the lifecycle stores with
+// the cache: in the committed directory of the build cache volumes, or in a label of a cache image.
+func (c *Client) InspectCache(ctx context.Context, opts InspectCacheOptions) ([]CacheContents, error) {
+	if opts.CacheImage != "" {
+		contents, err := c.inspectCacheImage(ctx, opts.CacheImage)
+		if err != nil {
+			return nil, err
+		}
+		return []CacheContents{contents}, nil
+	}
+
+	if opts.ImageName == "" {
+		return nil, errors.New("an image name or cache image is required")
+	}
+
+	volumes, err := c.ListCacheVolumes(ctx, opts.ImageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []CacheContents
+	for _, vol := range volumes {
+		if vol.Scope != "build" {
+			continue
+		}
+
+		if len(result) == 0 {
+			if opts.HelperImage == "" {
+				return nil, errors.New("an image to read cache volumes with is required")
+			}
+			if _, err := c.imageFetcher.Fetch(ctx, opts.HelperImage, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}); err != nil {
+				return nil, errors.Wrapf(err, "fetching image %s", style.Symbol(opts.HelperImage))
+			}
+		}
+
+		contents, err := c.inspectCacheVolume(ctx, vol, opts.HelperImage)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, contents)
+	}
+	return result, nil
+}
+
+// inspectCacheVolume reads the cache metadata of the volume, and the sizes and modification times of the layers it
+// holds as committed/<diff ID>.tar.
+func (c *Client) inspectCacheVolume(ctx context.Context, vol CacheVolume, helperImage string) (CacheContents, error) {
+	contents := CacheContents{Name: vol.Name, LastUsed: vol.LastUsed}
+	committedDir := path.Join(cacheMountPath, "committed")
+
+	err := c.withVolumesContainer(ctx, helperImage, []string{vol.Name + ":" + cacheMountPath}, func(containerID string) error {
+		metadataFile, _, err := c.docker.CopyFromContainer(ctx, containerID, path.Join(committedDir, cache.MetadataLabel))
+		if err != nil {
+			if dockerClient.IsErrNotFound(err) {
+				// no build committed to the cache yet
+				return nil
+			}
+			return errors.Wrapf(err, "reading cache volume %s", style.Symbol(vol.Name))
+		}
+		defer metadataFile.Close()
+
+		_, data, err := archive.ReadTarEntry(metadataFile, cache.MetadataLabel)
+		if err != nil {
+			return errors.Wrapf(err, "reading cache metadata of volume %s", style.Symbol(vol.Name))
+		}
+
+		var metadata platform.CacheMetadata
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return errors.Wrapf(err, "reading cache metadata of volume %s", style.Symbol(vol.Name))
+		}
+
+		contents.Buildpacks = cachedBuildpacks(metadata, func(sha string) (int64, time.Time) {
+			stat, err := c.docker.ContainerStatPath(ctx, containerID, path.Join(committedDir, sha+".tar"))
+			if err != nil {
+				c.logger.Debugf("Unable to get the size of cached layer %s: %s", style.Symbol(sha), err)
+				return -1, time.Time{}
+			}
+			return stat.Size, stat.Mtime
+		})
+		return nil
+	})
+	return contents, err
+}
+
+// inspectCacheImage reads the cache metadata of the cache image from its label, and the sizes of its layers.
+func (c *Client) inspectCacheImage(ctx context.Context, cacheImage string) (CacheContents, error) {
+	contents := CacheContents{Name: cacheImage}
+
+	imageRef, err := name.ParseReference(cacheImage, name.WeakValidation)
+	if err != nil {
+		return contents, errors.Wrapf(err, "invalid image name '%s'", cacheImage)
+	}
+
+	img, err := ggcrremote.Image(imageRef, c.remoteOptions(ctx)...)
+	if err != nil {
+		return contents, errors.Wrapf(err, "fetching cache image %s", style.Symbol(cacheImage))
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return contents, errors.Wrapf(err, "reading config of cache image %s", style.Symbol(cacheImage))
+	}
+	contents.LastUsed = configFile.Created.Time
+
+	var metadata platform.CacheMetadata
+	if label, ok := configFile.Config.Labels[cache.MetadataLabel]; ok {
+		if err := json.Unmarshal([]byte(label), &metadata); err != nil {
+			return contents, errors.Wrapf(err, "reading label %s of cache image %s", style.Symbol(cache.MetadataLabel), style.Symbol(cacheImage))
+		}
+	}
+
+	contents.Buildpacks = cachedBuildpacks(metadata, func(sha string) (int64, time.Time) {
+		hash, err := v1.NewHash(sha)
+		if err != nil {
+			return -1, time.Time{}
+		}
+		layer, err := img.LayerByDiffID(hash)
+		if err != nil {
+			return -1, time.Time{}
+		}
+		size, err := layer.Size()
+		if err != nil {
+			return -1, time.Time{}
+		}
+		return size, time.Time{}
+	})
+	return contents, nil
+}
+
+// cachedBuildpacks lists the layers of metadata flagged as cached, with the size and time stat returns for a diff ID.
+func cachedBuildpacks(metadata platform.CacheMetadata, stat func(sha string) (int64, time.Time)) []CachedBuildpack {
+	var buildpacks []CachedBuildpack
+	for _, bp := range metadata.Buildpacks {
+		cached := CachedBuildpack{ID: bp.ID, Version: bp.Version}
+		for layerName, layer := range bp.Layers {
+			if !layer.Cache || layer.SHA == "" {
+				continue
+			}
+			size, modified := stat(layer.SHA)
+			cached.Layers = append(cached.Layers, CachedLayer{
+				Name:   layerName,
+				SHA:    layer.SHA,
+				Size:   size,
+				Build:  layer.Build,
+				Launch: layer.Launch,
+				Cached: modified,
+			})
+		}
+		if len(cached.Layers) == 0 {
+			continue
+		}
+		sort.Slice(cached.Layers, func(i, j int) bool { return cached.Layers[i].Name < cached.Layers[j].Name })
+		buildpacks = append(buildpacks, cached)
+	}
+	sort.Slice(buildpacks, func(i, j int) bool { return buildpacks[i].ID < buildpacks[j].ID })
+	return buildpacks
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheInspect(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheInspect", testCacheInspect, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheInspect(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		tmpDir           string
+		appBuild         string
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithFetcher(fakeImageFetcher),
+			WithDockerClient(mockDockerClient),
+		)
+		h.AssertNil(t, err)
+
+		tmpDir, err = ioutil.TempDir("", "pack.cache-inspect.test.")
+		h.AssertNil(t, err)
+
+		appBuild, err = CacheVolumeName("some/app", "some.stack", "sha256:buildpacks", "build")
+		h.AssertNil(t, err)
+		appLaunch, err := CacheVolumeName("some/app", "some.stack", "sha256:buildpacks", "launch")
+		h.AssertNil(t, err)
+
+		mockDockerClient.EXPECT().
+			VolumeList(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, args filters.Args) (volume.VolumeListOKBody, error) {
+				var volumes []*types.Volume
+				for _, name := range []string{appBuild, appLaunch} {
+					if args.Match("name", name) {
+						volumes = append(volumes, &types.Volume{Name: name})
+					}
+				}
+				return volume.VolumeListOKBody{Volumes: volumes}, nil
+			}).AnyTimes()
+		mockDockerClient.EXPECT().DiskUsage(gomock.Any()).Return(types.DiskUsage{}, nil).AnyTimes()
+
+		fakeImageFetcher.LocalImages["some/builder"] = fakes.NewImage("some/builder", "", nil)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#InspectCache", func() {
+		it("reports the cached layers of the build cache volumes", func() {
+			metadataPath := filepath.Join(tmpDir, "metadata.tar")
+			h.AssertNil(t, archive.CreateSingleFileTar(metadataPath, "io.buildpacks.lifecycle.cache.metadata", `{
+  "buildpacks": [
+    {"key": "some/buildpack", "version": "1.0", "layers": {
+      "deps": {"sha": "sha256:deps", "cache": true, "build": true},
+      "launch-only": {"sha": "sha256:launch-only", "launch": true}
+    }},
+    {"key": "other/buildpack", "version": "2.0", "layers": {}}
+  ]
+}`))
+			metadataFile, err := os.Open(metadataPath)
+			h.AssertNil(t, err)
+
+			cachedAt := time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)
+			mockDockerClient.EXPECT().
+				ContainerCreate(gomock.Any(), &container.Config{Image: "some/builder"},
+					&container.HostConfig{Binds: []string{appBuild + ":/cache"}}, nil, nil, "").
+				Return(container.ContainerCreateCreatedBody{ID: "some-container"}, nil)
+			mockDockerClient.EXPECT().
+				CopyFromContainer(gomock.Any(), "some-container", "/cache/committed/io.buildpacks.lifecycle.cache.metadata").
+				Return(metadataFile, types.ContainerPathStat{}, nil)
+			mockDockerClient.EXPECT().
+				ContainerStatPath(gomock.Any(), "some-container", "/cache/committed/sha256:deps.tar").
+				Return(types.ContainerPathStat{Size: 3000, Mtime: cachedAt}, nil)
+			mockDockerClient.EXPECT().
+				ContainerRemove(gomock.Any(), "some-container", types.ContainerRemoveOptions{Force: true}).
+				Return(nil)
+
+			caches, err := subject.InspectCache(context.TODO(), InspectCacheOptions{ImageName: "some/app", HelperImage: "some/builder"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, caches, []CacheContents{{
+				Name: appBuild,
+				Buildpacks: []CachedBuildpack{{
+					ID:      "some/buildpack",
+					Version: "1.0",
+					Layers:  []CachedLayer{{Name: "deps", SHA: "sha256:deps", Size: 3000, Build: true, Cached: cachedAt}},
+				}},
+			}})
+		})
+
+		it("requires an image to read the volumes with", func() {
+			_, err := subject.InspectCache(context.TODO(), InspectCacheOptions{ImageName: "some/app"})
+			h.AssertError(t, err, "an image to read cache volumes with is required")
+		})
+
+		it("reports no caches for images without cache volumes", func() {
+			caches, err := subject.InspectCache(context.TODO(), InspectCacheOptions{ImageName: "other/app"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(caches), 0)
+		})
+	})
+}
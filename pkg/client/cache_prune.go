@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/cache"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// cacheUsageFile is the name of the file of the pack home recording when builds last used each cache volume.
+const cacheUsageFile = "cache-usage.json"
+
+// CacheLimits bound the disk usage of the cache volumes of the daemon. Zero values are unbounded.
+type CacheLimits struct {
+	// Maximum total size in bytes of the cache volumes. The least recently used volumes are removed until the
+	// total size is within the limit.
+	MaxSize int64
+
+	// Maximum time since a cache volume was last used, after which it is removed.
+	MaxAge time.Duration
+}
+
+// PruneCacheOptions is a configuration struct that controls the behavior of PruneCacheVolumes.
+type PruneCacheOptions struct {
+	CacheLimits
+
+	// Names of cache volumes to keep regardless of the limits, such as those of the current build.
+	Keep []string
+
+	// List the volumes which would be removed, without removing them.
+	DryRun bool
+}
+
+// PruneCacheVolumes removes the cache volumes exceeding the limits of opts, least recently used first, and returns
+// those it removed (or, for a dry run, would remove). Volumes in use by a container are kept, as are volumes of
+// unknown size when applying the maximum size.
+func (c *Client) PruneCacheVolumes(ctx context.Context, opts PruneCacheOptions) ([]CacheVolume, error) {
+	volumes, err := c.ListCacheVolumes(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(volumes, func(i, j int) bool { return volumes[i].LastUsed.Before(volumes[j].LastUsed) })
+
+	keep := map[string]bool{}
+	for _, name := range opts.Keep {
+		keep[name] = true
+	}
+
+	var totalSize int64
+	for _, vol := range volumes {
+		if vol.Size > 0 {
+			totalSize += vol.Size
+		}
+	}
+
+	var pruned []CacheVolume
+	for _, vol := range volumes {
+		if keep[vol.Name] || vol.InUse {
+			continue
+		}
+
+		expired := opts.MaxAge > 0 && !vol.LastUsed.IsZero() && time.Since(vol.LastUsed) > opts.MaxAge
+		oversized := opts.MaxSize > 0 && totalSize > opts.MaxSize && vol.Size > 0
+		if !expired && !oversized {
+			continue
+		}
+
+		if !opts.DryRun {
+			ok, err := c.removeCacheVolume(ctx, vol)
+			if err != nil {
+				return pruned, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		if vol.Size > 0 {
+			totalSize -= vol.Size
+		}
+		pruned = append(pruned, vol)
+	}
+
+	if opts.MaxSize > 0 && totalSize > opts.MaxSize {
+		c.logger.Warnf("Cache volumes use %d bytes, exceeding the maximum of %d bytes, after removing those not in use", totalSize, opts.MaxSize)
+	}
+	return pruned, nil
+}
+
+// readCacheUsage returns when builds of this host last used each cache volume, by name.
+func (c *Client) readCacheUsage() (map[string]time.Time, error) {
+	usage := map[string]time.Time{}
+	if c.cacheUsagePath == "" {
+		return usage, nil
+	}
+
+	contents, err := ioutil.ReadFile(c.cacheUsagePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return usage, nil
+		}
+		return usage, err
+	}
+	if err := json.Unmarshal(contents, &usage); err != nil {
+		return map[string]time.Time{}, errors.Wrapf(err, "parsing %s", style.Symbol(c.cacheUsagePath))
+	}
+	return usage, nil
+}
+
+// recordCacheUsage records that a build used the cache volumes at the given time.
+func (c *Client) recordCacheUsage(at time.Time, volumes ...string) error {
+	usage, err := c.readCacheUsage()
+	if err != nil {
+		return err
+	}
+	for _, name := range volumes {
+		usage[name] = at
+	}
+	return c.writeCacheUsage(usage)
+}
+
+// forgetCacheUsage removes the records of the usage of the cache volumes, once they are removed.
+func (c *Client) forgetCacheUsage(volumes ...string) error {
+	usage, err := c.readCacheUsage()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, name := range volumes {
+		if _, ok := usage[name]; ok {
+			delete(usage, name)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return c.writeCacheUsage(usage)
+}
+
+// writeCacheUsage replaces the file recording the usage of the cache volumes, so that concurrent builds never read
+// a partially written file.
+func (c *Client) writeCacheUsage(usage map[string]time.Time) error {
+	if c.cacheUsagePath == "" {
+		return nil
+	}
+
+	contents, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(c.cacheUsagePath)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, cacheUsageFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.cacheUsagePath)
+}
+
+// pruneCacheAfterBuild records that the build used its cache volumes, and prunes the other cache volumes exceeding
+// the CacheLimits of opts. Failures are only reported, as the build succeeded.
+func (c *Client) pruneCacheAfterBuild(ctx context.Context, opts BuildOptions, lifecycleOpts build.LifecycleOptions) {
+	cacheOpts := opts.Cache
+	var used []string
+	if cacheOpts.Build.Format == cache.CacheVolume && cacheOpts.Build.Source == "" {
+		key := cacheVolumeKey(lifecycleOpts, "build")
+		key.Name = opts.CacheName
+		used = append(used, key.VolumeName())
+	}
+	if !opts.NoLaunchCache && (cacheOpts.Launch.Format == cache.CacheImage || cacheOpts.Launch.Source == "") {
+		used = append(used, cacheVolumeKey(lifecycleOpts, "launch").VolumeName())
+	}
+
+	if err := c.recordCacheUsage(time.Now(), used...); err != nil {
+		c.logger.Warnf("Unable to record the usage of cache volumes: %s", err)
+	}
+
+	pruned, err := c.PruneCacheVolumes(ctx, PruneCacheOptions{CacheLimits: *opts.CacheLimits, Keep: used})
+	if err != nil {
+		c.logger.Warnf("Unable to prune cache volumes: %s", err)
+	}
+	for _, vol := range pruned {
+		c.logger.Infof("Pruned cache volume %s, last used %s", style.Symbol(vol.Name), vol.LastUsed.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCachePrune(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CachePrune", testCachePrune, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCachePrune(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		tmpDir           string
+		oldVolume        string
+		recentVolume     string
+		currentVolume    string
+		now              time.Time
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "cache-prune-test")
+		h.AssertNil(t, err)
+
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithDockerClient(mockDockerClient),
+		)
+		h.AssertNil(t, err)
+		subject.cacheUsagePath = filepath.Join(tmpDir, cacheUsageFile)
+
+		oldVolume, err = CacheVolumeName("old/app", "some.stack", "sha256:buildpacks", "build")
+		h.AssertNil(t, err)
+		recentVolume, err = CacheVolumeName("recent/app", "some.stack", "sha256:buildpacks", "build")
+		h.AssertNil(t, err)
+		currentVolume, err = CacheVolumeName("current/app", "some.stack", "sha256:buildpacks", "build")
+		h.AssertNil(t, err)
+
+		now = time.Now()
+		h.AssertNil(t, subject.recordCacheUsage(now.Add(-time.Hour), recentVolume))
+		h.AssertNil(t, subject.recordCacheUsage(now, currentVolume))
+
+		mockDockerClient.EXPECT().
+			VolumeList(gomock.Any(), gomock.Any()).
+			Return(volume.VolumeListOKBody{Volumes: []*types.Volume{
+				{Name: currentVolume, CreatedAt: now.Add(-72 * time.Hour).Format(time.RFC3339)},
+				{Name: recentVolume, CreatedAt: now.Add(-72 * time.Hour).Format(time.RFC3339)},
+				// never recorded as used, so last used when created
+				{Name: oldVolume, CreatedAt: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+			}}, nil).AnyTimes()
+		mockDockerClient.EXPECT().
+			DiskUsage(gomock.Any()).
+			Return(types.DiskUsage{Volumes: []*types.Volume{
+				{Name: oldVolume, UsageData: &types.VolumeUsageData{Size: 3000}},
+				{Name: recentVolume, UsageData: &types.VolumeUsageData{Size: 2000}},
+				{Name: currentVolume, UsageData: &types.VolumeUsageData{Size: 1000}},
+			}}, nil).AnyTimes()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#PruneCacheVolumes", func() {
+		it("removes the least recently used volumes until the total size is within the maximum", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), oldVolume, false).Return(nil)
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), recentVolume, false).Return(nil)
+
+			pruned, err := subject.PruneCacheVolumes(context.TODO(), PruneCacheOptions{CacheLimits: CacheLimits{MaxSize: 1500}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(pruned), 2)
+			h.AssertEq(t, pruned[0].Name, oldVolume)
+			h.AssertEq(t, pruned[1].Name, recentVolume)
+
+			usage, err := subject.readCacheUsage()
+			h.AssertNil(t, err)
+			_, ok := usage[recentVolume]
+			h.AssertFalse(t, ok)
+			_, ok = usage[currentVolume]
+			h.AssertTrue(t, ok)
+		})
+
+		it("removes the volumes unused for longer than the maximum age", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), oldVolume, false).Return(nil)
+
+			pruned, err := subject.PruneCacheVolumes(context.TODO(), PruneCacheOptions{CacheLimits: CacheLimits{MaxAge: 24 * time.Hour}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(pruned), 1)
+			h.AssertEq(t, pruned[0].Name, oldVolume)
+		})
+
+		it("keeps the given volumes", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), recentVolume, false).Return(nil)
+
+			pruned, err := subject.PruneCacheVolumes(context.TODO(), PruneCacheOptions{
+				CacheLimits: CacheLimits{MaxSize: 4000},
+				Keep:        []string{oldVolume},
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(pruned), 1)
+			h.AssertEq(t, pruned[0].Name, recentVolume)
+		})
+
+		it("does not remove volumes on a dry run", func() {
+			pruned, err := subject.PruneCacheVolumes(context.TODO(), PruneCacheOptions{
+				CacheLimits: CacheLimits{MaxSize: 1},
+				DryRun:      true,
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(pruned), 3)
+		})
+	})
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/cache"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// cacheVolumePrefix starts the names of the cache volumes pack creates.
+const cacheVolumePrefix = "pack-cache-"
+
+// cacheVolumeSuffix matches the end of the names of the cache volumes pack creates, following the image: a hash of
+// the key of the cache and its scope.
+var cacheVolumeSuffix = regexp.MustCompile(`^[0-9a-f]{12}\.([a-z]+)$`)
+
+// CacheVolumeName returns the name of the volume caching the layers of the given scope ("build" or "launch")
+// when building imageName with a builder of the stack stackID with the buildpacks of buildpacksDigest, as returned
+// by BuildpacksDigest. Both are empty for builds with BuildOptions.LegacyCacheKey.
+//
+// The name is derived from a hash of these values, so builds of the same image on different stacks or with
+// different buildpacks use different volumes, and external tools can compute which volumes belong to an image.
+func CacheVolumeName(imageName, stackID, buildpacksDigest, scope string) (string, error) {
+	imageRef, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid image name '%s'", imageName)
+	}
+
+	return cache.VolumeCacheKey{ImageRef: imageRef, StackID: stackID, BuildpacksDigest: buildpacksDigest, Scope: scope}.VolumeName(), nil
+}
+
+// BuildpacksDigest returns the digest of buildpacks keying the cache volumes of builds, whatever their order.
+func BuildpacksDigest(buildpacks []dist.BuildpackInfo) string {
+	return cache.BuildpacksDigest(buildpacks)
+}
+
+// cacheVolumeKey returns the key of the cache volume of the given scope of the build run with lifecycleOpts.
+func cacheVolumeKey(lifecycleOpts build.LifecycleOptions, scope string) cache.VolumeCacheKey {
+	return cache.VolumeCacheKey{
+		ImageRef:         lifecycleOpts.Image,
+		StackID:          lifecycleOpts.StackID,
+		BuildpacksDigest: lifecycleOpts.BuildpacksDigest,
+		Scope:            scope,
+	}
+}
+
+// CacheVolume describes a volume pack created to cache the layers of builds.
+type CacheVolume struct {
+	// Name of the volume.
+	Name string
+
+	// Scope is the kind of layers cached, e.g. "build" or "launch".
+	Scope string
+
+	// Size of the contents of the volume in bytes, or -1 when the daemon does not report it.
+	Size int64
+
+	// InUse is whether a container, such as that of a running build, mounts the volume.
+	InUse bool
+
+	// LastUsed is when a build last used the volume, or when the volume was created for volumes no build of this
+	// host recorded using. It is zero when unknown.
+	LastUsed time.Time
+}
+
+// ClearCacheOptions select the cache volumes removed by ClearCacheVolumes.
+type ClearCacheOptions struct {
+	// Remove the cache volumes of this image.
+	ImageName string
+
+	// Remove every cache volume. Exclusive with ImageName.
+	All bool
+
+	// Only remove the volumes of this scope, e.g. "launch" to remove the layers of the previous images exported to
+	// the daemon while keeping the cache of the buildpacks. Empty removes the volumes of every scope.
+	Scope string
+}
+
+// ListCacheVolumes lists the volumes pack created to cache the layers of builds, sorted by name, which it recognizes
+// by their names. When imageName is not empty, only the volumes caching the layers of that image are listed.
+func (c *Client) ListCacheVolumes(ctx context.Context, imageName string) ([]CacheVolume, error) {
+	prefix := cacheVolumePrefix
+	if imageName != "" {
+		imageRef, err := name.ParseReference(imageName, name.WeakValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid image name '%s'", imageName)
+		}
+		prefix = cache.VolumeNamePrefix(imageRef)
+	}
+
+	list, err := c.docker.VolumeList(ctx, filters.NewArgs(filters.Arg("name", prefix)))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing cache volumes")
+	}
+
+	usage, err := c.readCacheUsage()
+	if err != nil {
+		c.logger.Debugf("Unable to read when cache volumes were last used: %s", err)
+	}
+
+	var volumes []CacheVolume
+	for _, vol := range list.Volumes {
+		scope, ok := cacheVolumeScope(vol.Name, prefix)
+		if !ok {
+			continue
+		}
+
+		lastUsed, ok := usage[vol.Name]
+		if !ok {
+			lastUsed, _ = time.Parse(time.RFC3339, vol.CreatedAt)
+		}
+		volumes = append(volumes, CacheVolume{Name: vol.Name, Scope: scope, Size: -1, LastUsed: lastUsed})
+	}
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+
+	// the daemon only computes the sizes of volumes for its disk usage
+	diskUsage, err := c.docker.DiskUsage(ctx)
+	if err != nil {
+		c.logger.Debugf("Unable to get the sizes of the cache volumes: %s", err)
+	}
+	for _, vol := range diskUsage.Volumes {
+		if vol == nil || vol.UsageData == nil {
+			continue
+		}
+		for i := range volumes {
+			if volumes[i].Name == vol.Name {
+				volumes[i].Size = vol.UsageData.Size
+				volumes[i].InUse = vol.UsageData.RefCount > 0
+			}
+		}
+	}
+
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	return volumes, nil
+}
+
+// ClearCacheVolumes removes the cache volumes selected by opts, and returns those it removed. Volumes in use by a
+// container, such as that of a running build, are kept.
+func (c *Client) ClearCacheVolumes(ctx context.Context, opts ClearCacheOptions) ([]CacheVolume, error) {
+	switch {
+	case opts.All && opts.ImageName != "":
+		return nil, errors.New("an image name cannot be given when clearing all cache volumes")
+	case !opts.All && opts.ImageName == "":
+		return nil, errors.New("an image name is required unless clearing all cache volumes")
+	}
+
+	volumes, err := c.ListCacheVolumes(ctx, opts.ImageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []CacheVolume
+	for _, vol := range volumes {
+		if opts.Scope != "" && vol.Scope != opts.Scope {
+			continue
+		}
+		ok, err := c.removeCacheVolume(ctx, vol)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed = append(removed, vol)
+		}
+	}
+	return removed, nil
+}
+
+// removeCacheVolume removes vol unless it is in use, and returns whether it was removed.
+func (c *Client) removeCacheVolume(ctx context.Context, vol CacheVolume) (bool, error) {
+	if vol.InUse {
+		c.logger.Warnf("Not removing cache volume %s, which is in use", style.Symbol(vol.Name))
+		return false, nil
+	}
+
+	if err := c.docker.VolumeRemove(ctx, vol.Name, false); err != nil {
+		switch {
+		case dockerClient.IsErrNotFound(err):
+			return false, nil
+		case errdefs.IsConflict(err):
+			c.logger.Warnf("Not removing cache volume %s, which is in use", style.Symbol(vol.Name))
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "removing volume %s", style.Symbol(vol.Name))
+	}
+
+	c.logger.Debugf("Removed cache volume %s", style.Symbol(vol.Name))
+	if err := c.forgetCacheUsage(vol.Name); err != nil {
+		c.logger.Debugf("Unable to forget when cache volume %s was last used: %s", style.Symbol(vol.Name), err)
+	}
+	return true, nil
+}
+
+// cacheVolumeScope returns the scope of the cache volume named volumeName, when its name, starting with prefix,
+// follows the naming of the cache volumes pack creates.
+func cacheVolumeScope(volumeName, prefix string) (string, bool) {
+	if !strings.HasPrefix(volumeName, prefix) {
+		return "", false
+	}
+
+	rest := strings.TrimPrefix(volumeName, prefix)
+	if prefix == cacheVolumePrefix {
+		// the image part of the name may contain dashes, but not the hash following it
+		if i := strings.LastIndex(rest, "-"); i >= 0 {
+			rest = rest[i+1:]
+		}
+	}
+
+	matches := cacheVolumeSuffix.FindStringSubmatch(rest)
+	if matches == nil {
+		return "", false
+	}
+	return matches[1], true
+}
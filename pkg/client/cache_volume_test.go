@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheVolume(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheVolume", testCacheVolume, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheVolume(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		appBuild         string
+		appLaunch        string
+		otherBuild       string
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithDockerClient(mockDockerClient),
+		)
+		h.AssertNil(t, err)
+
+		appBuild, err = CacheVolumeName("some/app", "some.stack", "sha256:buildpacks", "build")
+		h.AssertNil(t, err)
+		appLaunch, err = CacheVolumeName("some/app", "some.stack", "sha256:buildpacks", "launch")
+		h.AssertNil(t, err)
+		otherBuild, err = CacheVolumeName("some/app:latest-other", "some.stack", "sha256:buildpacks", "build")
+		h.AssertNil(t, err)
+
+		mockDockerClient.EXPECT().
+			VolumeList(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, args filters.Args) (volume.VolumeListOKBody, error) {
+				var volumes []*types.Volume
+				for _, name := range []string{appBuild, appLaunch, otherBuild, "pack-cache-user-named"} {
+					if args.Match("name", name) {
+						volumes = append(volumes, &types.Volume{Name: name})
+					}
+				}
+				return volume.VolumeListOKBody{Volumes: volumes}, nil
+			}).AnyTimes()
+		mockDockerClient.EXPECT().
+			DiskUsage(gomock.Any()).
+			Return(types.DiskUsage{Volumes: []*types.Volume{
+				{Name: appBuild, UsageData: &types.VolumeUsageData{Size: 1000}},
+				{Name: appLaunch, UsageData: &types.VolumeUsageData{Size: 2000, RefCount: 1}},
+			}}, nil).AnyTimes()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ListCacheVolumes", func() {
+		it("lists the volumes named as cache volumes, with their sizes", func() {
+			volumes, err := subject.ListCacheVolumes(context.TODO(), "")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(volumes), 3)
+
+			byName := map[string]CacheVolume{}
+			for _, vol := range volumes {
+				byName[vol.Name] = vol
+			}
+			h.AssertEq(t, byName[appBuild], CacheVolume{Name: appBuild, Scope: "build", Size: 1000})
+			h.AssertEq(t, byName[appLaunch], CacheVolume{Name: appLaunch, Scope: "launch", Size: 2000, InUse: true})
+			h.AssertEq(t, byName[otherBuild], CacheVolume{Name: otherBuild, Scope: "build", Size: -1})
+		})
+
+		it("lists only the volumes of the image", func() {
+			volumes, err := subject.ListCacheVolumes(context.TODO(), "index.docker.io/some/app:latest")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(volumes), 2)
+			for _, vol := range volumes {
+				h.AssertNotEq(t, vol.Name, otherBuild)
+			}
+		})
+	})
+
+	when("#ClearCacheVolumes", func() {
+		it("removes the volumes of the image which are not in use", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), appBuild, false).Return(nil)
+
+			removed, err := subject.ClearCacheVolumes(context.TODO(), ClearCacheOptions{ImageName: "some/app"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(removed), 1)
+			h.AssertEq(t, removed[0].Name, appBuild)
+			h.AssertContains(t, out.String(), "Not removing cache volume '"+appLaunch+"', which is in use")
+		})
+
+		it("keeps volumes the daemon reports as in use", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), appBuild, false).Return(errdefs.Conflict(errors.New("volume is in use")))
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), otherBuild, false).Return(nil)
+
+			removed, err := subject.ClearCacheVolumes(context.TODO(), ClearCacheOptions{All: true})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(removed), 1)
+			h.AssertEq(t, removed[0].Name, otherBuild)
+			h.AssertContains(t, out.String(), "Not removing cache volume '"+appBuild+"', which is in use")
+		})
+
+		it("removes only the volumes of the scope", func() {
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), appBuild, false).Return(nil)
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), otherBuild, false).Return(nil)
+
+			removed, err := subject.ClearCacheVolumes(context.TODO(), ClearCacheOptions{All: true, Scope: "build"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(removed), 2)
+			h.AssertNotContains(t, out.String(), appLaunch)
+		})
+
+		it("requires either an image name or all", func() {
+			_, err := subject.ClearCacheVolumes(context.TODO(), ClearCacheOptions{})
+			h.AssertError(t, err, "an image name is required unless clearing all cache volumes")
+
+			_, err = subject.ClearCacheVolumes(context.TODO(), ClearCacheOptions{ImageName: "some/app", All: true})
+			h.AssertError(t, err, "an image name cannot be given when clearing all cache volumes")
+		})
+	})
+}
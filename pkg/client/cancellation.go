@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// CancellationReason is a machine-readable explanation of why a build was cancelled.
+type CancellationReason string
+
+const (
+	// CancellationReasonContext means the context of the build was cancelled without a recorded reason.
+	CancellationReasonContext CancellationReason = "context-canceled"
+	// CancellationReasonSignal means the user aborted the build, e.g. by sending SIGINT.
+	CancellationReasonSignal CancellationReason = "signal"
+	// CancellationReasonTimeout means the deadline of the build's context was exceeded.
+	CancellationReasonTimeout CancellationReason = "timeout"
+	// CancellationReasonPolicyViolation means the build was stopped for violating a platform policy.
+	CancellationReasonPolicyViolation CancellationReason = "policy-violation"
+)
+
+// CancelWithReasonFunc cancels a context, recording why it was cancelled.
+// Only the first reason is recorded.
+type CancelWithReasonFunc func(reason CancellationReason)
+
+type cancellationReasonKey struct{}
+
+type cancellationReasonHolder struct {
+	mu     sync.Mutex
+	reason CancellationReason
+}
+
+// WithCancellationReason returns a copy of parent which is cancelled, like context.WithCancel, when the
+// returned cancel function is called. The reason passed to cancel is reported by CancellationReasonFor.
+func WithCancellationReason(parent context.Context) (context.Context, CancelWithReasonFunc) {
+	holder := &cancellationReasonHolder{}
+	ctx, cancel := context.WithCancel(context.WithValue(parent, cancellationReasonKey{}, holder))
+
+	return ctx, func(reason CancellationReason) {
+		holder.mu.Lock()
+		if holder.reason == "" {
+			holder.reason = reason
+		}
+		holder.mu.Unlock()
+		cancel()
+	}
+}
+
+// CancellationReasonFor returns why ctx was cancelled, or an empty reason when ctx is not done.
+// A reason recorded through WithCancellationReason takes precedence, otherwise the reason is derived from ctx.Err().
+func CancellationReasonFor(ctx context.Context) CancellationReason {
+	if ctx.Err() == nil {
+		return ""
+	}
+
+	if holder, ok := ctx.Value(cancellationReasonKey{}).(*cancellationReasonHolder); ok {
+		holder.mu.Lock()
+		reason := holder.reason
+		holder.mu.Unlock()
+		if reason != "" {
+			return reason
+		}
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return CancellationReasonTimeout
+	}
+	return CancellationReasonContext
+}
+
+// BuildCancelledError is returned by Build when the build was cancelled before completing.
+type BuildCancelledError struct {
+	// Reason the build was cancelled.
+	Reason CancellationReason
+
+	// Err is the error the cancelled operation returned.
+	Err error
+}
+
+func (e *BuildCancelledError) Error() string {
+	return fmt.Sprintf("build cancelled (reason: %s): %s", e.Reason, e.Err)
+}
+
+// Unwrap supports errors.Is and errors.As.
+func (e *BuildCancelledError) Unwrap() error {
+	return e.Err
+}
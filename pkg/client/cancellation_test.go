@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/client"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCancellation(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Cancellation", testCancellation, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCancellation(t *testing.T, when spec.G, it spec.S) {
+	when("#CancellationReasonFor", func() {
+		it("returns no reason while the context is not done", func() {
+			ctx, cancel := client.WithCancellationReason(context.Background())
+			defer cancel(client.CancellationReasonContext)
+
+			h.AssertEq(t, client.CancellationReasonFor(ctx), client.CancellationReason(""))
+		})
+
+		it("returns the recorded reason", func() {
+			ctx, cancel := client.WithCancellationReason(context.Background())
+			cancel(client.CancellationReasonSignal)
+
+			h.AssertEq(t, client.CancellationReasonFor(ctx), client.CancellationReasonSignal)
+		})
+
+		it("keeps the first recorded reason", func() {
+			ctx, cancel := client.WithCancellationReason(context.Background())
+			cancel(client.CancellationReasonPolicyViolation)
+			cancel(client.CancellationReasonSignal)
+
+			h.AssertEq(t, client.CancellationReasonFor(ctx), client.CancellationReasonPolicyViolation)
+		})
+
+		it("reports a timeout when the deadline is exceeded", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+			defer cancel()
+			<-ctx.Done()
+
+			h.AssertEq(t, client.CancellationReasonFor(ctx), client.CancellationReasonTimeout)
+		})
+
+		it("reports a plain cancellation when no reason was recorded", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			h.AssertEq(t, client.CancellationReasonFor(ctx), client.CancellationReasonContext)
+		})
+
+		it("reports the reason recorded on a parent context", func() {
+			parent, cancel := client.WithCancellationReason(context.Background())
+			ctx, cancelChild := context.WithCancel(parent)
+			defer cancelChild()
+			cancel(client.CancellationReasonSignal)
+
+			h.AssertEq(t, client.CancellationReasonFor(ctx), client.CancellationReasonSignal)
+		})
+	})
+
+	when("#BuildCancelledError", func() {
+		it("includes the reason and wraps the cause", func() {
+			err := &client.BuildCancelledError{Reason: client.CancellationReasonTimeout, Err: context.DeadlineExceeded}
+
+			h.AssertEq(t, err.Error(), "build cancelled (reason: timeout): context deadline exceeded")
+			h.AssertTrue(t, errors.Is(err, context.DeadlineExceeded))
+		})
+	})
+}
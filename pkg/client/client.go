@@ -94,9 +94,22 @@ type Client struct {
 	lifecycleExecutor   LifecycleExecutor
 	buildpackDownloader BuildpackDownloader
 
-	experimental    bool
-	registryMirrors map[string]string
-	version         string
+	experimental       bool
+	registryMirrors    map[string]string
+	insecureRegistries []string
+	caCertificatePaths []string
+	caCertificates     []byte
+	retryPolicy        image.RetryPolicy
+	envPolicy          EnvPolicy
+	version            string
+	cacheUsagePath     string
+	injectedLayerCache string
+	endpointResolver   RegistryEndpointResolver
+	registryTransport  *registryTransport
+
+	// daemonHost is the podman socket the docker client was created for when it was detected, which the build
+	// containers are given access to rather than the docker socket.
+	daemonHost string
 }
 
 // Option is a type of function that mutate settings on the client.
@@ -171,57 +184,122 @@ func WithRegistryMirrors(registryMirrors map[string]string) Option {
 	}
 }
 
-// WithKeychain sets keychain of credentials to image registries
+// WithInsecureRegistries sets registries to access over plain HTTP, such as registries of a cluster without TLS.
+func WithInsecureRegistries(insecureRegistries []string) Option {
+	return func(c *Client) {
+		c.insecureRegistries = insecureRegistries
+	}
+}
+
+// WithRetryPolicy sets how registry operations failing transiently are retried, when fetching images, by the
+// lifecycle phases accessing registries and when modifying the exported image. Defaults to image.DefaultRetryPolicy.
+func WithRetryPolicy(policy image.RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithKeychain sets keychain of credentials to image registries, authn.DefaultKeychain by default.
+// See NewKeychain for a keychain also resolving the credentials of cloud registries.
 func WithKeychain(keychain authn.Keychain) Option {
 	return func(c *Client) {
 		c.keychain = keychain
 	}
 }
 
+// WithEnvPolicy restricts the environment variables which may be provided to buildpacks.
+func WithEnvPolicy(envPolicy EnvPolicy) Option {
+	return func(c *Client) {
+		c.envPolicy = envPolicy
+	}
+}
+
 const DockerAPIVersion = "1.38"
 
 // NewClient allocates and returns a Client configured with the specified options.
 func NewClient(opts ...Option) (*Client, error) {
 	client := &Client{
-		version:  pack.Version,
-		keychain: authn.DefaultKeychain,
+		version:     pack.Version,
+		keychain:    authn.DefaultKeychain,
+		retryPolicy: image.DefaultRetryPolicy,
 	}
 
 	for _, opt := range opts {
 		opt(client)
 	}
 
+	if err := client.envPolicy.Validate(); err != nil {
+		return nil, err
+	}
+
 	if client.logger == nil {
 		client.logger = logging.NewSimpleLogger(os.Stderr)
 	}
 
-	if client.docker == nil {
+	if len(client.caCertificatePaths) > 0 {
 		var err error
-		client.docker, err = dockerClient.NewClientWithOpts(
+		client.caCertificates, err = readCACertificates(client.caCertificatePaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client.registryTransport = newRegistryTransport(client.insecureRegistries, client.endpointResolver, client.caCertificates)
+
+	if client.docker == nil {
+		dockerOpts := []dockerClient.Opt{
 			dockerClient.FromEnv,
 			dockerClient.WithVersion(DockerAPIVersion),
-		)
+		}
+		if socket := podmanSocket(os.Getenv, socketExists); socket != "" {
+			client.logger.Debugf("Using podman socket %s", style.Symbol(socket))
+			client.daemonHost = socket
+			dockerOpts = append(dockerOpts, dockerClient.WithHost(socket))
+		}
+
+		var err error
+		client.docker, err = dockerClient.NewClientWithOpts(dockerOpts...)
 		if err != nil {
 			return nil, errors.Wrap(err, "creating docker client")
 		}
 	}
 
 	if client.downloader == nil {
-		packHome, err := iconfig.PackHome()
+		cacheHome, err := iconfig.PackCacheHome()
 		if err != nil {
-			return nil, errors.Wrap(err, "getting pack home")
+			return nil, errors.Wrap(err, "getting pack cache home")
 		}
-		client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"))
+		client.downloader = blob.NewDownloader(client.logger, filepath.Join(cacheHome, "download-cache"))
+	}
+
+	if client.cacheUsagePath == "" {
+		cacheHome, err := iconfig.PackCacheHome()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting pack cache home")
+		}
+		client.cacheUsagePath = filepath.Join(cacheHome, cacheUsageFile)
+	}
+
+	if client.injectedLayerCache == "" {
+		client.injectedLayerCache = iconfig.StateDir("layers")
 	}
 
 	if client.imageFetcher == nil {
-		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain))
+		client.imageFetcher = image.NewFetcher(
+			client.logger,
+			client.docker,
+			image.WithRegistryMirrors(client.registryMirrors),
+			image.WithInsecureRegistries(client.insecureRegistries),
+			image.WithKeychain(client.keychain),
+			image.WithRetryPolicy(client.retryPolicy),
+		)
 	}
 
 	if client.imageFactory == nil {
 		client.imageFactory = &imageFactory{
-			dockerClient: client.docker,
-			keychain:     client.keychain,
+			dockerClient:       client.docker,
+			keychain:           client.keychain,
+			insecureRegistries: client.insecureRegistries,
 		}
 	}
 
@@ -260,8 +338,9 @@ func (r *registryResolver) Resolve(registryName, bpName string) (string, error)
 }
 
 type imageFactory struct {
-	dockerClient dockerClient.CommonAPIClient
-	keychain     authn.Keychain
+	dockerClient       dockerClient.CommonAPIClient
+	keychain           authn.Keychain
+	insecureRegistries []string
 }
 
 func (f *imageFactory) NewImage(repoName string, daemon bool, imageOS string) (imgutil.Image, error) {
@@ -271,5 +350,6 @@ func (f *imageFactory) NewImage(repoName string, daemon bool, imageOS string) (i
 		return local.NewImage(repoName, f.dockerClient, local.WithDefaultPlatform(platform))
 	}
 
-	return remote.NewImage(repoName, f.keychain, remote.WithDefaultPlatform(platform))
+	imageOpts := append([]remote.ImageOption{remote.WithDefaultPlatform(platform)}, image.RegistrySettings(f.insecureRegistries)...)
+	return remote.NewImage(repoName, f.keychain, imageOpts...)
 }
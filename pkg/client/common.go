@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/google/go-containerregistry/pkg/name"
 
@@ -43,7 +44,7 @@ func (c *Client) resolveRunImage(runImage, imgRegistry, bldrRegistry string, sta
 		preferredRegistry,
 		stackInfo.RunImage.Image,
 		stackInfo.RunImage.Mirrors,
-		additionalMirrors[stackInfo.RunImage.Image],
+		runImageMirrors(additionalMirrors, stackInfo.RunImage.Image),
 	)
 
 	switch {
@@ -58,7 +59,7 @@ func (c *Client) resolveRunImage(runImage, imgRegistry, bldrRegistry string, sta
 }
 
 func getRegistry(logger logging.Logger, registryName string) (registry.Cache, error) {
-	home, err := config.PackHome()
+	home, err := config.PackCacheHome()
 	if err != nil {
 		return registry.Cache{}, err
 	}
@@ -107,6 +108,34 @@ func contains(slc []string, v string) bool {
 	return false
 }
 
+// runImageMirrors returns the mirrors of runImage configured in mirrors, whose keys may name the run image otherwise,
+// such as with or without the default registry and tag. Mirrors of every key naming the run image are returned.
+func runImageMirrors(mirrors map[string][]string, runImage string) []string {
+	if m, ok := mirrors[runImage]; ok {
+		return m
+	}
+
+	ref, err := name.ParseReference(runImage, name.WeakValidation)
+	if err != nil {
+		return nil
+	}
+
+	var images []string
+	for img := range mirrors {
+		other, err := name.ParseReference(img, name.WeakValidation)
+		if err == nil && other.Name() == ref.Name() {
+			images = append(images, img)
+		}
+	}
+	sort.Strings(images)
+
+	var result []string
+	for _, img := range images {
+		result = append(result, mirrors[img]...)
+	}
+	return result
+}
+
 func getBestRunMirror(registry string, runImage string, mirrors []string, preferredMirrors []string) string {
 	var runImageList []string
 	runImageList = append(runImageList, preferredMirrors...)
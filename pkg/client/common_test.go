@@ -87,6 +87,14 @@ func testCommon(t *testing.T, when spec.G, it spec.S) {
 				assert.NotEqual(runImageName, defaultMirror)
 				assert.Equal(runImageName, defaultRegistry+"/unique-run-img")
 			})
+
+			it("finds config mirrors of the run image named with its registry and tag", func() {
+				configMirrors := map[string][]string{
+					"index.docker.io/" + runImageName + ":latest": {gcrRegistry + "/unique-run-img"},
+				}
+				runImageName := subject.resolveRunImage("", gcrRegistry, "", stackInfo, configMirrors, true)
+				assert.Equal(runImageName, gcrRegistry+"/unique-run-img")
+			})
 		})
 
 		// If publish is false, we are using the local daemon, and want to match to the builder registry
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// DefaultContainerdNamespace is the containerd namespace the kubelet, and thus k3s, reads images from.
+const DefaultContainerdNamespace = "k8s.io"
+
+// ContainerdExportOptions configures the export of a built image to a containerd instance, such as a k3s node,
+// which is done by streaming the image from the daemon to `ctr images import`.
+type ContainerdExportOptions struct {
+	// Address of the containerd socket, for example /run/k3s/containerd/containerd.sock.
+	// If empty, the default address of ctr is used.
+	Address string
+
+	// Containerd namespace to import the image into. Defaults to DefaultContainerdNamespace.
+	Namespace string
+
+	// Snapshotter to unpack the image with. If empty, the default snapshotter of containerd is used.
+	Snapshotter string
+
+	// Path to the ctr binary. Defaults to "ctr" on the PATH.
+	CtrPath string
+}
+
+func (o ContainerdExportOptions) importArgs() []string {
+	var args []string
+	if o.Address != "" {
+		args = append(args, "--address", o.Address)
+	}
+
+	namespace := o.Namespace
+	if namespace == "" {
+		namespace = DefaultContainerdNamespace
+	}
+	args = append(args, "--namespace", namespace, "images", "import")
+
+	if o.Snapshotter != "" {
+		args = append(args, "--snapshotter", o.Snapshotter)
+	}
+	return append(args, "-")
+}
+
+// exportToContainerd streams the images from the daemon into containerd
+func (c *Client) exportToContainerd(ctx context.Context, opts ContainerdExportOptions, refs ...name.Reference) error {
+	var names []string
+	for _, ref := range refs {
+		names = append(names, ref.Name())
+	}
+
+	c.logger.Infof("Exporting %s to containerd", strings.Join(names, ", "))
+	imageStream, err := c.docker.ImageSave(ctx, names)
+	if err != nil {
+		return errors.Wrap(err, "saving image from daemon")
+	}
+	defer imageStream.Close()
+
+	ctrPath := opts.CtrPath
+	if ctrPath == "" {
+		ctrPath = "ctr"
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, ctrPath, opts.importArgs()...)
+	cmd.Stdin = imageStream
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "importing image into containerd with %s: %s", style.Symbol(ctrPath), strings.TrimSpace(output.String()))
+	}
+	c.logger.Debug(strings.TrimSpace(output.String()))
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestContainerdExport(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ContainerdExport", testContainerdExport, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testContainerdExport(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		tmpDir           string
+		fakeCtr          string
+	)
+
+	it.Before(func() {
+		h.SkipIf(t, runtime.GOOS == "windows", "the fake ctr is a shell script")
+
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithDockerClient(mockDockerClient),
+		)
+		h.AssertNil(t, err)
+
+		tmpDir, err = ioutil.TempDir("", "pack.containerd-export.test.")
+		h.AssertNil(t, err)
+
+		// records its arguments and stdin next to itself
+		fakeCtr = filepath.Join(tmpDir, "ctr")
+		h.AssertNil(t, ioutil.WriteFile(fakeCtr, []byte(`#!/bin/sh
+echo "$@" > "$(dirname "$0")/args"
+cat > "$(dirname "$0")/stdin"
+`), 0755))
+	})
+
+	it.After(func() {
+		if mockController != nil {
+			mockController.Finish()
+		}
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#exportToContainerd", func() {
+		it("streams the saved images to ctr images import", func() {
+			mockDockerClient.EXPECT().
+				ImageSave(gomock.Any(), []string{"index.docker.io/some/app:latest", "index.docker.io/some/app:v1"}).
+				Return(ioutil.NopCloser(strings.NewReader("some-image-tar")), nil)
+
+			err := subject.exportToContainerd(context.TODO(), ContainerdExportOptions{
+				Address:     "/run/k3s/containerd/containerd.sock",
+				Snapshotter: "native",
+				CtrPath:     fakeCtr,
+			}, name.MustParseReference("some/app"), name.MustParseReference("some/app:v1"))
+			h.AssertNil(t, err)
+
+			args, err := ioutil.ReadFile(filepath.Join(tmpDir, "args"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, strings.TrimSpace(string(args)),
+				"--address /run/k3s/containerd/containerd.sock --namespace k8s.io images import --snapshotter native -")
+
+			stdin, err := ioutil.ReadFile(filepath.Join(tmpDir, "stdin"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(stdin), "some-image-tar")
+		})
+
+		it("includes the output of ctr when the import fails", func() {
+			h.AssertNil(t, ioutil.WriteFile(fakeCtr, []byte("#!/bin/sh\necho 'namespace not found'\nexit 1\n"), 0755))
+			mockDockerClient.EXPECT().
+				ImageSave(gomock.Any(), gomock.Any()).
+				Return(ioutil.NopCloser(strings.NewReader("some-image-tar")), nil)
+
+			err := subject.exportToContainerd(context.TODO(), ContainerdExportOptions{Namespace: "missing", CtrPath: fakeCtr}, name.MustParseReference("some/app"))
+			h.AssertError(t, err, "namespace not found")
+		})
+	})
+}
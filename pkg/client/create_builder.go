@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
@@ -11,8 +12,11 @@ import (
 	pubbldr "github.com/buildpacks/pack/builder"
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/stack"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 )
 
@@ -37,50 +41,93 @@ type CreateBuilderOptions struct {
 
 	// Strategy for updating images before a build.
 	PullPolicy image.PullPolicy
+
+	// Create the builder a second time and fail if the two builds do not produce identical images.
+	VerifyReproducible bool
 }
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
 // If any configuration is invalid, it will error and exit without creating any images.
 func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) error {
-	if err := c.validateConfig(ctx, opts); err != nil {
+	runImageMixins, err := c.validateConfig(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.Config.Platforms) > 0 {
+		return c.createMultiArchBuilder(ctx, opts, runImageMixins)
+	}
+
+	digest, err := c.createAndSaveBuilder(ctx, opts, runImageMixins)
+	if err != nil || !opts.VerifyReproducible {
+		return err
+	}
+
+	c.logger.Infof("Creating builder %s again to verify it is reproducible", style.Symbol(opts.BuilderName))
+	rebuiltDigest, err := c.createAndSaveBuilder(ctx, opts, runImageMixins)
+	if err != nil {
 		return err
 	}
 
+	if rebuiltDigest != digest {
+		return errors.Errorf("builder %s is not reproducible: first build produced %s, second build produced %s",
+			style.Symbol(opts.BuilderName), style.Symbol(digest), style.Symbol(rebuiltDigest))
+	}
+	c.logger.Infof("Builder %s is reproducible, both builds produced %s", style.Symbol(opts.BuilderName), style.Symbol(digest))
+
+	return nil
+}
+
+// createAndSaveBuilder creates and saves the builder, returning the digest (or, for the daemon, the ID) of the saved image.
+// The buildpacks are validated against the mixins of the run images, keyed by image name.
+func (c *Client) createAndSaveBuilder(ctx context.Context, opts CreateBuilderOptions, runImageMixins map[string][]string) (string, error) {
 	bldr, err := c.createBaseBuilder(ctx, opts)
 	if err != nil {
-		return errors.Wrap(err, "failed to create builder")
+		return "", errors.Wrap(err, "failed to create builder")
 	}
 
 	if err := c.addBuildpacksToBuilder(ctx, opts, bldr); err != nil {
-		return errors.Wrap(err, "failed to add buildpacks to builder")
+		return "", errors.Wrap(err, "failed to add buildpacks to builder")
 	}
 
 	bldr.SetOrder(opts.Config.Order)
 	bldr.SetStack(opts.Config.Stack)
+	bldr.SetRunImageMixins(runImageMixins)
+
+	if err := bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version}); err != nil {
+		return "", err
+	}
 
-	return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	id, err := bldr.Image().Identifier()
+	if err != nil {
+		return "", errors.Wrap(err, "reading builder image identifier")
+	}
+
+	return parseDigestFromImageID(id), nil
 }
 
-func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions) error {
+// validateConfig validates the builder config, and returns the mixins of the accessible run images, keyed by image name
+func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions) (map[string][]string, error) {
 	if err := pubbldr.ValidateConfig(opts.Config); err != nil {
-		return errors.Wrap(err, "invalid builder config")
+		return nil, errors.Wrap(err, "invalid builder config")
 	}
 
-	if err := c.validateRunImageConfig(ctx, opts); err != nil {
-		return errors.Wrap(err, "invalid run image config")
+	runImageMixins, err := c.validateRunImageConfig(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid run image config")
 	}
 
-	return nil
+	return runImageMixins, nil
 }
 
-func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderOptions) error {
+func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderOptions) (map[string][]string, error) {
 	var runImages []imgutil.Image
 	for _, i := range append([]string{opts.Config.Stack.RunImage}, opts.Config.Stack.RunImageMirrors...) {
 		if !opts.Publish {
 			img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
 			if err != nil {
 				if errors.Cause(err) != image.ErrNotFound {
-					return errors.Wrap(err, "failed to fetch image")
+					return nil, errors.Wrap(err, "failed to fetch image")
 				}
 			} else {
 				runImages = append(runImages, img)
@@ -91,7 +138,7 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 		img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: false, PullPolicy: opts.PullPolicy})
 		if err != nil {
 			if errors.Cause(err) != image.ErrNotFound {
-				return errors.Wrap(err, "failed to fetch image")
+				return nil, errors.Wrap(err, "failed to fetch image")
 			}
 			c.logger.Warnf("run image %s is not accessible", style.Symbol(i))
 		} else {
@@ -99,23 +146,30 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 		}
 	}
 
+	runImageMixins := map[string][]string{}
 	for _, img := range runImages {
 		stackID, err := img.Label("io.buildpacks.stack.id")
 		if err != nil {
-			return errors.Wrap(err, "failed to label image")
+			return nil, errors.Wrap(err, "failed to label image")
 		}
 
 		if stackID != opts.Config.Stack.ID {
-			return fmt.Errorf(
+			return nil, fmt.Errorf(
 				"stack %s from builder config is incompatible with stack %s from run image %s",
 				style.Symbol(opts.Config.Stack.ID),
 				style.Symbol(stackID),
 				style.Symbol(img.Name()),
 			)
 		}
+
+		var mixins []string
+		if _, err := dist.GetLabel(img, stack.MixinsLabel, &mixins); err != nil {
+			return nil, errors.Wrapf(err, "getting label %s", stack.MixinsLabel)
+		}
+		runImageMixins[img.Name()] = mixins
 	}
 
-	return nil
+	return runImageMixins, nil
 }
 
 func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions) (*builder.Builder, error) {
@@ -135,6 +189,11 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 		return nil, errors.Wrap(err, "lookup image OS")
 	}
 
+	arch, err := baseImage.Architecture()
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup image architecture")
+	}
+
 	if os == "windows" && !c.experimental {
 		return nil, NewExperimentError("Windows containers support is currently experimental.")
 	}
@@ -149,7 +208,7 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 		)
 	}
 
-	lifecycle, err := c.fetchLifecycle(ctx, opts.Config.Lifecycle, opts.RelativeBaseDir, os)
+	lifecycle, err := c.fetchLifecycle(ctx, opts.Config.Lifecycle, opts.RelativeBaseDir, os, arch)
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch lifecycle")
 	}
@@ -159,7 +218,7 @@ func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOption
 	return bldr, nil
 }
 
-func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleConfig, relativeBaseDir, os string) (builder.Lifecycle, error) {
+func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleConfig, relativeBaseDir, os, arch string) (builder.Lifecycle, error) {
 	if config.Version != "" && config.URI != "" {
 		return nil, errors.Errorf(
 			"%s can only declare %s or %s, not both",
@@ -176,14 +235,14 @@ func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleCon
 			return nil, errors.Wrapf(err, "%s must be a valid semver", style.Symbol("lifecycle.version"))
 		}
 
-		uri = uriFromLifecycleVersion(*v, os)
+		uri = uriFromLifecycleVersion(*v, os, arch)
 	case config.URI != "":
 		uri, err = paths.FilePathToURI(config.URI, relativeBaseDir)
 		if err != nil {
 			return nil, err
 		}
 	default:
-		uri = uriFromLifecycleVersion(*semver.MustParse(builder.DefaultLifecycleVersion), os)
+		uri = uriFromLifecycleVersion(*semver.MustParse(builder.DefaultLifecycleVersion), os, arch)
 	}
 
 	blob, err := c.downloader.Download(ctx, uri)
@@ -191,6 +250,12 @@ func (c *Client) fetchLifecycle(ctx context.Context, config pubbldr.LifecycleCon
 		return nil, errors.Wrap(err, "downloading lifecycle")
 	}
 
+	if config.SHA256 != "" {
+		if err := verifyLifecycleChecksum(blob, uri, config.SHA256); err != nil {
+			return nil, err
+		}
+	}
+
 	lifecycle, err := builder.NewLifecycle(blob)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid lifecycle")
@@ -241,6 +306,25 @@ func (c *Client) addBuildpacksToBuilder(ctx context.Context, opts CreateBuilderO
 	return nil
 }
 
+func verifyLifecycleChecksum(lifecycleBlob blob.Blob, uri, expected string) error {
+	actual, err := blob.Digest(lifecycleBlob)
+	if err != nil {
+		return errors.Wrap(err, "computing lifecycle checksum")
+	}
+
+	expected = strings.ToLower(strings.TrimPrefix(expected, "sha256:"))
+	if actual != expected {
+		return fmt.Errorf(
+			"lifecycle from URI %s has checksum %s which does not match checksum %s from builder config",
+			style.Symbol(uri),
+			style.Symbol(actual),
+			style.Symbol(expected),
+		)
+	}
+
+	return nil
+}
+
 func validateBuildpack(bp buildpack.Buildpack, source, expectedID, expectedBPVersion string) error {
 	if expectedID != "" && bp.Descriptor().Info.ID != expectedID {
 		return fmt.Errorf(
@@ -263,10 +347,14 @@ func validateBuildpack(bp buildpack.Buildpack, source, expectedID, expectedBPVer
 	return nil
 }
 
-func uriFromLifecycleVersion(version semver.Version, os string) string {
+func uriFromLifecycleVersion(version semver.Version, os, arch string) string {
 	if os == "windows" {
 		return fmt.Sprintf("https://github.com/buildpacks/lifecycle/releases/download/v%s/lifecycle-v%s+windows.x86-64.tgz", version.String(), version.String())
 	}
 
+	if arch == "arm64" {
+		return fmt.Sprintf("https://github.com/buildpacks/lifecycle/releases/download/v%s/lifecycle-v%s+linux.arm64.tgz", version.String(), version.String())
+	}
+
 	return fmt.Sprintf("https://github.com/buildpacks/lifecycle/releases/download/v%s/lifecycle-v%s+linux.x86-64.tgz", version.String(), version.String())
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/local"
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/docker/docker/api/types"
 	"github.com/golang/mock/gomock"
@@ -99,9 +100,11 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 
 			fakeRunImage = fakes.NewImage("some/run-image", "", nil)
 			h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "run:mixinZ"]`))
 
 			fakeRunImageMirror = fakes.NewImage("localhost:5000/some/run-image", "", nil)
 			h.AssertNil(t, fakeRunImageMirror.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+			h.AssertNil(t, fakeRunImageMirror.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "run:mixinZ"]`))
 
 			exampleBuildpackBlob := blob.NewBlob(filepath.Join("testdata", "buildpack"))
 			mockDownloader.EXPECT().Download(gomock.Any(), "https://example.fake/bp-one.tgz").Return(exampleBuildpackBlob, nil).AnyTimes()
@@ -179,6 +182,38 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			return bldr
 		}
 
+		when("verifying reproducibility", func() {
+			var newBuildImage = func(imageID string) *fakes.Image {
+				img := fakes.NewImage("some/build-image", "", local.IDIdentifier{ImageID: imageID})
+				h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+				h.AssertNil(t, img.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "build:mixinY"]`))
+				h.AssertNil(t, img.SetEnv("CNB_USER_ID", "1234"))
+				h.AssertNil(t, img.SetEnv("CNB_GROUP_ID", "4321"))
+				return img
+			}
+
+			it.Before(func() {
+				opts.VerifyReproducible = true
+				prepareFetcherWithRunImages()
+			})
+
+			it("creates the builder twice and succeeds when both builds are identical", func() {
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(newBuildImage("some-image-id"), nil)
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(newBuildImage("some-image-id"), nil)
+
+				h.AssertNil(t, subject.CreateBuilder(context.TODO(), opts))
+				h.AssertContains(t, out.String(), "Builder 'some/builder' is reproducible")
+			})
+
+			it("fails when the builds differ", func() {
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(newBuildImage("some-image-id"), nil)
+				mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(newBuildImage("other-image-id"), nil)
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "builder 'some/builder' is not reproducible")
+			})
+		})
+
 		when("validating the builder config", func() {
 			it("should fail when the stack ID is empty", func() {
 				opts.Config.Stack.ID = ""
@@ -423,6 +458,33 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 					h.AssertError(t, err, "invalid lifecycle")
 				})
 			})
+
+			when("lifecycle checksum is provided", func() {
+				it.Before(func() {
+					prepareFetcherWithBuildImage()
+					prepareFetcherWithRunImages()
+					opts.Config.Lifecycle.URI = "fake"
+
+					uri, err := paths.FilePathToURI(opts.Config.Lifecycle.URI, opts.RelativeBaseDir)
+					h.AssertNil(t, err)
+
+					mockDownloader.EXPECT().Download(gomock.Any(), uri).Return(blob.NewBlob(filepath.Join("testdata", "empty-file")), nil).AnyTimes()
+				})
+
+				it("should fail when the checksum does not match", func() {
+					opts.Config.Lifecycle.SHA256 = "0000000000000000000000000000000000000000000000000000000000000000"
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "has checksum 'e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855' which does not match checksum '0000000000000000000000000000000000000000000000000000000000000000' from builder config")
+				})
+
+				it("should use the lifecycle when the checksum matches", func() {
+					opts.Config.Lifecycle.SHA256 = "sha256:E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "invalid lifecycle")
+				})
+			})
 		})
 
 		when("only lifecycle version is provided", func() {
@@ -471,6 +533,49 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 					h.AssertNil(t, err)
 				})
 			})
+
+			when("arm64", func() {
+				it("should download from predetermined uri", func() {
+					prepareFetcherWithBuildImage()
+					prepareFetcherWithRunImages()
+					opts.Config.Lifecycle.URI = ""
+					opts.Config.Lifecycle.Version = "3.4.5"
+					h.AssertNil(t, fakeBuildImage.SetArchitecture("arm64"))
+
+					mockDownloader.EXPECT().Download(
+						gomock.Any(),
+						"https://github.com/buildpacks/lifecycle/releases/download/v3.4.5/lifecycle-v3.4.5+linux.arm64.tgz",
+					).Return(
+						blob.NewBlob(filepath.Join("testdata", "lifecycle", "platform-0.4")), nil,
+					)
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertNil(t, err)
+				})
+			})
+		})
+
+		when("multiple platforms are configured", func() {
+			it.Before(func() {
+				prepareFetcherWithRunImages()
+				opts.Config.Platforms = []pubbldr.PlatformConfig{
+					{OS: "linux", Architecture: "amd64", BuildImage: "some/build-image:amd64"},
+					{OS: "linux", Architecture: "arm64", BuildImage: "some/build-image:arm64"},
+				}
+			})
+
+			it("requires publishing", func() {
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "builders with multiple platforms must be published")
+			})
+
+			it("does not support verifying reproducibility", func() {
+				opts.Publish = true
+				opts.VerifyReproducible = true
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+				h.AssertError(t, err, "verifying reproducibility is not supported for builders with multiple platforms")
+			})
 		})
 
 		when("no lifecycle version or URI is provided", func() {
@@ -541,6 +646,19 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("run image mixins are not satisfied", func() {
+			it("should return an error", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+				h.AssertNil(t, fakeRunImageMirror.SetLabel("io.buildpacks.stack.mixins", `["run:mixinZ"]`))
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+
+				h.AssertError(t, err, "validating run image: 'localhost:5000/some/run-image' missing required mixin(s): mixinX")
+				h.AssertEq(t, fakeBuildImage.IsSaved(), false)
+			})
+		})
+
 		when("creation succeeds", func() {
 			it("should set basic metadata", func() {
 				prepareFetcherWithBuildImage()
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// createMultiArchBuilder creates a builder for each of the platforms of the config, from the build image of the
+// platform, and publishes a manifest list referencing them under the name of the builder. Each builder is
+// published with the platform appended to its tag, e.g. my/builder:latest-linux-arm64.
+func (c *Client) createMultiArchBuilder(ctx context.Context, opts CreateBuilderOptions, runImageMixins map[string][]string) error {
+	if !opts.Publish {
+		return errors.New("builders with multiple platforms must be published, as the daemon cannot store a manifest list")
+	}
+	if opts.VerifyReproducible {
+		return errors.New("verifying reproducibility is not supported for builders with multiple platforms")
+	}
+
+	indexRef, err := name.NewTag(opts.BuilderName, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "builder name %s must be a tag when creating a builder with multiple platforms", style.Symbol(opts.BuilderName))
+	}
+
+	index := mutate.IndexMediaType(empty.Index, types.DockerManifestList)
+	for _, platform := range opts.Config.Platforms {
+		platformRef := indexRef.Context().Tag(platformTag(indexRef.TagStr(), platform))
+		c.logger.Infof("Creating builder %s for platform %s", style.Symbol(platformRef.Name()), style.Symbol(platform.String()))
+
+		platformOpts := opts
+		platformOpts.BuilderName = platformRef.Name()
+		platformOpts.Config.Stack.BuildImage = platform.BuildImage
+		digest, err := c.createAndSaveBuilder(ctx, platformOpts, runImageMixins)
+		if err != nil {
+			return errors.Wrapf(err, "creating builder for platform %s", style.Symbol(platform.String()))
+		}
+
+		img, err := ggcrremote.Image(platformRef.Context().Digest(digest), c.remoteOptions(ctx)...)
+		if err != nil {
+			return errors.Wrapf(err, "fetching builder %s", style.Symbol(platformRef.Name()))
+		}
+		if err := ensurePlatform(img, platform); err != nil {
+			return err
+		}
+
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				Platform: &v1.Platform{OS: platform.OS, Architecture: platform.Architecture, Variant: platform.Variant},
+			},
+		})
+	}
+
+	if err := ggcrremote.WriteIndex(indexRef, index, c.remoteOptions(ctx)...); err != nil {
+		return errors.Wrapf(err, "writing manifest list %s", style.Symbol(indexRef.Name()))
+	}
+	return nil
+}
+
+func platformTag(tag string, platform pubbldr.PlatformConfig) string {
+	return fmt.Sprintf("%s-%s", tag, strings.ReplaceAll(platform.String(), "/", "-"))
+}
+
+// ensurePlatform fails if the builder, whose OS and architecture are those of its build image, is not for the platform
+func ensurePlatform(img v1.Image, platform pubbldr.PlatformConfig) error {
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return errors.Wrap(err, "reading builder config")
+	}
+
+	if configFile.OS != platform.OS || configFile.Architecture != platform.Architecture {
+		return errors.Errorf("build image %s is for platform %s, not %s",
+			style.Symbol(platform.BuildImage), style.Symbol(configFile.OS+"/"+configFile.Architecture), style.Symbol(platform.String()))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// EmulationPolicy controls what Build does when the builder is not built for the architecture of the daemon,
+// such as an amd64 builder on Apple Silicon, in which case it can only run under emulation.
+type EmulationPolicy string
+
+const (
+	// EmulationWarn runs the builder under emulation, with a warning about the performance impact.
+	EmulationWarn EmulationPolicy = ""
+	// EmulationAllow runs the builder under emulation without a warning.
+	EmulationAllow EmulationPolicy = "allow"
+	// EmulationDeny fails the build instead of running the builder under emulation.
+	EmulationDeny EmulationPolicy = "deny"
+)
+
+// daemonArchitectures maps the architectures reported by the daemon, which are those of uname, to image architectures
+var daemonArchitectures = map[string]string{
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+	"armv7l":  "arm",
+}
+
+// ParseEmulationPolicy parses an emulation policy, as accepted by the --emulation flag.
+func ParseEmulationPolicy(policy string) (EmulationPolicy, error) {
+	switch EmulationPolicy(policy) {
+	case EmulationWarn, EmulationAllow, EmulationDeny:
+		return EmulationPolicy(policy), nil
+	case "warn":
+		return EmulationWarn, nil
+	}
+
+	return EmulationWarn, errors.Errorf("invalid emulation policy %s: must be one of %s, %s or %s",
+		style.Symbol(policy), style.Symbol("warn"), style.Symbol(string(EmulationAllow)), style.Symbol(string(EmulationDeny)))
+}
+
+// checkBuilderArchitecture applies the emulation policy when the builder does not match the architecture of the daemon
+func (c *Client) checkBuilderArchitecture(ctx context.Context, builderImage imgutil.Image, policy EmulationPolicy) error {
+	if policy == EmulationAllow {
+		return nil
+	}
+
+	builderArch, err := builderImage.Architecture()
+	if err != nil {
+		return errors.Wrap(err, "getting builder architecture")
+	}
+	if builderArch == "" {
+		return nil
+	}
+
+	// the check is advisory, so a daemon that cannot report its architecture is left to fail the build later, if at all
+	daemonArch, err := c.DaemonArchitecture(ctx)
+	if err != nil {
+		c.logger.Debugf("Not checking the builder architecture: %s", err)
+		return nil
+	}
+	if daemonArch == "" || daemonArch == builderArch {
+		return nil
+	}
+
+	if policy == EmulationDeny {
+		return errors.Errorf("builder %s is built for %s and would run under emulation on this %s daemon",
+			style.Symbol(builderImage.Name()), style.Symbol(builderArch), style.Symbol(daemonArch))
+	}
+
+	c.logger.Warnf("Builder %s is built for %s and will run under emulation on this %s daemon, which is significantly slower",
+		style.Symbol(builderImage.Name()), style.Symbol(builderArch), style.Symbol(daemonArch))
+	logging.Tip(c.logger, "Use a builder built for %s, or configure a native alternative to this builder under %s in the pack config", daemonArch, style.Symbol("native-builders"))
+	return nil
+}
+
+// DaemonArchitecture returns the architecture of the docker daemon builds run on, as an image architecture such as
+// amd64, which differs from the architecture of the host when the daemon is remote or runs in a VM.
+func (c *Client) DaemonArchitecture(ctx context.Context) (string, error) {
+	info, err := c.docker.Info(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting docker info")
+	}
+	if arch, ok := daemonArchitectures[info.Architecture]; ok {
+		return arch, nil
+	}
+	return info.Architecture, nil
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestEmulation(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Emulation", testEmulation, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testEmulation(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		builderImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithDockerClient(mockDockerClient),
+		)
+		h.AssertNil(t, err)
+
+		builderImage = fakes.NewImage("some/builder", "", nil)
+		h.AssertNil(t, builderImage.SetArchitecture("amd64"))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#checkBuilderArchitecture", func() {
+		when("the builder matches the architecture of the daemon", func() {
+			it("succeeds without a warning", func() {
+				mockDockerClient.EXPECT().Info(gomock.Any()).Return(types.Info{Architecture: "x86_64"}, nil)
+
+				h.AssertNil(t, subject.checkBuilderArchitecture(context.TODO(), builderImage, EmulationWarn))
+				h.AssertNotContains(t, out.String(), "emulation")
+			})
+		})
+
+		when("the builder would run under emulation", func() {
+			it.Before(func() {
+				mockDockerClient.EXPECT().Info(gomock.Any()).Return(types.Info{Architecture: "aarch64"}, nil).AnyTimes()
+			})
+
+			it("warns by default", func() {
+				h.AssertNil(t, subject.checkBuilderArchitecture(context.TODO(), builderImage, EmulationWarn))
+				h.AssertContains(t, out.String(), "Warning: Builder 'some/builder' is built for 'amd64' and will run under emulation on this 'arm64' daemon")
+			})
+
+			it("errors when emulation is denied", func() {
+				err := subject.checkBuilderArchitecture(context.TODO(), builderImage, EmulationDeny)
+				h.AssertError(t, err, "builder 'some/builder' is built for 'amd64' and would run under emulation on this 'arm64' daemon")
+			})
+
+			it("neither warns nor errors when emulation is allowed", func() {
+				h.AssertNil(t, subject.checkBuilderArchitecture(context.TODO(), builderImage, EmulationAllow))
+				h.AssertNotContains(t, out.String(), "emulation")
+			})
+		})
+
+		when("the daemon cannot report its architecture", func() {
+			it("skips the check", func() {
+				mockDockerClient.EXPECT().Info(gomock.Any()).Return(types.Info{}, errors.New("Cannot connect to the Docker daemon"))
+
+				h.AssertNil(t, subject.checkBuilderArchitecture(context.TODO(), builderImage, EmulationDeny))
+				h.AssertNotContains(t, out.String(), "emulation")
+			})
+		})
+	})
+
+	when("#ParseEmulationPolicy", func() {
+		it("parses the policies", func() {
+			for input, expected := range map[string]EmulationPolicy{
+				"":      EmulationWarn,
+				"warn":  EmulationWarn,
+				"allow": EmulationAllow,
+				"deny":  EmulationDeny,
+			} {
+				policy, err := ParseEmulationPolicy(input)
+				h.AssertNil(t, err)
+				h.AssertEq(t, policy, expected)
+			}
+		})
+
+		it("errors for unknown policies", func() {
+			_, err := ParseEmulationPolicy("sometimes")
+			h.AssertError(t, err, "invalid emulation policy 'sometimes'")
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnvPolicy restricts the environment variables which may be provided to buildpacks, through BuildOptions.Env
+// or the project descriptor. It lets platforms embedding the client prevent users from injecting variables
+// which change the behavior of the lifecycle or the buildpacks, such as CNB_* or LD_PRELOAD.
+//
+// Patterns are matched against variable names with path.Match, e.g. "CNB_*". A variable is allowed when it
+// matches no Deny pattern and, if any Allow patterns are provided, matches at least one of them.
+type EnvPolicy struct {
+	// Patterns of the names of variables which may be provided. If empty, every variable not denied is allowed.
+	Allow []string
+
+	// Patterns of the names of variables which may not be provided. Takes precedence over Allow.
+	Deny []string
+}
+
+// Validate returns an error if any of the patterns of the policy is malformed.
+func (p EnvPolicy) Validate() error {
+	for _, pattern := range append(append([]string{}, p.Allow...), p.Deny...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid environment policy pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+// Allows returns whether the variable with the given name may be provided.
+func (p EnvPolicy) Allows(name string) bool {
+	if matchesAny(p.Deny, name) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchesAny(p.Allow, name)
+}
+
+func (p EnvPolicy) check(env map[string]string) error {
+	var denied []string
+	for name := range env {
+		if !p.Allows(name) {
+			denied = append(denied, name)
+		}
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+
+	sort.Strings(denied)
+	return errors.Errorf("environment variable(s) not allowed by the environment policy: %s", strings.Join(denied, ", "))
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
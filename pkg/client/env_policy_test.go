@@ -0,0 +1,63 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestEnvPolicy(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "EnvPolicy", testEnvPolicy, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testEnvPolicy(t *testing.T, when spec.G, it spec.S) {
+	when("#Allows", func() {
+		it("allows every variable by default", func() {
+			h.AssertEq(t, EnvPolicy{}.Allows("LD_PRELOAD"), true)
+		})
+
+		it("denies variables matching a deny pattern", func() {
+			policy := EnvPolicy{Deny: []string{"CNB_*", "LD_PRELOAD"}}
+			h.AssertEq(t, policy.Allows("CNB_PLATFORM_API"), false)
+			h.AssertEq(t, policy.Allows("LD_PRELOAD"), false)
+			h.AssertEq(t, policy.Allows("BP_NODE_VERSION"), true)
+		})
+
+		it("only allows variables matching an allow pattern, unless denied", func() {
+			policy := EnvPolicy{Allow: []string{"BP_*"}, Deny: []string{"BP_DEBUG"}}
+			h.AssertEq(t, policy.Allows("BP_NODE_VERSION"), true)
+			h.AssertEq(t, policy.Allows("BP_DEBUG"), false)
+			h.AssertEq(t, policy.Allows("NODE_ENV"), false)
+		})
+	})
+
+	when("#check", func() {
+		it("lists the variables which are not allowed", func() {
+			err := EnvPolicy{Deny: []string{"CNB_*"}}.check(map[string]string{
+				"CNB_USER_ID":     "0",
+				"CNB_APP_DIR":     "/",
+				"BP_NODE_VERSION": "18",
+			})
+			h.AssertError(t, err, "environment variable(s) not allowed by the environment policy: CNB_APP_DIR, CNB_USER_ID")
+		})
+	})
+
+	when("#Validate", func() {
+		it("errors for malformed patterns", func() {
+			h.AssertError(t, EnvPolicy{Allow: []string{"BP_["}}.Validate(), "invalid environment policy pattern 'BP_['")
+		})
+	})
+
+	when("#WithEnvPolicy", func() {
+		it("errors when the policy is malformed", func() {
+			_, err := NewClient(WithEnvPolicy(EnvPolicy{Deny: []string{"["}}))
+			h.AssertError(t, err, "invalid environment policy pattern '['")
+		})
+	})
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// EphemeralLabel marks the images pack creates on the daemon for its own use, with the kind of image as value.
+	EphemeralLabel = "io.buildpacks.pack.ephemeral"
+
+	// EphemeralTargetLabel records the app image an ephemeral image was created for.
+	EphemeralTargetLabel = "io.buildpacks.pack.target"
+
+	// EphemeralKindBuilder is the kind of the builders pack creates for a single build.
+	EphemeralKindBuilder = "builder"
+
+	// DefaultEphemeralImageMinAge is the age ephemeral images must reach before `pack images prune` removes them
+	// by default, so that the builders of builds in progress are kept.
+	DefaultEphemeralImageMinAge = time.Hour
+)
+
+// EphemeralImage describes an image pack created on the daemon for its own use, such as the builder of a build.
+type EphemeralImage struct {
+	// ID of the image.
+	ID string
+
+	// Tags of the image.
+	Tags []string
+
+	// Kind of the image, e.g. EphemeralKindBuilder.
+	Kind string
+
+	// Target is the app image the ephemeral image was created for.
+	Target string
+
+	// Created is when the image was created.
+	Created time.Time
+
+	// Size of the image in bytes.
+	Size int64
+}
+
+// EphemeralImageFilter selects ephemeral images. The zero value selects every ephemeral image.
+type EphemeralImageFilter struct {
+	// Only select images created at least this long ago.
+	OlderThan time.Duration
+
+	// Only select images created for this app image.
+	Target string
+}
+
+// PruneEphemeralImagesOptions is a configuration struct that controls the behavior of PruneEphemeralImages.
+type PruneEphemeralImagesOptions struct {
+	// Images to remove. As the zero value selects the builders of builds in progress too, callers should
+	// usually set OlderThan, e.g. to DefaultEphemeralImageMinAge.
+	EphemeralImageFilter
+
+	// List the images which would be removed, without removing them.
+	DryRun bool
+}
+
+// ListEphemeralImages lists the images pack left on the daemon, most recent first. Ephemeral images are normally
+// removed at the end of the operation which created them, but remain when pack is interrupted.
+func (c *Client) ListEphemeralImages(ctx context.Context, filter EphemeralImageFilter) ([]EphemeralImage, error) {
+	summaries, err := c.docker.ImageList(ctx, types.ImageListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", EphemeralLabel)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing images")
+	}
+
+	var images []EphemeralImage
+	for _, summary := range summaries {
+		img := EphemeralImage{
+			ID:      summary.ID,
+			Tags:    summary.RepoTags,
+			Kind:    summary.Labels[EphemeralLabel],
+			Target:  summary.Labels[EphemeralTargetLabel],
+			Created: time.Unix(summary.Created, 0),
+			Size:    summary.Size,
+		}
+
+		if filter.Target != "" && img.Target != filter.Target {
+			continue
+		}
+		if time.Since(img.Created) < filter.OlderThan {
+			continue
+		}
+		images = append(images, img)
+	}
+
+	sort.Slice(images, func(i, j int) bool { return images[i].Created.After(images[j].Created) })
+	return images, nil
+}
+
+// PruneEphemeralImages removes the images pack left on the daemon, and returns those it removed
+// (or, for a dry run, would remove). Images used by containers, such as those of builds in progress, are kept.
+func (c *Client) PruneEphemeralImages(ctx context.Context, opts PruneEphemeralImagesOptions) ([]EphemeralImage, error) {
+	images, err := c.ListEphemeralImages(ctx, opts.EphemeralImageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := c.docker.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing containers")
+	}
+	inUse := map[string]bool{}
+	for _, ctr := range containers {
+		inUse[ctr.ImageID] = true
+	}
+
+	var unused []EphemeralImage
+	for _, img := range images {
+		if inUse[img.ID] {
+			c.logger.Warnf("Not removing ephemeral %s %s, which is in use", img.Kind, style.Symbol(img.ID))
+			continue
+		}
+		unused = append(unused, img)
+	}
+	if opts.DryRun {
+		return unused, nil
+	}
+
+	var removed []EphemeralImage
+	for _, img := range unused {
+		if _, err := c.docker.ImageRemove(ctx, img.ID, types.ImageRemoveOptions{PruneChildren: true}); err != nil {
+			switch {
+			case dockerClient.IsErrNotFound(err):
+				continue
+			case errdefs.IsConflict(err):
+				c.logger.Warnf("Not removing ephemeral %s %s: %s", img.Kind, style.Symbol(img.ID), err)
+				continue
+			}
+			return removed, errors.Wrapf(err, "removing image %s", style.Symbol(img.ID))
+		}
+		c.logger.Debugf("Removed ephemeral %s %s", img.Kind, style.Symbol(img.ID))
+		removed = append(removed, img)
+	}
+	return removed, nil
+}
+
+func labelEphemeralImage(img imgutil.Image, kind, target string) error {
+	if err := img.SetLabel(EphemeralLabel, kind); err != nil {
+		return errors.Wrapf(err, "setting label %s", style.Symbol(EphemeralLabel))
+	}
+	if err := img.SetLabel(EphemeralTargetLabel, target); err != nil {
+		return errors.Wrapf(err, "setting label %s", style.Symbol(EphemeralTargetLabel))
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestEphemeralImages(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "EphemeralImages", testEphemeralImages, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testEphemeralImages(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		now              time.Time
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithDockerClient(mockDockerClient),
+		)
+		h.AssertNil(t, err)
+
+		now = time.Now()
+		mockDockerClient.EXPECT().
+			ImageList(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, opts types.ImageListOptions) ([]types.ImageSummary, error) {
+				h.AssertEq(t, opts.Filters.Get("label"), []string{EphemeralLabel})
+				return []types.ImageSummary{
+					{
+						ID:       "sha256:old",
+						RepoTags: []string{"pack.local/builder/old:latest"},
+						Created:  now.Add(-48 * time.Hour).Unix(),
+						Labels:   map[string]string{EphemeralLabel: EphemeralKindBuilder, EphemeralTargetLabel: "index.docker.io/some/app:latest"},
+					},
+					{
+						ID:       "sha256:new",
+						RepoTags: []string{"pack.local/builder/new:latest"},
+						Created:  now.Unix(),
+						Labels:   map[string]string{EphemeralLabel: EphemeralKindBuilder, EphemeralTargetLabel: "index.docker.io/other/app:latest"},
+					},
+				}, nil
+			}).AnyTimes()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ListEphemeralImages", func() {
+		it("lists the labeled images, most recent first", func() {
+			images, err := subject.ListEphemeralImages(context.TODO(), EphemeralImageFilter{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(images), 2)
+			h.AssertEq(t, images[0].ID, "sha256:new")
+			h.AssertEq(t, images[1], EphemeralImage{
+				ID:      "sha256:old",
+				Tags:    []string{"pack.local/builder/old:latest"},
+				Kind:    EphemeralKindBuilder,
+				Target:  "index.docker.io/some/app:latest",
+				Created: time.Unix(now.Add(-48*time.Hour).Unix(), 0),
+			})
+		})
+
+		it("filters by age and target", func() {
+			images, err := subject.ListEphemeralImages(context.TODO(), EphemeralImageFilter{OlderThan: 24 * time.Hour})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(images), 1)
+			h.AssertEq(t, images[0].ID, "sha256:old")
+
+			images, err = subject.ListEphemeralImages(context.TODO(), EphemeralImageFilter{Target: "index.docker.io/other/app:latest"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(images), 1)
+			h.AssertEq(t, images[0].ID, "sha256:new")
+		})
+	})
+
+	when("#PruneEphemeralImages", func() {
+		var containers []types.Container
+
+		it.Before(func() {
+			containers = nil
+			mockDockerClient.EXPECT().
+				ContainerList(gomock.Any(), types.ContainerListOptions{All: true}).
+				DoAndReturn(func(context.Context, types.ContainerListOptions) ([]types.Container, error) {
+					return containers, nil
+				}).AnyTimes()
+		})
+
+		it("removes the selected images", func() {
+			mockDockerClient.EXPECT().
+				ImageRemove(gomock.Any(), "sha256:old", types.ImageRemoveOptions{PruneChildren: true}).
+				Return(nil, nil)
+
+			removed, err := subject.PruneEphemeralImages(context.TODO(), PruneEphemeralImagesOptions{
+				EphemeralImageFilter: EphemeralImageFilter{OlderThan: 24 * time.Hour},
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(removed), 1)
+			h.AssertEq(t, removed[0].ID, "sha256:old")
+		})
+
+		it("does not remove images on a dry run", func() {
+			removed, err := subject.PruneEphemeralImages(context.TODO(), PruneEphemeralImagesOptions{DryRun: true})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(removed), 2)
+		})
+
+		it("keeps the images used by containers", func() {
+			containers = []types.Container{{ID: "some-container", ImageID: "sha256:old"}}
+
+			removed, err := subject.PruneEphemeralImages(context.TODO(), PruneEphemeralImagesOptions{
+				EphemeralImageFilter: EphemeralImageFilter{OlderThan: 24 * time.Hour},
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(removed), 0)
+			h.AssertContains(t, out.String(), "Not removing ephemeral builder 'sha256:old', which is in use")
+		})
+
+		it("keeps the images the daemon refuses to remove", func() {
+			mockDockerClient.EXPECT().
+				ImageRemove(gomock.Any(), "sha256:old", types.ImageRemoveOptions{PruneChildren: true}).
+				Return(nil, errdefs.Conflict(errors.New("image is being used by a container")))
+
+			removed, err := subject.PruneEphemeralImages(context.TODO(), PruneEphemeralImagesOptions{
+				EphemeralImageFilter: EphemeralImageFilter{OlderThan: 24 * time.Hour},
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(removed), 0)
+			h.AssertContains(t, out.String(), "Not removing ephemeral builder 'sha256:old'")
+		})
+	})
+
+	when("#labelEphemeralImage", func() {
+		it("labels the image with its kind and target", func() {
+			img := fakes.NewImage("pack.local/builder/some:latest", "", nil)
+			h.AssertNil(t, labelEphemeralImage(img, EphemeralKindBuilder, "index.docker.io/some/app:latest"))
+
+			kind, err := img.Label(EphemeralLabel)
+			h.AssertNil(t, err)
+			h.AssertEq(t, kind, EphemeralKindBuilder)
+			target, err := img.Label(EphemeralTargetLabel)
+			h.AssertNil(t, err)
+			h.AssertEq(t, target, "index.docker.io/some/app:latest")
+		})
+	})
+}
@@ -23,3 +23,38 @@ func NewSoftError() SoftError {
 func (se SoftError) Error() string {
 	return ""
 }
+
+// FailureClass categorizes why a build failed, so that callers can react to each kind of failure differently.
+type FailureClass string
+
+const (
+	// FailureDetect means no group of buildpacks passed detection.
+	FailureDetect FailureClass = "detect"
+	// FailureBuild means a buildpack failed during the build phase.
+	FailureBuild FailureClass = "build"
+	// FailureExport means the app image could not be exported to the daemon or published to a registry.
+	FailureExport FailureClass = "export"
+	// FailureDaemon means the docker daemon could not be reached.
+	FailureDaemon FailureClass = "daemon"
+)
+
+// FailureError is returned by Build when the failure can be attributed to a FailureClass.
+// Its message is the message of the underlying error.
+type FailureError struct {
+	Class FailureClass
+	Err   error
+}
+
+func (e *FailureError) Error() string {
+	return e.Err.Error()
+}
+
+// Cause supports errors.Cause.
+func (e *FailureError) Cause() error {
+	return e.Err
+}
+
+// Unwrap supports errors.Is and errors.As.
+func (e *FailureError) Unwrap() error {
+	return e.Err
+}
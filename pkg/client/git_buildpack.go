@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// gitBuildpackPrefix starts the URIs of buildpacks sourced from git repositories, as git+<url>[#<ref>].
+const gitBuildpackPrefix = "git+"
+
+// cloneGitBuildpacks clones the buildpacks of opts sourced from git repositories, replacing their URIs with the
+// directories they are cloned into, so that they are packaged like buildpacks in local directories. The returned
+// function removes the clones.
+func (c *Client) cloneGitBuildpacks(ctx context.Context, opts *BuildOptions) (func(), error) {
+	var dirs []string
+	cleanup := func() {
+		for _, dir := range dirs {
+			os.RemoveAll(dir)
+		}
+	}
+
+	clone := func(uri string) (string, error) {
+		if !strings.HasPrefix(uri, gitBuildpackPrefix) {
+			return uri, nil
+		}
+
+		dir, err := c.cloneGitSource(ctx, strings.TrimPrefix(uri, gitBuildpackPrefix))
+		if err != nil {
+			return "", errors.Wrapf(err, "fetching buildpack %s", style.Symbol(uri))
+		}
+		dirs = append(dirs, dir)
+
+		if _, err := os.Stat(filepath.Join(dir, "buildpack.toml")); err != nil {
+			return "", errors.Errorf("buildpack %s has no %s at the root of its repository", style.Symbol(uri), style.Symbol("buildpack.toml"))
+		}
+		c.logger.Debugf("Using buildpack %s cloned into %s", style.Symbol(uri), style.Symbol(dir))
+		return dir, nil
+	}
+
+	buildpacks := make([]string, len(opts.Buildpacks))
+	for i, uri := range opts.Buildpacks {
+		dir, err := clone(uri)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		buildpacks[i] = dir
+	}
+	opts.Buildpacks = buildpacks
+
+	descriptorBuildpacks := append(opts.ProjectDescriptor.Build.Buildpacks[:0:0], opts.ProjectDescriptor.Build.Buildpacks...)
+	for i, bp := range descriptorBuildpacks {
+		dir, err := clone(bp.URI)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		descriptorBuildpacks[i].URI = dir
+	}
+	opts.ProjectDescriptor.Build.Buildpacks = descriptorBuildpacks
+
+	return cleanup, nil
+}
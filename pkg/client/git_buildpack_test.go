@@ -0,0 +1,106 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestGitBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "GitBuildpack", testGitBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testGitBuildpack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *Client
+		repoDir string
+		out     bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		repoDir, err = ioutil.TempDir("", "git-buildpack-test")
+		h.AssertNil(t, err)
+
+		repo, err := git.PlainInit(repoDir, false)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ioutil.WriteFile(filepath.Join(repoDir, "buildpack.toml"), []byte(`api = "0.7"
+[buildpack]
+id = "some/bp"
+version = "1.2.3"
+`), 0600))
+		worktree, err := repo.Worktree()
+		h.AssertNil(t, err)
+		_, err = worktree.Add("buildpack.toml")
+		h.AssertNil(t, err)
+		_, err = worktree.Commit("add buildpack", &git.CommitOptions{
+			Author: &object.Signature{Name: "test", When: time.Now()},
+		})
+		h.AssertNil(t, err)
+
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(repoDir))
+	})
+
+	when("#cloneGitBuildpacks", func() {
+		it("replaces the buildpacks in git repositories with their clones", func() {
+			opts := BuildOptions{
+				Buildpacks: []string{"some/other-bp@1.0.0", "git+file://" + filepath.ToSlash(repoDir)},
+				ProjectDescriptor: projectTypes.Descriptor{
+					Build: projectTypes.Build{Buildpacks: []projectTypes.Buildpack{{URI: "git+file://" + filepath.ToSlash(repoDir)}}},
+				},
+			}
+
+			cleanup, err := subject.cloneGitBuildpacks(context.TODO(), &opts)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, opts.Buildpacks[0], "some/other-bp@1.0.0")
+			h.AssertNotEq(t, opts.Buildpacks[1], "git+file://"+filepath.ToSlash(repoDir))
+			_, err = os.Stat(filepath.Join(opts.Buildpacks[1], "buildpack.toml"))
+			h.AssertNil(t, err)
+			_, err = os.Stat(filepath.Join(opts.ProjectDescriptor.Build.Buildpacks[0].URI, "buildpack.toml"))
+			h.AssertNil(t, err)
+
+			cleanup()
+			_, err = os.Stat(opts.Buildpacks[1])
+			h.AssertTrue(t, os.IsNotExist(err))
+		})
+
+		it("errors when the repository has no buildpack.toml", func() {
+			h.AssertNil(t, os.Remove(filepath.Join(repoDir, "buildpack.toml")))
+			repo, err := git.PlainOpen(repoDir)
+			h.AssertNil(t, err)
+			worktree, err := repo.Worktree()
+			h.AssertNil(t, err)
+			_, err = worktree.Commit("remove buildpack", &git.CommitOptions{
+				All:    true,
+				Author: &object.Signature{Name: "test", When: time.Now()},
+			})
+			h.AssertNil(t, err)
+
+			opts := BuildOptions{Buildpacks: []string{"git+file://" + filepath.ToSlash(repoDir)}}
+			_, err = subject.cloneGitBuildpacks(context.TODO(), &opts)
+			h.AssertError(t, err, "has no 'buildpack.toml' at the root of its repository")
+		})
+	})
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+var commitHashRegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// isGitURL reports whether appPath references a remote git repository, rather than a path on the local filesystem.
+func isGitURL(appPath string) bool {
+	u, err := url.Parse(appPath)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	switch u.Scheme {
+	case "http", "https", "git", "ssh":
+		return true
+	default:
+		return false
+	}
+}
+
+// cloneGitSource clones the repository referenced by source into a new temporary directory, which is returned.
+// Source is a git URL with an optional '#<ref>' fragment selecting the branch, tag or commit to check out.
+// Branches and tags are cloned shallowly, commits require the full history to be fetched.
+func (c *Client) cloneGitSource(ctx context.Context, source string) (string, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing git url %s", style.Symbol(source))
+	}
+	ref := u.Fragment
+	u.Fragment = ""
+	repoURL := u.String()
+
+	dir, err := ioutil.TempDir("", "pack.git.")
+	if err != nil {
+		return "", errors.Wrap(err, "creating temp dir")
+	}
+
+	if ref == "" {
+		c.logger.Debugf("Cloning %s", style.Symbol(repoURL))
+	} else {
+		c.logger.Debugf("Cloning %s at %s", style.Symbol(repoURL), style.Symbol(ref))
+	}
+
+	if err := c.cloneGitRef(ctx, dir, repoURL, ref); err != nil {
+		os.RemoveAll(dir)
+		return "", errors.Wrapf(err, "cloning %s", style.Symbol(source))
+	}
+
+	return dir, nil
+}
+
+func (c *Client) cloneGitRef(ctx context.Context, dir, repoURL, ref string) error {
+	progress := logging.GetWriterForLevel(c.logger, logging.DebugLevel)
+
+	if ref == "" {
+		_, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+			URL:      repoURL,
+			Depth:    1,
+			Tags:     git.NoTags,
+			Progress: progress,
+		})
+		return err
+	}
+
+	for _, refName := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)} {
+		_, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+			URL:           repoURL,
+			ReferenceName: refName,
+			SingleBranch:  true,
+			Depth:         1,
+			Tags:          git.NoTags,
+			Progress:      progress,
+		})
+		if err == nil {
+			return nil
+		}
+		if !isReferenceNotFound(err) {
+			return err
+		}
+
+		// a failed clone may leave a partially initialized repository behind
+		if err := resetDir(dir); err != nil {
+			return err
+		}
+	}
+
+	if !commitHashRegex.MatchString(ref) {
+		return errors.Errorf("no branch, tag or commit named %s", style.Symbol(ref))
+	}
+
+	repo, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+		URL:      repoURL,
+		Progress: progress,
+	})
+	if err != nil {
+		return err
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return errors.Wrapf(err, "resolving commit %s", style.Symbol(ref))
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	return worktree.Checkout(&git.CheckoutOptions{Hash: *hash})
+}
+
+// readClonedProjectDescriptor returns the project descriptor found at the root of a cloned repository, if any, along
+// with the base directory of the resources it references, which is the clone for its descriptor. A descriptor
+// provided by the caller always takes precedence, with its baseDir.
+func readClonedProjectDescriptor(dir string, descriptor projectTypes.Descriptor, baseDir string) (projectTypes.Descriptor, string, error) {
+	if !reflect.DeepEqual(descriptor, projectTypes.Descriptor{}) {
+		return descriptor, baseDir, nil
+	}
+
+	descriptorPath := filepath.Join(dir, "project.toml")
+	if _, err := os.Stat(descriptorPath); err != nil {
+		return descriptor, baseDir, nil
+	}
+
+	descriptor, err := project.ReadProjectDescriptor(descriptorPath)
+	return descriptor, dir, err
+}
+
+func isReferenceNotFound(err error) bool {
+	return errors.Is(err, git.NoMatchingRefSpecError{}) || errors.Is(err, plumbing.ErrReferenceNotFound)
+}
+
+func resetDir(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(dir, os.ModePerm)
+}
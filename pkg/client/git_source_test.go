@@ -0,0 +1,193 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestGitSource(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "GitSource", testGitSource, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testGitSource(t *testing.T, when spec.G, it spec.S) {
+	when("#isGitURL", func() {
+		for _, test := range []struct {
+			appPath  string
+			expected bool
+		}{
+			{"https://github.com/org/repo", true},
+			{"https://github.com/org/repo.git#main", true},
+			{"http://example.com/repo.git", true},
+			{"git://example.com/repo.git", true},
+			{"ssh://git@example.com/repo.git#v1.0.0", true},
+			{"", false},
+			{"some/app", false},
+			{"/some/app", false},
+			{`c:\some\app`, false},
+			{"file:///some/app", false},
+			{"https:///no-host", false},
+		} {
+			test := test
+			it(fmt.Sprintf("returns %t for '%s'", test.expected, test.appPath), func() {
+				h.AssertEq(t, isGitURL(test.appPath), test.expected)
+			})
+		}
+	})
+
+	when("#cloneGitSource", func() {
+		var (
+			subject *Client
+			out     bytes.Buffer
+			workDir string
+			bareDir string
+			repoURL string
+			commits []string
+		)
+
+		commit := func(repo *git.Repository, contents string) string {
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(workDir, "app.txt"), []byte(contents), 0600))
+			worktree, err := repo.Worktree()
+			h.AssertNil(t, err)
+			_, err = worktree.Add("app.txt")
+			h.AssertNil(t, err)
+			hash, err := worktree.Commit(contents, &git.CommitOptions{
+				Author: &object.Signature{Name: "test", When: time.Now()},
+			})
+			h.AssertNil(t, err)
+			return hash.String()
+		}
+
+		readClone := func(source string) string {
+			dir, err := subject.cloneGitSource(context.TODO(), source)
+			h.AssertNil(t, err)
+			defer os.RemoveAll(dir)
+
+			contents, err := ioutil.ReadFile(filepath.Join(dir, "app.txt"))
+			h.AssertNil(t, err)
+			return string(contents)
+		}
+
+		it.Before(func() {
+			var err error
+			workDir, err = ioutil.TempDir("", "git-source-work")
+			h.AssertNil(t, err)
+			bareDir, err = ioutil.TempDir("", "git-source-bare")
+			h.AssertNil(t, err)
+
+			repo, err := git.PlainInit(workDir, false)
+			h.AssertNil(t, err)
+			commits = []string{commit(repo, "first"), commit(repo, "second")}
+			_, err = repo.CreateTag("v1.0.0", plumbing.NewHash(commits[0]), nil)
+			h.AssertNil(t, err)
+
+			bare, err := git.PlainClone(bareDir, true, &git.CloneOptions{URL: workDir, Tags: git.AllTags})
+			h.AssertNil(t, err)
+			// a bare clone only has the branch of HEAD, so other branches are created in it directly
+			h.AssertNil(t, bare.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("release"), plumbing.NewHash(commits[0]))))
+			repoURL = "file://" + filepath.ToSlash(bareDir)
+
+			subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)))
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(workDir))
+			h.AssertNil(t, os.RemoveAll(bareDir))
+		})
+
+		it("clones the default branch", func() {
+			h.AssertEq(t, readClone(repoURL), "second")
+		})
+
+		it("clones a branch", func() {
+			h.AssertEq(t, readClone(repoURL+"#release"), "first")
+		})
+
+		it("clones a tag", func() {
+			h.AssertEq(t, readClone(repoURL+"#v1.0.0"), "first")
+		})
+
+		it("checks out a commit", func() {
+			h.AssertEq(t, readClone(repoURL+"#"+commits[0][:12]), "first")
+		})
+
+		it("errors for a ref which is neither a branch, a tag nor a commit", func() {
+			_, err := subject.cloneGitSource(context.TODO(), repoURL+"#no-such-ref")
+			h.AssertError(t, err, "no branch, tag or commit named 'no-such-ref'")
+		})
+	})
+
+	when("#readClonedProjectDescriptor", func() {
+		var dir string
+
+		it.Before(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "git-source-test")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(dir))
+		})
+
+		it("reads project.toml from the root of the clone", func() {
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(dir, "project.toml"), []byte(`
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+exclude = ["*.log"]
+`), 0600))
+
+			descriptor, baseDir, err := readClonedProjectDescriptor(dir, projectTypes.Descriptor{}, "/some/base-dir")
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.Build.Exclude, []string{"*.log"})
+			h.AssertEq(t, baseDir, dir)
+		})
+
+		it("prefers the provided descriptor", func() {
+			h.AssertNil(t, ioutil.WriteFile(filepath.Join(dir, "project.toml"), []byte(`
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+exclude = ["*.log"]
+`), 0600))
+
+			provided := projectTypes.Descriptor{Build: projectTypes.Build{Include: []string{"src"}}}
+			descriptor, baseDir, err := readClonedProjectDescriptor(dir, provided, "/some/base-dir")
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.Build.Include, []string{"src"})
+			h.AssertEq(t, len(descriptor.Build.Exclude), 0)
+			h.AssertEq(t, baseDir, "/some/base-dir")
+		})
+
+		it("returns an empty descriptor when the clone has no project.toml", func() {
+			descriptor, baseDir, err := readClonedProjectDescriptor(dir, projectTypes.Descriptor{}, "/some/base-dir")
+			h.AssertNil(t, err)
+			h.AssertNil(t, descriptor.SchemaVersion)
+			h.AssertEq(t, len(descriptor.Build.Include), 0)
+			h.AssertEq(t, len(descriptor.Build.Exclude), 0)
+			h.AssertEq(t, baseDir, "/some/base-dir")
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/versions"
+)
+
+// hostGatewayAPIVersion is the API version of Docker 20.10, the first to resolve the host-gateway of extra hosts.
+const hostGatewayAPIVersion = "1.41"
+
+// mapsHostGateway returns whether the phase containers of a build should map the host gateway, which is only the case
+// for linux containers on Docker Desktop, where the network of the host is the network of its VM and does not reach the
+// host, on a daemon resolving the host-gateway, and when the containers do not share another network.
+func mapsHostGateway(info types.Info, apiVersion, builderOS, network string) bool {
+	if builderOS == "windows" || network == "host" || strings.HasPrefix(network, "container:") {
+		return false
+	}
+	return strings.Contains(info.OperatingSystem, "Docker Desktop") &&
+		versions.GreaterThanOrEqualTo(apiVersion, hostGatewayAPIVersion)
+}
+
+func (c *Client) mapsHostGateway(ctx context.Context, builderOS, network string) bool {
+	info, err := c.docker.Info(ctx)
+	if err != nil {
+		c.logger.Debugf("Not mapping the host gateway: getting docker info: %s", err)
+		return false
+	}
+	version, err := c.docker.ServerVersion(ctx)
+	if err != nil {
+		c.logger.Debugf("Not mapping the host gateway: getting docker version: %s", err)
+		return false
+	}
+	return mapsHostGateway(info, version.APIVersion, builderOS, network)
+}
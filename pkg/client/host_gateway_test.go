@@ -0,0 +1,46 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestHostGateway(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "HostGateway", testHostGateway, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testHostGateway(t *testing.T, when spec.G, it spec.S) {
+	when("#mapsHostGateway", func() {
+		dockerDesktop := types.Info{OperatingSystem: "Docker Desktop"}
+
+		it("maps the host gateway for linux containers on Docker Desktop", func() {
+			h.AssertEq(t, mapsHostGateway(dockerDesktop, "1.41", "linux", ""), true)
+			h.AssertEq(t, mapsHostGateway(dockerDesktop, "1.43", "linux", "bridge"), true)
+		})
+
+		it("does not map the host gateway on daemons older than Docker 20.10", func() {
+			h.AssertEq(t, mapsHostGateway(dockerDesktop, "1.40", "linux", ""), false)
+		})
+
+		it("does not map the host gateway where the network of the host reaches the host", func() {
+			h.AssertEq(t, mapsHostGateway(types.Info{OperatingSystem: "Ubuntu 22.04.1 LTS"}, "1.41", "linux", ""), false)
+		})
+
+		it("does not map the host gateway for windows containers", func() {
+			h.AssertEq(t, mapsHostGateway(dockerDesktop, "1.41", "windows", ""), false)
+		})
+
+		it("does not map the host gateway for containers sharing another network", func() {
+			h.AssertEq(t, mapsHostGateway(dockerDesktop, "1.41", "linux", "host"), false)
+			h.AssertEq(t, mapsHostGateway(dockerDesktop, "1.41", "linux", "container:some-container"), false)
+		})
+	})
+}
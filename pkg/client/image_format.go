@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// ImageFormat determines the media types of the manifest, config and layers of a published image.
+type ImageFormat string
+
+const (
+	// ImageFormatDefault leaves the media types chosen by the lifecycle exporter, which are Docker media types.
+	ImageFormatDefault ImageFormat = ""
+	// ImageFormatDocker uses Docker media types.
+	ImageFormatDocker ImageFormat = "docker"
+	// ImageFormatOCI uses OCI media types.
+	ImageFormatOCI ImageFormat = "oci"
+)
+
+// ParseImageFormat parses an image format, as accepted by the --format flag.
+func ParseImageFormat(format string) (ImageFormat, error) {
+	switch ImageFormat(format) {
+	case ImageFormatDefault, ImageFormatDocker, ImageFormatOCI:
+		return ImageFormat(format), nil
+	}
+
+	return ImageFormatDefault, errors.Errorf("invalid image format %s: must be one of %s or %s",
+		style.Symbol(format), style.Symbol(string(ImageFormatDocker)), style.Symbol(string(ImageFormatOCI)))
+}
+
+// convertToOCI re-publishes the image at each of refs with OCI media types.
+// The lifecycle exporter does not support choosing media types, so the exported image is converted after the build.
+func (c *Client) convertToOCI(ctx context.Context, refs ...name.Reference) error {
+	for _, ref := range refs {
+		img, err := ggcrremote.Image(ref, c.remoteOptions(ctx)...)
+		if err != nil {
+			return errors.Wrapf(err, "fetching image %s", style.Symbol(ref.Name()))
+		}
+
+		converted, err := toOCIImage(img)
+		if err != nil {
+			return errors.Wrapf(err, "converting image %s to OCI media types", style.Symbol(ref.Name()))
+		}
+		if converted == img {
+			continue
+		}
+
+		if err := ggcrremote.Write(ref, converted, c.remoteOptions(ctx)...); err != nil {
+			return errors.Wrapf(err, "writing image %s", style.Symbol(ref.Name()))
+		}
+
+		digest, err := converted.Digest()
+		if err != nil {
+			return errors.Wrapf(err, "reading digest of image %s", style.Symbol(ref.Name()))
+		}
+		c.logger.Infof("Converted image %s to OCI media types, new digest %s", style.Symbol(ref.Name()), style.Symbol(digest.String()))
+	}
+
+	return nil
+}
+
+// toOCIImage returns img with OCI media types, or img itself if it already uses them.
+func toOCIImage(img v1.Image) (v1.Image, error) {
+	mediaType, err := img.MediaType()
+	if err != nil {
+		return nil, err
+	}
+	if mediaType == types.OCIManifestSchema1 {
+		return img, nil
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+
+	converted := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	converted = mutate.ConfigMediaType(converted, types.OCIConfigJSON)
+	for _, layer := range layers {
+		converted, err = mutate.Append(converted, mutate.Addendum{
+			Layer:     layer,
+			MediaType: types.OCILayer,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// restore the original config, including its history and the diff IDs of the unchanged layers
+	return mutate.ConfigFile(converted, configFile)
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestImageFormat(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ImageFormat", testImageFormat, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testImageFormat(t *testing.T, when spec.G, it spec.S) {
+	when("#ParseImageFormat", func() {
+		it("accepts the known formats", func() {
+			for _, format := range []ImageFormat{ImageFormatDefault, ImageFormatDocker, ImageFormatOCI} {
+				parsed, err := ParseImageFormat(string(format))
+				h.AssertNil(t, err)
+				h.AssertEq(t, parsed, format)
+			}
+		})
+
+		it("errors for unknown formats", func() {
+			_, err := ParseImageFormat("v1")
+			h.AssertError(t, err, "invalid image format 'v1'")
+		})
+	})
+
+	when("#toOCIImage", func() {
+		it("converts the manifest, config and layer media types", func() {
+			img, err := random.Image(1024, 2)
+			h.AssertNil(t, err)
+			mediaType, err := img.MediaType()
+			h.AssertNil(t, err)
+			h.AssertEq(t, mediaType, types.DockerManifestSchema2)
+
+			converted, err := toOCIImage(img)
+			h.AssertNil(t, err)
+
+			manifest, err := converted.Manifest()
+			h.AssertNil(t, err)
+			h.AssertEq(t, manifest.MediaType, types.OCIManifestSchema1)
+			h.AssertEq(t, manifest.Config.MediaType, types.OCIConfigJSON)
+			h.AssertEq(t, len(manifest.Layers), 2)
+			for _, layer := range manifest.Layers {
+				h.AssertEq(t, layer.MediaType, types.OCILayer)
+			}
+
+			originalConfig, err := img.ConfigFile()
+			h.AssertNil(t, err)
+			convertedConfig, err := converted.ConfigFile()
+			h.AssertNil(t, err)
+			h.AssertEq(t, convertedConfig.RootFS.DiffIDs, originalConfig.RootFS.DiffIDs)
+		})
+
+		it("leaves OCI images untouched", func() {
+			img, err := random.Image(1024, 1)
+			h.AssertNil(t, err)
+			ociImage, err := toOCIImage(img)
+			h.AssertNil(t, err)
+
+			again, err := toOCIImage(ociImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, again == ociImage, true)
+		})
+	})
+}
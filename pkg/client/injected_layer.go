@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/layer"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// InjectedLayer is a layer of files appended to the app image after it is exported, for files an organization
+// requires in every image, such as CA certificates or configuration files.
+type InjectedLayer struct {
+	Files []InjectedFile `toml:"files"`
+}
+
+// InjectedFile is a file of an InjectedLayer.
+type InjectedFile struct {
+	// Path of the file on the host.
+	Source string `toml:"source"`
+
+	// Absolute path of the file in the image.
+	Target string `toml:"target"`
+
+	// Permissions of the file in the image. Defaults to 0644.
+	Mode int64 `toml:"mode"`
+}
+
+// ReadInjectedLayer reads the definition of an InjectedLayer from a TOML file.
+// Relative sources are resolved against the directory of the file.
+func ReadInjectedLayer(definitionPath string) (InjectedLayer, error) {
+	var injectedLayer InjectedLayer
+	if _, err := toml.DecodeFile(definitionPath, &injectedLayer); err != nil {
+		return InjectedLayer{}, errors.Wrapf(err, "reading layer definition %s", style.Symbol(definitionPath))
+	}
+
+	for i, file := range injectedLayer.Files {
+		if file.Source != "" && !filepath.IsAbs(file.Source) {
+			injectedLayer.Files[i].Source = filepath.Join(filepath.Dir(definitionPath), file.Source)
+		}
+	}
+	return injectedLayer, nil
+}
+
+func (l InjectedLayer) validate() error {
+	if len(l.Files) == 0 {
+		return errors.New("injected layer must contain at least one file")
+	}
+	for _, file := range l.Files {
+		if file.Source == "" {
+			return errors.Errorf("injected file %s must have a source", style.Symbol(file.Target))
+		}
+		if !path.IsAbs(file.Target) {
+			return errors.Errorf("injected file target %s must be an absolute path", style.Symbol(file.Target))
+		}
+	}
+	return nil
+}
+
+// injectLayers appends the layers to the exported image. The layers are content-addressed, so identical
+// definitions produce identical layers, which are cached between builds.
+func (c *Client) injectLayers(ctx context.Context, imageRef name.Reference, additionalTags []string, layers []InjectedLayer, publish bool) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return errors.Wrapf(err, "fetching image %s", style.Symbol(imageRef.Name()))
+	}
+
+	imageOS, err := img.OS()
+	if err != nil {
+		return errors.Wrap(err, "getting image OS")
+	}
+	writerFactory, err := layer.NewWriterFactory(imageOS)
+	if err != nil {
+		return err
+	}
+
+	for _, injectedLayer := range layers {
+		layerPath, diffID, err := cachedInjectedLayer(injectedLayer, c.injectedLayerCache, writerFactory)
+		if err != nil {
+			return err
+		}
+
+		c.logger.Debugf("Adding injected layer %s", diffID)
+		if err := img.AddLayerWithDiffID(layerPath, diffID); err != nil {
+			return errors.Wrap(err, "adding injected layer")
+		}
+	}
+
+	// only saving is retried, as adding the layers again to the fetched image would duplicate them
+	if err := c.retryPolicy.Do(ctx, c.logger, "Saving image with injected layers", func() error {
+		return img.Save(additionalTags...)
+	}); err != nil {
+		return errors.Wrapf(err, "saving image %s", style.Symbol(imageRef.Name()))
+	}
+	return nil
+}
+
+// cachedInjectedLayer writes the layer to cacheDir, named after its diffID, and returns its path and diffID
+func cachedInjectedLayer(injectedLayer InjectedLayer, cacheDir string, writerFactory archive.TarWriterFactory) (string, string, error) {
+	files := append([]InjectedFile{}, injectedLayer.Files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Target < files[j].Target })
+
+	tarBuilder := archive.TarBuilder{}
+	addedDirs := map[string]bool{}
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(file.Source)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "reading injected file %s", style.Symbol(file.Source))
+		}
+
+		parent := "/"
+		for _, dir := range strings.Split(path.Dir(file.Target), "/") {
+			if dir == "" {
+				continue
+			}
+			parent = path.Join(parent, dir)
+			if !addedDirs[parent] {
+				tarBuilder.AddDir(parent, 0755, archive.NormalizedDateTime)
+				addedDirs[parent] = true
+			}
+		}
+
+		mode := file.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+		tarBuilder.AddFile(path.Clean(file.Target), mode, archive.NormalizedDateTime, contents)
+	}
+
+	if err := os.MkdirAll(cacheDir, 0750); err != nil {
+		return "", "", errors.Wrap(err, "creating injected layer cache")
+	}
+
+	tmpFile, err := ioutil.TempFile(cacheDir, "layer-*.tar")
+	if err != nil {
+		return "", "", errors.Wrap(err, "creating injected layer")
+	}
+	tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+
+	if err := tarBuilder.WriteToPath(tmpFile.Name(), writerFactory); err != nil {
+		return "", "", errors.Wrap(err, "writing injected layer")
+	}
+	diffID, err := dist.LayerDiffID(tmpFile.Name())
+	if err != nil {
+		return "", "", errors.Wrap(err, "getting injected layer diffID")
+	}
+
+	layerPath := filepath.Join(cacheDir, diffID.Hex+".tar")
+	if _, err := os.Stat(layerPath); os.IsNotExist(err) {
+		if err := os.Rename(tmpFile.Name(), layerPath); err != nil {
+			return "", "", errors.Wrap(err, "caching injected layer")
+		}
+	}
+	return layerPath, diffID.String(), nil
+}
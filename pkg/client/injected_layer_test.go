@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestInjectedLayer(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "InjectedLayer", testInjectedLayer, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testInjectedLayer(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		appImage         *fakes.Image
+		out              bytes.Buffer
+		tmpDir           string
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "pack.injected-layer.test.")
+		h.AssertNil(t, err)
+
+		h.AssertNil(t, ioutil.WriteFile(filepath.Join(tmpDir, "corp-ca.crt"), []byte("some-certificate"), 0600))
+		h.AssertNil(t, ioutil.WriteFile(filepath.Join(tmpDir, "layer.toml"), []byte(`
+[[files]]
+source = "corp-ca.crt"
+target = "/etc/ssl/certs/corp-ca.pem"
+`), 0600))
+
+		appImage = fakes.NewImage("index.docker.io/some/app:latest", "", nil)
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeImageFetcher.LocalImages[appImage.Name()] = appImage
+
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithFetcher(fakeImageFetcher),
+		)
+		h.AssertNil(t, err)
+		subject.injectedLayerCache = filepath.Join(tmpDir, "layers")
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, appImage.Cleanup())
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ReadInjectedLayer", func() {
+		it("resolves sources relative to the definition", func() {
+			injectedLayer, err := ReadInjectedLayer(filepath.Join(tmpDir, "layer.toml"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, injectedLayer, InjectedLayer{Files: []InjectedFile{{
+				Source: filepath.Join(tmpDir, "corp-ca.crt"),
+				Target: "/etc/ssl/certs/corp-ca.pem",
+			}}})
+		})
+	})
+
+	when("#injectLayers", func() {
+		it("appends the layer to the image and caches it by diffID", func() {
+			injectedLayer, err := ReadInjectedLayer(filepath.Join(tmpDir, "layer.toml"))
+			h.AssertNil(t, err)
+
+			err = subject.injectLayers(context.TODO(), name.MustParseReference("some/app"), nil, []InjectedLayer{injectedLayer}, false)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, appImage.IsSaved(), true)
+			layerPath, err := appImage.FindLayerWithPath("/etc/ssl/certs/corp-ca.pem")
+			h.AssertNil(t, err)
+			h.AssertTarFileContents(t, layerPath, "/etc/ssl/certs/corp-ca.pem", "some-certificate")
+
+			cachedLayers, err := ioutil.ReadDir(filepath.Join(tmpDir, "layers"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(cachedLayers), 1)
+			h.AssertTarFileContents(t, filepath.Join(tmpDir, "layers", cachedLayers[0].Name()), "/etc/ssl/certs/corp-ca.pem", "some-certificate")
+		})
+	})
+
+	when("#validate", func() {
+		it("requires absolute targets", func() {
+			err := InjectedLayer{Files: []InjectedFile{{Source: "corp-ca.crt", Target: "etc/corp-ca.pem"}}}.validate()
+			h.AssertError(t, err, "injected file target 'etc/corp-ca.pem' must be an absolute path")
+		})
+	})
+}
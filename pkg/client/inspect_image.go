@@ -103,6 +103,12 @@ func (c *Client) InspectImage(name string, daemon bool) (*ImageInfo, error) {
 		return nil, err
 	}
 
+	if len(buildMD.BOM) == 0 {
+		if buildMD.BOM, err = readOffloadedBOM(img); err != nil {
+			return nil, err
+		}
+	}
+
 	minimumBaseImageReferenceVersion := semver.MustParse("0.5.0")
 	actualLauncherVersion, err := semver.NewVersion(buildMD.Launcher.Version)
 
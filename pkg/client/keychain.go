@@ -0,0 +1,235 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buildpacks/lifecycle/auth"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Sources of the credentials of the keychains returned by NewKeychain.
+const (
+	// KeychainEnv resolves credentials from RegistryAuthEnv.
+	KeychainEnv = "env"
+
+	// KeychainDocker resolves credentials from the Docker config file, and the credential helpers it configures.
+	KeychainDocker = "docker"
+
+	// KeychainECR resolves credentials of Amazon ECR registries from the AWS credentials of the environment, with
+	// the keychain of the lifecycle.
+	KeychainECR = "ecr"
+
+	// KeychainGCR resolves credentials of Google Container and Artifact Registry registries from gcloud.
+	KeychainGCR = "gcr"
+
+	// KeychainACR resolves credentials of Azure Container Registry registries from the Azure service principal
+	// of the environment, with the keychain of the lifecycle.
+	KeychainACR = "acr"
+)
+
+// RegistryAuthEnv is the environment variable KeychainEnv resolves credentials from. Like CNB_REGISTRY_AUTH, it is a
+// JSON object mapping registries to Authorization headers, such as {"registry.example.com": "Bearer some-token"}.
+const RegistryAuthEnv = "PACK_REGISTRY_AUTH"
+
+// KeychainSources lists the sources of NewKeychain, in the order credentials are resolved from.
+var KeychainSources = []string{KeychainEnv, KeychainDocker, KeychainECR, KeychainGCR, KeychainACR}
+
+// NewKeychain returns a keychain resolving the credentials of registries from the first of sources which has
+// credentials for them. Only KeychainDocker is used when no source is given, as the cloud sources run credential
+// helpers or gcloud. The keychain is meant to be passed to WithKeychain, so that images can be published from CI
+// environments without logging in to registries first.
+func NewKeychain(sources ...string) (authn.Keychain, error) {
+	if len(sources) == 0 {
+		return authn.DefaultKeychain, nil
+	}
+
+	var keychains []authn.Keychain
+	for _, source := range sources {
+		switch source {
+		case KeychainEnv:
+			keychain, err := auth.NewEnvKeychain(RegistryAuthEnv)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading %s", style.Symbol(RegistryAuthEnv))
+			}
+			keychains = append(keychains, keychain)
+		case KeychainDocker:
+			keychains = append(keychains, authn.DefaultKeychain)
+		case KeychainECR:
+			keychains = append(keychains, newLifecycleKeychain(ecrRegistryPattern.MatchString))
+		case KeychainGCR:
+			keychains = append(keychains, &gcloudKeychain{accessToken: gcloudAccessToken})
+		case KeychainACR:
+			keychains = append(keychains, newLifecycleKeychain(isAzureRegistry))
+		default:
+			return nil, errors.Errorf("unknown keychain %s, must be one of %s", style.Symbol(source), strings.Join(KeychainSources, ", "))
+		}
+	}
+	return authn.NewMultiKeychain(keychains...), nil
+}
+
+// TokenKeychain resolves the credentials of registries to bearer tokens, such as short-lived tokens exchanged for the
+// identity of a cloud workload, without writing them to a docker config file. Registries without a token are
+// accessed anonymously, so the keychain is meant to precede others in a multi keychain. Tokens may be added after
+// the keychain is passed to a client, until credentials are resolved.
+type TokenKeychain struct {
+	mu     sync.RWMutex
+	tokens map[string]string
+}
+
+// NewTokenKeychain returns a keychain without tokens.
+func NewTokenKeychain() *TokenKeychain {
+	return &TokenKeychain{tokens: map[string]string{}}
+}
+
+// Add adds the token of a registry, in the form <registry>=<token>.
+func (k *TokenKeychain) Add(registryToken string) error {
+	parts := strings.SplitN(registryToken, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.New("invalid registry token: must be of the form <registry>=<token>")
+	}
+
+	registry, err := name.NewRegistry(parts[0], name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid registry %s", style.Symbol(parts[0]))
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.tokens[registry.RegistryStr()] = parts[1]
+	return nil
+}
+
+// Resolve implements authn.Keychain.
+func (k *TokenKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if token, ok := k.tokens[resource.RegistryStr()]; ok {
+		return &authn.Bearer{Token: token}, nil
+	}
+	return authn.Anonymous, nil
+}
+
+// lifecycleKeychain resolves the credentials of the registries it matches with the keychain of the lifecycle, which
+// includes the credential helpers of Amazon ECR and Azure Container Registry. That keychain resolves the credentials
+// of given images when created, so the credentials of each registry are resolved once, and kept.
+type lifecycleKeychain struct {
+	match func(registry string) bool
+
+	mu             sync.Mutex
+	authenticators map[string]authn.Authenticator
+}
+
+func newLifecycleKeychain(match func(registry string) bool) *lifecycleKeychain {
+	return &lifecycleKeychain{match: match, authenticators: map[string]authn.Authenticator{}}
+}
+
+func (k *lifecycleKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	registry := resource.RegistryStr()
+	if !k.match(registry) {
+		return authn.Anonymous, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if authenticator, ok := k.authenticators[registry]; ok {
+		return authenticator, nil
+	}
+
+	// any repository of the registry resolves its credentials
+	keychain, err := auth.DefaultKeychain(registry + "/pack")
+	if err != nil {
+		return nil, err
+	}
+	authenticator, err := keychain.Resolve(resource)
+	if err != nil {
+		return nil, err
+	}
+	k.authenticators[registry] = authenticator
+	return authenticator, nil
+}
+
+// gcloudKeychain resolves the credentials of Google registries to an access token of the account gcloud is
+// logged in with, kept until shortly before it expires. Registries are accessed anonymously when gcloud is not
+// installed, or not logged in.
+type gcloudKeychain struct {
+	accessToken func() (string, time.Time, error)
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// gcloudTokenMargin is how long before it expires an access token of gcloud is replaced, so that it does not expire
+// while in use.
+const gcloudTokenMargin = 5 * time.Minute
+
+func (k *gcloudKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	if !isGoogleRegistry(resource.RegistryStr()) {
+		return authn.Anonymous, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.token == "" || time.Now().Add(gcloudTokenMargin).After(k.expires) {
+		token, expires, err := k.accessToken()
+		if err != nil {
+			return authn.Anonymous, nil
+		}
+		k.token, k.expires = token, expires
+	}
+
+	return &authn.Basic{Username: "oauth2accesstoken", Password: k.token}, nil
+}
+
+// gcloudAccessToken returns an access token of the account gcloud is logged in with, and when it expires.
+func gcloudAccessToken() (string, time.Time, error) {
+	var out bytes.Buffer
+	cmd := exec.Command("gcloud", "config", "config-helper", "--format=json")
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", time.Time{}, err
+	}
+	return parseGcloudConfigHelper(out.Bytes())
+}
+
+// parseGcloudConfigHelper reads the access token, and its expiry, from the output of gcloud config config-helper.
+func parseGcloudConfigHelper(output []byte) (string, time.Time, error) {
+	var config struct {
+		Credential struct {
+			AccessToken string    `json:"access_token"`
+			TokenExpiry time.Time `json:"token_expiry"`
+		} `json:"credential"`
+	}
+	if err := json.Unmarshal(output, &config); err != nil {
+		return "", time.Time{}, errors.Wrap(err, "parsing gcloud credentials")
+	}
+	if config.Credential.AccessToken == "" {
+		return "", time.Time{}, errors.New("gcloud is not logged in")
+	}
+	return config.Credential.AccessToken, config.Credential.TokenExpiry, nil
+}
+
+func isGoogleRegistry(registry string) bool {
+	return registry == "gcr.io" ||
+		strings.HasSuffix(registry, ".gcr.io") ||
+		strings.HasSuffix(registry, "-docker.pkg.dev")
+}
+
+func isAzureRegistry(registry string) bool {
+	for _, suffix := range []string{".azurecr.io", ".azurecr.cn", ".azurecr.de", ".azurecr.us"} {
+		if strings.HasSuffix(registry, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestKeychain(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Keychain", testKeychain, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testKeychain(t *testing.T, when spec.G, it spec.S) {
+	when("#NewKeychain", func() {
+		it.After(func() {
+			h.AssertNil(t, os.Unsetenv(RegistryAuthEnv))
+		})
+
+		it("only resolves credentials from the Docker config file by default", func() {
+			h.AssertNil(t, os.Setenv(RegistryAuthEnv, "not-json"))
+
+			keychain, err := NewKeychain()
+			h.AssertNil(t, err)
+			h.AssertTrue(t, keychain == authn.DefaultKeychain)
+		})
+
+		it("resolves credentials from the environment", func() {
+			h.AssertNil(t, os.Setenv(RegistryAuthEnv, `{"registry.example.com": "Bearer some-token"}`))
+
+			keychain, err := NewKeychain(KeychainEnv)
+			h.AssertNil(t, err)
+
+			repo, err := name.NewRepository("registry.example.com/some/app")
+			h.AssertNil(t, err)
+			authenticator, err := keychain.Resolve(repo)
+			h.AssertNil(t, err)
+			authConfig, err := authenticator.Authorization()
+			h.AssertNil(t, err)
+			h.AssertEq(t, authConfig.RegistryToken, "some-token")
+		})
+
+		it("accesses registries without credentials anonymously", func() {
+			keychain, err := NewKeychain(KeychainEnv, KeychainGCR)
+			h.AssertNil(t, err)
+
+			repo, err := name.NewRepository("registry.example.com/some/app")
+			h.AssertNil(t, err)
+			authenticator, err := keychain.Resolve(repo)
+			h.AssertNil(t, err)
+			h.AssertEq(t, authenticator, authn.Anonymous)
+		})
+
+		it("fails for invalid credentials in the environment", func() {
+			h.AssertNil(t, os.Setenv(RegistryAuthEnv, "not-json"))
+
+			_, err := NewKeychain(KeychainEnv)
+			h.AssertError(t, err, "reading 'PACK_REGISTRY_AUTH'")
+		})
+
+		it("fails for unknown sources", func() {
+			_, err := NewKeychain("some-source")
+			h.AssertError(t, err, "unknown keychain 'some-source', must be one of env, docker, ecr, gcr, acr")
+		})
+	})
+
+	when("TokenKeychain", func() {
+		it("resolves registries to their bearer token", func() {
+			keychain := NewTokenKeychain()
+			h.AssertNil(t, keychain.Add("registry.example.com=some-token"))
+			h.AssertNil(t, keychain.Add("docker.io=other-token"))
+
+			for registry, token := range map[string]string{
+				"registry.example.com/some/app": "some-token",
+				"some/app":                      "other-token",
+			} {
+				repo, err := name.NewRepository(registry)
+				h.AssertNil(t, err)
+				authenticator, err := keychain.Resolve(repo)
+				h.AssertNil(t, err)
+				authConfig, err := authenticator.Authorization()
+				h.AssertNil(t, err)
+				h.AssertEq(t, authConfig.RegistryToken, token)
+			}
+		})
+
+		it("accesses registries without a token anonymously", func() {
+			keychain := NewTokenKeychain()
+
+			repo, err := name.NewRepository("registry.example.com/some/app")
+			h.AssertNil(t, err)
+			authenticator, err := keychain.Resolve(repo)
+			h.AssertNil(t, err)
+			h.AssertEq(t, authenticator, authn.Anonymous)
+		})
+
+		it("fails for invalid tokens", func() {
+			h.AssertError(t, NewTokenKeychain().Add("registry.example.com"), "invalid registry token: must be of the form <registry>=<token>")
+		})
+	})
+
+	when("gcloudKeychain", func() {
+		var (
+			keychain *gcloudKeychain
+			calls    int
+			expires  time.Time
+		)
+
+		it.Before(func() {
+			calls = 0
+			expires = time.Now().Add(time.Hour)
+			keychain = &gcloudKeychain{accessToken: func() (string, time.Time, error) {
+				calls++
+				return fmt.Sprintf("token-%d", calls), expires, nil
+			}}
+		})
+
+		resolve := func(repository string) *authn.AuthConfig {
+			repo, err := name.NewRepository(repository)
+			h.AssertNil(t, err)
+			authenticator, err := keychain.Resolve(repo)
+			h.AssertNil(t, err)
+			authConfig, err := authenticator.Authorization()
+			h.AssertNil(t, err)
+			return authConfig
+		}
+
+		it("keeps the access token until it expires", func() {
+			h.AssertEq(t, resolve("gcr.io/some/app").Password, "token-1")
+			h.AssertEq(t, resolve("europe-west1-docker.pkg.dev/some/app").Password, "token-1")
+			h.AssertEq(t, calls, 1)
+
+			keychain.expires = time.Now().Add(time.Minute)
+			h.AssertEq(t, resolve("gcr.io/some/app").Password, "token-2")
+			h.AssertEq(t, calls, 2)
+		})
+
+		it("does not run gcloud for other registries", func() {
+			h.AssertEq(t, *resolve("registry.example.com/some/app"), authn.AuthConfig{})
+			h.AssertEq(t, calls, 0)
+		})
+	})
+
+	when("#parseGcloudConfigHelper", func() {
+		it("reads the access token and its expiry", func() {
+			token, expires, err := parseGcloudConfigHelper([]byte(`{"credential": {"access_token": "some-token", "token_expiry": "2022-09-20T10:00:00Z"}}`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, token, "some-token")
+			h.AssertEq(t, expires.Equal(time.Date(2022, 9, 20, 10, 0, 0, 0, time.UTC)), true)
+		})
+
+		it("fails when gcloud is not logged in", func() {
+			_, _, err := parseGcloudConfigHelper([]byte(`{"credential": {}}`))
+			h.AssertError(t, err, "gcloud is not logged in")
+		})
+	})
+
+	when("#isAzureRegistry", func() {
+		it("matches Azure Container Registry registries", func() {
+			h.AssertTrue(t, isAzureRegistry("some.azurecr.io"))
+			h.AssertFalse(t, isAzureRegistry("registry.example.com"))
+		})
+	})
+
+	when("#isGoogleRegistry", func() {
+		it("matches Container and Artifact Registry registries", func() {
+			h.AssertTrue(t, isGoogleRegistry("gcr.io"))
+			h.AssertTrue(t, isGoogleRegistry("us.gcr.io"))
+			h.AssertTrue(t, isGoogleRegistry("europe-west1-docker.pkg.dev"))
+			h.AssertFalse(t, isGoogleRegistry("registry.example.com"))
+		})
+	})
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// maxLabelCount bounds the number of labels pack sets on an app image.
+	maxLabelCount = 128
+
+	// maxLabelSize bounds the size of a single label pack sets on an app image, key included. Daemons and registries
+	// reject image configs of a few megabytes, which the metadata labels of the lifecycle, such as the BOM, already
+	// approach on large builds.
+	maxLabelSize = 64 * 1024
+
+	// maxLabelsSize bounds the total size of the labels pack sets on an app image.
+	maxLabelsSize = 256 * 1024
+
+	// maxGeneratedLabelSize bounds the size of a single label the lifecycle sets on an app image. Larger labels are
+	// reported, and a BOM making the build metadata label larger is moved out of the config.
+	maxGeneratedLabelSize = 512 * 1024
+
+	// OffloadedBOMLabel holds the diff ID of the layer containing the BOM of an image, when the BOM was too large to
+	// remain in the build metadata label.
+	OffloadedBOMLabel = "io.buildpacks.pack.bom-layer"
+
+	// offloadedBOMPath is the path, in the layer referenced by OffloadedBOMLabel, of the BOM in JSON.
+	offloadedBOMPath = "/cnb/pack/bom.json"
+)
+
+// validateLabels checks the number and size of the labels pack sets on the app image, so that a build with
+// oversized labels fails before it runs rather than when its image is saved, with errors of the daemon or registry.
+func validateLabels(labels map[string]string) error {
+	if len(labels) > maxLabelCount {
+		return errors.Errorf("%d labels are set, but at most %d are allowed", len(labels), maxLabelCount)
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	total := 0
+	for _, key := range keys {
+		size := len(key) + len(labels[key])
+		if size > maxLabelSize {
+			return errors.Errorf("label %s is %s, but labels of at most %s are allowed", style.Symbol(key), humanSize(size), humanSize(maxLabelSize))
+		}
+		total += size
+	}
+	if total > maxLabelsSize {
+		return errors.Errorf("labels are %s in total, but at most %s are allowed", humanSize(total), humanSize(maxLabelsSize))
+	}
+	return nil
+}
+
+// guardGeneratedLabels checks the size of the labels the lifecycle set on the exported app image, which unlike those
+// of pack are only known once it is exported. The image is fetched without its layers, and is only saved again, under
+// its name and additional tags, when an oversized BOM in the build metadata label is moved to a layer of the image.
+// Other oversized labels are reported, as pack does not know how to shrink them.
+// The check is best effort: when the image cannot be fetched, it is skipped.
+func (c *Client) guardGeneratedLabels(ctx context.Context, imageName string, additionalTags []string, publish bool) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		c.logger.Debugf("Skipping label size check of image %s: %s", style.Symbol(imageName), err)
+		return nil
+	}
+
+	labels, err := img.Labels()
+	if err != nil {
+		return errors.Wrapf(err, "reading labels of image %s", style.Symbol(imageName))
+	}
+
+	var oversized []string
+	for key, value := range labels {
+		if len(value) > maxGeneratedLabelSize {
+			oversized = append(oversized, key)
+		}
+	}
+	sort.Strings(oversized)
+	if len(oversized) == 0 {
+		return nil
+	}
+
+	// Layers are read when the image is saved
+	tmpDir, err := ioutil.TempDir("", "pack.labels.")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	offloaded := false
+	for _, key := range oversized {
+		if key == platform.BuildMetadataLabel {
+			if offloaded, err = c.offloadBOM(img, labels[key], tmpDir); err != nil {
+				return errors.Wrapf(err, "moving BOM of image %s to a layer", style.Symbol(imageName))
+			}
+			if offloaded {
+				continue
+			}
+		}
+		c.logger.Warnf("Label %s of image %s is %s, which may exceed the limits of some daemons and registries",
+			style.Symbol(key), style.Symbol(imageName), humanSize(len(labels[key])))
+	}
+
+	if !offloaded {
+		return nil
+	}
+
+	if err := img.Save(additionalTags...); err != nil {
+		return errors.Wrapf(err, "saving image %s", style.Symbol(imageName))
+	}
+	return nil
+}
+
+// offloadBOM moves the BOM out of the build metadata label of img, into a layer. It does nothing, and returns false,
+// when the BOM is not the cause of the label being oversized. The layer is written to layerDir.
+func (c *Client) offloadBOM(img imgutil.Image, buildMetadata, layerDir string) (bool, error) {
+	imageOS, err := img.OS()
+	if err != nil {
+		return false, err
+	}
+	if imageOS == "windows" {
+		return false, nil
+	}
+
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(buildMetadata), &metadata); err != nil {
+		return false, errors.Wrapf(err, "parsing label %s", style.Symbol(platform.BuildMetadataLabel))
+	}
+
+	bom, ok := metadata["bom"]
+	if !ok || len(buildMetadata)-len(bom) > maxGeneratedLabelSize {
+		return false, nil
+	}
+	delete(metadata, "bom")
+
+	layerPath := filepath.Join(layerDir, "bom.tar")
+	if err := archive.CreateSingleFileTar(layerPath, offloadedBOMPath, string(bom)); err != nil {
+		return false, err
+	}
+	diffID, err := dist.LayerDiffID(layerPath)
+	if err != nil {
+		return false, err
+	}
+	if err := img.AddLayerWithDiffID(layerPath, diffID.String()); err != nil {
+		return false, err
+	}
+
+	if err := dist.SetLabel(img, platform.BuildMetadataLabel, metadata); err != nil {
+		return false, err
+	}
+	if err := img.SetLabel(OffloadedBOMLabel, diffID.String()); err != nil {
+		return false, err
+	}
+
+	c.logger.Infof("Moved BOM of %s to layer %s, as it was too large for a label", humanSize(len(bom)), style.Symbol(diffID.String()))
+	return true, nil
+}
+
+// readOffloadedBOM reads the BOM moved to a layer of img by guardGeneratedLabels, if any.
+func readOffloadedBOM(img imgutil.Image) ([]buildpack.BOMEntry, error) {
+	diffID, err := img.Label(OffloadedBOMLabel)
+	if err != nil || diffID == "" {
+		return nil, err
+	}
+
+	rc, err := img.GetLayer(diffID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading BOM layer %s", style.Symbol(diffID))
+	}
+	defer rc.Close()
+
+	_, contents, err := archive.ReadTarEntry(rc, offloadedBOMPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading BOM layer %s", style.Symbol(diffID))
+	}
+
+	var bom []buildpack.BOMEntry
+	if err := json.Unmarshal(contents, &bom); err != nil {
+		return nil, errors.Wrap(err, "parsing BOM")
+	}
+	return bom, nil
+}
+
+func humanSize(size int) string {
+	return fmt.Sprintf("%.1f KiB", float64(size)/1024)
+}
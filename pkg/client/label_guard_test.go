@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLabelGuard(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LabelGuard", testLabelGuard, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLabelGuard(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		fakeAppImage     *fakes.Image
+		subject          *Client
+		out              bytes.Buffer
+		tmpDir           string
+	)
+
+	largeBOM := func() []buildpack.BOMEntry {
+		var bom []buildpack.BOMEntry
+		for i := 0; i < 10; i++ {
+			bom = append(bom, buildpack.BOMEntry{
+				Require:   buildpack.Require{Name: "some-dependency", Metadata: map[string]interface{}{"license": strings.Repeat("x", maxGeneratedLabelSize/10)}},
+				Buildpack: buildpack.GroupBuildpack{ID: "some/buildpack", Version: "1.0.0"},
+			})
+		}
+		return bom
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "label-guard-test")
+		h.AssertNil(t, err)
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeAppImage = fakes.NewImage("index.docker.io/some/app:latest", "", nil)
+		fakeImageFetcher.LocalImages[fakeAppImage.Name()] = fakeAppImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, fakeAppImage.Cleanup())
+		h.AssertNilE(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#validateLabels", func() {
+		it("accepts labels within the limits", func() {
+			h.AssertNil(t, validateLabels(map[string]string{"team": "some-team", "revision": "abc123"}))
+		})
+
+		it("rejects too many labels", func() {
+			labels := map[string]string{}
+			for i := 0; i <= maxLabelCount; i++ {
+				labels[fmt.Sprintf("label-%d", i)] = "value"
+			}
+			h.AssertError(t, validateLabels(labels), fmt.Sprintf("%d labels are set, but at most %d are allowed", maxLabelCount+1, maxLabelCount))
+		})
+
+		it("rejects an oversized label", func() {
+			err := validateLabels(map[string]string{"team": "some-team", "notes": strings.Repeat("x", maxLabelSize)})
+			h.AssertError(t, err, "label 'notes' is 64.0 KiB, but labels of at most 64.0 KiB are allowed")
+		})
+
+		it("rejects oversized labels in total", func() {
+			labels := map[string]string{}
+			for i := 0; i < 5; i++ {
+				labels[fmt.Sprintf("label-%d", i)] = strings.Repeat("x", maxLabelSize-10)
+			}
+			h.AssertError(t, validateLabels(labels), "but at most 256.0 KiB are allowed")
+		})
+	})
+
+	when("#guardGeneratedLabels", func() {
+		it("leaves images with small labels untouched", func() {
+			h.AssertNil(t, fakeAppImage.SetLabel(platform.BuildMetadataLabel, `{"bom":[]}`))
+
+			h.AssertNil(t, subject.guardGeneratedLabels(context.TODO(), fakeAppImage.Name(), nil, false))
+			h.AssertEq(t, fakeAppImage.IsSaved(), false)
+		})
+
+		it("warns about oversized labels it cannot shrink", func() {
+			h.AssertNil(t, fakeAppImage.SetLabel("some.label", strings.Repeat("x", maxGeneratedLabelSize+1)))
+
+			h.AssertNil(t, subject.guardGeneratedLabels(context.TODO(), fakeAppImage.Name(), nil, false))
+			h.AssertContains(t, out.String(), "Warning: Label 'some.label' of image 'index.docker.io/some/app:latest' is 512.0 KiB")
+			h.AssertEq(t, fakeAppImage.IsSaved(), false)
+		})
+
+		it("moves an oversized BOM to a layer and saves the image", func() {
+			buildMetadata, err := json.Marshal(platform.BuildMetadata{BOM: largeBOM()})
+			h.AssertNil(t, err)
+			h.AssertNil(t, fakeAppImage.SetLabel(platform.BuildMetadataLabel, string(buildMetadata)))
+
+			h.AssertNil(t, subject.guardGeneratedLabels(context.TODO(), fakeAppImage.Name(), []string{"some/app:other-tag"}, false))
+
+			h.AssertEq(t, fakeAppImage.IsSaved(), true)
+			h.AssertContains(t, strings.Join(fakeAppImage.SavedNames(), " "), "some/app:other-tag")
+			label, err := fakeAppImage.Label(OffloadedBOMLabel)
+			h.AssertNil(t, err)
+			h.AssertContains(t, label, "sha256:")
+		})
+	})
+
+	when("#offloadBOM", func() {
+		it("moves the BOM to a layer from which it can be read", func() {
+			bom := largeBOM()
+			buildMetadata, err := json.Marshal(platform.BuildMetadata{BOM: bom, Launcher: platform.LauncherMetadata{Version: "0.14.0"}})
+			h.AssertNil(t, err)
+
+			offloaded, err := subject.offloadBOM(fakeAppImage, string(buildMetadata), tmpDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, offloaded, true)
+
+			label, err := fakeAppImage.Label(platform.BuildMetadataLabel)
+			h.AssertNil(t, err)
+			h.AssertTrue(t, len(label) < maxGeneratedLabelSize)
+			h.AssertNotContains(t, label, `"bom"`)
+			h.AssertContains(t, label, `"version":"0.14.0"`)
+
+			readBOM, err := readOffloadedBOM(fakeAppImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(readBOM), len(bom))
+			h.AssertEq(t, readBOM[0].Name, "some-dependency")
+		})
+
+		it("does nothing when the rest of the metadata is oversized", func() {
+			buildMetadata, err := json.Marshal(map[string]interface{}{
+				"bom":       []interface{}{},
+				"processes": strings.Repeat("x", maxGeneratedLabelSize+1),
+			})
+			h.AssertNil(t, err)
+
+			offloaded, err := subject.offloadBOM(fakeAppImage, string(buildMetadata), tmpDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, offloaded, false)
+		})
+	})
+
+	when("#readOffloadedBOM", func() {
+		it("returns nothing for images without an offloaded BOM", func() {
+			bom, err := readOffloadedBOM(fakeAppImage)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(bom), 0)
+		})
+	})
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// maxLambdaImageSize is the maximum uncompressed size of the container image of an AWS Lambda function.
+const maxLambdaImageSize = 10 * 1000 * 1000 * 1000
+
+// ecrRegistryPattern matches the hosts of Amazon ECR private registries, which Lambda functions run images from.
+var ecrRegistryPattern = regexp.MustCompile(`^[0-9]{12}\.dkr\.ecr(-fips)?\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// LambdaOptions adapts the app image to the requirements of AWS Lambda for the container images of functions.
+type LambdaOptions struct {
+	// Process type the function runs, which becomes the entrypoint of the image. If empty, the default process
+	// of the image is run.
+	ProcessType string
+}
+
+// LambdaError lists the requirements of AWS Lambda the app image does not meet.
+type LambdaError struct {
+	Image      string
+	Violations []string
+}
+
+func (e *LambdaError) Error() string {
+	return fmt.Sprintf("image %s does not meet the requirements of AWS Lambda:\n- %s", style.Symbol(e.Image), strings.Join(e.Violations, "\n- "))
+}
+
+// adaptForLambda sets the entrypoint of the exported app image to the process of the function, saving it again under
+// its name and additional tags, and checks that the image can run on AWS Lambda: it must be a single linux/amd64 or
+// linux/arm64 image of at most 10 GB, with an entrypoint, and when published, it must be in Amazon ECR with Docker
+// media types.
+func (c *Client) adaptForLambda(ctx context.Context, imageRef name.Reference, additionalTags []string, opts LambdaOptions, publish bool) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return errors.Wrapf(err, "fetching image %s", style.Symbol(imageRef.Name()))
+	}
+
+	var violations []string
+
+	imageOS, err := img.OS()
+	if err != nil {
+		return errors.Wrapf(err, "reading OS of image %s", style.Symbol(imageRef.Name()))
+	}
+	arch, err := img.Architecture()
+	if err != nil {
+		return errors.Wrapf(err, "reading architecture of image %s", style.Symbol(imageRef.Name()))
+	}
+	if imageOS != "linux" || (arch != "amd64" && arch != "arm64") {
+		violations = append(violations, fmt.Sprintf("the platform must be linux/amd64 or linux/arm64, not %s", style.Symbol(imageOS+"/"+arch)))
+	}
+
+	if opts.ProcessType != "" {
+		var buildMD platform.BuildMetadata
+		if _, err := dist.GetLabel(img, platform.BuildMetadataLabel, &buildMD); err != nil {
+			return errors.Wrapf(err, "reading build metadata of image %s", style.Symbol(imageRef.Name()))
+		}
+
+		if hasProcess(buildMD, opts.ProcessType) {
+			if err := img.SetEntrypoint(processEntrypoint(opts.ProcessType)); err != nil {
+				return errors.Wrap(err, "setting entrypoint")
+			}
+			if err := img.SetCmd(); err != nil {
+				return errors.Wrap(err, "clearing command")
+			}
+			if err := img.Save(additionalTags...); err != nil {
+				return errors.Wrapf(err, "saving image %s", style.Symbol(imageRef.Name()))
+			}
+			c.logger.Debugf("Set the entrypoint of image %s to process %s", style.Symbol(imageRef.Name()), style.Symbol(opts.ProcessType))
+		} else {
+			violations = append(violations, fmt.Sprintf("the image has no process of type %s", style.Symbol(opts.ProcessType)))
+		}
+	} else {
+		entrypoint, err := img.Entrypoint()
+		if err != nil {
+			return errors.Wrapf(err, "reading entrypoint of image %s", style.Symbol(imageRef.Name()))
+		}
+		if len(entrypoint) == 0 {
+			violations = append(violations, "the image must have an entrypoint: set a default process type or the process type of the function")
+		}
+	}
+
+	size, mediaType, err := c.lambdaImageSize(ctx, imageRef, publish)
+	if err != nil {
+		return err
+	}
+	if size > maxLambdaImageSize {
+		violations = append(violations, fmt.Sprintf("the image must be at most 10 GB, not %.1f GB", float64(size)/1000/1000/1000))
+	}
+	if publish && mediaType != types.DockerManifestSchema2 {
+		violations = append(violations, fmt.Sprintf("the manifest must have media type %s, not %s", style.Symbol(string(types.DockerManifestSchema2)), style.Symbol(string(mediaType))))
+	}
+
+	if publish && !ecrRegistryPattern.MatchString(imageRef.Context().RegistryStr()) {
+		violations = append(violations, fmt.Sprintf("the image must be published to Amazon ECR, not %s", style.Symbol(imageRef.Context().RegistryStr())))
+	}
+
+	if len(violations) > 0 {
+		return &LambdaError{Image: imageRef.Name(), Violations: violations}
+	}
+
+	c.logger.Infof("Image %s meets the requirements of AWS Lambda", style.Symbol(imageRef.Name()))
+	return nil
+}
+
+// lambdaImageSize returns the size of the app image, which is its uncompressed size in the daemon, and the compressed
+// size of its layers in a registry, as a lower bound. The media type of the manifest of published images is returned
+// as well, since Lambda does not run image indexes.
+func (c *Client) lambdaImageSize(ctx context.Context, imageRef name.Reference, publish bool) (int64, types.MediaType, error) {
+	if !publish {
+		inspect, _, err := c.docker.ImageInspectWithRaw(ctx, imageRef.Name())
+		if err != nil {
+			return 0, "", errors.Wrapf(err, "inspecting image %s", style.Symbol(imageRef.Name()))
+		}
+		return inspect.Size, "", nil
+	}
+
+	desc, err := ggcrremote.Get(imageRef, c.remoteOptions(ctx)...)
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "fetching image %s", style.Symbol(imageRef.Name()))
+	}
+	if !desc.MediaType.IsImage() {
+		return 0, desc.MediaType, nil
+	}
+
+	manifest, err := v1.ParseManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "reading manifest of image %s", style.Symbol(imageRef.Name()))
+	}
+
+	size := manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+	return size, desc.MediaType, nil
+}
+
+func hasProcess(buildMD platform.BuildMetadata, processType string) bool {
+	for _, process := range buildMD.Processes {
+		if process.Type == processType {
+			return true
+		}
+	}
+	return false
+}
+
+// processEntrypoint is the entrypoint of images running processType, which the launcher provides since Platform API 0.4.
+func processEntrypoint(processType string) string {
+	return "/cnb/process/" + processType
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLambda(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Lambda", testLambda, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLambda(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDockerClient *testmocks.MockCommonAPIClient
+		fakeAppImage     *fakes.Image
+		imageRef         name.Reference
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		fakeImageFetcher := ifakes.NewFakeImageFetcher()
+		fakeAppImage = fakes.NewImage("index.docker.io/some/app:latest", "", nil)
+		h.AssertNil(t, fakeAppImage.SetLabel(platform.BuildMetadataLabel, `{"processes":[{"type":"web"},{"type":"handler"}]}`))
+		h.AssertNil(t, fakeAppImage.SetEntrypoint("/cnb/process/web"))
+		fakeImageFetcher.LocalImages[fakeAppImage.Name()] = fakeAppImage
+
+		var err error
+		imageRef, err = name.ParseReference(fakeAppImage.Name())
+		h.AssertNil(t, err)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+			docker:       mockDockerClient,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNilE(t, fakeAppImage.Cleanup())
+	})
+
+	when("#adaptForLambda", func() {
+		it("accepts images meeting the requirements of Lambda", func() {
+			mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), imageRef.Name()).Return(types.ImageInspect{Size: 1 << 30}, nil, nil)
+
+			h.AssertNil(t, subject.adaptForLambda(context.TODO(), imageRef, nil, LambdaOptions{}, false))
+			h.AssertEq(t, fakeAppImage.IsSaved(), false)
+			h.AssertContains(t, out.String(), "meets the requirements of AWS Lambda")
+		})
+
+		it("sets the entrypoint to the process of the function", func() {
+			mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), imageRef.Name()).Return(types.ImageInspect{Size: 1 << 30}, nil, nil)
+
+			h.AssertNil(t, subject.adaptForLambda(context.TODO(), imageRef, []string{"some/app:other-tag"}, LambdaOptions{ProcessType: "handler"}, false))
+
+			h.AssertEq(t, fakeAppImage.IsSaved(), true)
+			entrypoint, err := fakeAppImage.Entrypoint()
+			h.AssertNil(t, err)
+			h.AssertEq(t, entrypoint, []string{"/cnb/process/handler"})
+			cmd, err := fakeAppImage.Cmd()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(cmd), 0)
+		})
+
+		it("reports every requirement the image does not meet", func() {
+			h.AssertNil(t, fakeAppImage.SetArchitecture("ppc64le"))
+			mockDockerClient.EXPECT().ImageInspectWithRaw(gomock.Any(), imageRef.Name()).Return(types.ImageInspect{Size: 11 * 1000 * 1000 * 1000}, nil, nil)
+
+			err := subject.adaptForLambda(context.TODO(), imageRef, nil, LambdaOptions{ProcessType: "worker"}, false)
+			h.AssertError(t, err, "image 'index.docker.io/some/app:latest' does not meet the requirements of AWS Lambda:\n"+
+				"- the platform must be linux/amd64 or linux/arm64, not 'linux/ppc64le'\n"+
+				"- the image has no process of type 'worker'\n"+
+				"- the image must be at most 10 GB, not 11.0 GB")
+			h.AssertEq(t, fakeAppImage.IsSaved(), false)
+		})
+	})
+
+	when("#ecrRegistryPattern", func() {
+		it("matches the registries of Amazon ECR", func() {
+			h.AssertTrue(t, ecrRegistryPattern.MatchString("123456789012.dkr.ecr.us-east-1.amazonaws.com"))
+			h.AssertTrue(t, ecrRegistryPattern.MatchString("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn"))
+			h.AssertFalse(t, ecrRegistryPattern.MatchString("index.docker.io"))
+		})
+	})
+}
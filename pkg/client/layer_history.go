@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/daemon"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// setLayerHistory describes what created each layer of the exported app image in the history of its config, and
+// saves it again under its name and additional tags, so that `docker history` shows the buildpack of each layer.
+// imgutil zeroes the history of the images it saves, so neither the lifecycle exporter nor pack provide one.
+func (c *Client) setLayerHistory(ctx context.Context, imageRef name.Reference, additionalTags []string, publish bool) error {
+	img, configFile, layersMD, err := c.fetchAppImage(ctx, imageRef, publish)
+	if err != nil {
+		return err
+	}
+
+	configFile = configFile.DeepCopy()
+	configFile.History = layerHistory(configFile, layersMD)
+	described, err := mutate.ConfigFile(img, configFile)
+	if err != nil {
+		return errors.Wrapf(err, "setting history of image %s", style.Symbol(imageRef.Name()))
+	}
+
+	for _, ref := range append([]string{imageRef.Name()}, additionalTags...) {
+		tag, err := name.NewTag(ref, name.WeakValidation)
+		if err != nil {
+			return errors.Wrapf(err, "parsing tag %s", style.Symbol(ref))
+		}
+
+		switch {
+		case publish:
+			err = ggcrremote.Write(tag, described, c.remoteOptions(ctx)...)
+		case ref == imageRef.Name():
+			_, err = daemon.Write(tag, described, daemon.WithContext(ctx), daemon.WithClient(c.docker))
+		default:
+			// the daemon loads the whole image, so it is written once and tagged with the additional tags
+			err = c.docker.ImageTag(ctx, imageRef.Name(), tag.Name())
+		}
+		if err != nil {
+			return errors.Wrapf(err, "writing image %s", style.Symbol(ref))
+		}
+	}
+
+	c.logger.Debugf("Described the layers of image %s in its history", style.Symbol(imageRef.Name()))
+	return nil
+}
+
+// fetchAppImage fetches an app image from the registry, or from the daemon unless remote, with its config and the
+// metadata of its layers recorded by the lifecycle.
+func (c *Client) fetchAppImage(ctx context.Context, imageRef name.Reference, remote bool) (v1.Image, *v1.ConfigFile, platform.LayersMetadata, error) {
+	var layersMD platform.LayersMetadata
+	img, configFile, err := c.fetchImageConfig(ctx, imageRef, remote)
+	if err != nil {
+		return nil, nil, layersMD, err
+	}
+
+	label, ok := configFile.Config.Labels[platform.LayerMetadataLabel]
+	if !ok {
+		return nil, nil, layersMD, errors.Errorf("image %s is missing label %s", style.Symbol(imageRef.Name()), style.Symbol(platform.LayerMetadataLabel))
+	}
+	if err := json.Unmarshal([]byte(label), &layersMD); err != nil {
+		return nil, nil, layersMD, errors.Wrapf(err, "reading label %s of image %s", style.Symbol(platform.LayerMetadataLabel), style.Symbol(imageRef.Name()))
+	}
+	return img, configFile, layersMD, nil
+}
+
+// fetchImageConfig fetches an image from the registry, or from the daemon unless remote, with its config.
+func (c *Client) fetchImageConfig(ctx context.Context, imageRef name.Reference, remote bool) (v1.Image, *v1.ConfigFile, error) {
+	var (
+		img v1.Image
+		err error
+	)
+	if remote {
+		img, err = ggcrremote.Image(imageRef, c.remoteOptions(ctx)...)
+	} else {
+		img, err = daemon.Image(imageRef, daemon.WithContext(ctx), daemon.WithClient(c.docker))
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fetching image %s", style.Symbol(imageRef.Name()))
+	}
+
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "reading config of image %s", style.Symbol(imageRef.Name()))
+	}
+	return img, configFile, nil
+}
+
+// layerHistory returns one history entry per layer of configFile, in order, describing the layer as part of the run
+// image, as a layer of a buildpack with its id and version, as a layer the lifecycle adds, or as a layer pack injects.
+func layerHistory(configFile *v1.ConfigFile, layersMD platform.LayersMetadata) []v1.History {
+	createdBy := map[string]string{}
+	describe := func(sha, description string) {
+		if _, ok := createdBy[sha]; sha != "" && !ok {
+			createdBy[sha] = description
+		}
+	}
+
+	for _, bp := range layersMD.Buildpacks {
+		for layerName, layer := range bp.Layers {
+			describe(layer.SHA, fmt.Sprintf("buildpack %s@%s layer %s", bp.ID, bp.Version, layerName))
+		}
+	}
+	for _, layer := range layersMD.App {
+		describe(layer.SHA, "lifecycle app")
+	}
+	describe(layersMD.Launcher.SHA, "lifecycle launcher")
+	describe(layersMD.Config.SHA, "lifecycle config")
+	describe(layersMD.ProcessTypes.SHA, "lifecycle process types")
+	if layersMD.BOM != nil {
+		describe(layersMD.BOM.SHA, "lifecycle sbom")
+	}
+
+	runImage := layersMD.Stack.RunImage.Image
+	if runImage == "" {
+		runImage = layersMD.RunImage.Reference
+	}
+
+	topLayer := -1
+	for i, diffID := range configFile.RootFS.DiffIDs {
+		if diffID.String() == layersMD.RunImage.TopLayer {
+			topLayer = i
+		}
+	}
+
+	history := make([]v1.History, len(configFile.RootFS.DiffIDs))
+	for i, diffID := range configFile.RootFS.DiffIDs {
+		history[i] = v1.History{Created: configFile.Created}
+		switch description, ok := createdBy[diffID.String()]; {
+		case i <= topLayer:
+			history[i].CreatedBy = "run image " + runImage
+		case ok:
+			history[i].CreatedBy = description
+		default:
+			// the layers appended after the export are injected by pack
+			history[i].CreatedBy = "pack injected layer"
+		}
+	}
+	return history
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLayerHistory(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LayerHistory", testLayerHistory, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLayerHistory(t *testing.T, when spec.G, it spec.S) {
+	when("#layerHistory", func() {
+		var (
+			configFile *v1.ConfigFile
+			layersMD   platform.LayersMetadata
+			created    = v1.Time{Time: time.Date(1980, time.January, 1, 0, 0, 1, 0, time.UTC)}
+		)
+
+		diffID := func(i int) v1.Hash {
+			return v1.Hash{Algorithm: "sha256", Hex: string(rune('a'+i)) + "000000000000000000000000000000000000000000000000000000000000000"}
+		}
+
+		it.Before(func() {
+			configFile = &v1.ConfigFile{Created: created}
+			for i := 0; i < 8; i++ {
+				configFile.RootFS.DiffIDs = append(configFile.RootFS.DiffIDs, diffID(i))
+			}
+
+			layersMD = platform.LayersMetadata{
+				RunImage: platform.RunImageMetadata{TopLayer: diffID(1).String(), Reference: "some/run@sha256:123"},
+				Buildpacks: []buildpack.LayersMetadata{
+					{ID: "some/buildpack", Version: "1.2.3", Layers: map[string]buildpack.LayerMetadata{"deps": {SHA: diffID(2).String()}}},
+				},
+				Launcher:     platform.LayerMetadata{SHA: diffID(3).String()},
+				App:          []platform.LayerMetadata{{SHA: diffID(4).String()}},
+				Config:       platform.LayerMetadata{SHA: diffID(5).String()},
+				ProcessTypes: platform.LayerMetadata{SHA: diffID(6).String()},
+			}
+			layersMD.Stack.RunImage.Image = "some/run"
+		})
+
+		it("describes each layer by what created it", func() {
+			history := layerHistory(configFile, layersMD)
+
+			var createdBy []string
+			for _, entry := range history {
+				h.AssertEq(t, entry.Created, created)
+				createdBy = append(createdBy, entry.CreatedBy)
+			}
+			h.AssertEq(t, createdBy, []string{
+				"run image some/run",
+				"run image some/run",
+				"buildpack some/buildpack@1.2.3 layer deps",
+				"lifecycle launcher",
+				"lifecycle app",
+				"lifecycle config",
+				"lifecycle process types",
+				"pack injected layer",
+			})
+		})
+
+		it("falls back to the reference of the run image", func() {
+			layersMD.Stack.RunImage.Image = ""
+
+			history := layerHistory(configFile, layersMD)
+			h.AssertEq(t, history[0].CreatedBy, "run image some/run@sha256:123")
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// catalogPageSize is the number of repositories requested from the registry catalog at once. Registries may
+// return fewer.
+const catalogPageSize = 100
+
+// ListBuildersOptions is a configuration struct that controls the behavior of ListBuilders.
+type ListBuildersOptions struct {
+	// Registry namespaces to search for builders, e.g. "gcr.io/my-team".
+	// A namespace consisting of a registry host alone searches the whole registry.
+	Namespaces []string
+}
+
+// BuilderSummary describes a builder image found by ListBuilders.
+type BuilderSummary struct {
+	// Image is the tag reference of the builder.
+	Image string
+
+	// Stack is the ID of the stack of the builder.
+	Stack string
+
+	// LifecycleVersion is the version of the lifecycle in the builder.
+	LifecycleVersion string
+
+	// Description of the builder, if it has one.
+	Description string
+}
+
+// ListBuilders enumerates the builder images in registry namespaces.
+// Repositories are discovered through the catalog API of each registry, and every tag of each repository is
+// inspected; images that are not builders are skipped. Both APIs are paginated, so large registries are listed
+// in full, but may take a while to inspect.
+func (c *Client) ListBuilders(ctx context.Context, opts ListBuildersOptions) ([]BuilderSummary, error) {
+	if len(opts.Namespaces) == 0 {
+		return nil, errors.New("at least one registry namespace is required")
+	}
+
+	var builders []BuilderSummary
+	for _, namespace := range opts.Namespaces {
+		host, prefix := splitNamespace(namespace)
+		registry, err := name.NewRegistry(host, name.WeakValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid registry namespace '%s'", namespace)
+		}
+
+		repositories, err := c.listRepositories(ctx, registry, prefix)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing repositories of %s", style.Symbol(namespace))
+		}
+
+		for _, repository := range repositories {
+			repo, err := name.NewRepository(registry.RegistryStr()+"/"+repository, name.WeakValidation)
+			if err != nil {
+				c.logger.Warnf("Skipping invalid repository %s: %s", style.Symbol(repository), err)
+				continue
+			}
+
+			tags, err := ggcrremote.List(repo, c.remoteOptions(ctx)...)
+			if err != nil {
+				c.logger.Warnf("Unable to list tags of %s: %s", style.Symbol(repo.Name()), err)
+				continue
+			}
+
+			for _, tag := range tags {
+				summary, ok := c.summarizeBuilder(ctx, repo.Tag(tag).Name())
+				if ok {
+					builders = append(builders, summary)
+				}
+			}
+		}
+	}
+
+	sort.Slice(builders, func(i, j int) bool {
+		return builders[i].Image < builders[j].Image
+	})
+	return builders, nil
+}
+
+// listRepositories pages through the catalog of registry, following the next page links of the registry, and keeps
+// the repositories within prefix. Registries may return pages smaller than requested before the last page.
+func (c *Client) listRepositories(ctx context.Context, registry name.Registry, prefix string) ([]string, error) {
+	options := append(c.remoteOptions(ctx), ggcrremote.WithPageSize(catalogPageSize))
+	catalog, err := ggcrremote.Catalog(ctx, registry, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []string
+	for _, repository := range catalog {
+		if prefix == "" || strings.HasPrefix(repository, prefix+"/") {
+			repositories = append(repositories, repository)
+		}
+	}
+	return repositories, nil
+}
+
+func (c *Client) summarizeBuilder(ctx context.Context, imageName string) (BuilderSummary, bool) {
+	img, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: false})
+	if err != nil {
+		c.logger.Warnf("Unable to fetch %s: %s", style.Symbol(imageName), err)
+		return BuilderSummary{}, false
+	}
+
+	bldr, err := builder.FromImage(img)
+	if err != nil {
+		c.logger.Debugf("Skipping %s: %s", style.Symbol(imageName), err)
+		return BuilderSummary{}, false
+	}
+
+	summary := BuilderSummary{
+		Image:       imageName,
+		Stack:       bldr.StackID,
+		Description: bldr.Description(),
+	}
+	if version := bldr.LifecycleDescriptor().Info.Version; version != nil {
+		summary.LifecycleVersion = version.String()
+	}
+	return summary, true
+}
+
+// splitNamespace splits a registry namespace into the registry host and the repository prefix.
+func splitNamespace(namespace string) (string, string) {
+	parts := strings.SplitN(strings.TrimSuffix(namespace, "/"), "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestListBuilders(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ListBuilders", testListBuilders, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testListBuilders(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server           *httptest.Server
+		host             string
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		subject          *Client
+		out              bytes.Buffer
+	)
+
+	push := func(repoAndTag string) {
+		img, err := random.Image(10, 1)
+		h.AssertNil(t, err)
+		ref, err := name.ParseReference(host + "/" + repoAndTag)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.Write(ref, img))
+	}
+
+	addBuilder := func(repoAndTag, stackID, lifecycleVersion string) {
+		push(repoAndTag)
+		img := fakes.NewImage(host+"/"+repoAndTag, "", nil)
+		h.AssertNil(t, img.SetEnv("CNB_USER_ID", "1234"))
+		h.AssertNil(t, img.SetEnv("CNB_GROUP_ID", "5678"))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", stackID))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.builder.metadata",
+			fmt.Sprintf(`{"description":"some description","stack":{"runImage":{"image":"some/run"}},"lifecycle":{"version":"%s"}}`, lifecycleVersion)))
+		fakeImageFetcher.RemoteImages[img.Name()] = img
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New())
+		host = strings.TrimPrefix(server.URL, "http://")
+
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+			keychain:     authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#ListBuilders", func() {
+		it("requires a namespace", func() {
+			_, err := subject.ListBuilders(context.TODO(), ListBuildersOptions{})
+			h.AssertError(t, err, "at least one registry namespace is required")
+		})
+
+		it("lists the builders within the namespace", func() {
+			addBuilder("my-team/builder:base", "some.stack.id", "0.14.0")
+			addBuilder("my-team/builder:full", "other.stack.id", "0.13.1")
+			addBuilder("other-team/builder:base", "some.stack.id", "0.14.0")
+			fakeImageFetcher.RemoteImages[host+"/my-team/app:latest"] = fakes.NewImage(host+"/my-team/app:latest", "", nil)
+			push("my-team/app:latest")
+
+			builders, err := subject.ListBuilders(context.TODO(), ListBuildersOptions{Namespaces: []string{host + "/my-team"}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, builders, []BuilderSummary{
+				{Image: host + "/my-team/builder:base", Stack: "some.stack.id", LifecycleVersion: "0.14.0", Description: "some description"},
+				{Image: host + "/my-team/builder:full", Stack: "other.stack.id", LifecycleVersion: "0.13.1", Description: "some description"},
+			})
+		})
+
+		it("lists the builders of the whole registry", func() {
+			addBuilder("my-team/builder:base", "some.stack.id", "0.14.0")
+			addBuilder("other-team/builder:base", "some.stack.id", "0.14.0")
+
+			builders, err := subject.ListBuilders(context.TODO(), ListBuildersOptions{Namespaces: []string{host}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(builders), 2)
+		})
+
+		it("follows the next page links of the catalog when pages are smaller than requested", func() {
+			addBuilder("my-team/builder-1:base", "some.stack.id", "0.14.0")
+			addBuilder("my-team/builder-2:base", "some.stack.id", "0.14.0")
+			addBuilder("my-team/builder-3:base", "some.stack.id", "0.14.0")
+
+			// serve the catalog one repository at a time, linking to the next page
+			pages := map[string]string{
+				"":                  "my-team/builder-1",
+				"my-team/builder-1": "my-team/builder-2",
+				"my-team/builder-2": "my-team/builder-3",
+			}
+			handler := server.Config.Handler
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2/_catalog" {
+					handler.ServeHTTP(w, r)
+					return
+				}
+				repository := pages[r.URL.Query().Get("last")]
+				if repository != "my-team/builder-3" {
+					w.Header().Set("Link", fmt.Sprintf(`</v2/_catalog?last=%s&n=100>; rel="next"`, url.QueryEscape(repository)))
+				}
+				fmt.Fprintf(w, `{"repositories":["%s"]}`, repository)
+			})
+
+			builders, err := subject.ListBuilders(context.TODO(), ListBuildersOptions{Namespaces: []string{host + "/my-team"}})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(builders), 3)
+		})
+	})
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// appTemplates are the files of a minimal web app in a language, and the builder known to build it.
+var appTemplates = map[string]appTemplate{
+	"go": {
+		builder: "paketobuildpacks/builder:base",
+		ignore:  []string{"/bin/"},
+		files: map[string]string{
+			"go.mod": "module {{name}}\n\ngo 1.18\n",
+			"main.go": `package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
+
+func main() {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "Hello from {{name}}!")
+	})
+	if err := http.ListenAndServe(":"+port, nil); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`,
+		},
+	},
+	"node": {
+		builder: "paketobuildpacks/builder:base",
+		ignore:  []string{"node_modules/"},
+		files: map[string]string{
+			"package.json": `{
+  "name": "{{name}}",
+  "version": "1.0.0",
+  "private": true,
+  "scripts": {
+    "start": "node server.js"
+  }
+}
+`,
+			"server.js": `const http = require("http");
+
+const port = process.env.PORT || 8080;
+
+http
+  .createServer((req, res) => {
+    res.end("Hello from {{name}}!\n");
+  })
+  .listen(port);
+`,
+		},
+	},
+	"python": {
+		builder: "heroku/buildpacks:20",
+		ignore:  []string{"__pycache__/", "*.pyc", ".venv/"},
+		files: map[string]string{
+			"requirements.txt": "",
+			"Procfile":         "web: python app.py\n",
+			"app.py": `import os
+from http.server import BaseHTTPRequestHandler, HTTPServer
+
+
+class Handler(BaseHTTPRequestHandler):
+    def do_GET(self):
+        self.send_response(200)
+        self.end_headers()
+        self.wfile.write(b"Hello from {{name}}!\n")
+
+
+HTTPServer(("", int(os.environ.get("PORT", "8080"))), Handler).serve_forever()
+`,
+		},
+	},
+}
+
+type appTemplate struct {
+	// builder is the suggested builder building apps of the language.
+	builder string
+	// ignore are the patterns of the .packignore of the app, in addition to those of all apps.
+	ignore []string
+	// files are the contents of the files of the app, by path, in which {{name}} is the name of the app.
+	files map[string]string
+}
+
+// appNamePattern matches the names of apps, which are used as the names of modules and packages.
+var appNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// NewAppOptions define the app generated by NewApp.
+type NewAppOptions struct {
+	// Directory to generate the app in. It must not exist.
+	Path string
+
+	// Name of the app, which may contain lowercase letters, digits, dots, dashes and underscores.
+	Name string
+
+	// Language of the app. Accepted values are go, node and python.
+	Language string
+
+	// Builder set in the project.toml of the app. Defaults to a builder suggested for the language.
+	Builder string
+}
+
+// NewApp generates a minimal web app, with a project.toml configuring the builder to build it with, and a
+// .packignore excluding the files which are not part of the app, so that it can be built with pack build.
+func (c *Client) NewApp(ctx context.Context, opts NewAppOptions) error {
+	template, ok := appTemplates[opts.Language]
+	if !ok {
+		return errors.Errorf("no template for language %s, must be one of %s", style.Symbol(opts.Language), strings.Join(AppLanguages(), ", "))
+	}
+
+	if !appNamePattern.MatchString(opts.Name) {
+		return errors.Errorf("invalid app name %s: must contain only lowercase letters, digits, dots, dashes and underscores", style.Symbol(opts.Name))
+	}
+
+	if _, err := os.Stat(opts.Path); !os.IsNotExist(err) {
+		return errors.Errorf("directory %s exists", style.Symbol(opts.Path))
+	}
+
+	builder := opts.Builder
+	if builder == "" {
+		builder = template.builder
+	}
+
+	files := map[string]string{
+		"project.toml": fmt.Sprintf("[_]\nschema-version = \"0.2\"\nid = \"{{name}}\"\nname = \"{{name}}\"\n\n[io.buildpacks]\nbuilder = %q\n", builder),
+		".packignore":  strings.Join(append([]string{".git/", "project.toml"}, template.ignore...), "\n") + "\n",
+	}
+	for path, contents := range template.files {
+		files[path] = contents
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// The following line's comment is for gosec, it will ignore rule 301 in this case
+	// G301: Expect directory permissions to be 0750 or less
+	/* #nosec G301 */
+	if err := os.MkdirAll(opts.Path, 0755); err != nil {
+		return errors.Wrapf(err, "creating directory %s", style.Symbol(opts.Path))
+	}
+
+	for _, path := range paths {
+		contents := strings.ReplaceAll(files[path], "{{name}}", opts.Name)
+		// The following line's comment is for gosec, it will ignore rule 306 in this case
+		// G306: Expect WriteFile permissions to be 0600 or less
+		/* #nosec G306 */
+		if err := ioutil.WriteFile(filepath.Join(opts.Path, path), []byte(contents), 0644); err != nil {
+			return errors.Wrapf(err, "writing %s", style.Symbol(path))
+		}
+		c.logger.Infof("    %s  %s", style.Symbol("create"), path)
+	}
+
+	return nil
+}
+
+// AppLanguages lists the languages NewApp generates apps in.
+func AppLanguages() []string {
+	var names []string
+	for name := range appTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
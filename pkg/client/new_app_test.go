@@ -0,0 +1,104 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestNewApp(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "NewApp", testNewApp, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testNewApp(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *client.Client
+		tmpDir  string
+		out     bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+
+		tmpDir, err = ioutil.TempDir("", "new-app-test")
+		h.AssertNil(t, err)
+
+		subject, err = client.NewClient(client.WithLogger(logging.NewLogWithWriters(&out, &out)))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#NewApp", func() {
+		it("generates an app with a project.toml and .packignore", func() {
+			appDir := filepath.Join(tmpDir, "my-app")
+			h.AssertNil(t, subject.NewApp(context.TODO(), client.NewAppOptions{
+				Path:     appDir,
+				Name:     "my-app",
+				Language: "go",
+			}))
+
+			_, err := os.Stat(filepath.Join(appDir, "main.go"))
+			h.AssertNil(t, err)
+			goMod, err := ioutil.ReadFile(filepath.Join(appDir, "go.mod"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(goMod), "module my-app")
+
+			descriptor, err := project.ReadProjectDescriptor(filepath.Join(appDir, "project.toml"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.Build.Builder, "paketobuildpacks/builder:base")
+			h.AssertEq(t, descriptor.Project.Name, "my-app")
+
+			packIgnore, err := ioutil.ReadFile(filepath.Join(appDir, ".packignore"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(packIgnore), ".git/\n")
+
+			h.AssertContains(t, out.String(), "create'  main.go")
+		})
+
+		it("sets the builder", func() {
+			appDir := filepath.Join(tmpDir, "my-app")
+			h.AssertNil(t, subject.NewApp(context.TODO(), client.NewAppOptions{
+				Path:     appDir,
+				Name:     "my-app",
+				Language: "node",
+				Builder:  "some/builder",
+			}))
+
+			descriptor, err := project.ReadProjectDescriptor(filepath.Join(appDir, "project.toml"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.Build.Builder, "some/builder")
+		})
+
+		it("fails for unknown languages", func() {
+			err := subject.NewApp(context.TODO(), client.NewAppOptions{Path: filepath.Join(tmpDir, "my-app"), Name: "my-app", Language: "cobol"})
+			h.AssertError(t, err, "no template for language 'cobol', must be one of go, node, python")
+		})
+
+		it("fails for invalid names", func() {
+			err := subject.NewApp(context.TODO(), client.NewAppOptions{Path: filepath.Join(tmpDir, "my-app"), Name: "My App", Language: "go"})
+			h.AssertError(t, err, "invalid app name 'My App'")
+		})
+
+		it("fails when the directory exists", func() {
+			err := subject.NewApp(context.TODO(), client.NewAppOptions{Path: tmpDir, Name: "my-app", Language: "go"})
+			h.AssertError(t, err, "exists")
+		})
+	})
+}
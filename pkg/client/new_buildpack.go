@@ -2,11 +2,15 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
 
 	"github.com/buildpacks/lifecycle/api"
 
@@ -29,8 +33,55 @@ exit 0
 
 exit 0
 `
+
+	// languageTemplates are the bin/detect and bin/build scripts of a buildpack for apps written in a language,
+	// detecting the app by a file it always has.
+	languageTemplates = map[string]languageTemplate{
+		"go":     {detectFile: "go.mod", dependencies: "go modules"},
+		"java":   {detectFile: "pom.xml", dependencies: "maven dependencies"},
+		"node":   {detectFile: "package.json", dependencies: "npm packages"},
+		"python": {detectFile: "requirements.txt", dependencies: "pip packages"},
+		"ruby":   {detectFile: "Gemfile", dependencies: "gems"},
+	}
 )
 
+type languageTemplate struct {
+	detectFile   string
+	dependencies string
+}
+
+func (t languageTemplate) binDetect() string {
+	return fmt.Sprintf(`#!/usr/bin/env bash
+
+set -euo pipefail
+
+if [[ ! -f %[1]s ]]; then
+  exit 100
+fi
+
+exit 0
+`, t.detectFile)
+}
+
+func (t languageTemplate) binBuild() string {
+	return fmt.Sprintf(`#!/usr/bin/env bash
+
+set -euo pipefail
+
+layers_dir="$1"
+env_dir="$2/env"
+plan_path="$3"
+
+echo "---> Installing %[1]s"
+
+# Install the %[1]s of the app in a layer, e.g.
+#   mkdir -p "${layers_dir}/dependencies"
+# and describe the layer in "${layers_dir}/dependencies.toml"
+
+exit 0
+`, t.dependencies)
+}
+
 type NewBuildpackOptions struct {
 	// api compat version of the output buildpack artifact.
 	API string
@@ -46,22 +97,44 @@ type NewBuildpackOptions struct {
 
 	// The stacks this buildpack will work with
 	Stacks []dist.Stack
+
+	// Language of the apps the buildpack builds, to generate bin/detect and bin/build scripts for.
+	// Generic stubs are generated when empty.
+	Language string
 }
 
 func (c *Client) NewBuildpack(ctx context.Context, opts NewBuildpackOptions) error {
+	binBuild, binDetect := bashBinBuild, bashBinDetect
+	if opts.Language != "" {
+		template, ok := languageTemplates[opts.Language]
+		if !ok {
+			return errors.Errorf("no template for language %s, must be one of %s", style.Symbol(opts.Language), strings.Join(languages(), ", "))
+		}
+		binBuild, binDetect = template.binBuild(), template.binDetect()
+	}
+
 	err := createBuildpackTOML(opts.Path, opts.ID, opts.Version, opts.API, opts.Stacks, c)
 	if err != nil {
 		return err
 	}
-	return createBashBuildpack(opts.Path, c)
+	return createBashBuildpack(opts.Path, binBuild, binDetect, c)
+}
+
+func languages() []string {
+	var names []string
+	for name := range languageTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-func createBashBuildpack(path string, c *Client) error {
-	if err := createBinScript(path, "build", bashBinBuild, c); err != nil {
+func createBashBuildpack(path, binBuild, binDetect string, c *Client) error {
+	if err := createBinScript(path, "build", binBuild, c); err != nil {
 		return err
 	}
 
-	if err := createBinScript(path, "detect", bashBinDetect, c); err != nil {
+	if err := createBinScript(path, "detect", binDetect, c); err != nil {
 		return err
 	}
 
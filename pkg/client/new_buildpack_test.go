@@ -76,6 +76,38 @@ func testNewBuildpack(t *testing.T, when spec.G, it spec.S) {
 			assertBuildpackToml(t, tmpDir, "example/my-cnb")
 		})
 
+		when("a language is given", func() {
+			it("creates scripts detecting apps of the language", func() {
+				err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.8",
+					Path:     tmpDir,
+					ID:       "example/my-cnb",
+					Version:  "0.0.0",
+					Language: "node",
+				})
+				h.AssertNil(t, err)
+
+				detect, err := ioutil.ReadFile(filepath.Join(tmpDir, "bin", "detect"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(detect), "if [[ ! -f package.json ]]; then\n  exit 100")
+
+				build, err := ioutil.ReadFile(filepath.Join(tmpDir, "bin", "build"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(build), "Installing npm packages")
+			})
+
+			it("errors for languages without a template", func() {
+				err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.8",
+					Path:     tmpDir,
+					ID:       "example/my-cnb",
+					Version:  "0.0.0",
+					Language: "cobol",
+				})
+				h.AssertError(t, err, "no template for language 'cobol', must be one of go, java, node, python, ruby")
+			})
+		})
+
 		when("files exist", func() {
 			it.Before(func() {
 				var err error
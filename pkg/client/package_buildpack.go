@@ -90,6 +90,7 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 	}
 
 	packageBuilder.SetBuildpack(bp)
+	packageBuilder.SetArchitecture(opts.Config.Platform.Arch)
 
 	for _, dep := range opts.Config.Dependencies {
 		var depBPs []buildpack.Buildpack
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// dockerSocket is where the docker daemon serves its API by default.
+const dockerSocket = "/var/run/docker.sock"
+
+// podmanSocket returns the address of the socket of the podman API service when it should be used instead of the
+// docker daemon, which is when DOCKER_HOST is not set and the docker socket does not exist, such as on hosts running
+// only podman. The socket of the rootless service of the user is preferred to the socket of the rootful service. It
+// returns an empty string when no podman socket exists.
+func podmanSocket(getenv func(string) string, exists func(string) bool) string {
+	if runtime.GOOS != "linux" || getenv("DOCKER_HOST") != "" || exists(dockerSocket) {
+		return ""
+	}
+
+	var sockets []string
+	if runtimeDir := getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		sockets = append(sockets, filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	sockets = append(sockets, "/run/podman/podman.sock")
+
+	for _, socket := range sockets {
+		if exists(socket) {
+			return "unix://" + socket
+		}
+	}
+	return ""
+}
+
+func socketExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode()&os.ModeSocket != 0
+}
+
+// buildDockerHost returns the address of the daemon exposed to the build containers: dockerHost when given, or else
+// the podman socket the client uses.
+func (c *Client) buildDockerHost(dockerHost string) string {
+	if dockerHost == "" {
+		return c.daemonHost
+	}
+	return dockerHost
+}
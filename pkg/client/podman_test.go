@@ -0,0 +1,73 @@
+package client
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPodman(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Podman", testPodman, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPodman(t *testing.T, when spec.G, it spec.S) {
+	when("#podmanSocket", func() {
+		var (
+			env     map[string]string
+			sockets map[string]bool
+		)
+
+		it.Before(func() {
+			h.SkipIf(t, runtime.GOOS != "linux", "podman sockets are only detected on linux")
+
+			env = map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"}
+			sockets = map[string]bool{
+				"/run/user/1000/podman/podman.sock": true,
+				"/run/podman/podman.sock":           true,
+			}
+		})
+
+		detect := func() string {
+			return podmanSocket(func(key string) string { return env[key] }, func(path string) bool { return sockets[path] })
+		}
+
+		it("prefers the rootless socket of the user", func() {
+			h.AssertEq(t, detect(), "unix:///run/user/1000/podman/podman.sock")
+		})
+
+		it("falls back to the rootful socket", func() {
+			delete(sockets, "/run/user/1000/podman/podman.sock")
+			h.AssertEq(t, detect(), "unix:///run/podman/podman.sock")
+		})
+
+		it("is not used when the docker socket exists", func() {
+			sockets[dockerSocket] = true
+			h.AssertEq(t, detect(), "")
+		})
+
+		it("is not used when DOCKER_HOST is set", func() {
+			env["DOCKER_HOST"] = "tcp://example.com:2376"
+			h.AssertEq(t, detect(), "")
+		})
+
+		it("is empty without podman sockets", func() {
+			sockets = map[string]bool{}
+			h.AssertEq(t, detect(), "")
+		})
+	})
+
+	when("#buildDockerHost", func() {
+		it("prefers the given docker host to the detected podman socket", func() {
+			subject := &Client{daemonHost: "unix:///run/podman/podman.sock"}
+			h.AssertEq(t, subject.buildDockerHost(""), "unix:///run/podman/podman.sock")
+			h.AssertEq(t, subject.buildDockerHost("inherit"), "inherit")
+		})
+	})
+}
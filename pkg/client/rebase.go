@@ -2,9 +2,12 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/lifecycle"
 	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/build"
@@ -27,13 +30,25 @@ type RebaseOptions struct {
 
 	// Image to rebase against. This image must have
 	// the same StackID as the previous run image.
+	// When it is a digest reference, the digest is recorded as
+	// the reference of the run image of the rebased image.
 	RunImage string
 
-	// A mapping from StackID to an array of mirrors.
-	// This mapping used only if both RunImage is omitted and Publish is true.
+	// A mapping from run image to an array of mirrors.
+	// This mapping is used only if RunImage is omitted.
 	// AdditionalMirrors gives us inputs to recalculate the 'best' run image
-	// based on the registry we are publishing to.
+	// based on the registry of the app image.
 	AdditionalMirrors map[string][]string
+
+	// Additional tags to save the rebased image under, in addition to RepoName.
+	AdditionalTags []string
+
+	// Describe the buildpack of each layer of the rebased image in its history, as for BuildOptions.LayerHistory,
+	// since the history is not kept by rebasing.
+	LayerHistory bool
+
+	// Report what rebasing would change, and whether it is safe, without saving the image.
+	DryRun bool
 }
 
 // Rebase updates the run image layers in an app image.
@@ -44,6 +59,12 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		return errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
 	}
 
+	for _, tag := range opts.AdditionalTags {
+		if _, err := c.parseTagReference(tag); err != nil {
+			return errors.Wrapf(err, "invalid tag '%s'", tag)
+		}
+	}
+
 	appImage, err := c.imageFetcher.Fetch(ctx, opts.RepoName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
 	if err != nil {
 		return err
@@ -78,13 +99,30 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		return err
 	}
 
+	if opts.DryRun {
+		return c.previewRebase(ctx, appImage, baseImage, md, opts.Publish)
+	}
+
+	var rebased imgutil.Image = appImage
+	if digestRef, ok := runImageDigest(runImageName); ok {
+		// the lifecycle records the digest of the manifest of the run image for the platform, or its image ID in
+		// the daemon, rather than the digest it was pinned to
+		rebased = &pinnedRunImage{Image: appImage, reference: digestRef}
+	}
+
 	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
 	rebaser := &lifecycle.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest()}
-	_, err = rebaser.Rebase(appImage, baseImage, nil)
+	_, err = rebaser.Rebase(rebased, baseImage, opts.AdditionalTags)
 	if err != nil {
 		return err
 	}
 
+	if opts.LayerHistory {
+		if err := c.setLayerHistory(ctx, imageRef, opts.AdditionalTags, opts.Publish); err != nil {
+			return err
+		}
+	}
+
 	appImageIdentifier, err := appImage.Identifier()
 	if err != nil {
 		return err
@@ -93,3 +131,40 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
 	return nil
 }
+
+// runImageDigest returns the run image as a digest reference, when it is pinned to a digest.
+func runImageDigest(runImageName string) (string, bool) {
+	ref, err := name.ParseReference(runImageName, name.WeakValidation)
+	if err != nil {
+		return "", false
+	}
+	digest, ok := ref.(name.Digest)
+	if !ok {
+		return "", false
+	}
+	return digest.String(), true
+}
+
+// pinnedRunImage is an app image being rebased on a run image pinned to a digest, which records that digest as the
+// reference of its run image in the metadata the lifecycle sets.
+type pinnedRunImage struct {
+	imgutil.Image
+	reference string
+}
+
+func (i *pinnedRunImage) SetLabel(key, value string) error {
+	if key != platform.LayerMetadataLabel {
+		return i.Image.SetLabel(key, value)
+	}
+
+	var md platform.LayersMetadataCompat
+	if err := json.Unmarshal([]byte(value), &md); err != nil {
+		return errors.Wrapf(err, "reading label %s", style.Symbol(platform.LayerMetadataLabel))
+	}
+	md.RunImage.Reference = i.reference
+	data, err := json.Marshal(md)
+	if err != nil {
+		return err
+	}
+	return i.Image.SetLabel(key, string(data))
+}
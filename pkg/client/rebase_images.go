@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// defaultRebaseConcurrency is the number of images RebaseImages rebases at once by default.
+const defaultRebaseConcurrency = 4
+
+// RebaseImagesOptions configure the rebase of many images by RebaseImages.
+type RebaseImagesOptions struct {
+	// Options of the rebase of each image. RepoName is replaced with each of RepoNames, and AdditionalTags must be
+	// empty, as the tags would be shared by the images.
+	RebaseOptions
+
+	// Names of the images to rebase.
+	RepoNames []string
+
+	// Number of images rebased at once. Defaults to 4.
+	Concurrency int
+}
+
+// RebaseResult is the outcome of the rebase of an image by RebaseImages.
+type RebaseResult struct {
+	// Name of the image.
+	RepoName string
+
+	// Error rebasing the image, or nil when it was rebased.
+	Err error
+}
+
+// RebaseImages rebases many images, such as all images built on a run image patched for a vulnerability, by a
+// bounded number of concurrent rebases. A failure to rebase an image does not stop the rebase of the others. The
+// results are in the order of opts.RepoNames.
+func (c *Client) RebaseImages(ctx context.Context, opts RebaseImagesOptions) ([]RebaseResult, error) {
+	if len(opts.RepoNames) == 0 {
+		return nil, errors.New("at least one image is required")
+	}
+	if len(opts.AdditionalTags) > 0 {
+		return nil, errors.New("additional tags cannot be given when rebasing many images")
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultRebaseConcurrency
+	}
+
+	results := make([]RebaseResult, len(opts.RepoNames))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, repoName := range opts.RepoNames {
+		wg.Add(1)
+		go func(i int, repoName string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			rebaseOpts := opts.RebaseOptions
+			rebaseOpts.RepoName = repoName
+			results[i] = RebaseResult{RepoName: repoName}
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Err = c.Rebase(ctx, rebaseOpts)
+		}(i, repoName)
+	}
+	wg.Wait()
+
+	return results, nil
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// previewRebase reports what rebasing appImage on runImage would change: the digests of the current and target run
+// images, whether the rebase is safe, which is when the lifecycle would accept it, and the layers replaced. Nothing
+// is saved. It errors when the rebase is not safe.
+func (c *Client) previewRebase(ctx context.Context, appImage, runImage imgutil.Image, md platform.LayersMetadataCompat, publish bool) error {
+	runImageID, err := runImage.Identifier()
+	if err != nil {
+		return err
+	}
+	runTopLayer, err := runImage.TopLayer()
+	if err != nil {
+		return err
+	}
+
+	c.logger.Infof("Dry run of rebasing %s:", style.Symbol(appImage.Name()))
+	c.logger.Infof("  Current run image: %s (top layer %s)", style.Symbol(md.RunImage.Reference), md.RunImage.TopLayer)
+	c.logger.Infof("  Target run image:  %s %s (top layer %s)", style.Symbol(runImage.Name()), style.Symbol(runImageID.String()), runTopLayer)
+
+	if err := rebaseSafety(appImage, runImage); err != nil {
+		c.logger.Info("  Safe: no")
+		return errors.Wrap(err, "rebase would fail")
+	}
+	c.logger.Info("  Safe: yes")
+
+	if md.RunImage.TopLayer == runTopLayer {
+		c.logger.Info("  Layers: unchanged, the image is already based on the target run image")
+		return nil
+	}
+	diff, err := c.rebaseLayerDiff(ctx, appImage.Name(), runImage.Name(), md.RunImage.TopLayer, publish)
+	if err != nil {
+		c.logger.Warnf("Unable to compare the layers of the images: %s", err)
+		return nil
+	}
+	c.logger.Infof("  Layers: %s", diff)
+	return nil
+}
+
+// rebaseSafety returns why the lifecycle would refuse to rebase appImage on runImage: their stacks differ, or the run
+// image lacks mixins the app image was built with.
+func rebaseSafety(appImage, runImage imgutil.Image) error {
+	appStackID, err := appImage.Label(platform.StackIDLabel)
+	if err != nil {
+		return err
+	}
+	runStackID, err := runImage.Label(platform.StackIDLabel)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case appStackID == "":
+		return errors.New("stack not defined on app image")
+	case runStackID == "":
+		return errors.New("stack not defined on run image")
+	case appStackID != runStackID:
+		return errors.Errorf("incompatible stack: %s is not compatible with %s", style.Symbol(runStackID), style.Symbol(appStackID))
+	}
+
+	appMixins, err := imageMixins(appImage)
+	if err != nil {
+		return err
+	}
+	runMixins, err := imageMixins(runImage)
+	if err != nil {
+		return err
+	}
+
+	provided := map[string]bool{}
+	for _, mixin := range runMixins {
+		provided[mixin] = true
+	}
+	var missing []string
+	for _, mixin := range appMixins {
+		if !provided[mixin] {
+			missing = append(missing, mixin)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("missing required mixin(s): %v", missing)
+	}
+	return nil
+}
+
+func imageMixins(img imgutil.Image) ([]string, error) {
+	label, err := img.Label(platform.MixinsLabel)
+	if err != nil || label == "" {
+		return nil, err
+	}
+
+	var mixins []string
+	if err := json.Unmarshal([]byte(label), &mixins); err != nil {
+		return nil, errors.Wrapf(err, "reading label %s of image %s", style.Symbol(platform.MixinsLabel), style.Symbol(img.Name()))
+	}
+	return mixins, nil
+}
+
+// rebaseDiff counts the layers replaced by a rebase.
+type rebaseDiff struct {
+	// Removed and Added are the layers of the current and target run images the other one does not have.
+	Removed, Added int
+	// Kept are the layers of the app image above the run image.
+	Kept int
+}
+
+func (d rebaseDiff) String() string {
+	return fmt.Sprintf("%d run image layer(s) removed, %d added, %d app layer(s) kept", d.Removed, d.Added, d.Kept)
+}
+
+// rebaseLayerDiff compares the layers of the app image below oldTopLayer, the top layer of its run image, with the
+// layers of the target run image.
+func (c *Client) rebaseLayerDiff(ctx context.Context, appImageName, runImageName, oldTopLayer string, remote bool) (rebaseDiff, error) {
+	var diffIDs [2][]v1.Hash
+	for i, imageName := range []string{appImageName, runImageName} {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		if err != nil {
+			return rebaseDiff{}, err
+		}
+		_, configFile, err := c.fetchImageConfig(ctx, ref, remote)
+		if err != nil {
+			return rebaseDiff{}, err
+		}
+		diffIDs[i] = configFile.RootFS.DiffIDs
+	}
+	return diffRebaseLayers(diffIDs[0], oldTopLayer, diffIDs[1])
+}
+
+func diffRebaseLayers(appDiffIDs []v1.Hash, oldTopLayer string, runDiffIDs []v1.Hash) (rebaseDiff, error) {
+	top := -1
+	for i, diffID := range appDiffIDs {
+		if diffID.String() == oldTopLayer {
+			top = i
+			break
+		}
+	}
+	if top < 0 {
+		return rebaseDiff{}, errors.Errorf("top layer %s of the run image is not a layer of the app image", oldTopLayer)
+	}
+
+	oldRun := map[v1.Hash]bool{}
+	for _, diffID := range appDiffIDs[:top+1] {
+		oldRun[diffID] = true
+	}
+	newRun := map[v1.Hash]bool{}
+	for _, diffID := range runDiffIDs {
+		newRun[diffID] = true
+	}
+
+	diff := rebaseDiff{Kept: len(appDiffIDs) - top - 1}
+	for diffID := range oldRun {
+		if !newRun[diffID] {
+			diff.Removed++
+		}
+	}
+	for diffID := range newRun {
+		if !oldRun[diffID] {
+			diff.Added++
+		}
+	}
+	return diff, nil
+}
@@ -3,9 +3,11 @@ package client
 import (
 	"bytes"
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -91,6 +93,34 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("run image is pinned to a digest", func() {
+				var (
+					fakePinnedRunImage *fakes.Image
+					pinnedRunImage     = "registry.example.com/some/run@sha256:" + strings.Repeat("a", 64)
+				)
+
+				it.Before(func() {
+					fakePinnedRunImage = fakes.NewImage(pinnedRunImage, "pinned-top-layer-sha", &fakeIdentifier{name: "pinned-image-id"})
+					h.AssertNil(t, fakePinnedRunImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.bionic"))
+					fakeImageFetcher.LocalImages[pinnedRunImage] = fakePinnedRunImage
+				})
+
+				it.After(func() {
+					h.AssertNilE(t, fakePinnedRunImage.Cleanup())
+				})
+
+				it("records the digest as the reference of the run image", func() {
+					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
+						RunImage: pinnedRunImage,
+						RepoName: "some/app",
+					}))
+					h.AssertEq(t, fakeAppImage.Base(), pinnedRunImage)
+					lbl, _ := fakeAppImage.Label("io.buildpacks.lifecycle.metadata")
+					h.AssertContains(t, lbl, `"runImage":{"topLayer":"pinned-top-layer-sha","reference":"`+pinnedRunImage+`"`)
+					h.AssertContains(t, lbl, `"stack":{"runImage":{"image":"some/run"`)
+				})
+			})
+
 			when("run image is NOT provided by the user", func() {
 				when("the image has a label with a run image specified", func() {
 					it("uses the run image provided in the App image label", func() {
@@ -148,6 +178,64 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 
+				when("additional tags are given", func() {
+					it("saves the rebased image under them", func() {
+						h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
+							RepoName:       "some/app",
+							AdditionalTags: []string{"some/app:v1"},
+						}))
+						h.AssertContains(t, strings.Join(fakeAppImage.SavedNames(), ","), "some/app:v1")
+					})
+
+					it("errors for invalid tags", func() {
+						err := subject.Rebase(context.TODO(), RebaseOptions{
+							RepoName:       "some/app",
+							AdditionalTags: []string{"some/app@sha256:invalid"},
+						})
+						h.AssertError(t, err, "invalid tag 'some/app@sha256:invalid'")
+					})
+				})
+
+				when("dry run", func() {
+					it("reports the rebase without changing the image", func() {
+						h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata",
+							`{"runImage":{"topLayer":"run-image-top-layer-sha","reference":"run-image-digest"},"stack":{"runImage":{"image":"some/run"}}}`))
+
+						h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
+							RepoName: "some/app",
+							DryRun:   true,
+						}))
+						h.AssertEq(t, fakeAppImage.IsSaved(), false)
+						h.AssertContains(t, out.String(), "Current run image: 'run-image-digest'")
+						h.AssertContains(t, out.String(), "Target run image:  'some/run' 'run-image-digest'")
+						h.AssertContains(t, out.String(), "Safe: yes")
+						h.AssertContains(t, out.String(), "the image is already based on the target run image")
+					})
+
+					it("errors when the stacks differ", func() {
+						h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.id", "other.stack"))
+
+						err := subject.Rebase(context.TODO(), RebaseOptions{
+							RepoName: "some/app",
+							DryRun:   true,
+						})
+						h.AssertError(t, err, "rebase would fail: incompatible stack: 'other.stack' is not compatible with 'io.buildpacks.stacks.bionic'")
+						h.AssertEq(t, fakeAppImage.IsSaved(), false)
+						h.AssertContains(t, out.String(), "Safe: no")
+					})
+
+					it("errors when the run image lacks mixins of the app image", func() {
+						h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.stack.mixins", `["curl","git"]`))
+						h.AssertNil(t, fakeRunImage.SetLabel("io.buildpacks.stack.mixins", `["curl"]`))
+
+						err := subject.Rebase(context.TODO(), RebaseOptions{
+							RepoName: "some/app",
+							DryRun:   true,
+						})
+						h.AssertError(t, err, "missing required mixin(s): [git]")
+					})
+				})
+
 				when("the image does not have a label with a run image specified", func() {
 					it("returns an error", func() {
 						h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata", "{}"))
@@ -219,9 +307,46 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("#RebaseImages", func() {
+			it("rebases each image and reports those which failed", func() {
+				results, err := subject.RebaseImages(context.TODO(), RebaseImagesOptions{
+					RepoNames:   []string{"some/app", "missing/app"},
+					Concurrency: 1,
+				})
+				h.AssertNil(t, err)
+				h.AssertEq(t, len(results), 2)
+				h.AssertEq(t, results[0], RebaseResult{RepoName: "some/app"})
+				h.AssertEq(t, fakeAppImage.Base(), "some/run")
+				h.AssertEq(t, results[1].RepoName, "missing/app")
+				h.AssertError(t, results[1].Err, "does not exist on the daemon")
+			})
+
+			it("errors with additional tags", func() {
+				_, err := subject.RebaseImages(context.TODO(), RebaseImagesOptions{
+					RebaseOptions: RebaseOptions{AdditionalTags: []string{"some/app:v1"}},
+					RepoNames:     []string{"some/app"},
+				})
+				h.AssertError(t, err, "additional tags cannot be given when rebasing many images")
+			})
+		})
 	})
 }
 
+func TestDiffRebaseLayers(t *testing.T) {
+	hash := func(s string) v1.Hash {
+		return v1.Hash{Algorithm: "sha256", Hex: s}
+	}
+	appDiffIDs := []v1.Hash{hash("base"), hash("old-os"), hash("app"), hash("launcher")}
+
+	diff, err := diffRebaseLayers(appDiffIDs, "sha256:old-os", []v1.Hash{hash("base"), hash("new-os"), hash("new-certs")})
+	h.AssertNil(t, err)
+	h.AssertEq(t, diff, rebaseDiff{Removed: 1, Added: 2, Kept: 2})
+
+	_, err = diffRebaseLayers(appDiffIDs, "sha256:unknown", nil)
+	h.AssertError(t, err, "top layer sha256:unknown of the run image is not a layer of the app image")
+}
+
 type fakeIdentifier struct {
 	name string
 }
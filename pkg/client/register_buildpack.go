@@ -2,12 +2,15 @@ package client
 
 import (
 	"context"
-	"errors"
 	"net/url"
 	"runtime"
 	"strings"
 
+	"github.com/buildpacks/imgutil"
+	"github.com/pkg/errors"
+
 	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
@@ -31,7 +34,7 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 	}
 
 	var buildpackInfo dist.BuildpackInfo
-	if _, err := dist.GetLabel(appImage, buildpack.MetadataLabel, &buildpackInfo); err != nil {
+	if err := validateBuildpackPackage(appImage, &buildpackInfo); err != nil {
 		return err
 	}
 
@@ -59,6 +62,10 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 			return err
 		}
 
+		if err := registry.Validate(buildpack); err != nil {
+			return err
+		}
+
 		issue, err := registry.CreateGithubIssue(buildpack)
 		if err != nil {
 			return err
@@ -87,6 +94,10 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 			return err
 		}
 
+		if err := registry.Validate(buildpack); err != nil {
+			return err
+		}
+
 		if err := registry.GitCommit(buildpack, username, registryCache); err != nil {
 			return err
 		}
@@ -95,6 +106,29 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 	return nil
 }
 
+// validateBuildpackPackage reads the buildpack of a buildpack package into info, ensuring the package contains it
+func validateBuildpackPackage(img imgutil.Image, info *dist.BuildpackInfo) error {
+	ok, err := dist.GetLabel(img, buildpack.MetadataLabel, info)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("image %s is not a buildpack package: missing label %s", style.Symbol(img.Name()), style.Symbol(buildpack.MetadataLabel))
+	}
+	if info.ID == "" || info.Version == "" {
+		return errors.Errorf("buildpack package %s must have a buildpack ID and version", style.Symbol(img.Name()))
+	}
+
+	var layers dist.BuildpackLayers
+	if _, err := dist.GetLabel(img, dist.BuildpackLayersLabel, &layers); err != nil {
+		return err
+	}
+	if _, ok := layers.Get(info.ID, info.Version); !ok {
+		return errors.Errorf("buildpack package %s does not contain buildpack %s", style.Symbol(img.Name()), style.Symbol(info.FullName()))
+	}
+	return nil
+}
+
 func parseUsernameFromURL(url string) (string, error) {
 	parts := strings.Split(url, "/")
 	if len(parts) < 3 {
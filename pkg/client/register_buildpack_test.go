@@ -37,6 +37,8 @@ func testRegisterBuildpack(t *testing.T, when spec.G, it spec.S) {
 
 			h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.buildpackage.metadata",
 				`{"id":"heroku/java-function","version":"1.1.1","stacks":[{"id":"heroku-18"},{"id":"io.buildpacks.stacks.bionic"},{"id":"org.cloudfoundry.stacks.cflinuxfs3"}]}`))
+			h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.buildpack.layers",
+				`{"heroku/java-function":{"1.1.1":{"api":"0.2","layerDiffID":"sha256:some-diff-id"}}}`))
 			fakeImageFetcher.RemoteImages["buildpack/image"] = fakeAppImage
 
 			fakeLogger := logging.NewLogWithWriters(&out, &out)
@@ -77,6 +79,41 @@ func testRegisterBuildpack(t *testing.T, when spec.G, it spec.S) {
 				}))
 		})
 
+		it("should return error for an image which is not a buildpack package", func() {
+			notPackage := fakes.NewImage("not-a-package/image", "", &fakeIdentifier{name: "buildpack-image"})
+			fakeImageFetcher.RemoteImages["not-a-package/image"] = notPackage
+
+			h.AssertError(t, subject.RegisterBuildpack(context.TODO(),
+				RegisterBuildpackOptions{
+					ImageName: "not-a-package/image",
+					Type:      "github",
+					URL:       registry.DefaultRegistryURL,
+					Name:      registry.DefaultRegistryName,
+				}), "image 'not-a-package/image' is not a buildpack package")
+		})
+
+		it("should return error when the package does not contain the buildpack", func() {
+			h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.buildpack.layers", `{}`))
+
+			h.AssertError(t, subject.RegisterBuildpack(context.TODO(),
+				RegisterBuildpackOptions{
+					ImageName: "buildpack/image",
+					Type:      "github",
+					URL:       registry.DefaultRegistryURL,
+					Name:      registry.DefaultRegistryName,
+				}), "buildpack package 'buildpack/image' does not contain buildpack 'heroku/java-function@1.1.1'")
+		})
+
+		it("should return error when the address of the package is not a digest (github)", func() {
+			h.AssertError(t, subject.RegisterBuildpack(context.TODO(),
+				RegisterBuildpackOptions{
+					ImageName: "buildpack/image",
+					Type:      "github",
+					URL:       registry.DefaultRegistryURL,
+					Name:      registry.DefaultRegistryName,
+				}), "invalid entry: 'buildpack-image' is not a digest reference")
+		})
+
 		it("should throw error if missing URL (github)", func() {
 			h.AssertError(t, subject.RegisterBuildpack(context.TODO(),
 				RegisterBuildpackOptions{
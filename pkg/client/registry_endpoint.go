@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// RegistryEndpointResolver resolves the host of an image registry, such as internal-registry, to the address to
+// connect to, as host or host:port, so that platforms may locate registries through service discovery instead of
+// DNS. An empty address falls back to resolving the host through DNS.
+type RegistryEndpointResolver func(ctx context.Context, host string) (address string, err error)
+
+// WithRegistryEndpointResolver resolves the registries the client interacts with through resolver, for this client
+// only. TLS certificates are still verified against the host of the registry. Images read and written through
+// imgutil, and registries accessed by the Docker daemon, or by the lifecycle from its containers, are not resolved.
+func WithRegistryEndpointResolver(resolver RegistryEndpointResolver) Option {
+	return func(c *Client) {
+		c.endpointResolver = resolver
+	}
+}
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// resolvingDialer returns a dialer connecting to the addresses resolver resolves hosts to, through dial.
+func resolvingDialer(resolver RegistryEndpointResolver, dial dialFunc) dialFunc {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return dial(ctx, network, addr)
+		}
+
+		resolved, err := resolver(ctx, host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving registry %s", style.Symbol(host))
+		}
+		if resolved == "" {
+			return dial(ctx, network, addr)
+		}
+		if _, _, err := net.SplitHostPort(resolved); err != nil {
+			resolved = net.JoinHostPort(resolved, port)
+		}
+		return dial(ctx, network, resolved)
+	}
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRegistryEndpoint(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RegistryEndpoint", testRegistryEndpoint, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRegistryEndpoint(t *testing.T, when spec.G, it spec.S) {
+	when("#resolvingDialer", func() {
+		var (
+			server   *httptest.Server
+			resolved []string
+		)
+
+		it.Before(func() {
+			resolved = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.Host))
+			}))
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		get := func(resolver RegistryEndpointResolver, url string) (string, error) {
+			dial := resolvingDialer(resolver, nil)
+			httpClient := &http.Client{Transport: &http.Transport{DialContext: dial}}
+			resp, err := httpClient.Get(url)
+			if err != nil {
+				return "", err
+			}
+			defer resp.Body.Close()
+
+			var body [256]byte
+			n, _ := resp.Body.Read(body[:])
+			return string(body[:n]), nil
+		}
+
+		it("connects to the address the host resolves to", func() {
+			address := server.Listener.Addr().String()
+			body, err := get(func(ctx context.Context, host string) (string, error) {
+				resolved = append(resolved, host)
+				return address, nil
+			}, "http://internal-registry/v2/")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, resolved, []string{"internal-registry"})
+			h.AssertEq(t, body, "internal-registry")
+		})
+
+		it("keeps the port of the registry when the resolver returns a host", func() {
+			_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+			h.AssertNil(t, err)
+
+			body, err := get(func(ctx context.Context, host string) (string, error) {
+				return "127.0.0.1", nil
+			}, "http://internal-registry:"+port+"/v2/")
+			h.AssertNil(t, err)
+			h.AssertEq(t, body, "internal-registry:"+port)
+		})
+
+		it("falls back to the address of the registry when the resolver returns none", func() {
+			body, err := get(func(ctx context.Context, host string) (string, error) {
+				return "", nil
+			}, server.URL)
+			h.AssertNil(t, err)
+			h.AssertEq(t, body, server.Listener.Addr().String())
+		})
+
+		it("fails when the registry cannot be resolved", func() {
+			_, err := get(func(ctx context.Context, host string) (string, error) {
+				return "", errors.New("no such service")
+			}, "http://internal-registry/v2/")
+			h.AssertError(t, err, "resolving registry 'internal-registry': no such service")
+		})
+	})
+
+	when("#WithRegistryEndpointResolver", func() {
+		it("resolves the registries of the client", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(r.Host))
+			}))
+			defer server.Close()
+
+			subject, err := NewClient(
+				WithLogger(logging.NewSimpleLogger(ioutil.Discard)),
+				WithRegistryEndpointResolver(func(ctx context.Context, host string) (string, error) {
+					return server.Listener.Addr().String(), nil
+				}),
+			)
+			h.AssertNil(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "http://internal-registry/v2/", nil)
+			h.AssertNil(t, err)
+			resp, err := subject.registryTransport.RoundTrip(req)
+			h.AssertNil(t, err)
+			h.AssertNil(t, resp.Body.Close())
+			h.AssertEq(t, resp.StatusCode, http.StatusOK)
+		})
+	})
+}
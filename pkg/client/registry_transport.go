@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"sync"
+
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// registryTransport is the transport of the operations of a client on registries, which applies the registry settings
+// of the client, such as its insecure registries, CA certificates and endpoint resolver, to the requests of the client only, without
+// changing the default transports of the process. The insecure registries are accessed without verifying their
+// certificates, or over plain HTTP when they do not serve TLS.
+//
+// The transport is given to the operations pack makes with go-containerregistry, through remoteOptions. The version
+// of imgutil pack uses to read and write images, such as when fetching the builder or saving a published image, takes
+// no transport, so its requests reach registries through the default transport of the process, and the registry
+// settings of the client only apply to them through the registry settings imgutil provides, for insecure registries.
+type registryTransport struct {
+	secure   *http.Transport
+	insecure *http.Transport
+
+	insecureRegistries map[string]bool
+
+	// plainHTTP are the insecure registries which did not serve TLS.
+	plainHTTP sync.Map
+}
+
+func newRegistryTransport(insecureRegistries []string, resolver RegistryEndpointResolver, caCertificates []byte) *registryTransport {
+	t := &registryTransport{
+		secure:             ggcrremote.DefaultTransport.Clone(),
+		insecure:           ggcrremote.DefaultTransport.Clone(),
+		insecureRegistries: map[string]bool{},
+	}
+	if len(caCertificates) > 0 {
+		t.secure.TLSClientConfig = &tls.Config{RootCAs: certPool(caCertificates), MinVersion: tls.VersionTLS12}
+	}
+	if resolver != nil {
+		t.secure.DialContext = resolvingDialer(resolver, t.secure.DialContext)
+		t.insecure.DialContext = resolvingDialer(resolver, t.insecure.DialContext)
+	}
+	/* #nosec G402 */
+	t.insecure.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	for _, registry := range insecureRegistries {
+		t.insecureRegistries[registry] = true
+	}
+	return t
+}
+
+func (t *registryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if !t.insecureRegistries[host] {
+		return t.secure.RoundTrip(req)
+	}
+
+	if _, ok := t.plainHTTP.Load(host); ok || req.URL.Scheme != "https" {
+		return t.insecure.RoundTrip(withScheme(req, "http"))
+	}
+
+	resp, err := t.insecure.RoundTrip(req)
+	if err == nil || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+
+	// the registry may not serve TLS at all
+	httpResp, httpErr := t.insecure.RoundTrip(withScheme(req, "http"))
+	if httpErr != nil {
+		return nil, err
+	}
+	t.plainHTTP.Store(host, true)
+	return httpResp, nil
+}
+
+// withScheme returns a copy of req to the same URL with scheme.
+func withScheme(req *http.Request, scheme string) *http.Request {
+	if req.URL.Scheme == scheme {
+		return req
+	}
+
+	clone := req.Clone(req.Context())
+	clone.URL.Scheme = scheme
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			clone.Body = body
+		}
+	}
+	return clone
+}
+
+// remoteOptions returns the options of the operations of go-containerregistry on registries, applying the registry
+// settings of the client: its keychain, transport and retry policy.
+func (c *Client) remoteOptions(ctx context.Context) []ggcrremote.Option {
+	opts := []ggcrremote.Option{
+		ggcrremote.WithContext(ctx),
+		ggcrremote.WithAuthFromKeychain(c.keychain),
+		ggcrremote.WithRetryPredicate(c.retryPolicy.Retryable),
+	}
+	if c.registryTransport != nil {
+		opts = append(opts, ggcrremote.WithTransport(c.registryTransport))
+	}
+	if c.retryPolicy.Attempts > 0 {
+		opts = append(opts, ggcrremote.WithRetryBackoff(ggcrremote.Backoff{
+			Duration: c.retryPolicy.Backoff,
+			Factor:   2,
+			Steps:    c.retryPolicy.Attempts,
+			Cap:      c.retryPolicy.MaxBackoff,
+		}))
+	}
+	return opts
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRegistryTransport(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RegistryTransport", testRegistryTransport, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRegistryTransport(t *testing.T, when spec.G, it spec.S) {
+	var registry http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	get := func(transport http.RoundTripper, url string) error {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		h.AssertNil(t, err)
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	when("#RoundTrip", func() {
+		it("verifies the certificates of registries", func() {
+			server := httptest.NewTLSServer(registry)
+			defer server.Close()
+
+			err := get(newRegistryTransport(nil, nil, nil), server.URL+"/v2/")
+			h.AssertError(t, err, "certificate")
+		})
+
+		it("trusts the CA certificates of the client", func() {
+			server := httptest.NewTLSServer(registry)
+			defer server.Close()
+
+			caCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			h.AssertNil(t, get(newRegistryTransport(nil, nil, caCertificate), server.URL+"/v2/"))
+
+			// other clients do not trust them
+			err := get(newRegistryTransport(nil, nil, nil), server.URL+"/v2/")
+			h.AssertError(t, err, "certificate")
+		})
+
+		it("does not verify the certificates of insecure registries", func() {
+			server := httptest.NewTLSServer(registry)
+			defer server.Close()
+
+			subject := newRegistryTransport([]string{strings.TrimPrefix(server.URL, "https://")}, nil, nil)
+			h.AssertNil(t, get(subject, server.URL+"/v2/"))
+		})
+
+		it("accesses insecure registries which do not serve TLS over plain HTTP", func() {
+			server := httptest.NewServer(registry)
+			defer server.Close()
+
+			host := strings.TrimPrefix(server.URL, "http://")
+			subject := newRegistryTransport([]string{host}, nil, nil)
+			h.AssertNil(t, get(subject, "https://"+host+"/v2/"))
+			h.AssertNil(t, get(subject, "https://"+host+"/v2/some/repo/tags/list"))
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// RunAppOptions is a configuration struct that controls the behavior of RunApp.
+type RunAppOptions struct {
+	// Options of the build of the app. The image is built in the daemon.
+	Build BuildOptions
+
+	// Ports to publish, in the form accepted by 'docker run --publish', e.g. "8080" or "127.0.0.1:80:8080/tcp".
+	// A port alone is published on the same port of the host.
+	Ports []string
+
+	// Runtime environment variables of the app.
+	Env map[string]string
+}
+
+// RunApp builds an app, then runs the app image in the daemon, streaming its output to the logger until the app
+// exits or ctx is done. The container is removed when RunApp returns. An app exiting with a non-zero status is
+// reported as an error.
+func (c *Client) RunApp(ctx context.Context, opts RunAppOptions) error {
+	if opts.Build.Publish {
+		return errors.New("running the app is only supported when building in the daemon")
+	}
+	if _, _, err := parsePorts(opts.Ports); err != nil {
+		return err
+	}
+
+	if err := c.Build(ctx, opts.Build); err != nil {
+		return err
+	}
+	c.logger.Infof("Successfully built image %s", style.Symbol(opts.Build.Image))
+
+	containerID, err := c.startAppContainer(ctx, opts.Build.Image, appContainerOptions{Ports: opts.Ports, Env: opts.Env})
+	if err != nil {
+		return err
+	}
+	defer c.removeAppContainer(containerID)
+
+	bodyChan, errChan := c.docker.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
+	select {
+	case body := <-bodyChan:
+		if body.StatusCode != 0 {
+			return errors.Errorf("app exited with status %d", body.StatusCode)
+		}
+		return nil
+	case err := <-errChan:
+		if ctx.Err() != nil {
+			return nil
+		}
+		return errors.Wrapf(err, "waiting for container %s", style.Symbol(containerID))
+	}
+}
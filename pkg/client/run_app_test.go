@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRunApp(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RunApp", testRunApp, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRunApp(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithDockerClient(mockDockerClient))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#RunApp", func() {
+		it("does not run published images", func() {
+			err := subject.RunApp(context.TODO(), RunAppOptions{Build: BuildOptions{Image: "some/app", Publish: true}})
+			h.AssertError(t, err, "running the app is only supported when building in the daemon")
+		})
+
+		it("validates the ports before building", func() {
+			err := subject.RunApp(context.TODO(), RunAppOptions{Build: BuildOptions{Image: "some/app"}, Ports: []string{"not-a-port"}})
+			h.AssertError(t, err, "parsing ports")
+		})
+	})
+
+	when("#startAppContainer", func() {
+		it("publishes the ports and sets the env of the app", func() {
+			mockDockerClient.EXPECT().
+				ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+				DoAndReturn(func(_ context.Context, config *container.Config, hostConfig *container.HostConfig, _, _ interface{}, _ string) (container.ContainerCreateCreatedBody, error) {
+					h.AssertEq(t, config.Image, "some/app")
+					h.AssertEq(t, config.Env, []string{"GREETING=hello", "PORT=8080"})
+					h.AssertEq(t, config.ExposedPorts, nat.PortSet{"8080/tcp": struct{}{}, "9090/udp": struct{}{}})
+					h.AssertEq(t, hostConfig.PortBindings, nat.PortMap{
+						"8080/tcp": []nat.PortBinding{{HostPort: "8080"}},
+						"9090/udp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "9000"}},
+					})
+					return container.ContainerCreateCreatedBody{ID: "0123456789abcdef"}, nil
+				})
+			mockDockerClient.EXPECT().ContainerStart(gomock.Any(), "0123456789abcdef", gomock.Any()).Return(nil)
+			mockDockerClient.EXPECT().
+				ContainerLogs(gomock.Any(), "0123456789abcdef", gomock.Any()).
+				Return(ioutil.NopCloser(&bytes.Buffer{}), nil)
+
+			id, err := subject.startAppContainer(context.TODO(), "some/app", appContainerOptions{
+				Ports: []string{"8080", "127.0.0.1:9000:9090/udp"},
+				Env:   map[string]string{"PORT": "8080", "GREETING": "hello"},
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, id, "0123456789abcdef")
+			h.AssertContains(t, out.String(), "Started container '0123456789ab' running 'some/app'")
+		})
+
+		it("removes the container when it fails to start", func() {
+			mockDockerClient.EXPECT().
+				ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+				Return(container.ContainerCreateCreatedBody{ID: "0123456789abcdef"}, nil)
+			mockDockerClient.EXPECT().ContainerStart(gomock.Any(), "0123456789abcdef", gomock.Any()).Return(context.Canceled)
+			mockDockerClient.EXPECT().
+				ContainerRemove(gomock.Any(), "0123456789abcdef", types.ContainerRemoveOptions{Force: true}).
+				Return(nil)
+
+			_, err := subject.startAppContainer(context.TODO(), "some/app", appContainerOptions{})
+			h.AssertError(t, err, "starting container for image 'some/app'")
+		})
+	})
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// SearchBuildpacksOptions is a configuration struct that controls the behavior of SearchBuildpacks.
+type SearchBuildpacksOptions struct {
+	// Query the IDs of the buildpacks must contain. An empty query matches every buildpack.
+	Query string
+
+	// RegistryName of the registry to search, or empty for the default registry.
+	RegistryName string
+}
+
+// RegistryBuildpack is a buildpack of a buildpack registry.
+type RegistryBuildpack struct {
+	// ID of the buildpack, as <namespace>/<name>.
+	ID string
+
+	// Version is the latest version of the buildpack which is not yanked.
+	Version string
+
+	// Address is the digest reference of the buildpack package of the version.
+	Address string
+}
+
+// SearchBuildpacks searches the index of a buildpack registry for buildpacks, which can then be used as
+// urn:cnb:registry:<id>@<version>.
+func (c *Client) SearchBuildpacks(opts SearchBuildpacksOptions) ([]RegistryBuildpack, error) {
+	registryCache, err := getRegistry(c.logger, opts.RegistryName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid registry %s", style.Symbol(opts.RegistryName))
+	}
+
+	buildpacks, err := registryCache.Search(opts.Query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching registry %s", style.Symbol(opts.RegistryName))
+	}
+
+	var results []RegistryBuildpack
+	for _, bp := range buildpacks {
+		results = append(results, RegistryBuildpack{
+			ID:      bp.Namespace + "/" + bp.Name,
+			Version: bp.Version,
+			Address: bp.Address,
+		})
+	}
+	return results, nil
+}
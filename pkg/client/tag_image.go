@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/docker/docker/api/types"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// TagImageOptions is a configuration struct that controls the behavior of TagImage.
+type TagImageOptions struct {
+	// Name of the image to tag.
+	Image string
+
+	// Tags to add to Image.
+	Tags []string
+
+	// Flag to tag the image in its registry, rather than in the docker daemon.
+	// Registry tags must be in the repository of Image.
+	Publish bool
+}
+
+// UntagImageOptions is a configuration struct that controls the behavior of UntagImage.
+type UntagImageOptions struct {
+	// Tags to remove from the docker daemon.
+	Tags []string
+}
+
+// TagImage adds tags to an image.
+// Tags refer to the existing manifest, so the digest of the image does not change, and its CNB metadata
+// labels and any SBOMs or signatures attached to it by the referrers API keep applying to every tag.
+func (c *Client) TagImage(ctx context.Context, opts TagImageOptions) error {
+	if len(opts.Tags) == 0 {
+		return errors.New("at least one tag is required")
+	}
+
+	imageRef, err := name.ParseReference(opts.Image, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+	}
+
+	tagRefs, err := c.parseTags(opts.Tags)
+	if err != nil {
+		return err
+	}
+
+	if err := c.warnIfNotCNBImage(ctx, opts.Image, opts.Publish); err != nil {
+		return err
+	}
+
+	if !opts.Publish {
+		for _, tagRef := range tagRefs {
+			if err := c.docker.ImageTag(ctx, opts.Image, tagRef.Name()); err != nil {
+				return errors.Wrapf(err, "tagging image %s as %s", style.Symbol(opts.Image), style.Symbol(tagRef.Name()))
+			}
+			c.logger.Infof("Tagged %s as %s", style.Symbol(opts.Image), style.Symbol(tagRef.Name()))
+		}
+		return nil
+	}
+
+	for _, tagRef := range tagRefs {
+		if tagRef.Context().Name() != imageRef.Context().Name() {
+			return errors.Errorf("tag %s must be in the repository of image %s",
+				style.Symbol(tagRef.Name()), style.Symbol(imageRef.Context().Name()))
+		}
+	}
+
+	desc, err := ggcrremote.Get(imageRef, c.remoteOptions(ctx)...)
+	if err != nil {
+		return errors.Wrapf(err, "fetching manifest of image %s", style.Symbol(opts.Image))
+	}
+
+	for _, tagRef := range tagRefs {
+		if err := ggcrremote.Tag(tagRef, desc, c.remoteOptions(ctx)...); err != nil {
+			return errors.Wrapf(err, "tagging image %s as %s", style.Symbol(opts.Image), style.Symbol(tagRef.Name()))
+		}
+		c.logger.Infof("Tagged %s as %s", style.Symbol(opts.Image), style.Symbol(tagRef.Name()))
+	}
+
+	return nil
+}
+
+// UntagImage removes tags from the docker daemon. The image itself is kept as long as it has other tags.
+// Tags are not removed from registries: the registry API only deletes manifests, which would remove the image
+// from every tag referring to it.
+func (c *Client) UntagImage(ctx context.Context, opts UntagImageOptions) error {
+	if len(opts.Tags) == 0 {
+		return errors.New("at least one tag is required")
+	}
+
+	tagRefs, err := c.parseTags(opts.Tags)
+	if err != nil {
+		return err
+	}
+
+	for _, tagRef := range tagRefs {
+		// Removing a reference of an image with several tags only removes that tag
+		if _, err := c.docker.ImageRemove(ctx, tagRef.Name(), types.ImageRemoveOptions{}); err != nil {
+			return errors.Wrapf(err, "removing tag %s", style.Symbol(tagRef.Name()))
+		}
+		c.logger.Infof("Removed tag %s", style.Symbol(tagRef.Name()))
+	}
+
+	return nil
+}
+
+func (c *Client) parseTags(tags []string) ([]name.Tag, error) {
+	var tagRefs []name.Tag
+	for _, tag := range tags {
+		tagRef, err := name.NewTag(tag, name.WeakValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tag '%s'", tag)
+		}
+		tagRefs = append(tagRefs, tagRef)
+	}
+	return tagRefs, nil
+}
+
+func (c *Client) warnIfNotCNBImage(ctx context.Context, imageName string, publish bool) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		if errors.Cause(err) == image.ErrNotFound {
+			return errors.Wrapf(image.ErrNotFound, "image '%s' cannot be found", imageName)
+		}
+		return err
+	}
+
+	metadata, err := img.Label(platform.LayerMetadataLabel)
+	if err != nil {
+		return errors.Wrapf(err, "reading labels of image %s", style.Symbol(imageName))
+	}
+	if metadata == "" {
+		c.logger.Warnf("Image %s was not built by Cloud Native Buildpacks", style.Symbol(imageName))
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTagImage(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "TagImage", testTagImage, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTagImage(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fakeImageFetcher *ifakes.FakeImageFetcher
+		fakeAppImage     *fakes.Image
+		subject          *Client
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		fakeImageFetcher = ifakes.NewFakeImageFetcher()
+		fakeAppImage = fakes.NewImage("example.com/some/app", "", nil)
+		fakeImageFetcher.RemoteImages["example.com/some/app"] = fakeAppImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fakeImageFetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, fakeAppImage.Cleanup())
+	})
+
+	when("#TagImage", func() {
+		it("requires tags", func() {
+			err := subject.TagImage(context.TODO(), TagImageOptions{Image: "example.com/some/app"})
+			h.AssertError(t, err, "at least one tag is required")
+		})
+
+		it("fails for an invalid tag", func() {
+			err := subject.TagImage(context.TODO(), TagImageOptions{Image: "example.com/some/app", Tags: []string{"some/app@sha256:abc"}})
+			h.AssertError(t, err, "invalid tag 'some/app@sha256:abc'")
+		})
+
+		it("fails when the image cannot be found", func() {
+			err := subject.TagImage(context.TODO(), TagImageOptions{Image: "some/missing", Tags: []string{"some/missing:stable"}, Publish: true})
+			h.AssertError(t, err, "image 'some/missing' cannot be found")
+		})
+
+		when("publishing", func() {
+			it("requires registry tags to be in the repository of the image", func() {
+				h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.lifecycle.metadata", "{}"))
+
+				err := subject.TagImage(context.TODO(), TagImageOptions{
+					Image:   "example.com/some/app",
+					Tags:    []string{"example.com/other/app:stable"},
+					Publish: true,
+				})
+				h.AssertError(t, err, "tag 'example.com/other/app:stable' must be in the repository of image 'example.com/some/app'")
+				h.AssertNotContains(t, out.String(), "was not built by Cloud Native Buildpacks")
+			})
+
+			it("warns when the image was not built by buildpacks", func() {
+				err := subject.TagImage(context.TODO(), TagImageOptions{
+					Image:   "example.com/some/app",
+					Tags:    []string{"example.com/other/app:stable"},
+					Publish: true,
+				})
+				h.AssertNotNil(t, err)
+				h.AssertContains(t, out.String(), "Warning: Image 'example.com/some/app' was not built by Cloud Native Buildpacks")
+			})
+		})
+	})
+
+	when("#UntagImage", func() {
+		it("requires tags", func() {
+			err := subject.UntagImage(context.TODO(), UntagImageOptions{})
+			h.AssertError(t, err, "at least one tag is required")
+		})
+
+		it("fails for an invalid tag", func() {
+			err := subject.UntagImage(context.TODO(), UntagImageOptions{Tags: []string{"Some/App"}})
+			h.AssertError(t, err, "invalid tag 'Some/App'")
+		})
+	})
+}
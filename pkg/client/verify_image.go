@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/docker/docker/api/types"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+// VerifyImageOptions is a configuration struct that controls the behavior of VerifyImage.
+type VerifyImageOptions struct {
+	// Name of the app image to verify, which must have been built with BuildOptions.RecordInputs.
+	Image string
+
+	// Fetch the image from the daemon rather than from its registry.
+	Daemon bool
+
+	// Path to the source of the app the image was built from.
+	AppPath string
+
+	// Environment variables the image was built with, which are only recorded as a digest.
+	Env map[string]string
+
+	// ProjectDescriptor of the app, as for the build of the image.
+	ProjectDescriptor projectTypes.Descriptor
+
+	// Strategy for fetching the builder, buildpacks and run image of the build.
+	PullPolicy image.PullPolicy
+}
+
+// LayerVerification compares a layer of an app image to the same layer of its rebuild.
+type LayerVerification struct {
+	// Layer describes what created the layer, as in the history set by BuildOptions.LayerHistory.
+	Layer string
+
+	// Expected is the diff ID of the layer in the app image.
+	Expected string
+
+	// Actual is the diff ID of the layer in the rebuild, or an empty string when the rebuild does not have it.
+	Actual string
+}
+
+// Reproduced is whether the rebuild produced the same layer as the app image.
+func (l LayerVerification) Reproduced() bool {
+	return l.Expected == l.Actual
+}
+
+// VerifyImage rebuilds an app image on the daemon from the inputs recorded in its BuildInputsLabel, with the same
+// builder, buildpacks and run image, and compares its layers to those of the rebuild. The source of the app and the
+// environment variables given must match the recorded digests. The rebuild and its cache are removed afterwards.
+func (c *Client) VerifyImage(ctx context.Context, opts VerifyImageOptions) ([]LayerVerification, error) {
+	imageRef, err := name.ParseReference(opts.Image, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid image name '%s'", opts.Image)
+	}
+
+	_, configFile, layersMD, err := c.fetchAppImage(ctx, imageRef, !opts.Daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	label, ok := configFile.Config.Labels[BuildInputsLabel]
+	if !ok {
+		return nil, errors.Errorf("image %s does not record its build inputs: it must be built with %s", style.Symbol(opts.Image), style.Symbol("--record-inputs"))
+	}
+	var inputs BuildInputs
+	if err := json.Unmarshal([]byte(label), &inputs); err != nil {
+		return nil, errors.Wrapf(err, "reading label %s of image %s", style.Symbol(BuildInputsLabel), style.Symbol(opts.Image))
+	}
+
+	appPath, err := c.processAppPath(opts.AppPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid app path '%s'", opts.AppPath)
+	}
+	fileFilter, err := getFileFilter(opts.ProjectDescriptor, appPath)
+	if err != nil {
+		return nil, err
+	}
+	sourceDigest, err := appSourceDigest(appPath, fileFilter)
+	if err != nil {
+		return nil, errors.Wrap(err, "computing digest of app")
+	}
+	if sourceDigest != inputs.SourceDigest {
+		return nil, errors.Errorf("source of the app at %s has digest %s, but image %s was built from source with digest %s",
+			style.Symbol(appPath), style.Symbol(sourceDigest), style.Symbol(opts.Image), style.Symbol(inputs.SourceDigest))
+	}
+	if envDigest(opts.Env) != inputs.EnvDigest {
+		return nil, errors.Errorf("the environment variables given differ from those image %s was built with", style.Symbol(opts.Image))
+	}
+
+	builder, err := c.imageFetcher.Fetch(ctx, inputs.Builder, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching builder %s", style.Symbol(inputs.Builder))
+	}
+	builderID, err := builder.Identifier()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading identifier of builder %s", style.Symbol(inputs.Builder))
+	}
+	if builderID.String() != inputs.BuilderID {
+		return nil, errors.Errorf("builder %s is image %s, but image %s was built with builder image %s",
+			style.Symbol(inputs.Builder), style.Symbol(builderID.String()), style.Symbol(opts.Image), style.Symbol(inputs.BuilderID))
+	}
+
+	rebuildRef, err := rebuildReference()
+	if err != nil {
+		return nil, err
+	}
+	defer c.removeRebuild(ctx, rebuildRef)
+
+	c.logger.Infof("Rebuilding image %s as %s", style.Symbol(opts.Image), style.Symbol(rebuildRef.Name()))
+	if err := c.Build(ctx, BuildOptions{
+		Image:             rebuildRef.Name(),
+		Builder:           inputs.Builder,
+		AppPath:           opts.AppPath,
+		RunImage:          layersMD.RunImage.Reference,
+		Buildpacks:        inputs.Buildpacks,
+		Env:               opts.Env,
+		ProjectDescriptor: opts.ProjectDescriptor,
+		PullPolicy:        opts.PullPolicy,
+		ClearCache:        true,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "rebuilding image %s", style.Symbol(opts.Image))
+	}
+
+	_, rebuiltConfig, rebuiltMD, err := c.fetchAppImage(ctx, rebuildRef, false)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := describedLayers(configFile, layersMD)
+	actual := map[string]string{}
+	for _, layer := range describedLayers(rebuiltConfig, rebuiltMD) {
+		actual[layer.Description] = layer.DiffID
+	}
+
+	var layers []LayerVerification
+	for _, layer := range expected {
+		layers = append(layers, LayerVerification{
+			Layer:    layer.Description,
+			Expected: layer.DiffID,
+			Actual:   actual[layer.Description],
+		})
+	}
+	return layers, nil
+}
+
+type describedLayer struct {
+	Description string
+	DiffID      string
+}
+
+// describedLayers returns the layers of an app image with a description of each, as in its layer history, which is
+// numbered when several layers share it, such as the layers of the run image.
+func describedLayers(configFile *v1.ConfigFile, layersMD platform.LayersMetadata) []describedLayer {
+	history := layerHistory(configFile, layersMD)
+	seen := map[string]int{}
+
+	layers := make([]describedLayer, len(history))
+	for i, entry := range history {
+		description := entry.CreatedBy
+		seen[description]++
+		if n := seen[description]; n > 1 {
+			description = fmt.Sprintf("%s (%d)", description, n)
+		}
+		layers[i] = describedLayer{Description: description, DiffID: configFile.RootFS.DiffIDs[i].String()}
+	}
+	return layers
+}
+
+// rebuildReference returns a unique name for the rebuild of an image on the daemon.
+func rebuildReference() (name.Reference, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, errors.Wrap(err, "generating name of rebuild")
+	}
+	return name.ParseReference(fmt.Sprintf("pack.local/verify/%s:latest", hex.EncodeToString(suffix)), name.WeakValidation)
+}
+
+// removeRebuild removes the rebuild of an image and its cache volumes, reporting failures as warnings.
+func (c *Client) removeRebuild(ctx context.Context, rebuildRef name.Reference) {
+	if _, err := c.docker.ImageRemove(ctx, rebuildRef.Name(), types.ImageRemoveOptions{Force: true, PruneChildren: true}); err != nil {
+		c.logger.Warnf("Unable to remove rebuild %s: %s", style.Symbol(rebuildRef.Name()), err)
+	}
+	if _, err := c.ClearCacheVolumes(ctx, ClearCacheOptions{ImageName: rebuildRef.Name()}); err != nil {
+		c.logger.Warnf("Unable to remove cache volumes of rebuild %s: %s", style.Symbol(rebuildRef.Name()), err)
+	}
+}
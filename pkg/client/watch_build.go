@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/snapshot"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// WatchBuildOptions is a configuration struct that controls the behavior of WatchBuild.
+type WatchBuildOptions struct {
+	// Options of each build. The app must be a directory.
+	Build BuildOptions
+
+	// How often the app directory is checked for changes.
+	Interval time.Duration
+
+	// Flag to run the app image after each successful build, replacing the container of the previous build.
+	Run bool
+
+	// Ports to publish when running the app, in the form accepted by 'docker run --publish', e.g. "8080" or
+	// "127.0.0.1:80:8080/tcp". A port alone is published on the same port of the host. When empty, the ports
+	// exposed by the app image are published on the same ports of the host.
+	Ports []string
+}
+
+// WatchBuild builds an app, then rebuilds it whenever the files of the app directory change, until ctx is done.
+// Each build uses the same options, so caches and the builder are reused. Failed builds are logged, and do not
+// stop the watch. Files excluded from the build, by the project descriptor or the ignore file, are not watched.
+func (c *Client) WatchBuild(ctx context.Context, opts WatchBuildOptions) error {
+	if opts.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	if opts.Run && opts.Build.Publish {
+		return errors.New("running the app is only supported when building in the daemon")
+	}
+	if _, _, err := parsePorts(opts.Ports); err != nil {
+		return err
+	}
+
+	appPath, err := c.processAppPath(opts.Build.AppPath)
+	if err != nil {
+		return errors.Wrapf(err, "invalid app path '%s'", opts.Build.AppPath)
+	}
+	if fi, err := os.Stat(appPath); err != nil || !fi.IsDir() {
+		return errors.Errorf("watching requires the app to be a directory, but %s is not", style.Symbol(appPath))
+	}
+
+	fileFilter, err := getFileFilter(opts.Build.ProjectDescriptor, appPath)
+	if err != nil {
+		return err
+	}
+
+	var containerID string
+	defer func() {
+		if containerID != "" {
+			c.removeAppContainer(containerID)
+		}
+	}()
+
+	current, err := snapshot.Take(appPath, fileFilter)
+	if err != nil {
+		return errors.Wrapf(err, "reading app directory %s", style.Symbol(appPath))
+	}
+
+	for {
+		err := c.Build(ctx, opts.Build)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			c.logger.Errorf("Build failed: %s", err)
+		} else {
+			c.logger.Infof("Successfully built image %s", style.Symbol(opts.Build.Image))
+			if opts.Run {
+				if containerID != "" {
+					c.removeAppContainer(containerID)
+					containerID = ""
+				}
+				if containerID, err = c.runWatchedApp(ctx, opts); err != nil {
+					c.logger.Errorf("Unable to run the app: %s", err)
+				}
+			}
+		}
+
+		c.logger.Infof("Watching %s for changes", style.Symbol(appPath))
+		var changed []string
+		for len(changed) == 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(opts.Interval):
+			}
+
+			next, err := snapshot.Take(appPath, fileFilter)
+			if err != nil {
+				return errors.Wrapf(err, "reading app directory %s", style.Symbol(appPath))
+			}
+			changed = current.Changed(next)
+			current = next
+		}
+
+		c.logger.Infof("Rebuilding, as %s changed", style.Symbol(summarizePaths(changed)))
+	}
+}
+
+// runWatchedApp runs the app image built by WatchBuild, publishing the ports of the options or, by default, the ports
+// exposed by the image.
+func (c *Client) runWatchedApp(ctx context.Context, opts WatchBuildOptions) (string, error) {
+	ports := opts.Ports
+	if len(ports) == 0 {
+		var err error
+		if ports, err = c.exposedPorts(ctx, opts.Build.Image); err != nil {
+			return "", err
+		}
+	}
+	return c.startAppContainer(ctx, opts.Build.Image, appContainerOptions{Ports: ports})
+}
+
+// summarizePaths joins the first few paths, for logging.
+func summarizePaths(paths []string) string {
+	const max = 3
+	if len(paths) <= max {
+		return strings.Join(paths, ", ")
+	}
+	return strings.Join(paths[:max], ", ") + ", ..."
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestWatchBuild(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "WatchBuild", testWatchBuild, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testWatchBuild(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *Client
+		out     bytes.Buffer
+		appDir  string
+	)
+
+	it.Before(func() {
+		var err error
+		appDir, err = ioutil.TempDir("", "watch-build-test")
+		h.AssertNil(t, err)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: ifakes.NewFakeImageFetcher(),
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, os.RemoveAll(appDir))
+	})
+
+	when("#WatchBuild", func() {
+		it("requires a positive interval", func() {
+			err := subject.WatchBuild(context.TODO(), WatchBuildOptions{Build: BuildOptions{AppPath: appDir}})
+			h.AssertError(t, err, "interval must be greater than zero")
+		})
+
+		it("does not run published images", func() {
+			err := subject.WatchBuild(context.TODO(), WatchBuildOptions{
+				Build:    BuildOptions{AppPath: appDir, Publish: true},
+				Interval: time.Second,
+				Run:      true,
+			})
+			h.AssertError(t, err, "running the app is only supported when building in the daemon")
+		})
+
+		it("rejects invalid ports", func() {
+			err := subject.WatchBuild(context.TODO(), WatchBuildOptions{
+				Build:    BuildOptions{AppPath: appDir},
+				Interval: time.Second,
+				Run:      true,
+				Ports:    []string{"not-a-port"},
+			})
+			h.AssertError(t, err, "parsing ports")
+		})
+
+		it("requires the app to be a directory", func() {
+			appFile := filepath.Join(appDir, "app.tar")
+			h.AssertNil(t, archive.CreateSingleFileTar(appFile, "some-file", "some-content"))
+
+			err := subject.WatchBuild(context.TODO(), WatchBuildOptions{Build: BuildOptions{AppPath: appFile}, Interval: time.Second})
+			h.AssertError(t, err, "watching requires the app to be a directory")
+		})
+
+		it("stops when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := subject.WatchBuild(ctx, WatchBuildOptions{
+				Build:    BuildOptions{AppPath: appDir, Image: "some/app", Builder: "some/builder"},
+				Interval: time.Second,
+			})
+			h.AssertNil(t, err)
+		})
+	})
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// WatchRunImageOptions is a configuration struct that controls the behavior of WatchRunImage.
+type WatchRunImageOptions struct {
+	// Tag of the run image to watch for updates.
+	RunImage string
+
+	// How often the registry is checked for a new digest of RunImage.
+	Interval time.Duration
+
+	// Names of app images to rebase onto RunImage when it is updated.
+	Images []string
+
+	// Flag to publish rebased images to their registry, rather than rebasing them in the daemon.
+	Publish bool
+
+	// Strategy for pulling images during rebase.
+	PullPolicy image.PullPolicy
+
+	// A mapping from StackID to an array of mirrors, handed to Rebase.
+	AdditionalMirrors map[string][]string
+
+	// URL notified, by a POST request with a RunImageUpdate body, when RunImage is updated.
+	WebhookURL string
+}
+
+// RunImageUpdate describes a change of the digest a run image tag points to.
+type RunImageUpdate struct {
+	RunImage       string `json:"run_image"`
+	PreviousDigest string `json:"previous_digest"`
+	Digest         string `json:"digest"`
+}
+
+// webhookTimeout bounds the notification of a webhook, so that an unresponsive webhook does not stall the watch.
+const webhookTimeout = 30 * time.Second
+
+// WatchRunImage polls the registry for updates of a run image tag until ctx is done.
+// Whenever the tag points to a new digest, the configured webhook is notified and the configured
+// app images are rebased onto the updated run image, by digest. Failures of these actions are logged, but do not
+// stop the watch: the rebase of the images is attempted again at the next check until it succeeds.
+func (c *Client) WatchRunImage(ctx context.Context, opts WatchRunImageOptions) error {
+	if opts.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+
+	runImageRef, err := name.ParseReference(opts.RunImage, name.WeakValidation)
+	if err != nil {
+		return errors.Wrapf(err, "invalid run image name '%s'", opts.RunImage)
+	}
+
+	digest, err := c.runImageDigest(ctx, opts.RunImage)
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("Watching run image %s at %s", style.Symbol(opts.RunImage), style.Symbol(digest))
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	// notified is the digest the webhook was last notified of, which may not be rebased onto yet
+	notified := digest
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := c.runImageDigest(ctx, opts.RunImage)
+		if err != nil {
+			c.logger.Warnf("Unable to check run image for updates: %s", err)
+			continue
+		}
+		if current == digest {
+			continue
+		}
+
+		if current != notified {
+			c.logger.Infof("Run image %s updated to %s", style.Symbol(opts.RunImage), style.Symbol(current))
+			c.notifyRunImageUpdate(ctx, opts.WebhookURL, RunImageUpdate{RunImage: opts.RunImage, PreviousDigest: digest, Digest: current})
+			notified = current
+		}
+
+		if c.rebaseOntoRunImage(ctx, opts, runImageRef.Context().Digest(current).Name()) {
+			digest = current
+		}
+	}
+}
+
+func (c *Client) notifyRunImageUpdate(ctx context.Context, webhookURL string, update RunImageUpdate) {
+	if webhookURL == "" {
+		return
+	}
+
+	if err := notifyRunImageWebhook(ctx, webhookURL, update); err != nil {
+		c.logger.Errorf("Notifying webhook %s: %s", style.Symbol(webhookURL), err)
+	} else {
+		c.logger.Debugf("Notified webhook %s", style.Symbol(webhookURL))
+	}
+}
+
+// rebaseOntoRunImage rebases the images onto runImage, a digest reference of the updated run image, so that they
+// are rebased onto the digest which was detected rather than whatever the tag points to when pulled. It returns
+// whether every image was rebased.
+func (c *Client) rebaseOntoRunImage(ctx context.Context, opts WatchRunImageOptions, runImage string) bool {
+	rebased := true
+	for _, imageName := range opts.Images {
+		err := c.Rebase(ctx, RebaseOptions{
+			RepoName:          imageName,
+			Publish:           opts.Publish,
+			PullPolicy:        opts.PullPolicy,
+			RunImage:          runImage,
+			AdditionalMirrors: opts.AdditionalMirrors,
+		})
+		if err != nil {
+			c.logger.Errorf("Rebasing image %s: %s", style.Symbol(imageName), err)
+			rebased = false
+			continue
+		}
+		c.logger.Infof("Successfully rebased image %s", style.Symbol(imageName))
+	}
+	return rebased
+}
+
+func (c *Client) runImageDigest(ctx context.Context, runImage string) (string, error) {
+	img, err := c.imageFetcher.Fetch(ctx, runImage, image.FetchOptions{Daemon: false})
+	if err != nil {
+		return "", errors.Wrapf(err, "fetching run image %s", style.Symbol(runImage))
+	}
+
+	id, err := img.Identifier()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading digest of run image %s", style.Symbol(runImage))
+	}
+
+	return identifierDigest(id), nil
+}
+
+func identifierDigest(id imgutil.Identifier) string {
+	if v, ok := id.(remote.DigestIdentifier); ok {
+		return v.Digest.DigestStr()
+	}
+	return id.String()
+}
+
+func notifyRunImageWebhook(ctx context.Context, url string, update RunImageUpdate) error {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return errors.Wrap(err, "encoding update")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Timeout: webhookTimeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
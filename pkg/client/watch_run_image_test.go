@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestWatchRunImage(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "WatchRunImage", testWatchRunImage, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testWatchRunImage(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject      *Client
+		imageFetcher *sequenceImageFetcher
+		out          bytes.Buffer
+	)
+
+	it.Before(func() {
+		imageFetcher = &sequenceImageFetcher{}
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: imageFetcher,
+		}
+	})
+
+	when("#WatchRunImage", func() {
+		it("notifies the webhook when the run image digest changes", func() {
+			imageFetcher.images = []imgutil.Image{
+				fakes.NewImage("some/run", "", &fakeIdentifier{name: "sha256:first"}),
+				fakes.NewImage("some/run", "", &fakeIdentifier{name: "sha256:first"}),
+				fakes.NewImage("some/run", "", &fakeIdentifier{name: "sha256:second"}),
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			updates := make(chan RunImageUpdate, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var update RunImageUpdate
+				h.AssertNil(t, json.NewDecoder(r.Body).Decode(&update))
+				updates <- update
+				cancel()
+			}))
+			defer server.Close()
+
+			err := subject.WatchRunImage(ctx, WatchRunImageOptions{
+				RunImage:   "some/run",
+				Interval:   time.Millisecond,
+				WebhookURL: server.URL,
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, <-updates, RunImageUpdate{
+				RunImage:       "some/run",
+				PreviousDigest: "sha256:first",
+				Digest:         "sha256:second",
+			})
+			h.AssertContains(t, out.String(), "Run image 'some/run' updated to 'sha256:second'")
+		})
+
+		it("keeps watching when the registry cannot be reached", func() {
+			imageFetcher.images = []imgutil.Image{
+				fakes.NewImage("some/run", "", &fakeIdentifier{name: "sha256:first"}),
+				nil,
+				fakes.NewImage("some/run", "", &fakeIdentifier{name: "sha256:second"}),
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cancel()
+			}))
+			defer server.Close()
+
+			h.AssertNil(t, subject.WatchRunImage(ctx, WatchRunImageOptions{
+				RunImage:   "some/run",
+				Interval:   time.Millisecond,
+				WebhookURL: server.URL,
+			}))
+			h.AssertContains(t, out.String(), "Unable to check run image for updates")
+			h.AssertContains(t, out.String(), "updated to 'sha256:second'")
+		})
+
+		it("errors when the run image cannot be fetched initially", func() {
+			err := subject.WatchRunImage(context.Background(), WatchRunImageOptions{
+				RunImage: "some/run",
+				Interval: time.Millisecond,
+			})
+			h.AssertError(t, err, "fetching run image 'some/run'")
+		})
+
+		it("errors when the interval is not positive", func() {
+			err := subject.WatchRunImage(context.Background(), WatchRunImageOptions{RunImage: "some/run"})
+			h.AssertError(t, err, "interval must be greater than zero")
+		})
+	})
+}
+
+// sequenceImageFetcher returns its images in order, one per call, repeating the last one once exhausted.
+// A nil image is returned as a fetch error.
+type sequenceImageFetcher struct {
+	mu     sync.Mutex
+	images []imgutil.Image
+	calls  int
+}
+
+func (f *sequenceImageFetcher) Fetch(_ context.Context, name string, _ image.FetchOptions) (imgutil.Image, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.images) == 0 {
+		return nil, errors.Wrapf(image.ErrNotFound, "image '%s' does not exist in registry", name)
+	}
+
+	i := f.calls
+	if i >= len(f.images) {
+		i = len(f.images) - 1
+	}
+	f.calls++
+
+	if f.images[i] == nil {
+		return nil, errors.New("registry unavailable")
+	}
+	return f.images[i], nil
+}
@@ -14,6 +14,7 @@ type YankBuildpackOptions struct {
 	Version string
 	Type    string
 	URL     string
+	Name    string
 	Yank    bool
 }
 
@@ -24,10 +25,6 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 	if err != nil {
 		return err
 	}
-	issueURL, err := registry.GetIssueURL(opts.URL)
-	if err != nil {
-		return err
-	}
 
 	buildpack := registry.Buildpack{
 		Namespace: namespace,
@@ -36,6 +33,25 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 		Yanked:    opts.Yank,
 	}
 
+	if opts.Type == "git" {
+		registryCache, err := getRegistry(c.logger, opts.Name)
+		if err != nil {
+			return err
+		}
+
+		username, err := parseUsernameFromURL(opts.URL)
+		if err != nil {
+			return err
+		}
+
+		return registry.GitCommit(buildpack, username, registryCache)
+	}
+
+	issueURL, err := registry.GetIssueURL(opts.URL)
+	if err != nil {
+		return err
+	}
+
 	issue, err := registry.CreateGithubIssue(buildpack)
 	if err != nil {
 		return err
@@ -28,7 +28,8 @@ func (c *ImageOrURI) DisplayString() string {
 }
 
 type Platform struct {
-	OS string `toml:"os"`
+	OS   string `toml:"os"`
+	Arch string `toml:"arch,omitempty"`
 }
 
 type Order []OrderEntry
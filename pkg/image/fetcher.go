@@ -34,6 +34,20 @@ func WithRegistryMirrors(registryMirrors map[string]string) FetcherOption {
 	}
 }
 
+// WithInsecureRegistries supply registries to access over plain HTTP, or without verifying their certificates.
+func WithInsecureRegistries(insecureRegistries []string) FetcherOption {
+	return func(c *Fetcher) {
+		c.insecureRegistries = insecureRegistries
+	}
+}
+
+// WithRetryPolicy retries fetching remote images, and pulling images into the daemon, when they fail transiently.
+func WithRetryPolicy(policy RetryPolicy) FetcherOption {
+	return func(c *Fetcher) {
+		c.retryPolicy = policy
+	}
+}
+
 func WithKeychain(keychain authn.Keychain) FetcherOption {
 	return func(c *Fetcher) {
 		c.keychain = keychain
@@ -41,16 +55,22 @@ func WithKeychain(keychain authn.Keychain) FetcherOption {
 }
 
 type Fetcher struct {
-	docker          client.CommonAPIClient
-	logger          logging.Logger
-	registryMirrors map[string]string
-	keychain        authn.Keychain
+	docker             client.CommonAPIClient
+	logger             logging.Logger
+	registryMirrors    map[string]string
+	insecureRegistries []string
+	keychain           authn.Keychain
+	retryPolicy        RetryPolicy
 }
 
 type FetchOptions struct {
 	Daemon     bool
 	Platform   string
 	PullPolicy PullPolicy
+
+	// InsecureRegistries are accessed over plain HTTP, in addition to the insecure registries of the fetcher.
+	// Images pulled by the daemon are accessed as configured in the daemon.
+	InsecureRegistries []string
 }
 
 func NewFetcher(logger logging.Logger, docker client.CommonAPIClient, opts ...FetcherOption) *Fetcher {
@@ -76,7 +96,12 @@ func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions)
 	}
 
 	if !options.Daemon {
-		return f.fetchRemoteImage(name)
+		var img imgutil.Image
+		err := f.retryPolicy.Do(ctx, f.logger, "Fetching image "+style.Symbol(name), func() (err error) {
+			img, err = f.fetchRemoteImage(name, options.InsecureRegistries)
+			return err
+		})
+		return img, rateLimitError(ctx, f.keychain, name, err)
 	}
 
 	switch options.PullPolicy {
@@ -91,9 +116,11 @@ func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions)
 	}
 
 	f.logger.Debugf("Pulling image %s", style.Symbol(name))
-	err = f.pullImage(ctx, name, options.Platform)
+	err = f.retryPolicy.Do(ctx, f.logger, "Pulling image "+style.Symbol(name), func() error {
+		return f.pullImage(ctx, name, options.Platform)
+	})
 	if err != nil && !errors.Is(err, ErrNotFound) {
-		return nil, err
+		return nil, rateLimitError(ctx, f.keychain, name, err)
 	}
 
 	return f.fetchDaemonImage(name)
@@ -112,8 +139,12 @@ func (f *Fetcher) fetchDaemonImage(name string) (imgutil.Image, error) {
 	return image, nil
 }
 
-func (f *Fetcher) fetchRemoteImage(name string) (imgutil.Image, error) {
-	image, err := remote.NewImage(name, f.keychain, remote.FromBaseImage(name))
+func (f *Fetcher) fetchRemoteImage(name string, insecureRegistries []string) (imgutil.Image, error) {
+	imageOpts := []remote.ImageOption{remote.FromBaseImage(name)}
+	imageOpts = append(imageOpts, RegistrySettings(f.insecureRegistries)...)
+	imageOpts = append(imageOpts, RegistrySettings(insecureRegistries)...)
+
+	image, err := remote.NewImage(name, f.keychain, imageOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +156,15 @@ func (f *Fetcher) fetchRemoteImage(name string) (imgutil.Image, error) {
 	return image, nil
 }
 
+// RegistrySettings returns the options of remote images accessing insecureRegistries over plain HTTP.
+func RegistrySettings(insecureRegistries []string) []remote.ImageOption {
+	var opts []remote.ImageOption
+	for _, registry := range insecureRegistries {
+		opts = append(opts, remote.WithRegistrySetting(registry, true, false))
+	}
+	return opts
+}
+
 func (f *Fetcher) pullImage(ctx context.Context, imageID string, platform string) error {
 	regAuth, err := f.registryAuth(imageID)
 	if err != nil {
@@ -0,0 +1,131 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// dockerHubQuotaRepository is the repository Docker Hub reports the pull quota of users for, without consuming it.
+const dockerHubQuotaRepository = "ratelimitpreview/test"
+
+// RateLimitError is returned when a registry, such as Docker Hub, refused to serve an image because the user reached
+// the rate limit of the registry.
+type RateLimitError struct {
+	Image    string
+	Registry string
+
+	// Limit and Remaining are the pull quota reported by Docker Hub, such as "100;w=21600" for 100 pulls per 6 hours.
+	// They are empty when unknown.
+	Limit     string
+	Remaining string
+
+	Err error
+}
+
+func (e *RateLimitError) Error() string {
+	quota := ""
+	if e.Limit != "" {
+		quota = fmt.Sprintf(" (remaining %s of %s)", e.Remaining, e.Limit)
+	}
+	return fmt.Sprintf("fetching image %s exceeded the rate limit of registry %s%s: authenticate to the registry "+
+		"to raise the limit, configure a registry mirror, or retry later", style.Symbol(e.Image), style.Symbol(e.Registry), quota)
+}
+
+// Unwrap supports errors.Is and errors.As.
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// IsRateLimited returns whether err is a refusal of a registry to serve a request because of its rate limit, either
+// from the registry or relayed by the daemon.
+func IsRateLimited(err error) bool {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return true
+	}
+
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		if transportErr.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+		for _, diagnostic := range transportErr.Errors {
+			if diagnostic.Code == transport.TooManyRequestsErrorCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the type of the errors of the daemon, and of some errors of imgutil, is lost
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "toomanyrequests") || strings.Contains(message, "429 too many requests")
+}
+
+// rateLimitError returns a RateLimitError for err when it is a rate limit of the registry of imageName, and err
+// otherwise. The remaining quota is looked up for Docker Hub.
+func rateLimitError(ctx context.Context, keychain authn.Keychain, imageName string, err error) error {
+	if err == nil || !IsRateLimited(err) {
+		return err
+	}
+
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return err
+	}
+
+	ref, parseErr := name.ParseReference(imageName, name.WeakValidation)
+	if parseErr != nil {
+		return err
+	}
+
+	rateLimitErr = &RateLimitError{Image: imageName, Registry: ref.Context().RegistryStr(), Err: err}
+	if rateLimitErr.Registry == name.DefaultRegistry {
+		rateLimitErr.Limit, rateLimitErr.Remaining = dockerHubQuota(ctx, keychain)
+	}
+	return rateLimitErr
+}
+
+// dockerHubQuota returns the pull quota of the user of keychain on Docker Hub, or empty strings when it cannot be
+// looked up. Looking up the quota does not consume it.
+func dockerHubQuota(ctx context.Context, keychain authn.Keychain) (limit, remaining string) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	repo, err := name.NewRepository(dockerHubQuotaRepository)
+	if err != nil {
+		return "", ""
+	}
+	auth, err := keychain.Resolve(repo.Registry)
+	if err != nil {
+		return "", ""
+	}
+	rt, err := transport.NewWithContext(ctx, repo.Registry, auth, remote.DefaultTransport, []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return "", ""
+	}
+
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/latest", repo.RegistryStr(), repo.RepositoryStr())
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", ""
+	}
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return "", ""
+	}
+	defer resp.Body.Close()
+
+	return resp.Header.Get("ratelimit-limit"), resp.Header.Get("ratelimit-remaining")
+}
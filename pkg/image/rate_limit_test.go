@@ -0,0 +1,97 @@
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRateLimit(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RateLimit", testRateLimit, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRateLimit(t *testing.T, when spec.G, it spec.S) {
+	when("#IsRateLimited", func() {
+		it("recognizes rate limits of registries", func() {
+			h.AssertTrue(t, image.IsRateLimited(&transport.Error{StatusCode: http.StatusTooManyRequests}))
+			h.AssertTrue(t, image.IsRateLimited(errors.Wrap(&transport.Error{
+				StatusCode: http.StatusForbidden,
+				Errors:     []transport.Diagnostic{{Code: transport.TooManyRequestsErrorCode}},
+			}, "fetching manifest")))
+		})
+
+		it("recognizes rate limits relayed by the daemon", func() {
+			h.AssertTrue(t, image.IsRateLimited(errors.New("toomanyrequests: You have reached your pull rate limit.")))
+		})
+
+		it("ignores other failures", func() {
+			h.AssertFalse(t, image.IsRateLimited(&transport.Error{StatusCode: http.StatusServiceUnavailable}))
+		})
+	})
+
+	when("RateLimitError", func() {
+		it("reports the remaining quota", func() {
+			err := &image.RateLimitError{Image: "some/image", Registry: "index.docker.io", Limit: "100;w=21600", Remaining: "0;w=21600"}
+			h.AssertContains(t, err.Error(), "fetching image 'some/image' exceeded the rate limit of registry 'index.docker.io' (remaining 0;w=21600 of 100;w=21600)")
+		})
+	})
+
+	when("#Fetch", func() {
+		var (
+			server   *httptest.Server
+			registry string
+			out      bytes.Buffer
+		)
+
+		it.Before(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v2/" {
+					return
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			registry = strings.TrimPrefix(server.URL, "http://")
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("returns a RateLimitError when the registry rate limits the image", func() {
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&out, &out), nil, image.WithInsecureRegistries([]string{registry}))
+
+			_, err := fetcher.Fetch(context.TODO(), registry+"/some/image", image.FetchOptions{})
+
+			var rateLimitErr *image.RateLimitError
+			h.AssertTrue(t, errors.As(err, &rateLimitErr))
+			h.AssertEq(t, rateLimitErr.Registry, registry)
+		})
+
+		it("waits and retries when allowed by the retry policy", func() {
+			fetcher := image.NewFetcher(logging.NewLogWithWriters(&out, &out), nil,
+				image.WithInsecureRegistries([]string{registry}),
+				image.WithRetryPolicy(image.RetryPolicy{Attempts: 2, RateLimitBackoff: time.Millisecond}),
+			)
+
+			_, err := fetcher.Fetch(context.TODO(), registry+"/some/image", image.FetchOptions{})
+			h.AssertNotNil(t, err)
+			h.AssertContains(t, out.String(), "retrying in 1ms (attempt 2 of 2)")
+		})
+	})
+}
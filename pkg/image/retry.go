@@ -0,0 +1,120 @@
+package image
+
+import (
+	"context"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// RetryPolicy retries registry operations failing transiently, such as pushes of large images interrupted by
+// connection resets or 5xx responses. The zero value does not retry.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times an operation is attempted, including the first one.
+	Attempts int
+
+	// Backoff is how long to wait before the second attempt. The wait doubles before each further attempt.
+	Backoff time.Duration
+
+	// MaxBackoff bounds the wait between attempts. If zero, the wait is not bounded.
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes are the HTTP status codes of registry responses failing transiently.
+	RetryableStatusCodes []int
+
+	// RateLimitBackoff is how long to wait before retrying an operation refused by the rate limit of a registry, such
+	// as Docker Hub. If zero, such operations are not retried.
+	RateLimitBackoff time.Duration
+}
+
+// DefaultRetryPolicy attempts operations 3 times, waiting 1s and then 2s, and retries the status codes of gateway
+// and server errors.
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:             3,
+	Backoff:              time.Second,
+	MaxBackoff:           30 * time.Second,
+	RetryableStatusCodes: []int{500, 502, 503, 504},
+}
+
+// statusCodePattern matches the status codes of registry responses in the messages of errors which lost their type,
+// such as those of the daemon.
+var statusCodePattern = regexp.MustCompile(`(?:status code|HTTP status:) ([0-9]{3})`)
+
+// Do runs op until it succeeds, fails with an error which is not transient, or all attempts are made. The error of the
+// last attempt is returned.
+func (p RetryPolicy) Do(ctx context.Context, logger logging.Logger, description string, op func() error) error {
+	return p.DoWhen(ctx, logger, description, p.Retryable, op)
+}
+
+// DoWhen runs op like Do, retrying the errors for which retryable returns true.
+func (p RetryPolicy) DoWhen(ctx context.Context, logger logging.Logger, description string, retryable func(error) bool, op func() error) error {
+	backoff := p.Backoff
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= p.Attempts || !retryable(err) {
+			return err
+		}
+
+		wait := backoff
+		if p.RateLimitBackoff > 0 && IsRateLimited(err) {
+			wait = p.RateLimitBackoff
+		}
+
+		logger.Warnf("%s failed, retrying in %s (attempt %d of %d): %s", description, wait, attempt+1, p.Attempts, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// Retryable returns whether err is a transient failure: a response with a retryable status code, a reset or timed out
+// connection, a connection closed in the middle of a response, or a rate limit when RateLimitBackoff is set.
+func (p RetryPolicy) Retryable(err error) bool {
+	if IsRateLimited(err) {
+		return p.RateLimitBackoff > 0
+	}
+
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		return p.retryableStatusCode(transportErr.StatusCode)
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if match := statusCodePattern.FindStringSubmatch(err.Error()); match != nil {
+		code, _ := strconv.Atoi(match[1])
+		return p.retryableStatusCode(code)
+	}
+	return false
+}
+
+func (p RetryPolicy) retryableStatusCode(code int) bool {
+	for _, retryable := range p.RetryableStatusCodes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "RetryPolicy", testRetryPolicy, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRetryPolicy(t *testing.T, when spec.G, it spec.S) {
+	var (
+		policy image.RetryPolicy
+		logger logging.Logger
+		out    bytes.Buffer
+	)
+
+	it.Before(func() {
+		policy = image.RetryPolicy{Attempts: 3, Backoff: time.Millisecond, RetryableStatusCodes: []int{502, 503}}
+		logger = logging.NewLogWithWriters(&out, &out)
+	})
+
+	when("#Do", func() {
+		it("retries transient failures until the operation succeeds", func() {
+			attempts := 0
+			err := policy.Do(context.TODO(), logger, "Pushing image", func() error {
+				attempts++
+				if attempts < 3 {
+					return &transport.Error{StatusCode: http.StatusServiceUnavailable}
+				}
+				return nil
+			})
+
+			h.AssertNil(t, err)
+			h.AssertEq(t, attempts, 3)
+			h.AssertContains(t, out.String(), "Pushing image failed, retrying in 1ms (attempt 2 of 3)")
+			h.AssertContains(t, out.String(), "Pushing image failed, retrying in 2ms (attempt 3 of 3)")
+		})
+
+		it("returns the error of the last attempt", func() {
+			attempts := 0
+			err := policy.Do(context.TODO(), logger, "Pushing image", func() error {
+				attempts++
+				return errors.Wrap(io.ErrUnexpectedEOF, "writing layer")
+			})
+
+			h.AssertError(t, err, "writing layer: unexpected EOF")
+			h.AssertEq(t, attempts, 3)
+		})
+
+		it("does not retry other failures", func() {
+			attempts := 0
+			err := policy.Do(context.TODO(), logger, "Pushing image", func() error {
+				attempts++
+				return &transport.Error{StatusCode: http.StatusUnauthorized}
+			})
+
+			h.AssertNotNil(t, err)
+			h.AssertEq(t, attempts, 1)
+		})
+
+		it("does not retry with the zero policy", func() {
+			attempts := 0
+			err := image.RetryPolicy{}.Do(context.TODO(), logger, "Pushing image", func() error {
+				attempts++
+				return io.ErrUnexpectedEOF
+			})
+
+			h.AssertNotNil(t, err)
+			h.AssertEq(t, attempts, 1)
+		})
+	})
+
+	when("#Retryable", func() {
+		it("recognizes the status codes of errors of the daemon", func() {
+			h.AssertTrue(t, policy.Retryable(errors.New("received unexpected HTTP status: 502 Bad Gateway")))
+			h.AssertFalse(t, policy.Retryable(errors.New("received unexpected HTTP status: 500 Internal Server Error")))
+		})
+	})
+}
@@ -0,0 +1,34 @@
+package lifecycleoutput
+
+import "io"
+
+// Group is the group of buildpacks which passed detection, as written to group.toml.
+type Group struct {
+	Group      []GroupElement `toml:"group"`
+	Extensions []GroupElement `toml:"group-extensions,omitempty"`
+}
+
+// GroupElement is a buildpack or image extension of a Group.
+type GroupElement struct {
+	ID       string `toml:"id"`
+	Version  string `toml:"version"`
+	API      string `toml:"api"`
+	Homepage string `toml:"homepage,omitempty"`
+	Optional bool   `toml:"optional,omitempty"`
+}
+
+// DecodeGroup decodes the contents of a group.toml.
+func DecodeGroup(r io.Reader) (Group, error) {
+	var group Group
+	err := decode(r, GroupFile, &group)
+	return group, err
+}
+
+// ReadGroup reads a group.toml from path.
+func ReadGroup(path string) (group Group, err error) {
+	err = readFile(path, func(r io.Reader) error {
+		group, err = DecodeGroup(r)
+		return err
+	})
+	return group, err
+}
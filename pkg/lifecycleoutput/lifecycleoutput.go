@@ -0,0 +1,48 @@
+// Package lifecycleoutput provides typed models and parsers for the TOML files the lifecycle writes during a build,
+// for platform tooling that post-processes build outputs.
+//
+// The models only contain the fields which are part of the Platform API. Unknown keys are ignored, so files written
+// by newer lifecycles can still be read.
+package lifecycleoutput
+
+import (
+	"io"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// GroupFile is the name of the file the detector writes the selected group of buildpacks to.
+	GroupFile = "group.toml"
+
+	// PlanFile is the name of the file the detector writes the resolved build plan to.
+	PlanFile = "plan.toml"
+
+	// ReportFile is the name of the file the exporter writes the export report to.
+	ReportFile = "report.toml"
+
+	// MetadataFile is the name of the file the builder writes the build metadata to, in the config directory
+	// of the layers directory.
+	MetadataFile = "metadata.toml"
+)
+
+func decode(r io.Reader, kind string, v interface{}) error {
+	if _, err := toml.NewDecoder(r).Decode(v); err != nil {
+		return errors.Wrapf(err, "decoding %s", kind)
+	}
+	return nil
+}
+
+func readFile(path string, decodeFunc func(io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	return decodeFunc(f)
+}
@@ -0,0 +1,184 @@
+package lifecycleoutput_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/lifecycleoutput"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLifecycleOutput(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LifecycleOutput", testLifecycleOutput, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLifecycleOutput(t *testing.T, when spec.G, it spec.S) {
+	when("#ReadGroup", func() {
+		var tmpDir string
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "pack.lifecycle-output.test.")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNilE(t, os.RemoveAll(tmpDir))
+		})
+
+		it("reads the group from the file", func() {
+			path := filepath.Join(tmpDir, lifecycleoutput.GroupFile)
+			h.AssertNil(t, ioutil.WriteFile(path, []byte(`
+[[group]]
+  id = "some/buildpack"
+  version = "1.0.0"
+  api = "0.8"
+  homepage = "https://example.com"
+
+[[group]]
+  id = "some/optional-buildpack"
+  version = "2.0.0"
+  api = "0.8"
+  optional = true
+`), 0600))
+
+			group, err := lifecycleoutput.ReadGroup(path)
+			h.AssertNil(t, err)
+			h.AssertEq(t, group, lifecycleoutput.Group{Group: []lifecycleoutput.GroupElement{
+				{ID: "some/buildpack", Version: "1.0.0", API: "0.8", Homepage: "https://example.com"},
+				{ID: "some/optional-buildpack", Version: "2.0.0", API: "0.8", Optional: true},
+			}})
+		})
+
+		it("errors when the file does not exist", func() {
+			_, err := lifecycleoutput.ReadGroup(filepath.Join(tmpDir, "missing.toml"))
+			h.AssertError(t, err, "opening")
+		})
+	})
+
+	when("#DecodePlan", func() {
+		it("decodes the entries and finds them by dependency", func() {
+			plan, err := lifecycleoutput.DecodePlan(strings.NewReader(`
+[[entries]]
+  [[entries.providers]]
+    id = "some/node-engine"
+    version = "1.0.0"
+  [[entries.requires]]
+    name = "node"
+    [entries.requires.metadata]
+      version = "18.*"
+      launch = true
+
+[[entries]]
+  [[entries.providers]]
+    id = "some/npm-install"
+    version = "1.0.0"
+  [[entries.requires]]
+    name = "node_modules"
+`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(plan.Entries), 2)
+
+			entries := plan.Find("node")
+			h.AssertEq(t, len(entries), 1)
+			h.AssertEq(t, entries[0].Providers[0].ID, "some/node-engine")
+			h.AssertEq(t, entries[0].Requires[0].Metadata, map[string]interface{}{"version": "18.*", "launch": true})
+			h.AssertEq(t, len(plan.Find("python")), 0)
+		})
+
+		it("errors for invalid TOML", func() {
+			_, err := lifecycleoutput.DecodePlan(strings.NewReader("[[entries"))
+			h.AssertError(t, err, "decoding plan.toml")
+		})
+	})
+
+	when("#DecodeReport", func() {
+		it("decodes the image and build report", func() {
+			exportReport, err := lifecycleoutput.DecodeReport(strings.NewReader(`
+[image]
+  tags = ["some/app:latest", "some/app:v1"]
+  image-id = "sha256:some-image-id"
+  digest = "sha256:some-digest"
+  manifest-size = 1234
+
+[build]
+  [[build.bom]]
+    name = "node"
+    [build.bom.metadata]
+      version = "18.1.0"
+    [build.bom.buildpack]
+      id = "some/node-engine"
+      version = "1.0.0"
+`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, exportReport.Image, lifecycleoutput.ImageReport{
+				Tags:         []string{"some/app:latest", "some/app:v1"},
+				ImageID:      "sha256:some-image-id",
+				Digest:       "sha256:some-digest",
+				ManifestSize: 1234,
+			})
+			h.AssertEq(t, len(exportReport.Build.BOM), 1)
+			h.AssertEq(t, exportReport.Build.BOM[0].Buildpack.ID, "some/node-engine")
+		})
+	})
+
+	when("#DecodeMetadata", func() {
+		it("decodes the buildpacks and processes", func() {
+			metadata, err := lifecycleoutput.DecodeMetadata(strings.NewReader(`
+buildpack-default-process-type = "web"
+
+[[buildpacks]]
+  id = "some/buildpack"
+  version = "1.0.0"
+  api = "0.8"
+
+[[processes]]
+  type = "web"
+  command = "node"
+  args = ["server.js"]
+  direct = true
+  default = true
+  buildpack-id = "some/buildpack"
+
+[[processes]]
+  type = "worker"
+  command = "node"
+  args = ["worker.js"]
+  direct = true
+  buildpack-id = "some/buildpack"
+
+[[labels]]
+  key = "some-key"
+  value = "some-value"
+
+[[slices]]
+  paths = ["public/*"]
+`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, metadata.BuildpackDefaultProcessType, "web")
+			h.AssertEq(t, metadata.Buildpacks, []lifecycleoutput.GroupElement{{ID: "some/buildpack", Version: "1.0.0", API: "0.8"}})
+			h.AssertEq(t, metadata.Labels, []lifecycleoutput.Label{{Key: "some-key", Value: "some-value"}})
+			h.AssertEq(t, metadata.Slices, []lifecycleoutput.Slice{{Paths: []string{"public/*"}}})
+
+			process, ok := metadata.DefaultProcess("")
+			h.AssertEq(t, ok, true)
+			h.AssertEq(t, process.Type, "web")
+
+			process, ok = metadata.DefaultProcess("worker")
+			h.AssertEq(t, ok, true)
+			h.AssertEq(t, process.Args, []string{"worker.js"})
+
+			_, ok = metadata.DefaultProcess("missing")
+			h.AssertEq(t, ok, false)
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package lifecycleoutput
+
+import "io"
+
+// Metadata is the metadata of a build, as written to config/metadata.toml in the layers directory.
+type Metadata struct {
+	Buildpacks                  []GroupElement `toml:"buildpacks"`
+	Extensions                  []GroupElement `toml:"extensions,omitempty"`
+	Processes                   []Process      `toml:"processes"`
+	Labels                      []Label        `toml:"labels,omitempty"`
+	Slices                      []Slice        `toml:"slices,omitempty"`
+	BOM                         []BOMEntry     `toml:"bom,omitempty"`
+	BuildpackDefaultProcessType string         `toml:"buildpack-default-process-type,omitempty"`
+}
+
+// Process is a process type contributed by a buildpack.
+type Process struct {
+	Type        string   `toml:"type"`
+	Command     string   `toml:"command"`
+	Args        []string `toml:"args"`
+	Direct      bool     `toml:"direct"`
+	Default     bool     `toml:"default,omitempty"`
+	BuildpackID string   `toml:"buildpack-id"`
+	WorkingDir  string   `toml:"working-dir,omitempty"`
+}
+
+// Label is an image label contributed by a buildpack.
+type Label struct {
+	Key   string `toml:"key"`
+	Value string `toml:"value"`
+}
+
+// Slice is a set of paths of the app directory exported as a separate layer.
+type Slice struct {
+	Paths []string `toml:"paths"`
+}
+
+// DefaultProcess returns the process of the given type, or the process marked as default when processType is empty.
+func (m Metadata) DefaultProcess(processType string) (Process, bool) {
+	for _, process := range m.Processes {
+		if (processType != "" && process.Type == processType) || (processType == "" && process.Default) {
+			return process, true
+		}
+	}
+	return Process{}, false
+}
+
+// DecodeMetadata decodes the contents of a metadata.toml.
+func DecodeMetadata(r io.Reader) (Metadata, error) {
+	var metadata Metadata
+	err := decode(r, MetadataFile, &metadata)
+	return metadata, err
+}
+
+// ReadMetadata reads a metadata.toml from path.
+func ReadMetadata(path string) (metadata Metadata, err error) {
+	err = readFile(path, func(r io.Reader) error {
+		metadata, err = DecodeMetadata(r)
+		return err
+	})
+	return metadata, err
+}
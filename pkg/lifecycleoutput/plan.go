@@ -0,0 +1,51 @@
+package lifecycleoutput
+
+import "io"
+
+// Plan is the resolved build plan, as written to plan.toml.
+type Plan struct {
+	Entries []PlanEntry `toml:"entries"`
+}
+
+// PlanEntry is a dependency of the build plan, with the buildpacks which provide it and the requirements for it.
+type PlanEntry struct {
+	Providers []GroupElement `toml:"providers"`
+	Requires  []Require      `toml:"requires"`
+}
+
+// Require is a requirement of a buildpack for a dependency of the build plan.
+type Require struct {
+	Name     string                 `toml:"name"`
+	Version  string                 `toml:"version,omitempty"`
+	Metadata map[string]interface{} `toml:"metadata,omitempty"`
+}
+
+// Find returns the entries which require the dependency with the given name.
+func (p Plan) Find(name string) []PlanEntry {
+	var entries []PlanEntry
+	for _, entry := range p.Entries {
+		for _, req := range entry.Requires {
+			if req.Name == name {
+				entries = append(entries, entry)
+				break
+			}
+		}
+	}
+	return entries
+}
+
+// DecodePlan decodes the contents of a plan.toml.
+func DecodePlan(r io.Reader) (Plan, error) {
+	var plan Plan
+	err := decode(r, PlanFile, &plan)
+	return plan, err
+}
+
+// ReadPlan reads a plan.toml from path.
+func ReadPlan(path string) (plan Plan, err error) {
+	err = readFile(path, func(r io.Reader) error {
+		plan, err = DecodePlan(r)
+		return err
+	})
+	return plan, err
+}
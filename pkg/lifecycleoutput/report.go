@@ -0,0 +1,46 @@
+package lifecycleoutput
+
+import "io"
+
+// Report is the result of an export, as written to report.toml.
+type Report struct {
+	Image ImageReport `toml:"image"`
+	Build BuildReport `toml:"build,omitempty"`
+}
+
+// ImageReport describes the exported app image.
+type ImageReport struct {
+	Tags         []string `toml:"tags"`
+	ImageID      string   `toml:"image-id,omitempty"`
+	Digest       string   `toml:"digest,omitempty"`
+	ManifestSize int64    `toml:"manifest-size,omitempty"`
+}
+
+// BuildReport describes the build of the exported app image.
+type BuildReport struct {
+	BOM []BOMEntry `toml:"bom,omitempty"`
+}
+
+// BOMEntry is an entry of the legacy Bill-of-Materials, contributed by a buildpack.
+type BOMEntry struct {
+	Name      string                 `toml:"name"`
+	Version   string                 `toml:"version,omitempty"`
+	Metadata  map[string]interface{} `toml:"metadata,omitempty"`
+	Buildpack GroupElement           `toml:"buildpack"`
+}
+
+// DecodeReport decodes the contents of a report.toml.
+func DecodeReport(r io.Reader) (Report, error) {
+	var report Report
+	err := decode(r, ReportFile, &report)
+	return report, err
+}
+
+// ReadReport reads a report.toml from path.
+func ReadReport(path string) (report Report, err error) {
+	err = readFile(path, func(r io.Reader) error {
+		report, err = DecodeReport(r)
+		return err
+	})
+	return report, err
+}
@@ -41,6 +41,8 @@ type LogWithWriters struct {
 	clock    func() time.Time
 	out      io.Writer
 	errOut   io.Writer
+	teeOut   io.Writer
+	teeErr   io.Writer
 }
 
 // NewLogWithWriters creates a logger to be used with pack CLI.
@@ -95,10 +97,26 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 	}
 
 	if level == ErrorLevel {
-		return newLogWriter(lw.errOut, lw.clock, lw.wantTime)
+		return newLogWriter(lw.errOut, lw.clock, lw.wantTime).tee(lw.teeErr)
 	}
 
-	return newLogWriter(lw.out, lw.clock, lw.wantTime)
+	return newLogWriter(lw.out, lw.clock, lw.wantTime).tee(lw.teeOut)
+}
+
+// Tee copies the output of the logger, without colors and timestamps, to out and errOut, such as to ship it to
+// centralized logging. The copies do not affect the output, nor whether it is a terminal. It returns a function
+// which stops copying.
+func (lw *LogWithWriters) Tee(out, errOut io.Writer) func() {
+	lw.Lock()
+	defer lw.Unlock()
+
+	lw.teeOut, lw.teeErr = out, errOut
+	return func() {
+		lw.Lock()
+		defer lw.Unlock()
+
+		lw.teeOut, lw.teeErr = nil, nil
+	}
 }
 
 // Writer returns the base Writer for the LogWithWriters
@@ -154,6 +172,7 @@ func appendMissingLineFeed(msg string) string {
 type logWriter struct {
 	sync.Mutex
 	out         io.Writer
+	teeOut      io.Writer
 	clock       func() time.Time
 	wantTime    bool
 	wantNoColor bool
@@ -169,12 +188,22 @@ func newLogWriter(writer io.Writer, clock func() time.Time, wantTime bool) *logW
 	}
 }
 
+// tee copies the messages written to out, unless it is nil
+func (lw *logWriter) tee(out io.Writer) *logWriter {
+	lw.teeOut = out
+	return lw
+}
+
 // Write writes a message prepended by the time to the set io.Writer
 func (lw *logWriter) Write(buf []byte) (n int, err error) {
 	lw.Lock()
 	defer lw.Unlock()
 
 	length := len(buf)
+	if lw.teeOut != nil {
+		// failing to copy the message must not fail logging it
+		_, _ = lw.teeOut.Write(stripColor(buf))
+	}
 	if lw.wantNoColor {
 		buf = stripColor(buf)
 	}
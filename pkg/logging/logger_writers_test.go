@@ -1,6 +1,7 @@
 package logging_test
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -211,6 +212,22 @@ func testLogWithWriters(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("teeing", func() {
+		it("copies the output without colors", func() {
+			var teeOut, teeErr bytes.Buffer
+			untee := logger.Tee(&teeOut, &teeErr)
+
+			logger.Info(color.HiBlueString("test"))
+			logger.Error("failure")
+			untee()
+			logger.Info("after")
+
+			h.AssertEq(t, fOut(), "\x1b[94mtest\x1b[0m\nafter\n")
+			h.AssertEq(t, teeOut.String(), "test\n")
+			h.AssertContains(t, teeErr.String(), "failure\n")
+		})
+	})
+
 	it("will convert an empty string to a line feed", func() {
 		logger.Info("")
 		expected := "\n"
@@ -0,0 +1,35 @@
+// Package phase describes the containers running the lifecycle phases of a build, for golden-file tests, dry runs
+// and external tools auditing what a build runs.
+package phase
+
+// Spec is the fully resolved specification of the container of a lifecycle phase. The same build configuration
+// always produces the same spec, so that specs can be compared across builds and audited without running them.
+// Registry credentials are redacted.
+type Spec struct {
+	// Name of the phase, such as detector or creator.
+	Name string `json:"name"`
+
+	// Image the container runs.
+	Image string `json:"image"`
+
+	// Cmd is the path of the phase binary, followed by its arguments.
+	Cmd []string `json:"cmd"`
+
+	// Env lists the environment variables of the container, in the order they are set.
+	Env []string `json:"env,omitempty"`
+
+	// User the container runs as, the user of the image if empty.
+	User string `json:"user,omitempty"`
+
+	// Binds lists the volumes and host paths mounted in the container.
+	Binds []string `json:"binds,omitempty"`
+
+	// NetworkMode is the network the container is connected to.
+	NetworkMode string `json:"networkMode,omitempty"`
+
+	// ExtraHosts lists the host names mapped in the container, as host:address.
+	ExtraHosts []string `json:"extraHosts,omitempty"`
+
+	// Labels of the container.
+	Labels map[string]string `json:"labels,omitempty"`
+}